@@ -0,0 +1,34 @@
+package main
+
+import (
+	"fmt"
+
+	"github.com/v1adhope/flights/internal/configs"
+	"github.com/v1adhope/flights/internal/usecases/infrastructure/cache"
+	"github.com/v1adhope/flights/pkg/resp"
+)
+
+type cacheStore interface {
+	cache.Store
+	Close() error
+}
+
+// buildCacheStore returns nil when caching is off.
+func buildCacheStore(cfg configs.Cache) (cacheStore, error) {
+	switch cfg.Store {
+	case "none":
+		return nil, nil
+	case "memory":
+		return cache.NewMemory(cfg.Capacity), nil
+	case "redis":
+		client := resp.New(
+			resp.WithAddr(cfg.RedisAddr),
+			resp.WithPassword(cfg.RedisPassword),
+			resp.WithDb(cfg.RedisDb),
+		)
+
+		return cache.NewRedis(client, cfg.RedisKeyPrefix), nil
+	}
+
+	return nil, fmt.Errorf("main: cache: buildCacheStore: unknown store %q", cfg.Store)
+}
@@ -0,0 +1,77 @@
+package main
+
+import (
+	"context"
+	"flag"
+	"log"
+	"os"
+	"os/signal"
+	"syscall"
+
+	"github.com/v1adhope/flights/internal/configs"
+	"github.com/v1adhope/flights/internal/controllers/http/middleware"
+	"github.com/v1adhope/flights/pkg/logger"
+	"gopkg.in/yaml.v3"
+)
+
+// configCmd runs "config print [--redacted]", it prints the loaded config as YAML.
+func configCmd(cfg configs.Config, args []string) {
+	if len(args) == 0 || args[0] != "print" {
+		log.Fatal("usage: config print [--redacted]")
+	}
+
+	fs := flag.NewFlagSet("config print", flag.ExitOnError)
+	redacted := fs.Bool("redacted", false, "mask secrets")
+	fs.Parse(args[1:])
+
+	if *redacted {
+		cfg = cfg.Redacted()
+	}
+
+	enc := yaml.NewEncoder(os.Stdout)
+	enc.SetIndent(2)
+
+	if err := enc.Encode(cfg); err != nil {
+		log.Fatal(err)
+	}
+}
+
+// watchReload loads the config again on SIGHUP, applies the reloadable fields and logs the ones which need a restart.
+// A config that fails to load or to validate is logged and ignored.
+func watchReload(ctx context.Context, cfg configs.Config, args []string, l *logger.Log, sampleRatio *middleware.SampleRatio) {
+	hup := make(chan os.Signal, 1)
+	signal.Notify(hup, syscall.SIGHUP)
+	defer signal.Stop(hup)
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-hup:
+		}
+
+		next, _, err := configs.Load(args)
+		if err != nil {
+			l.ErrorCtx(ctx, err, "%s", "config reload")
+			continue
+		}
+
+		if err := l.SetLevel(next.Log.Level); err != nil {
+			l.ErrorCtx(ctx, err, "%s", "config reload")
+			continue
+		}
+
+		sampleRatio.Set(next.Log.RequestSampleRatio)
+
+		// Changes are compared with the last loaded config, so each one is reported once
+		for _, change := range cfg.Diff(next) {
+			if !change.Reloadable {
+				l.InfoCtx(ctx, "config reload: %s changed, restart to apply it", change.Env)
+			}
+		}
+
+		l.InfoCtx(ctx, "%s", "config reloaded")
+
+		cfg = next
+	}
+}
@@ -0,0 +1,70 @@
+package main
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+
+	"github.com/gin-gonic/gin"
+	v2 "github.com/v1adhope/flights/internal/controllers/http/v2"
+	"github.com/v1adhope/flights/internal/usecases"
+	"github.com/v1adhope/flights/internal/usecases/infrastructure/repository"
+	"github.com/v1adhope/flights/pkg/flightsclient"
+	"github.com/v1adhope/flights/pkg/logger"
+	"github.com/v1adhope/flights/pkg/postgresql"
+)
+
+// _directURL is never dialed, handlerTransport serves every request.
+const _directURL = "http://flightsctl.invalid"
+
+// handlerTransport serves requests of the client with the handler instead of the network.
+type handlerTransport struct {
+	handler http.Handler
+}
+
+func (t handlerTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	w := httptest.NewRecorder()
+
+	t.handler.ServeHTTP(w, req)
+
+	resp := w.Result()
+	resp.Request = req
+
+	return resp, nil
+}
+
+// openPostgres opens the database of --db.
+func openPostgres(ctx context.Context, connStr string) (usecases.Reposer, func(), error) {
+	pd, err := postgresql.Build(
+		ctx,
+		postgresql.WithConnStr(connStr),
+	)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	return repository.New(pd), pd.Close, nil
+}
+
+// directClient serves the client with the v2 handlers over usecases of repos,
+// so requests are validated exactly as the API validates them.
+func directClient(repos usecases.Reposer) (*flightsclient.Client, error) {
+	gin.SetMode(gin.ReleaseMode)
+	router := gin.New()
+	router.Use(gin.Recovery())
+	v2.Register(&v2.Router{
+		Handler:  router,
+		Usecases: usecases.New(repos),
+		Log: logger.New(
+			logger.WithLevel("error"),
+		),
+	})
+
+	return flightsclient.New(
+		_directURL,
+		flightsclient.WithHTTPClient(&http.Client{
+			Transport: handlerTransport{router},
+		}),
+		flightsclient.WithUserAgent("flightsctl"),
+	)
+}
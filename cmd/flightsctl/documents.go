@@ -0,0 +1,111 @@
+package main
+
+import (
+	"github.com/spf13/cobra"
+	"github.com/v1adhope/flights/pkg/flightsclient"
+)
+
+var _documentTypes = []string{
+	flightsclient.DocumentPassport,
+	flightsclient.DocumentIdCard,
+	flightsclient.DocumentInternationalPassport,
+}
+
+func documentsTable(documents []flightsclient.Document) table {
+	t := table{
+		value:   documents,
+		headers: []string{"ID", "TYPE", "NUMBER"},
+		rows:    make([][]string, 0, len(documents)),
+	}
+
+	for _, document := range documents {
+		t.rows = append(t.rows, []string{document.Id, document.Type, document.Number})
+	}
+
+	return t
+}
+
+func (a *app) documentsCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:     "documents",
+		Aliases: []string{"document"},
+		Short:   "Manage documents of passengers",
+	}
+
+	cmd.AddCommand(
+		a.documentsAddCmd(),
+		a.documentsReplaceCmd(),
+		a.documentsDeleteCmd(),
+	)
+
+	return cmd
+}
+
+func registerDocumentFlags(cmd *cobra.Command, document *flightsclient.Document) {
+	flags := cmd.Flags()
+	flags.StringVar(&document.Type, "type", "", `one of "Passport", "Id card", "International passport"`)
+	flags.StringVar(&document.Number, "number", "", "number, digits only")
+
+	cmd.RegisterFlagCompletionFunc("type", cobra.FixedCompletions(_documentTypes, cobra.ShellCompDirectiveNoFileComp))
+
+	for _, name := range []string{"type", "number"} {
+		cmd.MarkFlagRequired(name)
+	}
+}
+
+func (a *app) documentsAddCmd() *cobra.Command {
+	document := flightsclient.Document{}
+
+	cmd := &cobra.Command{
+		Use:     "add PASSENGER_ID",
+		Short:   "Add a document to a passenger",
+		Example: `  flightsctl documents add 1efa5f3d-2b9c-6d0e-8f1a-0242ac120002 --type "Id card" --number 7700112233`,
+		Args:    cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			document.PassengerId = args[0]
+
+			id, err := a.client.CreateDocument(cmd.Context(), document)
+			if err != nil {
+				return err
+			}
+
+			return a.printId(cmd.OutOrStdout(), id)
+		},
+	}
+
+	registerDocumentFlags(cmd, &document)
+
+	return cmd
+}
+
+func (a *app) documentsReplaceCmd() *cobra.Command {
+	document := flightsclient.Document{}
+
+	cmd := &cobra.Command{
+		Use:   "replace DOCUMENT_ID",
+		Short: "Replace a document, --passenger-id moves it to another passenger",
+		Args:  cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			document.Id = args[0]
+
+			return a.client.ReplaceDocument(cmd.Context(), document)
+		},
+	}
+
+	registerDocumentFlags(cmd, &document)
+	cmd.Flags().StringVar(&document.PassengerId, "passenger-id", "", "owner of the document")
+	cmd.MarkFlagRequired("passenger-id")
+
+	return cmd
+}
+
+func (a *app) documentsDeleteCmd() *cobra.Command {
+	return &cobra.Command{
+		Use:   "delete DOCUMENT_ID",
+		Short: "Delete a document",
+		Args:  cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return a.client.DeleteDocument(cmd.Context(), args[0])
+		},
+	}
+}
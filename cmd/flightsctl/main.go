@@ -0,0 +1,152 @@
+// flightsctl manages tickets, passengers and documents from the command line.
+//
+// It talks to the API at --api-url, with --db it works on the database directly
+// through the same handlers and validation the API uses.
+package main
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"os"
+	"time"
+
+	"github.com/spf13/cobra"
+	"github.com/v1adhope/flights/internal/usecases"
+	"github.com/v1adhope/flights/pkg/flightsclient"
+)
+
+type app struct {
+	apiURL  string
+	db      string
+	output  string
+	timeout time.Duration
+
+	// open opens the storage of --db
+	open func(ctx context.Context, db string) (usecases.Reposer, func(), error)
+
+	client *flightsclient.Client
+	close  func()
+}
+
+func main() {
+	a := &app{
+		open: openPostgres,
+	}
+
+	if err := a.rootCmd().Execute(); err != nil {
+		os.Exit(1)
+	}
+}
+
+func (a *app) rootCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:           "flightsctl",
+		Short:         "Manage tickets, passengers and documents of the Flights service",
+		SilenceUsage:  true,
+		SilenceErrors: false,
+		PersistentPreRunE: func(cmd *cobra.Command, args []string) error {
+			if !needsClient(cmd) {
+				return nil
+			}
+
+			return a.connect(cmd.Context())
+		},
+		PersistentPostRun: func(cmd *cobra.Command, args []string) {
+			if a.close != nil {
+				a.close()
+			}
+		},
+	}
+
+	flags := cmd.PersistentFlags()
+	flags.StringVar(&a.apiURL, "api-url", envOr("FLIGHTSCTL_API_URL", "http://localhost:8081"), "base URL of the API, $FLIGHTSCTL_API_URL")
+	flags.StringVar(&a.db, "db", os.Getenv("FLIGHTSCTL_DB"), "Postgres connection string to work without the API, $FLIGHTSCTL_DB")
+	flags.StringVarP(&a.output, "output", "o", _outputTable, "output format, one of table, csv, json")
+	flags.DurationVar(&a.timeout, "timeout", 30*time.Second, "timeout of a request to the API")
+
+	cmd.RegisterFlagCompletionFunc("output", cobra.FixedCompletions(_outputs, cobra.ShellCompDirectiveNoFileComp))
+
+	cmd.AddCommand(
+		a.ticketsCmd(),
+		a.passengersCmd(),
+		a.documentsCmd(),
+		a.bindCmd(),
+		a.unbindCmd(),
+		a.reportCmd(),
+	)
+
+	return cmd
+}
+
+// connect builds the client for the API or, with --db, for the handlers of the API served in process.
+func (a *app) connect(ctx context.Context) error {
+	if err := checkOutput(a.output); err != nil {
+		return err
+	}
+
+	if a.db != "" {
+		repos, close, err := a.open(ctx, a.db)
+		if err != nil {
+			return err
+		}
+
+		client, err := directClient(repos)
+		if err != nil {
+			close()
+			return err
+		}
+
+		a.client, a.close = client, close
+
+		return nil
+	}
+
+	client, err := flightsclient.New(
+		a.apiURL,
+		flightsclient.WithHTTPClient(newHTTPClient(a.timeout)),
+		flightsclient.WithUserAgent("flightsctl"),
+	)
+	if err != nil {
+		return err
+	}
+
+	a.client = client
+
+	return nil
+}
+
+// needsClient is false for completion and help, they must work without the API and the database.
+func needsClient(cmd *cobra.Command) bool {
+	for c := cmd; c != nil; c = c.Parent() {
+		switch c.Name() {
+		case "completion", "help", cobra.ShellCompRequestCmd, cobra.ShellCompNoDescRequestCmd:
+			return false
+		}
+	}
+
+	return true
+}
+
+func newHTTPClient(timeout time.Duration) *http.Client {
+	return &http.Client{
+		Timeout: timeout,
+	}
+}
+
+func envOr(key, fallback string) string {
+	if value := os.Getenv(key); value != "" {
+		return value
+	}
+
+	return fallback
+}
+
+func parseTime(flag, value string) (time.Time, error) {
+	t, err := time.Parse(time.RFC3339, value)
+	if err != nil {
+		return time.Time{}, fmt.Errorf("--%s must be an RFC 3339 time like 2030-01-02T15:04:05+03:00: %w", flag, err)
+	}
+
+	return t, nil
+}
@@ -0,0 +1,318 @@
+package main
+
+import (
+	"bytes"
+	"context"
+	"encoding/csv"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"slices"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	v2 "github.com/v1adhope/flights/internal/controllers/http/v2"
+	"github.com/v1adhope/flights/internal/usecases"
+	"github.com/v1adhope/flights/internal/usecases/infrastructure/memory"
+	"github.com/v1adhope/flights/pkg/flightsclient"
+	"github.com/v1adhope/flights/pkg/logger"
+)
+
+// ctl runs flightsctl commands against the API served by srv, with --db against repos without it.
+type ctl struct {
+	t        *testing.T
+	repos    usecases.Reposer
+	srv      *httptest.Server
+	requests *atomic.Int32
+	flags    []string
+}
+
+func newCtl(t *testing.T, direct bool) ctl {
+	t.Setenv("FLIGHTSCTL_API_URL", "")
+	t.Setenv("FLIGHTSCTL_DB", "")
+
+	repos := memory.New()
+
+	gin.SetMode(gin.ReleaseMode)
+	router := gin.New()
+	v2.Register(&v2.Router{
+		Handler:  router,
+		Usecases: usecases.New(repos),
+		Log: logger.New(
+			logger.WithLevel("error"),
+			logger.WithOutput(io.Discard),
+		),
+	})
+
+	requests := &atomic.Int32{}
+
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests.Add(1)
+		router.ServeHTTP(w, r)
+	}))
+	t.Cleanup(srv.Close)
+
+	c := ctl{
+		t:        t,
+		repos:    repos,
+		srv:      srv,
+		requests: requests,
+		flags:    []string{"--api-url", srv.URL},
+	}
+
+	if direct {
+		c.flags = append(c.flags, "--db", "postgres://flightsctl.invalid/flights")
+	}
+
+	return c
+}
+
+func (c ctl) run(args ...string) (string, error) {
+	a := &app{
+		open: func(ctx context.Context, db string) (usecases.Reposer, func(), error) {
+			assert.Equal(c.t, "postgres://flightsctl.invalid/flights", db)
+
+			return c.repos, func() {}, nil
+		},
+	}
+
+	out := &bytes.Buffer{}
+
+	cmd := a.rootCmd()
+	cmd.SetOut(out)
+	cmd.SetErr(io.Discard)
+	cmd.SetArgs(append(slices.Clone(c.flags), args...))
+
+	err := cmd.Execute()
+
+	return out.String(), err
+}
+
+func (c ctl) mustRun(args ...string) string {
+	out, err := c.run(args...)
+	require.NoError(c.t, err, args)
+
+	return out
+}
+
+// create runs a create command and returns the id of the created resource.
+func (c ctl) create(args ...string) string {
+	created := struct {
+		Id string `json:"id"`
+	}{}
+
+	require.NoError(c.t, json.Unmarshal([]byte(c.mustRun(append(args, "-o", "json")...)), &created))
+	require.NotEmpty(c.t, created.Id)
+
+	return created.Id
+}
+
+func (c ctl) csv(args ...string) [][]string {
+	records, err := csv.NewReader(strings.NewReader(c.mustRun(append(args, "-o", "csv")...))).ReadAll()
+	require.NoError(c.t, err)
+
+	return records
+}
+
+func TestCommands(t *testing.T) {
+	for _, direct := range []bool{false, true} {
+		name := "API"
+		if direct {
+			name = "Direct"
+		}
+
+		t.Run(name, func(t *testing.T) {
+			c := newCtl(t, direct)
+
+			testCommands(t, c)
+
+			if direct {
+				assert.Zero(t, c.requests.Load(), "--db works without the API")
+			} else {
+				assert.NotZero(t, c.requests.Load())
+			}
+		})
+	}
+}
+
+func testCommands(t *testing.T, c ctl) {
+	ticketId := c.create("tickets", "create",
+		"--provider", "Emirates",
+		"--from", "Moscow",
+		"--to", "Hanoi",
+		"--fly-at", "2130-01-02T15:04:05+03:00",
+		"--arrive-at", "2130-01-03T06:04:05+07:00",
+	)
+	passengerId := c.create("passengers", "create", "--first-name", "Riley", "--last-name", "Scott", "--middle-name", "Reed")
+	documentId := c.create("documents", "add", passengerId, "--type", "Id card", "--number", "7700112233")
+
+	c.mustRun("bind", ticketId, passengerId)
+
+	assert.Equal(t, [][]string{
+		{"ID", "FIRST NAME", "LAST NAME", "MIDDLE NAME"},
+		{passengerId, "Riley", "Scott", "Reed"},
+	}, c.csv("tickets", "passengers", ticketId))
+
+	tickets := []flightsclient.Ticket{}
+	require.NoError(t, json.Unmarshal([]byte(c.mustRun("passengers", "tickets", passengerId, "-o", "json")), &tickets))
+	require.Len(t, tickets, 1)
+	assert.Equal(t, ticketId, tickets[0].Id)
+	assert.Equal(t, "Emirates", tickets[0].Provider)
+
+	out := c.mustRun("passengers", "documents", passengerId)
+	assert.Regexp(t, `^ID\s+TYPE\s+NUMBER\n`, out)
+	assert.Regexp(t, documentId+`\s+Id card\s+7700112233\n$`, out)
+
+	now := time.Now().UTC()
+	report := c.csv("report", passengerId,
+		"--from", now.Add(-time.Hour).Format(time.RFC3339),
+		"--to", now.Add(time.Hour).Format(time.RFC3339),
+	)
+	require.Len(t, report, 2)
+	assert.Equal(t, []string{"DATE OF ISSUE", "FLY AT", "TICKET ID", "FROM", "TO", "SERVICE PROVIDED"}, report[0])
+	assert.Equal(t, []string{ticketId, "Moscow", "Hanoi", "false"}, report[1][2:])
+
+	// Replace keeps the fields without flags
+	c.mustRun("tickets", "replace", ticketId, "--provider", "China Airlines")
+
+	ticket := c.csv("tickets", "get", ticketId)
+	require.Len(t, ticket, 2)
+	assert.Equal(t, []string{ticketId, "China Airlines", "Moscow", "Hanoi", "2130-01-02T12:04:05Z", "2130-01-02T23:04:05Z"}, ticket[1][:6])
+
+	c.create("tickets", "create",
+		"--provider", "Emirates",
+		"--from", "Hanoi",
+		"--to", "Moscow",
+		"--fly-at", "2130-02-02T15:04:05Z",
+		"--arrive-at", "2130-02-03T15:04:05Z",
+	)
+
+	assert.Len(t, c.csv("tickets", "list", "--page-size", "1"), 3)
+	assert.Len(t, c.csv("tickets", "list", "--limit", "1"), 2)
+
+	c.mustRun("documents", "replace", documentId, "--type", "Passport", "--number", "1", "--passenger-id", passengerId)
+	assert.Equal(t, [][]string{
+		{"ID", "TYPE", "NUMBER"},
+		{documentId, "Passport", "1"},
+	}, c.csv("passengers", "documents", passengerId))
+
+	_, err := c.run("passengers", "delete", passengerId)
+	assert.ErrorIs(t, err, flightsclient.ErrorPassengerHasDocuments)
+
+	_, err = c.run("tickets", "delete", ticketId)
+	assert.ErrorIs(t, err, flightsclient.ErrorsThereArePassengersOnTheFlight)
+
+	c.mustRun("unbind", ticketId, passengerId)
+	c.mustRun("documents", "delete", documentId)
+	c.mustRun("passengers", "delete", passengerId)
+	c.mustRun("tickets", "delete", ticketId)
+
+	_, err = c.run("tickets", "get", ticketId)
+	assert.ErrorIs(t, err, flightsclient.ErrorNothingFound)
+
+	// The API validates what the flags don't
+	_, err = c.run("tickets", "create",
+		"--provider", "Emirates",
+		"--from", "Moscow",
+		"--to", "Hanoi",
+		"--fly-at", "2020-01-02T15:04:05Z",
+		"--arrive-at", "2130-01-03T06:04:05Z",
+	)
+
+	apiErr := &flightsclient.Error{}
+	require.ErrorAs(t, err, &apiErr)
+	assert.Equal(t, http.StatusUnprocessableEntity, apiErr.Status)
+	require.Len(t, apiErr.Fields, 1)
+	assert.Equal(t, "flyAt", apiErr.Fields[0].Field)
+}
+
+func TestFlags(t *testing.T) {
+	tcs := []struct {
+		key  string
+		args []string
+		err  string
+	}{
+		{
+			key:  "Unknown output",
+			args: []string{"tickets", "list", "-o", "yaml"},
+			err:  `--output must be one of table, csv, json, got "yaml"`,
+		},
+		{
+			key:  "Missing argument",
+			args: []string{"tickets", "get"},
+			err:  "accepts 1 arg(s), received 0",
+		},
+		{
+			key:  "Missing second argument",
+			args: []string{"bind", "1"},
+			err:  "accepts 2 arg(s), received 1",
+		},
+		{
+			key:  "Required flags",
+			args: []string{"passengers", "create", "--first-name", "Riley"},
+			err:  `required flag(s) "last-name", "middle-name" not set`,
+		},
+		{
+			key:  "Time flag",
+			args: []string{"tickets", "create", "--provider", "Emirates", "--from", "Moscow", "--to", "Hanoi", "--fly-at", "tomorrow", "--arrive-at", "2130-01-03T06:04:05Z"},
+			err:  "--fly-at must be an RFC 3339 time like 2030-01-02T15:04:05+03:00",
+		},
+		{
+			key:  "Report period",
+			args: []string{"report", "1efa5f3d-2b9c-6d0e-8f1a-0242ac120002", "--from", "2030-01-01", "--to", "2030-02-01T00:00:00Z"},
+			err:  "--from must be an RFC 3339 time",
+		},
+		{
+			key:  "Duration flag",
+			args: []string{"--timeout", "soon", "tickets", "list"},
+			err:  `invalid argument "soon" for "--timeout" flag`,
+		},
+		{
+			key:  "Unknown flag",
+			args: []string{"tickets", "list", "--pages", "1"},
+			err:  "unknown flag: --pages",
+		},
+	}
+
+	c := newCtl(t, false)
+
+	for _, tc := range tcs {
+		_, err := c.run(tc.args...)
+		assert.ErrorContains(t, err, tc.err, tc.key)
+	}
+
+	assert.Zero(t, c.requests.Load(), "invalid flags are rejected before any request")
+}
+
+func TestDefaultOutput(t *testing.T) {
+	for _, direct := range []bool{false, true} {
+		c := newCtl(t, direct)
+
+		id := c.create("passengers", "create", "--first-name", "Riley", "--last-name", "Scott", "--middle-name", "Reed")
+
+		// Table is the default output
+		out := c.mustRun("passengers", "get", id)
+		assert.Equal(t, "ID"+strings.Repeat(" ", len(id))+"FIRST NAME  LAST NAME  MIDDLE NAME\n"+id+"  Riley       Scott      Reed\n", out)
+
+		passenger := flightsclient.Passenger{}
+		require.NoError(t, json.Unmarshal([]byte(c.mustRun("passengers", "get", id, "--output", "json")), &passenger))
+		assert.Equal(t, flightsclient.Passenger{Id: id, FirstName: "Riley", LastName: "Scott", MiddleName: "Reed"}, passenger)
+	}
+}
+
+func TestHelpWithoutAPI(t *testing.T) {
+	c := newCtl(t, false)
+	c.flags = []string{"--api-url", "http://flightsctl.invalid"}
+
+	out := c.mustRun("help", "tickets")
+	assert.Contains(t, out, "Manage tickets")
+
+	out = c.mustRun("completion", "bash")
+	assert.Contains(t, out, "bash completion")
+}
@@ -0,0 +1,78 @@
+package main
+
+import (
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"io"
+	"strings"
+	"text/tabwriter"
+)
+
+const (
+	_outputTable = "table"
+	_outputCSV   = "csv"
+	_outputJSON  = "json"
+)
+
+var _outputs = []string{_outputTable, _outputCSV, _outputJSON}
+
+func checkOutput(output string) error {
+	for _, o := range _outputs {
+		if o == output {
+			return nil
+		}
+	}
+
+	return fmt.Errorf("--output must be one of %s, got %q", strings.Join(_outputs, ", "), output)
+}
+
+// table is what a command prints, value is printed as is in JSON.
+type table struct {
+	value   any
+	headers []string
+	rows    [][]string
+}
+
+func (a *app) print(w io.Writer, t table) error {
+	switch a.output {
+	case _outputJSON:
+		enc := json.NewEncoder(w)
+		enc.SetIndent("", "  ")
+
+		return enc.Encode(t.value)
+	case _outputCSV:
+		cw := csv.NewWriter(w)
+
+		if err := cw.Write(t.headers); err != nil {
+			return err
+		}
+
+		if err := cw.WriteAll(t.rows); err != nil {
+			return err
+		}
+
+		return cw.Error()
+	}
+
+	tw := tabwriter.NewWriter(w, 0, 4, 2, ' ', 0)
+
+	fmt.Fprintln(tw, strings.Join(t.headers, "\t"))
+
+	for _, row := range t.rows {
+		fmt.Fprintln(tw, strings.Join(row, "\t"))
+	}
+
+	return tw.Flush()
+}
+
+// printId prints the id of a created resource.
+func (a *app) printId(w io.Writer, id string) error {
+	return a.print(w, table{
+		value: map[string]string{
+			"id": id,
+		},
+		headers: []string{"ID"},
+		rows:    [][]string{{id}},
+	})
+}
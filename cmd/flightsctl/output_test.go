@@ -0,0 +1,106 @@
+package main
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func namesTable() table {
+	return table{
+		value: []map[string]string{
+			{"id": "1", "name": "Riley Scott"},
+			{"id": "22", "name": "Scott, Reed"},
+		},
+		headers: []string{"ID", "NAME"},
+		rows: [][]string{
+			{"1", "Riley Scott"},
+			{"22", "Scott, Reed"},
+		},
+	}
+}
+
+func TestPrint(t *testing.T) {
+	tcs := []struct {
+		key    string
+		output string
+		want   string
+	}{
+		{
+			key:    "Table aligns columns",
+			output: _outputTable,
+			want: "ID  NAME\n" +
+				"1   Riley Scott\n" +
+				"22  Scott, Reed\n",
+		},
+		{
+			key:    "CSV quotes separators",
+			output: _outputCSV,
+			want: "ID,NAME\n" +
+				"1,Riley Scott\n" +
+				"22,\"Scott, Reed\"\n",
+		},
+		{
+			key:    "JSON prints the value",
+			output: _outputJSON,
+			want: "[\n" +
+				"  {\n" +
+				"    \"id\": \"1\",\n" +
+				"    \"name\": \"Riley Scott\"\n" +
+				"  },\n" +
+				"  {\n" +
+				"    \"id\": \"22\",\n" +
+				"    \"name\": \"Scott, Reed\"\n" +
+				"  }\n" +
+				"]\n",
+		},
+	}
+
+	for _, tc := range tcs {
+		w := &bytes.Buffer{}
+
+		a := &app{output: tc.output}
+		require.NoError(t, a.print(w, namesTable()), tc.key)
+
+		assert.Equal(t, tc.want, w.String(), tc.key)
+	}
+}
+
+func TestPrintEmpty(t *testing.T) {
+	tcs := []struct {
+		output string
+		want   string
+	}{
+		{output: _outputTable, want: "ID\n"},
+		{output: _outputCSV, want: "ID\n"},
+		{output: _outputJSON, want: "[]\n"},
+	}
+
+	for _, tc := range tcs {
+		w := &bytes.Buffer{}
+
+		a := &app{output: tc.output}
+		require.NoError(t, a.print(w, table{value: []string{}, headers: []string{"ID"}}), tc.output)
+
+		assert.Equal(t, tc.want, w.String(), tc.output)
+	}
+}
+
+func TestPrintId(t *testing.T) {
+	w := &bytes.Buffer{}
+
+	a := &app{output: _outputJSON}
+	require.NoError(t, a.printId(w, "1efa5f3d-2b9c-6d0e-8f1a-0242ac120002"))
+
+	assert.JSONEq(t, `{"id":"1efa5f3d-2b9c-6d0e-8f1a-0242ac120002"}`, w.String())
+}
+
+func TestCheckOutput(t *testing.T) {
+	for _, output := range _outputs {
+		assert.NoError(t, checkOutput(output), output)
+	}
+
+	assert.EqualError(t, checkOutput("yaml"), `--output must be one of table, csv, json, got "yaml"`)
+}
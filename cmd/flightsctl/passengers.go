@@ -0,0 +1,163 @@
+package main
+
+import (
+	"github.com/spf13/cobra"
+	"github.com/v1adhope/flights/pkg/flightsclient"
+)
+
+var _passengerHeaders = []string{"ID", "FIRST NAME", "LAST NAME", "MIDDLE NAME"}
+
+func passengerRow(p flightsclient.Passenger) []string {
+	return []string{
+		p.Id,
+		p.FirstName,
+		p.LastName,
+		p.MiddleName,
+	}
+}
+
+func passengersTable(passengers []flightsclient.Passenger) table {
+	t := table{
+		value:   passengers,
+		headers: _passengerHeaders,
+		rows:    make([][]string, 0, len(passengers)),
+	}
+
+	for _, passenger := range passengers {
+		t.rows = append(t.rows, passengerRow(passenger))
+	}
+
+	return t
+}
+
+func (a *app) passengersCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:     "passengers",
+		Aliases: []string{"passenger"},
+		Short:   "Manage passengers",
+	}
+
+	cmd.AddCommand(
+		a.passengersGetCmd(),
+		a.passengersCreateCmd(),
+		a.passengersDeleteCmd(),
+		a.passengersTicketsCmd(),
+		a.passengersDocumentsCmd(),
+	)
+
+	return cmd
+}
+
+func (a *app) passengersGetCmd() *cobra.Command {
+	return &cobra.Command{
+		Use:   "get PASSENGER_ID",
+		Short: "Show a passenger",
+		Args:  cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			passenger, err := a.client.GetPassenger(cmd.Context(), args[0])
+			if err != nil {
+				return err
+			}
+
+			return a.print(cmd.OutOrStdout(), table{
+				value:   passenger,
+				headers: _passengerHeaders,
+				rows:    [][]string{passengerRow(passenger)},
+			})
+		},
+	}
+}
+
+func (a *app) passengersCreateCmd() *cobra.Command {
+	passenger := flightsclient.Passenger{}
+
+	cmd := &cobra.Command{
+		Use:   "create",
+		Short: "Add a passenger",
+		Args:  cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			id, err := a.client.CreatePassenger(cmd.Context(), passenger)
+			if err != nil {
+				return err
+			}
+
+			return a.printId(cmd.OutOrStdout(), id)
+		},
+	}
+
+	flags := cmd.Flags()
+	flags.StringVar(&passenger.FirstName, "first-name", "", "first name in latin letters")
+	flags.StringVar(&passenger.LastName, "last-name", "", "last name in latin letters")
+	flags.StringVar(&passenger.MiddleName, "middle-name", "", "middle name in latin letters")
+
+	for _, name := range []string{"first-name", "last-name", "middle-name"} {
+		cmd.MarkFlagRequired(name)
+	}
+
+	return cmd
+}
+
+func (a *app) passengersDeleteCmd() *cobra.Command {
+	return &cobra.Command{
+		Use:   "delete PASSENGER_ID",
+		Short: "Delete a passenger without documents",
+		Args:  cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return a.client.DeletePassenger(cmd.Context(), args[0], "")
+		},
+	}
+}
+
+func (a *app) passengersTicketsCmd() *cobra.Command {
+	return &cobra.Command{
+		Use:   "tickets PASSENGER_ID",
+		Short: "List tickets of a passenger",
+		Args:  cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			tickets, err := a.client.GetPassengerTickets(cmd.Context(), args[0])
+			if err != nil {
+				return err
+			}
+
+			return a.print(cmd.OutOrStdout(), ticketsTable(tickets))
+		},
+	}
+}
+
+func (a *app) passengersDocumentsCmd() *cobra.Command {
+	return &cobra.Command{
+		Use:   "documents PASSENGER_ID",
+		Short: "List documents of a passenger",
+		Args:  cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			documents, err := a.client.GetPassengerDocuments(cmd.Context(), args[0])
+			if err != nil {
+				return err
+			}
+
+			return a.print(cmd.OutOrStdout(), documentsTable(documents))
+		},
+	}
+}
+
+func (a *app) bindCmd() *cobra.Command {
+	return &cobra.Command{
+		Use:   "bind TICKET_ID PASSENGER_ID",
+		Short: "Put a passenger on the flight of a ticket",
+		Args:  cobra.ExactArgs(2),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return a.client.BindPassenger(cmd.Context(), args[0], args[1])
+		},
+	}
+}
+
+func (a *app) unbindCmd() *cobra.Command {
+	return &cobra.Command{
+		Use:   "unbind TICKET_ID PASSENGER_ID",
+		Short: "Take a passenger off the flight of a ticket",
+		Args:  cobra.ExactArgs(2),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return a.client.UnbindPassenger(cmd.Context(), args[0], args[1])
+		},
+	}
+}
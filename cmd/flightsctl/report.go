@@ -0,0 +1,63 @@
+package main
+
+import (
+	"strconv"
+	"time"
+
+	"github.com/spf13/cobra"
+)
+
+func (a *app) reportCmd() *cobra.Command {
+	from, to := "", ""
+
+	cmd := &cobra.Command{
+		Use:     "report PASSENGER_ID",
+		Short:   "Report flights of a passenger for a period",
+		Example: "  flightsctl report 1efa5f3d-2b9c-6d0e-8f1a-0242ac120002 --from 2030-01-01T00:00:00Z --to 2030-02-01T00:00:00Z -o csv",
+		Args:    cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			fromT, err := parseTime("from", from)
+			if err != nil {
+				return err
+			}
+
+			toT, err := parseTime("to", to)
+			if err != nil {
+				return err
+			}
+
+			rows, err := a.client.GetPassengerReport(cmd.Context(), args[0], fromT, toT)
+			if err != nil {
+				return err
+			}
+
+			t := table{
+				value:   rows,
+				headers: []string{"DATE OF ISSUE", "FLY AT", "TICKET ID", "FROM", "TO", "SERVICE PROVIDED"},
+				rows:    make([][]string, 0, len(rows)),
+			}
+
+			for _, row := range rows {
+				t.rows = append(t.rows, []string{
+					row.DateOfIssue.Format(time.RFC3339),
+					row.FlyAt.Format(time.RFC3339),
+					row.TicketId,
+					row.FlyFrom,
+					row.FlyTo,
+					strconv.FormatBool(row.ServiceProvided),
+				})
+			}
+
+			return a.print(cmd.OutOrStdout(), t)
+		},
+	}
+
+	cmd.Flags().StringVar(&from, "from", "", "start of the period, RFC 3339")
+	cmd.Flags().StringVar(&to, "to", "", "end of the period, RFC 3339")
+
+	for _, name := range []string{"from", "to"} {
+		cmd.MarkFlagRequired(name)
+	}
+
+	return cmd
+}
@@ -0,0 +1,252 @@
+package main
+
+import (
+	"strings"
+	"time"
+
+	"github.com/spf13/cobra"
+	"github.com/v1adhope/flights/pkg/flightsclient"
+)
+
+var _ticketHeaders = []string{"ID", "PROVIDER", "FROM", "TO", "FLY AT", "ARRIVE AT", "CREATED AT"}
+
+func ticketRow(t flightsclient.Ticket) []string {
+	return []string{
+		t.Id,
+		t.Provider,
+		t.FlyFrom,
+		t.FlyTo,
+		t.FlyAt.Format(time.RFC3339),
+		t.ArriveAt.Format(time.RFC3339),
+		t.CreatedAt.Format(time.RFC3339),
+	}
+}
+
+func ticketsTable(tickets []flightsclient.Ticket) table {
+	t := table{
+		value:   tickets,
+		headers: _ticketHeaders,
+		rows:    make([][]string, 0, len(tickets)),
+	}
+
+	for _, ticket := range tickets {
+		t.rows = append(t.rows, ticketRow(ticket))
+	}
+
+	return t
+}
+
+// ticketFlags are the fields of a ticket, replace changes only the flags that are set.
+type ticketFlags struct {
+	provider string
+	flyFrom  string
+	flyTo    string
+	flyAt    string
+	arriveAt string
+}
+
+func (f *ticketFlags) register(cmd *cobra.Command) {
+	flags := cmd.Flags()
+	flags.StringVar(&f.provider, "provider", "", "airline, e.g. Emirates")
+	flags.StringVar(&f.flyFrom, "from", "", "city of departure")
+	flags.StringVar(&f.flyTo, "to", "", "city of arrival")
+	flags.StringVar(&f.flyAt, "fly-at", "", "departure time, RFC 3339")
+	flags.StringVar(&f.arriveAt, "arrive-at", "", "arrival time, RFC 3339")
+}
+
+func (f *ticketFlags) apply(cmd *cobra.Command, ticket *flightsclient.Ticket) error {
+	flags := cmd.Flags()
+
+	if flags.Changed("provider") {
+		ticket.Provider = f.provider
+	}
+
+	if flags.Changed("from") {
+		ticket.FlyFrom = f.flyFrom
+	}
+
+	if flags.Changed("to") {
+		ticket.FlyTo = f.flyTo
+	}
+
+	if flags.Changed("fly-at") {
+		flyAt, err := parseTime("fly-at", f.flyAt)
+		if err != nil {
+			return err
+		}
+
+		ticket.FlyAt = flyAt
+	}
+
+	if flags.Changed("arrive-at") {
+		arriveAt, err := parseTime("arrive-at", f.arriveAt)
+		if err != nil {
+			return err
+		}
+
+		ticket.ArriveAt = arriveAt
+	}
+
+	return nil
+}
+
+func (a *app) ticketsCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:     "tickets",
+		Aliases: []string{"ticket"},
+		Short:   "Manage tickets",
+	}
+
+	cmd.AddCommand(
+		a.ticketsListCmd(),
+		a.ticketsGetCmd(),
+		a.ticketsCreateCmd(),
+		a.ticketsReplaceCmd(),
+		a.ticketsDeleteCmd(),
+		a.ticketsPassengersCmd(),
+	)
+
+	return cmd
+}
+
+func (a *app) ticketsListCmd() *cobra.Command {
+	pageSize := 0
+	limit := 0
+
+	cmd := &cobra.Command{
+		Use:   "list",
+		Short: "List tickets in creation order",
+		Args:  cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			tickets := []flightsclient.Ticket{}
+
+			for ticket, err := range a.client.Tickets(cmd.Context(), pageSize) {
+				if err != nil {
+					return err
+				}
+
+				tickets = append(tickets, ticket)
+
+				if limit > 0 && len(tickets) == limit {
+					break
+				}
+			}
+
+			return a.print(cmd.OutOrStdout(), ticketsTable(tickets))
+		},
+	}
+
+	cmd.Flags().IntVar(&pageSize, "page-size", 100, "tickets fetched per request")
+	cmd.Flags().IntVar(&limit, "limit", 0, "print at most this many tickets, 0 prints all of them")
+
+	return cmd
+}
+
+func (a *app) ticketsGetCmd() *cobra.Command {
+	return &cobra.Command{
+		Use:   "get TICKET_ID",
+		Short: "Show a ticket",
+		Args:  cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			ticket, err := a.client.GetTicket(cmd.Context(), args[0])
+			if err != nil {
+				return err
+			}
+
+			return a.print(cmd.OutOrStdout(), table{
+				value:   ticket,
+				headers: _ticketHeaders,
+				rows:    [][]string{ticketRow(ticket)},
+			})
+		},
+	}
+}
+
+func (a *app) ticketsCreateCmd() *cobra.Command {
+	f := ticketFlags{}
+
+	cmd := &cobra.Command{
+		Use:     "create",
+		Short:   "Create a ticket",
+		Example: "  flightsctl tickets create --provider Emirates --from Moscow --to Hanoi --fly-at 2030-01-02T15:04:05+03:00 --arrive-at 2030-01-03T06:04:05+07:00",
+		Args:    cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			ticket := flightsclient.Ticket{}
+
+			if err := f.apply(cmd, &ticket); err != nil {
+				return err
+			}
+
+			id, err := a.client.CreateTicket(cmd.Context(), ticket)
+			if err != nil {
+				return err
+			}
+
+			return a.printId(cmd.OutOrStdout(), id)
+		},
+	}
+
+	f.register(cmd)
+
+	for _, name := range []string{"provider", "from", "to", "fly-at", "arrive-at"} {
+		cmd.MarkFlagRequired(name)
+	}
+
+	return cmd
+}
+
+func (a *app) ticketsReplaceCmd() *cobra.Command {
+	f := ticketFlags{}
+
+	cmd := &cobra.Command{
+		Use:   "replace TICKET_ID",
+		Short: "Change fields of a ticket, fields without flags are kept",
+		Long: strings.TrimSpace(`
+Change fields of a ticket, fields without flags are kept.
+The ticket is replaced only if nobody has changed it since it was read.`),
+		Args: cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			ticket, err := a.client.GetTicket(cmd.Context(), args[0])
+			if err != nil {
+				return err
+			}
+
+			if err := f.apply(cmd, &ticket); err != nil {
+				return err
+			}
+
+			return a.client.ReplaceTicket(cmd.Context(), ticket)
+		},
+	}
+
+	f.register(cmd)
+
+	return cmd
+}
+
+func (a *app) ticketsDeleteCmd() *cobra.Command {
+	return &cobra.Command{
+		Use:   "delete TICKET_ID",
+		Short: "Delete a ticket without passengers",
+		Args:  cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return a.client.DeleteTicket(cmd.Context(), args[0], "")
+		},
+	}
+}
+
+func (a *app) ticketsPassengersCmd() *cobra.Command {
+	return &cobra.Command{
+		Use:   "passengers TICKET_ID",
+		Short: "List passengers of a ticket",
+		Args:  cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			passengers, err := a.client.GetTicketPassengers(cmd.Context(), args[0])
+			if err != nil {
+				return err
+			}
+
+			return a.print(cmd.OutOrStdout(), passengersTable(passengers))
+		},
+	}
+}
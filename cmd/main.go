@@ -3,50 +3,251 @@ package main
 import (
 	"context"
 	"log"
+	"os"
+	"os/signal"
+	"syscall"
+	"time"
 
 	"github.com/gin-gonic/gin"
+	"github.com/v1adhope/flights/db"
 	"github.com/v1adhope/flights/internal/configs"
+	grpcv1 "github.com/v1adhope/flights/internal/controllers/grpc/v1"
+	"github.com/v1adhope/flights/internal/controllers/http/middleware"
 	v1 "github.com/v1adhope/flights/internal/controllers/http/v1"
+	v2 "github.com/v1adhope/flights/internal/controllers/http/v2"
 	"github.com/v1adhope/flights/internal/usecases"
+	"github.com/v1adhope/flights/internal/usecases/infrastructure/cache"
+	"github.com/v1adhope/flights/internal/usecases/infrastructure/changes"
+	"github.com/v1adhope/flights/internal/usecases/infrastructure/observer"
 	"github.com/v1adhope/flights/internal/usecases/infrastructure/repository"
+	"github.com/v1adhope/flights/internal/usecases/infrastructure/webhook"
+	"github.com/v1adhope/flights/pkg/grpcsrv/grpcsrv"
 	"github.com/v1adhope/flights/pkg/httpsrv/httpsrv"
 	"github.com/v1adhope/flights/pkg/logger"
-	"github.com/v1adhope/flights/pkg/postgresql"
+	"github.com/v1adhope/flights/pkg/metrics"
+	"github.com/v1adhope/flights/pkg/migrator"
+	"github.com/v1adhope/flights/pkg/tracing"
 )
 
+// _tracingShutdownTimeout bounds flushing spans on exit, so an unreachable collector can't hang it.
+const _tracingShutdownTimeout = 5 * time.Second
+
 func main() {
+	os.Exit(run())
+}
+
+// run returns the exit code after its deferred cleanups, so main exits without hiding a panic.
+func run() int {
 	mainCtx := context.Background()
 
-	configs.MustConfig()
+	args := configs.MustConfig(os.Args[1:])
+
+	if len(args) > 0 && args[0] == "config" {
+		configCmd(configs.Global, args[1:])
+		return 0
+	}
+
+	if len(args) > 0 && args[0] == "migrate" {
+		migrateDb(mainCtx, configs.Global.Postgres, args[1:])
+		return 0
+	}
 
-	pd, err := postgresql.Build(
+	tp, err := tracing.New(
 		mainCtx,
-		postgresql.WithConnStr(configs.Global.Postgres.ConnStr),
+		tracing.WithExporter(configs.Global.Tracing.Exporter),
+		tracing.WithServiceName(configs.Global.Tracing.ServiceName),
+		tracing.WithOtlpEndpoint(configs.Global.Tracing.OtlpEndpoint),
+		tracing.WithOtlpInsecure(configs.Global.Tracing.OtlpInsecure),
+		tracing.WithFilePath(configs.Global.Tracing.FilePath),
+		tracing.WithSampleRatio(configs.Global.Tracing.SampleRatio),
 	)
 	if err != nil {
 		log.Fatal(err)
 	}
-	defer pd.Close()
+	defer func() {
+		ctx, cancel := context.WithTimeout(mainCtx, _tracingShutdownTimeout)
+		defer cancel()
+
+		if err := tp.Shutdown(ctx); err != nil {
+			log.Print(err)
+		}
+	}()
+
+	repo, pd, err := buildStorage(mainCtx, configs.Global, tp)
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	mtr := metrics.New()
+
+	// The memory storage has no pool
+	if pd != nil {
+		defer pd.Close()
+
+		if err := mtr.RegisterPgxPool(pd.Pool); err != nil {
+			log.Fatal(err)
+		}
+	}
 
-	repo := repository.New(pd)
+	migrationVersion, err := migrator.Latest(db.Migrations)
+	if err != nil {
+		log.Fatal(err)
+	}
 
-	uc := usecases.New(repo)
+	health := usecases.NewHealthChecker(repo, migrationVersion, configs.Global.Srv.HealthTimeout)
 
 	log := logger.New(
-		logger.WithLevel("debug"),
+		logger.WithLevel(configs.Global.Log.Level),
+		logger.WithFormat(configs.Global.Log.Format),
+	)
+
+	store, err := buildCacheStore(configs.Global.Cache)
+	if err != nil {
+		log.Error(err, "%s", "cache store")
+		return 1
+	}
+
+	var (
+		repos  usecases.Reposer = repo
+		cached *cache.Repository
+	)
+	if store != nil {
+		defer store.Close()
+
+		cached = cache.New(
+			repo,
+			store,
+			log,
+			cache.WithTtl(configs.Global.Cache.Ttl),
+			cache.WithObserver(mtr),
+		)
+		repos = cached
+	}
+
+	uc := usecases.New(
+		repos,
+		usecases.WithObserver(observer.NewMetrics(mtr)),
+		usecases.WithObserver(observer.NewTracing(tp)),
+	)
+
+	if len(args) > 0 && args[0] == "outbox-replay" {
+		outboxReplay(mainCtx, uc, args[1:])
+		return 0
+	}
+
+	sink, err := buildOutboxSink(configs.Global.Outbox)
+	if err != nil {
+		log.Error(err, "%s", "outbox sink")
+		return 1
+	}
+	defer sink.Close()
+
+	relayCtx, stopRelay := context.WithCancel(mainCtx)
+	defer stopRelay()
+
+	dispatcher := usecases.NewWebhookDispatcher(
+		repo,
+		webhook.NewSender(),
+		log,
+		configs.Global.Webhooks.Interval,
+		configs.Global.Webhooks.BatchSize,
+		configs.Global.Webhooks.MaxAttempts,
+		configs.Global.Webhooks.Backoff,
+		configs.Global.Webhooks.MaxBackoff,
+		configs.Global.Webhooks.SendTimeout,
+	)
+	go dispatcher.Run(relayCtx)
+
+	relay := usecases.NewRelay(
+		repo,
+		sink,
+		log,
+		configs.Global.Outbox.RelayInterval,
+		configs.Global.Outbox.BatchSize,
+		configs.Global.Outbox.RelayLease,
 	)
+	go relay.Run(relayCtx)
+
+	// Changes committed through other service replicas invalidate the local cache, the memory storage is never shared
+	if cached != nil && pd != nil {
+		go changes.NewSubscriber(pd, repository.ChangesChannel, log, cached).Run(relayCtx)
+	}
+
+	sampleRatio := middleware.NewSampleRatio(configs.Global.Log.RequestSampleRatio)
+
+	go watchReload(relayCtx, configs.Global, os.Args[1:], log, sampleRatio)
 
 	v1.SetMode(configs.Global.Srv.Mode)
 	router := gin.New()
-	router.Use(gin.Logger(), gin.Recovery())
+	router.Use(
+		middleware.RequestID(),
+		middleware.Tracing(tp),
+		middleware.RequestLog(log, sampleRatio),
+		gin.Recovery(),
+		middleware.Metrics(mtr),
+		middleware.ReadYourWrites(configs.Global.Postgres.ReadYourWritesWindow),
+	)
+	if configs.Global.Srv.AdminSocket == "" {
+		router.GET("/metrics", gin.WrapH(mtr.Handler()))
+	}
 	v1.Register(&v1.Router{
+		Handler:              router,
+		Usecases:             uc,
+		Log:                  log,
+		GraphqlMaxDepth:      configs.Global.Graphql.MaxDepth,
+		GraphqlMaxComplexity: configs.Global.Graphql.MaxComplexity,
+		Health:               health,
+	})
+	v2.Register(&v2.Router{
 		Handler:  router,
 		Usecases: uc,
 		Log:      log,
 	})
-	v1Srv := httpsrv.New(
+
+	grpcSrv := grpcsrv.New(
+		grpcsrv.WithSocket(configs.Global.Grpc.Socket),
+		grpcsrv.WithShutdownTimeout(configs.Global.Grpc.ShutdownTimeout),
+		grpcsrv.WithUnaryInterceptors(grpcv1.UnaryInterceptors(log)...),
+	)
+	grpcHealth := grpcv1.Register(&grpcv1.Server{
+		Server:   grpcSrv.Server,
+		Usecases: uc,
+	})
+
+	apiSrv := httpsrv.New(
 		router,
-		httpsrv.WithShutdownTimeout(configs.Global.Srv.ShutdownTimeout),
+		httpsrv.WithSocket(configs.Global.Srv.Socket),
+		httpsrv.WithReadTimeout(configs.Global.Srv.ReadTimeout),
+		httpsrv.WithWriteTimeout(configs.Global.Srv.WriteTimeout),
+		httpsrv.WithReadHeaderTimeout(configs.Global.Srv.ReadHeaderTimeout),
+		httpsrv.WithIdleTimeout(configs.Global.Srv.IdleTimeout),
+		httpsrv.WithMaxHeaderBytes(configs.Global.Srv.MaxHeaderBytes),
+		httpsrv.WithTLS(configs.Global.Srv.TlsCertFile, configs.Global.Srv.TlsKeyFile),
+		httpsrv.WithHttp2(!configs.Global.Srv.DisableHttp2),
 	)
-	v1Srv.Run()
+
+	servers := httpsrv.NewGroup(
+		httpsrv.WithShutdownTimeout(configs.Global.Srv.ShutdownTimeout),
+		httpsrv.WithDrainDelay(configs.Global.Srv.DrainDelay),
+		httpsrv.WithOnShutdown(health.Drain),
+		httpsrv.WithOnShutdown(grpcHealth.Shutdown),
+	).Add(apiSrv, grpcSrv)
+
+	if configs.Global.Srv.AdminSocket != "" {
+		admin := gin.New()
+		admin.Use(gin.Recovery())
+		admin.GET("/metrics", gin.WrapH(mtr.Handler()))
+
+		servers.Add(httpsrv.New(admin, httpsrv.WithSocket(configs.Global.Srv.AdminSocket)))
+	}
+
+	sigCtx, stop := signal.NotifyContext(mainCtx, syscall.SIGINT, syscall.SIGTERM)
+	defer stop()
+
+	if err := servers.Run(sigCtx); err != nil {
+		log.Error(err, "%s", "servers")
+		return 1
+	}
+
+	return 0
 }
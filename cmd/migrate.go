@@ -0,0 +1,96 @@
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"log"
+	"os"
+	"text/tabwriter"
+
+	"github.com/v1adhope/flights/db"
+	"github.com/v1adhope/flights/internal/configs"
+	"github.com/v1adhope/flights/pkg/migrator"
+)
+
+const _migrateUsage = "usage: migrate up|down|force|status|version [flags]"
+
+// migrateDb runs the migrate subcommand, it needs only the connection string of the config.
+func migrateDb(ctx context.Context, cfg configs.Postgres, args []string) {
+	if len(args) == 0 {
+		log.Fatal(_migrateUsage)
+	}
+
+	fs := flag.NewFlagSet("migrate "+args[0], flag.ExitOnError)
+	allowDestructive := fs.Bool("allow-destructive", false, "let migrations drop tables that have rows")
+	steps := fs.Int("steps", 1, "number of migrations down reverts")
+	version := fs.Int("version", -1, "version force sets, the schema must be at it")
+	fs.Parse(args[1:])
+
+	m, err := migrator.New(
+		db.Migrations,
+		cfg.ConnStr,
+		migrator.WithAllowDestructive(*allowDestructive),
+	)
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	switch args[0] {
+	case "up":
+		err = m.Up(ctx)
+	case "down":
+		err = m.Down(ctx, *steps)
+	case "force":
+		if *version < 0 {
+			log.Fatal("migrate force: -version is required")
+		}
+
+		err = m.Force(ctx, uint(*version))
+	case "status":
+		err = printMigrationsStatus(ctx, m)
+	case "version":
+	default:
+		log.Fatal(_migrateUsage)
+	}
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	current, err := m.Version(ctx)
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	log.Printf("database version is %d", current)
+}
+
+func printMigrationsStatus(ctx context.Context, m *migrator.Migrator) error {
+	statuses, err := m.Status(ctx)
+	if err != nil {
+		return err
+	}
+
+	tw := tabwriter.NewWriter(os.Stdout, 0, 4, 2, ' ', 0)
+	fmt.Fprintln(tw, "VERSION\tNAME\tAPPLIED\tMODIFIED")
+
+	for _, s := range statuses {
+		fmt.Fprintf(tw, "%d\t%s\t%t\t%t\n", s.Version, s.Name, s.Applied, s.Modified)
+	}
+
+	return tw.Flush()
+}
+
+// autoMigrate applies pending migrations on start, the advisory lock of migrator lets replicas start together.
+func autoMigrate(ctx context.Context, cfg configs.Postgres) error {
+	if !cfg.AutoMigrate {
+		return nil
+	}
+
+	m, err := migrator.New(db.Migrations, cfg.ConnStr)
+	if err != nil {
+		return err
+	}
+
+	return m.Up(ctx)
+}
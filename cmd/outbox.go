@@ -0,0 +1,45 @@
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"log"
+
+	"github.com/v1adhope/flights/internal/configs"
+	"github.com/v1adhope/flights/internal/usecases"
+	"github.com/v1adhope/flights/internal/usecases/infrastructure/publisher"
+)
+
+type outboxSink interface {
+	usecases.Publisher
+	Close() error
+}
+
+func buildOutboxSink(cfg configs.Outbox) (outboxSink, error) {
+	switch cfg.Sink {
+	case "memory":
+		return publisher.NewMemory(), nil
+	case "nats":
+		return publisher.NewNats(cfg.NatsUrl, cfg.NatsSubjectPrefix)
+	case "kafka":
+		return publisher.NewKafka(cfg.KafkaRestProxyUrl, cfg.KafkaTopic), nil
+	}
+
+	return nil, fmt.Errorf("main: outbox: buildOutboxSink: unknown sink %q", cfg.Sink)
+}
+
+// outboxReplay marks published events as unpublished, so the relay delivers them again.
+func outboxReplay(ctx context.Context, uc *usecases.Usecases, args []string) {
+	fs := flag.NewFlagSet("outbox-replay", flag.ExitOnError)
+	fromSeq := fs.Int64("from-seq", 0, "replay events starting from this outbox sequence number")
+	aggregateId := fs.String("aggregate-id", "", "replay events of this aggregate only")
+	fs.Parse(args)
+
+	n, err := uc.ReplayEvents(ctx, *fromSeq, *aggregateId)
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	log.Printf("%d events will be relayed again", n)
+}
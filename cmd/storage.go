@@ -0,0 +1,53 @@
+package main
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/v1adhope/flights/internal/configs"
+	"github.com/v1adhope/flights/internal/usecases"
+	"github.com/v1adhope/flights/internal/usecases/infrastructure/memory"
+	"github.com/v1adhope/flights/internal/usecases/infrastructure/repository"
+	"github.com/v1adhope/flights/pkg/postgresql"
+	"github.com/v1adhope/flights/pkg/tracing"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// buildStorage returns the repository of cfg.Storage, the driver is nil for the memory one.
+func buildStorage(ctx context.Context, cfg configs.Config, tp trace.TracerProvider) (usecases.Reposer, *postgresql.Driver, error) {
+	switch cfg.Storage {
+	case "memory":
+		return memory.New(), nil, nil
+	case "postgres":
+		if err := autoMigrate(ctx, cfg.Postgres); err != nil {
+			return nil, nil, fmt.Errorf("main: storage: buildStorage: autoMigrate: %w", err)
+		}
+
+		pd, err := postgresql.Build(
+			ctx,
+			postgresql.WithConnStr(cfg.Postgres.ConnStr),
+			postgresql.WithTracer(tracing.NewPgxTracer(tp)),
+			postgresql.WithTxIsoLevel(cfg.Postgres.TxIsoLevel),
+			postgresql.WithTxMaxRetries(cfg.Postgres.TxMaxRetries),
+			postgresql.WithTxRetryDelay(cfg.Postgres.TxRetryDelay),
+			postgresql.WithQueryMaxRetries(cfg.Postgres.QueryMaxRetries),
+			postgresql.WithRetryMaxDelay(cfg.Postgres.RetryMaxDelay),
+			postgresql.WithMaxConns(cfg.Postgres.MaxConns),
+			postgresql.WithMinConns(cfg.Postgres.MinConns),
+			postgresql.WithMaxConnLifetime(cfg.Postgres.MaxConnLifetime),
+			postgresql.WithMaxConnIdleTime(cfg.Postgres.MaxConnIdleTime),
+			postgresql.WithHealthCheckPeriod(cfg.Postgres.HealthCheckPeriod),
+			postgresql.WithStatementTimeout(cfg.Postgres.StatementTimeout),
+			postgresql.WithApplicationName(cfg.Postgres.ApplicationName),
+			postgresql.WithReplicas(cfg.Postgres.ReplicaConnStrs...),
+			postgresql.WithReplicaCheckInterval(cfg.Postgres.ReplicaCheckInterval),
+		)
+		if err != nil {
+			return nil, nil, fmt.Errorf("main: storage: buildStorage: %w", err)
+		}
+
+		return repository.New(pd), pd, nil
+	}
+
+	return nil, nil, fmt.Errorf("main: storage: buildStorage: unknown storage %q", cfg.Storage)
+}
@@ -0,0 +1,22 @@
+// Package db embeds the migrations, so the service migrates its database without the sources.
+package db
+
+import (
+	"embed"
+	"io/fs"
+)
+
+//go:embed migrations/*.sql
+var embedded embed.FS
+
+// Migrations holds the files of db/migrations at its root.
+var Migrations = mustSub(embedded, "migrations")
+
+func mustSub(fsys fs.FS, dir string) fs.FS {
+	sub, err := fs.Sub(fsys, dir)
+	if err != nil {
+		panic(err)
+	}
+
+	return sub
+}
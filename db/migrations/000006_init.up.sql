@@ -0,0 +1,15 @@
+create table if not exists outbox (
+  seq bigserial,
+  event_id uuid not null,
+  type varchar(255) not null,
+  aggregate_type varchar(255) not null,
+  aggregate_id uuid not null,
+  payload jsonb not null,
+  occurred_at timestamp with time zone not null,
+  published_at timestamp with time zone,
+
+  constraint pk_outbox_seq primary key(seq),
+  constraint uq_outbox_event_id unique(event_id)
+);
+
+create index if not exists idxs_outbox_unpublished_seq on outbox(seq) where published_at is null;
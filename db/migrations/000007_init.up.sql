@@ -0,0 +1,31 @@
+create table if not exists webhook_subscriptions (
+  subscription_id uuid,
+  url varchar(2048) not null,
+  secret varchar(255) not null,
+  event_types varchar(255)[] not null default '{}',
+  active boolean not null default true,
+  created_at timestamp with time zone not null,
+
+  constraint pk_webhook_subscriptions_subscription_id primary key(subscription_id)
+);
+
+create table if not exists webhook_deliveries (
+  delivery_id uuid,
+  subscription_id uuid not null,
+  event_id uuid not null,
+  event_type varchar(255) not null,
+  payload jsonb not null,
+  status varchar(32) not null,
+  attempts integer not null default 0,
+  next_attempt_at timestamp with time zone,
+  last_status_code integer,
+  last_error text,
+  created_at timestamp with time zone not null,
+  delivered_at timestamp with time zone,
+
+  constraint pk_webhook_deliveries_delivery_id primary key(delivery_id),
+  constraint uq_webhook_deliveries_subscription_id_event_id unique(subscription_id, event_id),
+  constraint fk_webhook_deliveries_webhook_subscriptions_subscription_id foreign key(subscription_id) references webhook_subscriptions(subscription_id) on delete cascade
+);
+
+create index if not exists idxs_webhook_deliveries_due on webhook_deliveries(next_attempt_at) where status = 'pending';
@@ -0,0 +1,12 @@
+create table if not exists idempotency_keys (
+  idempotency_key varchar(255),
+  request_hash varchar(64) not null,
+  status_code integer not null,
+  location varchar(2048) not null default '',
+  body bytea not null,
+  created_at timestamp with time zone not null,
+
+  constraint pk_idempotency_keys_idempotency_key primary key(idempotency_key)
+);
+
+create index if not exists idxs_idempotency_keys_created_at on idempotency_keys(created_at);
@@ -0,0 +1 @@
+drop index if exists idxs_documents_passenger_id;
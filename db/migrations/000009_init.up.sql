@@ -0,0 +1 @@
+create index if not exists idxs_documents_passenger_id on documents(passenger_id);
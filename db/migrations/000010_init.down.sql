@@ -0,0 +1 @@
+alter table outbox drop column if exists claimed_until;
@@ -0,0 +1 @@
+alter table outbox add column if not exists claimed_until timestamp with time zone;
@@ -3,35 +3,146 @@ package configs
 import (
 	"log"
 	"time"
-
-	"github.com/ilyakaznacheev/cleanenv"
-	"github.com/joho/godotenv"
 )
 
+// Fields are read in layers, every layer overrides the previous one:
+// env-default tags, the YAML file, the environment, NAME_FILE secrets and flags, see Load.
+// Fields tagged secret are masked by Redacted, fields tagged reload are applied on SIGHUP without a restart.
 type (
 	Config struct {
-		Postgres Postgres
-		Srv      Srv
+		// Storage is postgres or memory, memory keeps everything in process for demos and loses it on exit
+		Storage  string   `yaml:"storage" env-default:"postgres" env:"SERVICE_STORAGE"`
+		Postgres Postgres `yaml:"postgres"`
+		Srv      Srv      `yaml:"srv"`
+		Grpc     Grpc     `yaml:"grpc"`
+		Graphql  Graphql  `yaml:"graphql"`
+		Outbox   Outbox   `yaml:"outbox"`
+		Webhooks Webhooks `yaml:"webhooks"`
+		Cache    Cache    `yaml:"cache"`
+		Tracing  Tracing  `yaml:"tracing"`
+		Log      Log      `yaml:"log"`
 	}
 
 	Postgres struct {
-		ConnStr string `env-required:"true" env:"SERVICE_POSTGRES_CONN_STR"`
+		ConnStr      string        `yaml:"connStr" env:"SERVICE_POSTGRES_CONN_STR" secret:"true"`
+		TxIsoLevel   string        `yaml:"txIsoLevel" env-default:"read committed" env:"SERVICE_POSTGRES_TX_ISO_LEVEL"`
+		TxMaxRetries int           `yaml:"txMaxRetries" env-default:"3" env:"SERVICE_POSTGRES_TX_MAX_RETRIES"`
+		TxRetryDelay time.Duration `yaml:"txRetryDelay" env-default:"10ms" env:"SERVICE_POSTGRES_TX_RETRY_DELAY"`
+		AutoMigrate  bool          `yaml:"autoMigrate" env-default:"false" env:"SERVICE_POSTGRES_AUTO_MIGRATE"`
+		// QueryMaxRetries of statements outside transactions which failed without taking effect
+		QueryMaxRetries int           `yaml:"queryMaxRetries" env-default:"2" env:"SERVICE_POSTGRES_QUERY_MAX_RETRIES"`
+		RetryMaxDelay   time.Duration `yaml:"retryMaxDelay" env-default:"1s" env:"SERVICE_POSTGRES_RETRY_MAX_DELAY"`
+		// Zero pool settings keep the pool_* parameters of ConnStr or the defaults of the driver
+		MaxConns          int32         `yaml:"maxConns" env-default:"0" env:"SERVICE_POSTGRES_MAX_CONNS"`
+		MinConns          int32         `yaml:"minConns" env-default:"0" env:"SERVICE_POSTGRES_MIN_CONNS"`
+		MaxConnLifetime   time.Duration `yaml:"maxConnLifetime" env-default:"0s" env:"SERVICE_POSTGRES_MAX_CONN_LIFETIME"`
+		MaxConnIdleTime   time.Duration `yaml:"maxConnIdleTime" env-default:"0s" env:"SERVICE_POSTGRES_MAX_CONN_IDLE_TIME"`
+		HealthCheckPeriod time.Duration `yaml:"healthCheckPeriod" env-default:"0s" env:"SERVICE_POSTGRES_HEALTH_CHECK_PERIOD"`
+		// StatementTimeout cancels statements running longer on the server, 0 turns it off
+		StatementTimeout time.Duration `yaml:"statementTimeout" env-default:"0s" env:"SERVICE_POSTGRES_STATEMENT_TIMEOUT"`
+		ApplicationName  string        `yaml:"applicationName" env-default:"flights" env:"SERVICE_POSTGRES_APPLICATION_NAME"`
+		// ReplicaConnStrs are separated by ";" in env, reads go to healthy replicas in turn
+		ReplicaConnStrs      []string      `yaml:"replicaConnStrs" env-separator:";" env:"SERVICE_POSTGRES_REPLICA_CONN_STRS" secret:"true"`
+		ReplicaCheckInterval time.Duration `yaml:"replicaCheckInterval" env-default:"5s" env:"SERVICE_POSTGRES_REPLICA_CHECK_INTERVAL"`
+		// ReadYourWritesWindow sends the reads of an HTTP client to the primary for a while after it wrote, 0 turns it off
+		ReadYourWritesWindow time.Duration `yaml:"readYourWritesWindow" env-default:"5s" env:"SERVICE_POSTGRES_READ_YOUR_WRITES_WINDOW"`
 	}
 
 	Srv struct {
-		Mode            string        `env-required:"true" env:"SERVICE_SRV_MODE"`
-		ShutdownTimeout time.Duration `env-required:"true" env:"SERVICE_SRV_SHUTDOWN_TIMEOUT"`
+		Mode string `yaml:"mode" env:"SERVICE_SRV_MODE"`
+		// Socket is "host:port" or "unix:/path/to/socket"
+		Socket            string        `yaml:"socket" env-default:":8080" env:"SERVICE_SRV_SOCKET"`
+		ReadTimeout       time.Duration `yaml:"readTimeout" env-default:"10s" env:"SERVICE_SRV_READ_TIMEOUT"`
+		WriteTimeout      time.Duration `yaml:"writeTimeout" env-default:"10s" env:"SERVICE_SRV_WRITE_TIMEOUT"`
+		ReadHeaderTimeout time.Duration `yaml:"readHeaderTimeout" env-default:"5s" env:"SERVICE_SRV_READ_HEADER_TIMEOUT"`
+		IdleTimeout       time.Duration `yaml:"idleTimeout" env-default:"60s" env:"SERVICE_SRV_IDLE_TIMEOUT"`
+		MaxHeaderBytes    int           `yaml:"maxHeaderBytes" env-default:"1048576" env:"SERVICE_SRV_MAX_HEADER_BYTES"`
+		// TlsCertFile and TlsKeyFile serve HTTPS, renewed files are picked up without a restart
+		TlsCertFile  string `yaml:"tlsCertFile" env:"SERVICE_SRV_TLS_CERT_FILE"`
+		TlsKeyFile   string `yaml:"tlsKeyFile" env:"SERVICE_SRV_TLS_KEY_FILE"`
+		DisableHttp2 bool   `yaml:"disableHttp2" env-default:"false" env:"SERVICE_SRV_DISABLE_HTTP2"`
+		// AdminSocket serves /metrics apart from the API, it is served by the API when empty
+		AdminSocket     string        `yaml:"adminSocket" env:"SERVICE_SRV_ADMIN_SOCKET"`
+		ShutdownTimeout time.Duration `yaml:"shutdownTimeout" env-default:"0s" env:"SERVICE_SRV_SHUTDOWN_TIMEOUT"`
+		DrainDelay      time.Duration `yaml:"drainDelay" env-default:"0s" env:"SERVICE_SRV_DRAIN_DELAY"`
+		// HealthTimeout limits every check of the health endpoints
+		HealthTimeout time.Duration `yaml:"healthTimeout" env-default:"2s" env:"SERVICE_SRV_HEALTH_TIMEOUT"`
+	}
+
+	Grpc struct {
+		Socket          string        `yaml:"socket" env-default:":9090" env:"SERVICE_GRPC_SOCKET"`
+		ShutdownTimeout time.Duration `yaml:"shutdownTimeout" env-default:"10s" env:"SERVICE_GRPC_SHUTDOWN_TIMEOUT"`
+	}
+
+	Graphql struct {
+		MaxDepth      int `yaml:"maxDepth" env-default:"8" env:"SERVICE_GRAPHQL_MAX_DEPTH"`
+		MaxComplexity int `yaml:"maxComplexity" env-default:"5000" env:"SERVICE_GRAPHQL_MAX_COMPLEXITY"`
+	}
+
+	Outbox struct {
+		// Sink is one of memory, nats, kafka
+		Sink          string        `yaml:"sink" env-default:"memory" env:"SERVICE_OUTBOX_SINK"`
+		RelayInterval time.Duration `yaml:"relayInterval" env-default:"1s" env:"SERVICE_OUTBOX_RELAY_INTERVAL"`
+		BatchSize     uint64        `yaml:"batchSize" env-default:"100" env:"SERVICE_OUTBOX_BATCH_SIZE"`
+		// RelayLease is how long claimed events wait for the relay, a failed aggregate is retried after it
+		RelayLease        time.Duration `yaml:"relayLease" env-default:"30s" env:"SERVICE_OUTBOX_RELAY_LEASE"`
+		NatsUrl           string        `yaml:"natsUrl" env-default:"nats://127.0.0.1:4222" env:"SERVICE_OUTBOX_NATS_URL" secret:"true"`
+		NatsSubjectPrefix string        `yaml:"natsSubjectPrefix" env-default:"flights" env:"SERVICE_OUTBOX_NATS_SUBJECT_PREFIX"`
+		KafkaRestProxyUrl string        `yaml:"kafkaRestProxyUrl" env-default:"http://127.0.0.1:8082" env:"SERVICE_OUTBOX_KAFKA_REST_PROXY_URL" secret:"true"`
+		KafkaTopic        string        `yaml:"kafkaTopic" env-default:"flights.events" env:"SERVICE_OUTBOX_KAFKA_TOPIC"`
+	}
+
+	Webhooks struct {
+		Interval    time.Duration `yaml:"interval" env-default:"1s" env:"SERVICE_WEBHOOKS_INTERVAL"`
+		BatchSize   uint64        `yaml:"batchSize" env-default:"50" env:"SERVICE_WEBHOOKS_BATCH_SIZE"`
+		MaxAttempts int           `yaml:"maxAttempts" env-default:"8" env:"SERVICE_WEBHOOKS_MAX_ATTEMPTS"`
+		Backoff     time.Duration `yaml:"backoff" env-default:"30s" env:"SERVICE_WEBHOOKS_BACKOFF"`
+		MaxBackoff  time.Duration `yaml:"maxBackoff" env-default:"1h" env:"SERVICE_WEBHOOKS_MAX_BACKOFF"`
+		SendTimeout time.Duration `yaml:"sendTimeout" env-default:"10s" env:"SERVICE_WEBHOOKS_SEND_TIMEOUT"`
+	}
+
+	Cache struct {
+		// Store is one of none, memory, redis, memory is local to every service replica
+		Store    string        `yaml:"store" env-default:"none" env:"SERVICE_CACHE_STORE"`
+		Capacity int           `yaml:"capacity" env-default:"10000" env:"SERVICE_CACHE_CAPACITY"`
+		Ttl      time.Duration `yaml:"ttl" env-default:"1m" env:"SERVICE_CACHE_TTL"`
+		// RedisAddr is any server speaking the Redis protocol
+		RedisAddr      string `yaml:"redisAddr" env-default:"127.0.0.1:6379" env:"SERVICE_CACHE_REDIS_ADDR"`
+		RedisPassword  string `yaml:"redisPassword" env:"SERVICE_CACHE_REDIS_PASSWORD" secret:"true"`
+		RedisDb        int    `yaml:"redisDb" env-default:"0" env:"SERVICE_CACHE_REDIS_DB"`
+		RedisKeyPrefix string `yaml:"redisKeyPrefix" env-default:"flights:cache:" env:"SERVICE_CACHE_REDIS_KEY_PREFIX"`
+	}
+
+	Log struct {
+		// Level is one of debug, info, warn, error
+		Level string `yaml:"level" env-default:"info" env:"SERVICE_LOG_LEVEL" reload:"true"`
+		// Format is json or text
+		Format string `yaml:"format" env-default:"json" env:"SERVICE_LOG_FORMAT"`
+		// RequestSampleRatio of successful requests is logged, failed ones are logged always
+		RequestSampleRatio float64 `yaml:"requestSampleRatio" env-default:"1" env:"SERVICE_LOG_REQUEST_SAMPLE_RATIO" reload:"true"`
+	}
+
+	Tracing struct {
+		// Exporter is one of none, otlp, stdout, file
+		Exporter     string  `yaml:"exporter" env-default:"none" env:"SERVICE_TRACING_EXPORTER"`
+		ServiceName  string  `yaml:"serviceName" env-default:"flights" env:"SERVICE_TRACING_SERVICE_NAME"`
+		OtlpEndpoint string  `yaml:"otlpEndpoint" env-default:"localhost:4318" env:"SERVICE_TRACING_OTLP_ENDPOINT"`
+		OtlpInsecure bool    `yaml:"otlpInsecure" env-default:"true" env:"SERVICE_TRACING_OTLP_INSECURE"`
+		FilePath     string  `yaml:"filePath" env-default:"traces.json" env:"SERVICE_TRACING_FILE_PATH"`
+		SampleRatio  float64 `yaml:"sampleRatio" env-default:"1" env:"SERVICE_TRACING_SAMPLE_RATIO"`
 	}
 )
 
 var Global Config
 
-func MustConfig() {
-	if err := godotenv.Load(); err != nil {
-		log.Fatalf("config: can't load envs from .env: %v", err)
+// MustConfig loads Global from args without the program name and returns the arguments left after flags.
+func MustConfig(args []string) []string {
+	cfg, rest, err := Load(args)
+	if err != nil {
+		log.Fatalf("config: %v", err)
 	}
 
-	if err := cleanenv.ReadEnv(&Global); err != nil {
-		log.Fatalf("config: can't read envs: %v", err)
-	}
+	Global = cfg
+
+	return rest
 }
@@ -0,0 +1,230 @@
+package configs
+
+import (
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"reflect"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/joho/godotenv"
+	"gopkg.in/yaml.v3"
+)
+
+const (
+	_envPrefix  = "SERVICE_"
+	_fileSuffix = "_FILE"
+	_configFlag = "config"
+	_configFile = "SERVICE_CONFIG_FILE"
+	_dotenvFile = ".env"
+)
+
+// Load reads the config from the YAML file of --config or SERVICE_CONFIG_FILE, the environment extended by .env,
+// NAME_FILE secrets and flags, then validates it.
+// Every env name NAME_OF_FIELD has the flag --name-of-field without the SERVICE_ prefix.
+// Flags are parsed until the first non-flag argument, the arguments starting with it are returned.
+func Load(args []string) (Config, []string, error) {
+	var cfg Config
+
+	fs := flag.NewFlagSet("service", flag.ContinueOnError)
+	path := fs.String(_configFlag, os.Getenv(_configFile), "path of the YAML config file")
+
+	overrides := make(map[string]string)
+	walk(&cfg, func(f reflect.StructField, _ reflect.Value) {
+		env := f.Tag.Get("env")
+
+		fs.Func(flagName(env), "overrides "+env, func(s string) error {
+			overrides[env] = s
+			return nil
+		})
+	})
+
+	if err := fs.Parse(args); err != nil {
+		return cfg, nil, fmt.Errorf("configs: load: Load: Parse: %w", err)
+	}
+
+	if err := godotenv.Load(_dotenvFile); err != nil && !errors.Is(err, os.ErrNotExist) {
+		return cfg, nil, fmt.Errorf("configs: load: Load: godotenv.Load: %w", err)
+	}
+
+	// Defaults go first, so the file and env can set zero values over them
+	var errs []error
+	walk(&cfg, func(f reflect.StructField, v reflect.Value) {
+		if s, ok := f.Tag.Lookup("env-default"); ok {
+			if err := set(f, v, s); err != nil {
+				errs = append(errs, fmt.Errorf("%s: default: %w", f.Tag.Get("env"), err))
+			}
+		}
+	})
+
+	if err := errors.Join(errs...); err != nil {
+		return cfg, nil, err
+	}
+
+	if *path != "" {
+		if err := readFile(*path, &cfg); err != nil {
+			return cfg, nil, fmt.Errorf("configs: load: Load: readFile: %w", err)
+		}
+	}
+
+	walk(&cfg, func(f reflect.StructField, v reflect.Value) {
+		env := f.Tag.Get("env")
+
+		if s, ok := os.LookupEnv(env); ok {
+			if err := set(f, v, s); err != nil {
+				errs = append(errs, fmt.Errorf("%s: %w", env, err))
+			}
+		}
+
+		if err := readSecretFile(f, v); err != nil {
+			errs = append(errs, err)
+		}
+
+		if s, ok := overrides[env]; ok {
+			if err := set(f, v, s); err != nil {
+				errs = append(errs, fmt.Errorf("--%s: %w", flagName(env), err))
+			}
+		}
+	})
+
+	if err := errors.Join(errs...); err != nil {
+		return cfg, nil, err
+	}
+
+	if err := cfg.Validate(); err != nil {
+		return cfg, nil, err
+	}
+
+	return cfg, fs.Args(), nil
+}
+
+// readFile decodes the YAML file into cfg, the fields missing from it keep their values.
+func readFile(path string, cfg *Config) error {
+	f, err := os.Open(path)
+	if err != nil {
+		return fmt.Errorf("Open: %w", err)
+	}
+	defer f.Close()
+
+	if err := yaml.NewDecoder(f).Decode(cfg); err != nil && !errors.Is(err, io.EOF) {
+		return fmt.Errorf("Decode: %w", err)
+	}
+
+	return nil
+}
+
+// readSecretFile sets v to the content of the file of NAME_FILE, so secrets can be mounted as files.
+func readSecretFile(f reflect.StructField, v reflect.Value) error {
+	env := f.Tag.Get("env")
+
+	path, ok := os.LookupEnv(env + _fileSuffix)
+	if !ok {
+		return nil
+	}
+
+	if _, ok := os.LookupEnv(env); ok {
+		return fmt.Errorf("%s: %s is set too, set only one of them", env+_fileSuffix, env)
+	}
+
+	content, err := os.ReadFile(path)
+	if err != nil {
+		return fmt.Errorf("%s: %w", env+_fileSuffix, err)
+	}
+
+	if err := set(f, v, strings.TrimRight(string(content), "\r\n")); err != nil {
+		return fmt.Errorf("%s: %w", env+_fileSuffix, err)
+	}
+
+	return nil
+}
+
+// walk calls fn with every field of cfg tagged with env.
+func walk(cfg *Config, fn func(f reflect.StructField, v reflect.Value)) {
+	var rec func(v reflect.Value)
+	rec = func(v reflect.Value) {
+		for i := range v.NumField() {
+			f := v.Type().Field(i)
+
+			if f.Type.Kind() == reflect.Struct && f.Type != reflect.TypeOf(time.Duration(0)) {
+				rec(v.Field(i))
+				continue
+			}
+
+			if f.Tag.Get("env") != "" {
+				fn(f, v.Field(i))
+			}
+		}
+	}
+
+	rec(reflect.ValueOf(cfg).Elem())
+}
+
+func flagName(env string) string {
+	return strings.ReplaceAll(strings.ToLower(strings.TrimPrefix(env, _envPrefix)), "_", "-")
+}
+
+// set parses s of env, a default or a flag into v.
+func set(f reflect.StructField, v reflect.Value, s string) error {
+	if v.Type() == reflect.TypeOf([]string(nil)) {
+		sep := f.Tag.Get("env-separator")
+		if sep == "" {
+			sep = ","
+		}
+
+		v.Set(reflect.ValueOf(strings.Split(s, sep)))
+
+		return nil
+	}
+
+	if v.Type() == reflect.TypeOf(time.Duration(0)) {
+		d, err := time.ParseDuration(s)
+		if err != nil {
+			return err
+		}
+
+		v.SetInt(int64(d))
+
+		return nil
+	}
+
+	switch v.Kind() {
+	case reflect.String:
+		v.SetString(s)
+	case reflect.Bool:
+		b, err := strconv.ParseBool(s)
+		if err != nil {
+			return err
+		}
+
+		v.SetBool(b)
+	case reflect.Int, reflect.Int32, reflect.Int64:
+		n, err := strconv.ParseInt(s, 10, v.Type().Bits())
+		if err != nil {
+			return err
+		}
+
+		v.SetInt(n)
+	case reflect.Uint, reflect.Uint32, reflect.Uint64:
+		n, err := strconv.ParseUint(s, 10, v.Type().Bits())
+		if err != nil {
+			return err
+		}
+
+		v.SetUint(n)
+	case reflect.Float64:
+		f, err := strconv.ParseFloat(s, 64)
+		if err != nil {
+			return err
+		}
+
+		v.SetFloat(f)
+	default:
+		return fmt.Errorf("unsupported type %s", v.Type())
+	}
+
+	return nil
+}
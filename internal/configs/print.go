@@ -0,0 +1,102 @@
+package configs
+
+import (
+	"fmt"
+	"reflect"
+
+	"gopkg.in/yaml.v3"
+)
+
+const _redacted = "<redacted>"
+
+// Redacted returns a copy of c with the fields tagged secret masked.
+func (c Config) Redacted() Config {
+	walk(&c, func(f reflect.StructField, v reflect.Value) {
+		if f.Tag.Get("secret") != "true" {
+			return
+		}
+
+		if v.Kind() == reflect.Slice {
+			redacted := make([]string, v.Len())
+			for i := range redacted {
+				redacted[i] = _redacted
+			}
+
+			v.Set(reflect.ValueOf(redacted))
+
+			return
+		}
+
+		if v.String() != "" {
+			v.SetString(_redacted)
+		}
+	})
+
+	return c
+}
+
+// MarshalYAML keeps the order of fields and writes durations as "1s", so the output can be read back with --config.
+func (c Config) MarshalYAML() (any, error) {
+	return node(reflect.ValueOf(c)), nil
+}
+
+func node(v reflect.Value) *yaml.Node {
+	if v.Kind() == reflect.Slice {
+		n := &yaml.Node{Kind: yaml.SequenceNode}
+		for i := range v.Len() {
+			n.Content = append(n.Content, node(v.Index(i)))
+		}
+
+		return n
+	}
+
+	if v.Kind() != reflect.Struct {
+		n := &yaml.Node{}
+		n.SetString(fmt.Sprint(v.Interface()))
+		// untagged scalars are written plain, so numbers, bools and durations aren't quoted
+		if v.Kind() != reflect.String {
+			n.Tag = ""
+		}
+
+		return n
+	}
+
+	n := &yaml.Node{Kind: yaml.MappingNode}
+	for i := range v.NumField() {
+		key := &yaml.Node{}
+		key.SetString(v.Type().Field(i).Tag.Get("yaml"))
+
+		n.Content = append(n.Content, key, node(v.Field(i)))
+	}
+
+	return n
+}
+
+// Change is a field that differs between two configs.
+type Change struct {
+	Env string
+	// Reloadable fields are applied without a restart
+	Reloadable bool
+}
+
+// Diff lists the fields of next that differ from c.
+func (c Config) Diff(next Config) []Change {
+	var nextFields []reflect.Value
+	walk(&next, func(_ reflect.StructField, v reflect.Value) {
+		nextFields = append(nextFields, v)
+	})
+
+	var changes []Change
+	i := 0
+	walk(&c, func(f reflect.StructField, v reflect.Value) {
+		if !reflect.DeepEqual(v.Interface(), nextFields[i].Interface()) {
+			changes = append(changes, Change{
+				Env:        f.Tag.Get("env"),
+				Reloadable: f.Tag.Get("reload") == "true",
+			})
+		}
+		i++
+	})
+
+	return changes
+}
@@ -0,0 +1,143 @@
+package configs
+
+import (
+	"errors"
+	"fmt"
+	"slices"
+	"strings"
+)
+
+// Validate reports every invalid field at once.
+func (c Config) Validate() error {
+	var errs []error
+
+	check := func(err error) {
+		if err != nil {
+			errs = append(errs, err)
+		}
+	}
+
+	check(oneOf("SERVICE_STORAGE", c.Storage, "postgres", "memory"))
+	if c.Storage == "postgres" {
+		check(required("SERVICE_POSTGRES_CONN_STR", c.Postgres.ConnStr))
+	}
+	check(oneOf("SERVICE_POSTGRES_TX_ISO_LEVEL", c.Postgres.TxIsoLevel, "read committed", "repeatable read", "serializable"))
+	check(notNegative("SERVICE_POSTGRES_TX_MAX_RETRIES", c.Postgres.TxMaxRetries))
+	check(positive("SERVICE_POSTGRES_TX_RETRY_DELAY", c.Postgres.TxRetryDelay))
+	check(notNegative("SERVICE_POSTGRES_QUERY_MAX_RETRIES", c.Postgres.QueryMaxRetries))
+	check(positive("SERVICE_POSTGRES_RETRY_MAX_DELAY", c.Postgres.RetryMaxDelay))
+	check(notNegative("SERVICE_POSTGRES_MAX_CONNS", c.Postgres.MaxConns))
+	check(notNegative("SERVICE_POSTGRES_MIN_CONNS", c.Postgres.MinConns))
+	if c.Postgres.MaxConns > 0 && c.Postgres.MinConns > c.Postgres.MaxConns {
+		check(fmt.Errorf("SERVICE_POSTGRES_MIN_CONNS: %d must not exceed SERVICE_POSTGRES_MAX_CONNS %d", c.Postgres.MinConns, c.Postgres.MaxConns))
+	}
+	check(notNegative("SERVICE_POSTGRES_MAX_CONN_LIFETIME", c.Postgres.MaxConnLifetime))
+	check(notNegative("SERVICE_POSTGRES_MAX_CONN_IDLE_TIME", c.Postgres.MaxConnIdleTime))
+	check(notNegative("SERVICE_POSTGRES_HEALTH_CHECK_PERIOD", c.Postgres.HealthCheckPeriod))
+	check(notNegative("SERVICE_POSTGRES_STATEMENT_TIMEOUT", c.Postgres.StatementTimeout))
+	check(positive("SERVICE_POSTGRES_REPLICA_CHECK_INTERVAL", c.Postgres.ReplicaCheckInterval))
+	check(notNegative("SERVICE_POSTGRES_READ_YOUR_WRITES_WINDOW", c.Postgres.ReadYourWritesWindow))
+	if slices.Contains(c.Postgres.ReplicaConnStrs, "") {
+		check(fmt.Errorf("SERVICE_POSTGRES_REPLICA_CONN_STRS: contains an empty connection string"))
+	}
+
+	check(oneOf("SERVICE_SRV_MODE", c.Srv.Mode, "debug", "release", "test"))
+	check(required("SERVICE_SRV_SOCKET", c.Srv.Socket))
+	check(positive("SERVICE_SRV_READ_TIMEOUT", c.Srv.ReadTimeout))
+	check(positive("SERVICE_SRV_WRITE_TIMEOUT", c.Srv.WriteTimeout))
+	check(positive("SERVICE_SRV_READ_HEADER_TIMEOUT", c.Srv.ReadHeaderTimeout))
+	check(positive("SERVICE_SRV_IDLE_TIMEOUT", c.Srv.IdleTimeout))
+	check(positive("SERVICE_SRV_MAX_HEADER_BYTES", c.Srv.MaxHeaderBytes))
+	check(together("SERVICE_SRV_TLS_CERT_FILE", c.Srv.TlsCertFile, "SERVICE_SRV_TLS_KEY_FILE", c.Srv.TlsKeyFile))
+	check(notNegative("SERVICE_SRV_SHUTDOWN_TIMEOUT", c.Srv.ShutdownTimeout))
+	check(notNegative("SERVICE_SRV_DRAIN_DELAY", c.Srv.DrainDelay))
+	check(positive("SERVICE_SRV_HEALTH_TIMEOUT", c.Srv.HealthTimeout))
+
+	check(required("SERVICE_GRPC_SOCKET", c.Grpc.Socket))
+	check(notNegative("SERVICE_GRPC_SHUTDOWN_TIMEOUT", c.Grpc.ShutdownTimeout))
+
+	check(positive("SERVICE_GRAPHQL_MAX_DEPTH", c.Graphql.MaxDepth))
+	check(positive("SERVICE_GRAPHQL_MAX_COMPLEXITY", c.Graphql.MaxComplexity))
+
+	check(oneOf("SERVICE_OUTBOX_SINK", c.Outbox.Sink, "memory", "nats", "kafka"))
+	check(positive("SERVICE_OUTBOX_RELAY_INTERVAL", c.Outbox.RelayInterval))
+	check(positive("SERVICE_OUTBOX_BATCH_SIZE", c.Outbox.BatchSize))
+	check(positive("SERVICE_OUTBOX_RELAY_LEASE", c.Outbox.RelayLease))
+
+	check(positive("SERVICE_WEBHOOKS_INTERVAL", c.Webhooks.Interval))
+	check(positive("SERVICE_WEBHOOKS_BATCH_SIZE", c.Webhooks.BatchSize))
+	check(positive("SERVICE_WEBHOOKS_MAX_ATTEMPTS", c.Webhooks.MaxAttempts))
+	check(notNegative("SERVICE_WEBHOOKS_BACKOFF", c.Webhooks.Backoff))
+	check(notNegative("SERVICE_WEBHOOKS_MAX_BACKOFF", c.Webhooks.MaxBackoff))
+	check(positive("SERVICE_WEBHOOKS_SEND_TIMEOUT", c.Webhooks.SendTimeout))
+
+	check(oneOf("SERVICE_CACHE_STORE", c.Cache.Store, "none", "memory", "redis"))
+	check(positive("SERVICE_CACHE_CAPACITY", c.Cache.Capacity))
+	check(positive("SERVICE_CACHE_TTL", c.Cache.Ttl))
+	if c.Cache.Store == "redis" {
+		check(required("SERVICE_CACHE_REDIS_ADDR", c.Cache.RedisAddr))
+		check(notNegative("SERVICE_CACHE_REDIS_DB", c.Cache.RedisDb))
+	}
+
+	check(oneOf("SERVICE_TRACING_EXPORTER", c.Tracing.Exporter, "none", "otlp", "stdout", "file"))
+	check(required("SERVICE_TRACING_SERVICE_NAME", c.Tracing.ServiceName))
+	check(ratio("SERVICE_TRACING_SAMPLE_RATIO", c.Tracing.SampleRatio))
+
+	check(oneOf("SERVICE_LOG_LEVEL", c.Log.Level, "debug", "info", "warn", "error"))
+	check(oneOf("SERVICE_LOG_FORMAT", c.Log.Format, "json", "text"))
+	check(ratio("SERVICE_LOG_REQUEST_SAMPLE_RATIO", c.Log.RequestSampleRatio))
+
+	return errors.Join(errs...)
+}
+
+type number interface {
+	~int | ~int32 | ~int64 | ~uint64 | ~float64
+}
+
+func required(env, v string) error {
+	if v == "" {
+		return fmt.Errorf("%s: is required", env)
+	}
+
+	return nil
+}
+
+func together(env, v, otherEnv, other string) error {
+	if (v == "") != (other == "") {
+		return fmt.Errorf("%s: must be set together with %s", env, otherEnv)
+	}
+
+	return nil
+}
+
+func oneOf(env, v string, allowed ...string) error {
+	if !slices.Contains(allowed, v) {
+		return fmt.Errorf("%s: %q is not one of %s", env, v, strings.Join(allowed, ", "))
+	}
+
+	return nil
+}
+
+func positive[T number](env string, v T) error {
+	if v <= 0 {
+		return fmt.Errorf("%s: %v must be positive", env, v)
+	}
+
+	return nil
+}
+
+func notNegative[T number](env string, v T) error {
+	if v < 0 {
+		return fmt.Errorf("%s: %v must not be negative", env, v)
+	}
+
+	return nil
+}
+
+func ratio(env string, v float64) error {
+	if v < 0 || v > 1 {
+		return fmt.Errorf("%s: %v must be between 0 and 1", env, v)
+	}
+
+	return nil
+}
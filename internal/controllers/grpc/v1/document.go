@@ -0,0 +1,113 @@
+package v1
+
+import (
+	"context"
+
+	"github.com/v1adhope/flights/internal/entities"
+	flightsv1 "github.com/v1adhope/flights/pkg/pb/flights/v1"
+)
+
+type documentService struct {
+	flightsv1.UnimplementedDocumentServiceServer
+	documentU DocumentUsecaser
+}
+
+type documentReq struct {
+	Type        string `proto:"type" binding:"required,oneof='Passport' 'Id card' 'International passport'"`
+	Number      string `proto:"number" binding:"required,max=255,number"`
+	PassengerId string `proto:"passenger_id" binding:"required,uuid"`
+}
+
+func (r *documentReq) toEntity(id string) entities.Document {
+	return entities.Document{
+		Id:          id,
+		Type:        r.Type,
+		Number:      r.Number,
+		PassengerId: r.PassengerId,
+	}
+}
+
+func (s *documentService) CreateDocument(ctx context.Context, in *flightsv1.CreateDocumentRequest) (*flightsv1.CreateDocumentResponse, error) {
+	req := documentReq{
+		Type:        in.GetType(),
+		Number:      in.GetNumber(),
+		PassengerId: in.GetPassengerId(),
+	}
+
+	if err := validateReq(req); err != nil {
+		return nil, err
+	}
+
+	id, err := s.documentU.CreateDocument(ctx, req.toEntity(""))
+	if err != nil {
+		return nil, err
+	}
+
+	return &flightsv1.CreateDocumentResponse{Id: id}, nil
+}
+
+func (s *documentService) ReplaceDocument(ctx context.Context, in *flightsv1.ReplaceDocumentRequest) (*flightsv1.ReplaceDocumentResponse, error) {
+	params := idReq{in.GetId()}
+
+	if err := validateReq(params); err != nil {
+		return nil, err
+	}
+
+	req := documentReq{
+		Type:        in.GetType(),
+		Number:      in.GetNumber(),
+		PassengerId: in.GetPassengerId(),
+	}
+
+	if err := validateReq(req); err != nil {
+		return nil, err
+	}
+
+	if err := s.documentU.ReplaceDocument(ctx, req.toEntity(params.Value)); err != nil {
+		return nil, err
+	}
+
+	return &flightsv1.ReplaceDocumentResponse{}, nil
+}
+
+func (s *documentService) DeleteDocument(ctx context.Context, in *flightsv1.DeleteDocumentRequest) (*flightsv1.DeleteDocumentResponse, error) {
+	params := idReq{in.GetId()}
+
+	if err := validateReq(params); err != nil {
+		return nil, err
+	}
+
+	if err := s.documentU.DeleteDocument(ctx, entities.Id{Value: params.Value}); err != nil {
+		return nil, err
+	}
+
+	return &flightsv1.DeleteDocumentResponse{}, nil
+}
+
+func (s *documentService) ListDocumentsByPassenger(ctx context.Context, in *flightsv1.ListDocumentsByPassengerRequest) (*flightsv1.ListDocumentsByPassengerResponse, error) {
+	params := passengerIdReq{in.GetPassengerId()}
+
+	if err := validateReq(params); err != nil {
+		return nil, err
+	}
+
+	documents, err := s.documentU.GetDocumentsByPassengerId(ctx, entities.Id{Value: params.Value})
+	if err != nil {
+		return nil, err
+	}
+
+	resp := &flightsv1.ListDocumentsByPassengerResponse{
+		Documents: make([]*flightsv1.Document, 0, len(documents)),
+	}
+
+	for _, document := range documents {
+		resp.Documents = append(resp.Documents, &flightsv1.Document{
+			Id:          document.Id,
+			Type:        document.Type,
+			Number:      document.Number,
+			PassengerId: document.PassengerId,
+		})
+	}
+
+	return resp, nil
+}
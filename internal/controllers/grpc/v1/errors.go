@@ -0,0 +1,144 @@
+package v1
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"strings"
+	"time"
+
+	"github.com/v1adhope/flights/internal/controllers/http/i18n"
+	"github.com/v1adhope/flights/internal/controllers/http/validation"
+	"github.com/v1adhope/flights/internal/entities"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/protoadapt"
+	"google.golang.org/protobuf/types/known/durationpb"
+)
+
+// bindError marks an invalid request, like gin.ErrorTypeBind does in the HTTP API.
+type bindError struct {
+	err error
+}
+
+func (e *bindError) Error() string {
+	return e.err.Error()
+}
+
+func (e *bindError) Unwrap() error {
+	return e.err
+}
+
+// _codes maps categories of entities errors to status codes the way errorsHandler of the HTTP API maps them to HTTP statuses:
+// 422 is InvalidArgument, 204 is NotFound, 409 is AlreadyExists or FailedPrecondition and 403 is PermissionDenied.
+var _codes = map[entities.Category]codes.Code{
+	entities.CategoryInvalid:          codes.InvalidArgument,
+	entities.CategoryNotFound:         codes.NotFound,
+	entities.CategoryMissingReference: codes.FailedPrecondition,
+	entities.CategoryAlreadyExists:    codes.AlreadyExists,
+	entities.CategoryRuleViolation:    codes.PermissionDenied,
+	entities.CategoryUnavailable:      codes.Unavailable,
+}
+
+// errorsInterceptor maps errors to status codes, domain errors carry ErrorInfo with their code as the reason
+// and RetryInfo if they are retryable.
+func errorsInterceptor(log Logger) grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req any, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (any, error) {
+		resp, err := handler(ctx, req)
+		if err == nil {
+			return resp, nil
+		}
+
+		if _, ok := status.FromError(err); ok {
+			return resp, err
+		}
+
+		var bindErr *bindError
+
+		if errors.As(err, &bindErr) {
+			log.DebugCtx(ctx, err, "%s: %s", info.FullMethod, "InvalidArgument")
+			return nil, bindStatus(ctx, bindErr).Err()
+		}
+
+		if domainErr, ok := entities.AsError(err); ok {
+			if code, ok := _codes[domainErr.Category]; ok {
+				log.DebugCtx(ctx, err, "%s: %s", info.FullMethod, code)
+				return nil, domainStatus(code, domainErr).Err()
+			}
+		}
+
+		log.ErrorCtx(ctx, err, "%s: %s", info.FullMethod, "Internal")
+		return nil, status.Error(codes.Internal, "Internal error")
+	}
+}
+
+// bindStatus lists the rejected fields as BadRequest violations named as in the proto messages,
+// descriptions are in English and localized messages follow the accept-language metadata.
+func bindStatus(ctx context.Context, bindErr *bindError) *status.Status {
+	st := status.New(codes.InvalidArgument, bindErr.Error())
+
+	locale := i18n.Negotiate(strings.Join(metadata.ValueFromIncomingContext(ctx, "accept-language"), ","))
+	fieldErrs := validation.FieldErrors(bindErr, i18n.Fallback)
+	localized := validation.FieldErrors(bindErr, locale)
+
+	badRequest := &errdetails.BadRequest{
+		FieldViolations: make([]*errdetails.BadRequest_FieldViolation, 0, len(fieldErrs)),
+	}
+
+	for i, fieldErr := range fieldErrs {
+		badRequest.FieldViolations = append(badRequest.FieldViolations, &errdetails.BadRequest_FieldViolation{
+			Field:       fieldErr.Field,
+			Description: fieldErr.Message,
+			Reason:      fieldErr.Rule,
+			LocalizedMessage: &errdetails.LocalizedMessage{
+				Locale:  locale,
+				Message: localized[i].Message,
+			},
+		})
+	}
+
+	withDetails, err := st.WithDetails(badRequest)
+	if err != nil {
+		return st
+	}
+
+	return withDetails
+}
+
+func domainStatus(code codes.Code, domainErr *entities.Error) *status.Status {
+	st := status.New(code, domainErr.Message)
+
+	errInfo := &errdetails.ErrorInfo{
+		Reason:   domainErr.Code,
+		Domain:   "flights",
+		Metadata: map[string]string{},
+	}
+
+	for key, value := range domainErr.Details {
+		if str, ok := value.(string); ok {
+			errInfo.Metadata[key] = str
+			continue
+		}
+
+		data, _ := json.Marshal(value)
+		errInfo.Metadata[key] = string(data)
+	}
+
+	details := []protoadapt.MessageV1{errInfo}
+
+	if domainErr.Retryable {
+		details = append(details, &errdetails.RetryInfo{
+			RetryDelay: durationpb.New(time.Second),
+		})
+	}
+
+	withDetails, err := st.WithDetails(details...)
+	if err != nil {
+		return st
+	}
+
+	return withDetails
+}
@@ -0,0 +1,13 @@
+package v1
+
+type idReq struct {
+	Value string `proto:"id" binding:"required,uuid"`
+}
+
+type passengerIdReq struct {
+	Value string `proto:"passenger_id" binding:"required,uuid"`
+}
+
+type ticketIdReq struct {
+	Value string `proto:"ticket_id" binding:"required,uuid"`
+}
@@ -0,0 +1,598 @@
+package v1_test
+
+import (
+	"context"
+	"errors"
+	"io"
+	"net"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	grpcv1 "github.com/v1adhope/flights/internal/controllers/grpc/v1"
+	"github.com/v1adhope/flights/internal/entities"
+	"github.com/v1adhope/flights/internal/usecases"
+	"github.com/v1adhope/flights/internal/usecases/infrastructure/memory"
+	"github.com/v1adhope/flights/pkg/logger"
+	flightsv1 "github.com/v1adhope/flights/pkg/pb/flights/v1"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/health"
+	healthpb "google.golang.org/grpc/health/grpc_health_v1"
+	"google.golang.org/grpc/metadata"
+	reflectionpb "google.golang.org/grpc/reflection/grpc_reflection_v1"
+	"google.golang.org/grpc/status"
+	"google.golang.org/grpc/test/bufconn"
+)
+
+const _invalidId = "d1f0a6c2"
+
+type clients struct {
+	conn      *grpc.ClientConn
+	health    *health.Server
+	ticket    flightsv1.TicketServiceClient
+	passenger flightsv1.PassengerServiceClient
+	document  flightsv1.DocumentServiceClient
+	report    flightsv1.ReportServiceClient
+}
+
+// serve runs the gRPC API over repo on an in-memory listener.
+func serve(t *testing.T, repo usecases.Reposer) clients {
+	log := logger.New(
+		logger.WithLevel("error"),
+		logger.WithOutput(io.Discard),
+	)
+
+	srv := grpc.NewServer(grpc.ChainUnaryInterceptor(grpcv1.UnaryInterceptors(log)...))
+	healthSrv := grpcv1.Register(&grpcv1.Server{
+		Server:   srv,
+		Usecases: usecases.New(repo),
+	})
+
+	lis := bufconn.Listen(1 << 20)
+	go srv.Serve(lis)
+	t.Cleanup(srv.Stop)
+
+	conn, err := grpc.NewClient(
+		"passthrough:///bufconn",
+		grpc.WithContextDialer(func(ctx context.Context, _ string) (net.Conn, error) {
+			return lis.DialContext(ctx)
+		}),
+		grpc.WithTransportCredentials(insecure.NewCredentials()),
+	)
+	require.NoError(t, err)
+	t.Cleanup(func() {
+		conn.Close()
+	})
+
+	return clients{
+		conn:      conn,
+		health:    healthSrv,
+		ticket:    flightsv1.NewTicketServiceClient(conn),
+		passenger: flightsv1.NewPassengerServiceClient(conn),
+		document:  flightsv1.NewDocumentServiceClient(conn),
+		report:    flightsv1.NewReportServiceClient(conn),
+	}
+}
+
+func createTicketReq() *flightsv1.CreateTicketRequest {
+	return &flightsv1.CreateTicketRequest{
+		Provider: "Emirates",
+		FlyFrom:  "Moscow",
+		FlyTo:    "Hanoi",
+		FlyAt:    "3022-01-02T15:04:05+03:00",
+		ArriveAt: "3022-01-03T18:04:40+07:00",
+	}
+}
+
+func TestRPCs(t *testing.T) {
+	ctx := context.Background()
+	c := serve(t, memory.New())
+
+	ticket, err := c.ticket.CreateTicket(ctx, createTicketReq())
+	require.NoError(t, err)
+
+	_, err = c.ticket.ReplaceTicket(ctx, &flightsv1.ReplaceTicketRequest{
+		Id:       ticket.GetId(),
+		Provider: "Aeroflot",
+		FlyFrom:  "Moscow",
+		FlyTo:    "Hanoi",
+		FlyAt:    "3022-01-02T15:04:05+03:00",
+		ArriveAt: "3022-01-03T18:04:40+07:00",
+	})
+	require.NoError(t, err)
+
+	tickets, err := c.ticket.ListTickets(ctx, &flightsv1.ListTicketsRequest{})
+	require.NoError(t, err)
+	require.Len(t, tickets.GetTickets(), 1)
+	assert.Equal(t, ticket.GetId(), tickets.GetTickets()[0].GetId())
+	assert.Equal(t, "Aeroflot", tickets.GetTickets()[0].GetProvider())
+
+	passenger, err := c.passenger.CreatePassenger(ctx, &flightsv1.CreatePassengerRequest{
+		FirstName:  "Wendi",
+		LastName:   "Reyes",
+		MiddleName: "Mejia",
+	})
+	require.NoError(t, err)
+
+	_, err = c.passenger.ReplacePassenger(ctx, &flightsv1.ReplacePassengerRequest{
+		Id:         passenger.GetId(),
+		FirstName:  "Iris",
+		LastName:   "Reyes",
+		MiddleName: "Mejia",
+	})
+	require.NoError(t, err)
+
+	passengers, err := c.passenger.ListPassengers(ctx, &flightsv1.ListPassengersRequest{})
+	require.NoError(t, err)
+	require.Len(t, passengers.GetPassengers(), 1)
+	assert.Equal(t, "Iris", passengers.GetPassengers()[0].GetFirstName())
+
+	document, err := c.document.CreateDocument(ctx, &flightsv1.CreateDocumentRequest{
+		Type:        "Passport",
+		Number:      "4510123456",
+		PassengerId: passenger.GetId(),
+	})
+	require.NoError(t, err)
+
+	_, err = c.document.ReplaceDocument(ctx, &flightsv1.ReplaceDocumentRequest{
+		Id:          document.GetId(),
+		Type:        "International passport",
+		Number:      "751234567",
+		PassengerId: passenger.GetId(),
+	})
+	require.NoError(t, err)
+
+	documents, err := c.document.ListDocumentsByPassenger(ctx, &flightsv1.ListDocumentsByPassengerRequest{PassengerId: passenger.GetId()})
+	require.NoError(t, err)
+	require.Len(t, documents.GetDocuments(), 1)
+	assert.Equal(t, "International passport", documents.GetDocuments()[0].GetType())
+
+	_, err = c.passenger.BindToTicket(ctx, &flightsv1.BindToTicketRequest{Id: passenger.GetId(), TicketId: ticket.GetId()})
+	require.NoError(t, err)
+
+	bound, err := c.passenger.ListPassengersByTicket(ctx, &flightsv1.ListPassengersByTicketRequest{TicketId: ticket.GetId()})
+	require.NoError(t, err)
+	require.Len(t, bound.GetPassengers(), 1)
+	assert.Equal(t, passenger.GetId(), bound.GetPassengers()[0].GetId())
+
+	info, err := c.ticket.GetTicketWholeInfo(ctx, &flightsv1.GetTicketWholeInfoRequest{Id: ticket.GetId()})
+	require.NoError(t, err)
+	assert.Equal(t, ticket.GetId(), info.GetInfo().GetTicket().GetId())
+	require.Len(t, info.GetInfo().GetPassengers(), 1)
+	require.Len(t, info.GetInfo().GetPassengers()[0].GetDocuments(), 1)
+	assert.Equal(t, document.GetId(), info.GetInfo().GetPassengers()[0].GetDocuments()[0].GetId())
+
+	report, err := c.report.GetReportByPassengerForPeriod(ctx, &flightsv1.GetReportByPassengerForPeriodRequest{
+		PassengerId: passenger.GetId(),
+		From:        time.Now().Add(-time.Hour).Format(time.RFC3339),
+		To:          "4000-01-01T00:00:00Z",
+	})
+	require.NoError(t, err)
+	require.Len(t, report.GetRows(), 1)
+	assert.Equal(t, ticket.GetId(), report.GetRows()[0].GetTicketId())
+
+	_, err = c.passenger.UnbindFromTicket(ctx, &flightsv1.UnbindFromTicketRequest{Id: passenger.GetId(), TicketId: ticket.GetId()})
+	require.NoError(t, err)
+
+	_, err = c.document.DeleteDocument(ctx, &flightsv1.DeleteDocumentRequest{Id: document.GetId()})
+	require.NoError(t, err)
+
+	_, err = c.passenger.DeletePassenger(ctx, &flightsv1.DeletePassengerRequest{Id: passenger.GetId()})
+	require.NoError(t, err)
+
+	_, err = c.ticket.DeleteTicket(ctx, &flightsv1.DeleteTicketRequest{Id: ticket.GetId()})
+	require.NoError(t, err)
+
+	_, err = c.ticket.ListTickets(ctx, &flightsv1.ListTicketsRequest{})
+	assert.Equal(t, codes.NotFound, status.Code(err))
+}
+
+func TestInvalidArgument(t *testing.T) {
+	ctx := context.Background()
+	c := serve(t, memory.New())
+
+	pastTicket := createTicketReq()
+	pastTicket.FlyAt = "2001-01-02T15:04:05+03:00"
+	pastTicket.ArriveAt = "2001-01-01T15:04:05+03:00"
+
+	tcs := []struct {
+		key    string
+		call   func() error
+		fields []string
+		rules  []string
+	}{
+		{
+			key: "CreateTicket",
+			call: func() error {
+				_, err := c.ticket.CreateTicket(ctx, &flightsv1.CreateTicketRequest{FlyFrom: "M0scow", FlyAt: "tomorrow", ArriveAt: "3022-01-03T18:04:40+07:00"})
+				return err
+			},
+			fields: []string{"provider", "fly_from", "fly_to", "fly_at", "fly_at"},
+			rules:  []string{"required", "names", "required", "rfc3339Time", "flyght_before_now"},
+		},
+		{
+			key: "CreateTicketInPast",
+			call: func() error {
+				_, err := c.ticket.CreateTicket(ctx, pastTicket)
+				return err
+			},
+			fields: []string{"fly_at", "arrive_at"},
+			rules:  []string{"flyght_before_now", "arrive_before_fly"},
+		},
+		{
+			key: "ReplaceTicket",
+			call: func() error {
+				_, err := c.ticket.ReplaceTicket(ctx, &flightsv1.ReplaceTicketRequest{Id: _invalidId})
+				return err
+			},
+			fields: []string{"id"},
+			rules:  []string{"uuid"},
+		},
+		{
+			key: "DeleteTicket",
+			call: func() error {
+				_, err := c.ticket.DeleteTicket(ctx, &flightsv1.DeleteTicketRequest{Id: _invalidId})
+				return err
+			},
+			fields: []string{"id"},
+			rules:  []string{"uuid"},
+		},
+		{
+			key: "GetTicketWholeInfo",
+			call: func() error {
+				_, err := c.ticket.GetTicketWholeInfo(ctx, &flightsv1.GetTicketWholeInfoRequest{})
+				return err
+			},
+			fields: []string{"id"},
+			rules:  []string{"required"},
+		},
+		{
+			key: "CreatePassenger",
+			call: func() error {
+				_, err := c.passenger.CreatePassenger(ctx, &flightsv1.CreatePassengerRequest{FirstName: "W3ndi", LastName: "Reyes"})
+				return err
+			},
+			fields: []string{"first_name", "middle_name"},
+			rules:  []string{"names", "required"},
+		},
+		{
+			key: "ReplacePassenger",
+			call: func() error {
+				_, err := c.passenger.ReplacePassenger(ctx, &flightsv1.ReplacePassengerRequest{Id: _invalidId})
+				return err
+			},
+			fields: []string{"id"},
+			rules:  []string{"uuid"},
+		},
+		{
+			key: "DeletePassenger",
+			call: func() error {
+				_, err := c.passenger.DeletePassenger(ctx, &flightsv1.DeletePassengerRequest{Id: _invalidId})
+				return err
+			},
+			fields: []string{"id"},
+			rules:  []string{"uuid"},
+		},
+		{
+			key: "BindToTicket",
+			call: func() error {
+				_, err := c.passenger.BindToTicket(ctx, &flightsv1.BindToTicketRequest{Id: _invalidId})
+				return err
+			},
+			fields: []string{"id", "ticket_id"},
+			rules:  []string{"uuid", "required"},
+		},
+		{
+			key: "UnbindFromTicket",
+			call: func() error {
+				_, err := c.passenger.UnbindFromTicket(ctx, &flightsv1.UnbindFromTicketRequest{TicketId: _invalidId})
+				return err
+			},
+			fields: []string{"id", "ticket_id"},
+			rules:  []string{"required", "uuid"},
+		},
+		{
+			key: "ListPassengersByTicket",
+			call: func() error {
+				_, err := c.passenger.ListPassengersByTicket(ctx, &flightsv1.ListPassengersByTicketRequest{TicketId: _invalidId})
+				return err
+			},
+			fields: []string{"ticket_id"},
+			rules:  []string{"uuid"},
+		},
+		{
+			key: "CreateDocument",
+			call: func() error {
+				_, err := c.document.CreateDocument(ctx, &flightsv1.CreateDocumentRequest{Type: "Visa", Number: "45l0", PassengerId: _invalidId})
+				return err
+			},
+			fields: []string{"type", "number", "passenger_id"},
+			rules:  []string{"oneof", "number", "uuid"},
+		},
+		{
+			key: "ReplaceDocument",
+			call: func() error {
+				_, err := c.document.ReplaceDocument(ctx, &flightsv1.ReplaceDocumentRequest{Id: _invalidId})
+				return err
+			},
+			fields: []string{"id"},
+			rules:  []string{"uuid"},
+		},
+		{
+			key: "DeleteDocument",
+			call: func() error {
+				_, err := c.document.DeleteDocument(ctx, &flightsv1.DeleteDocumentRequest{Id: _invalidId})
+				return err
+			},
+			fields: []string{"id"},
+			rules:  []string{"uuid"},
+		},
+		{
+			key: "ListDocumentsByPassenger",
+			call: func() error {
+				_, err := c.document.ListDocumentsByPassenger(ctx, &flightsv1.ListDocumentsByPassengerRequest{PassengerId: _invalidId})
+				return err
+			},
+			fields: []string{"passenger_id"},
+			rules:  []string{"uuid"},
+		},
+		{
+			key: "GetReportByPassengerForPeriod",
+			call: func() error {
+				_, err := c.report.GetReportByPassengerForPeriod(ctx, &flightsv1.GetReportByPassengerForPeriodRequest{
+					PassengerId: "1efc3c2d-4b4e-6a3e-8f4a-0242ac120002",
+					From:        "2024-02-01T00:00:00Z",
+					To:          "2024-01-01T00:00:00Z",
+				})
+				return err
+			},
+			fields: []string{"to"},
+			rules:  []string{"from_after_to"},
+		},
+	}
+
+	for _, tc := range tcs {
+		t.Run(tc.key, func(t *testing.T) {
+			violations := fieldViolations(t, tc.call())
+
+			fields, rules := []string{}, []string{}
+			for _, violation := range violations {
+				fields = append(fields, violation.GetField())
+				rules = append(rules, violation.GetReason())
+
+				assert.NotEmpty(t, violation.GetDescription())
+				assert.Equal(t, "en", violation.GetLocalizedMessage().GetLocale())
+			}
+
+			assert.Equal(t, tc.fields, fields)
+			assert.Equal(t, tc.rules, rules)
+		})
+	}
+}
+
+func TestInvalidArgumentLocalized(t *testing.T) {
+	c := serve(t, memory.New())
+	ctx := metadata.AppendToOutgoingContext(context.Background(), "accept-language", "ru-RU, en;q=0.5")
+
+	_, err := c.ticket.DeleteTicket(ctx, &flightsv1.DeleteTicketRequest{Id: _invalidId})
+
+	violations := fieldViolations(t, err)
+	require.Len(t, violations, 1)
+	assert.Equal(t, "ru", violations[0].GetLocalizedMessage().GetLocale())
+	assert.NotEqual(t, violations[0].GetDescription(), violations[0].GetLocalizedMessage().GetMessage())
+}
+
+func fieldViolations(t *testing.T, err error) []*errdetails.BadRequest_FieldViolation {
+	st, ok := status.FromError(err)
+	require.True(t, ok, err)
+	require.Equal(t, codes.InvalidArgument, st.Code(), st.Message())
+
+	for _, detail := range st.Details() {
+		if badRequest, ok := detail.(*errdetails.BadRequest); ok {
+			return badRequest.GetFieldViolations()
+		}
+	}
+
+	require.Fail(t, "no BadRequest details", st.Details())
+
+	return nil
+}
+
+// failing lists tickets with err, the error of any usecase reaches the interceptor the same way.
+type failing struct {
+	usecases.Reposer
+	err error
+}
+
+func (r failing) GetTickets(ctx context.Context) ([]entities.Ticket, error) {
+	return []entities.Ticket{}, r.err
+}
+
+// panicking panics listing tickets.
+type panicking struct {
+	usecases.Reposer
+}
+
+func (r panicking) GetTickets(ctx context.Context) ([]entities.Ticket, error) {
+	panic("broken repository")
+}
+
+func TestRecovery(t *testing.T) {
+	ctx := context.Background()
+	c := serve(t, panicking{memory.New()})
+
+	_, err := c.ticket.ListTickets(ctx, &flightsv1.ListTicketsRequest{})
+
+	st, ok := status.FromError(err)
+	require.True(t, ok, err)
+	assert.Equal(t, codes.Internal, st.Code())
+	assert.Equal(t, "Internal error", st.Message())
+
+	// The server keeps serving
+	_, err = c.ticket.CreateTicket(ctx, createTicketReq())
+	assert.NoError(t, err)
+}
+
+func TestErrorCodes(t *testing.T) {
+	ctx := context.Background()
+
+	tcs := []struct {
+		key       string
+		err       error
+		code      codes.Code
+		reason    string
+		retryable bool
+	}{
+		{
+			key:    "Invalid",
+			err:    entities.ErrorIdempotencyKeyReused,
+			code:   codes.InvalidArgument,
+			reason: entities.ErrorIdempotencyKeyReused.Code,
+		},
+		{
+			key:    "NotFound",
+			err:    entities.ErrorNothingFound,
+			code:   codes.NotFound,
+			reason: entities.ErrorNothingFound.Code,
+		},
+		{
+			key:    "MissingReference",
+			err:    entities.ErrorTicketDoesNotExists,
+			code:   codes.FailedPrecondition,
+			reason: entities.ErrorTicketDoesNotExists.Code,
+		},
+		{
+			key:    "AlreadyExists",
+			err:    entities.ErrorHasAlreadyExists,
+			code:   codes.AlreadyExists,
+			reason: entities.ErrorHasAlreadyExists.Code,
+		},
+		{
+			key:    "RuleViolation",
+			err:    entities.ErrorsThereArePassengersOnTheFlight,
+			code:   codes.PermissionDenied,
+			reason: entities.ErrorsThereArePassengersOnTheFlight.Code,
+		},
+		{
+			key:       "Unavailable",
+			err:       entities.ErrorTryAgain,
+			code:      codes.Unavailable,
+			reason:    entities.ErrorTryAgain.Code,
+			retryable: true,
+		},
+		{
+			key:  "Wrapped",
+			err:  errors.Join(errors.New("repository: ticket: GetTickets"), entities.ErrorNothingFound.WithDetails(map[string]any{"ids": []string{"a"}})),
+			code: codes.NotFound,
+			// Details that aren't strings are sent as JSON
+			reason: entities.ErrorNothingFound.Code,
+		},
+		{
+			key:  "Internal",
+			err:  errors.New("repository: ticket: GetTickets: connection refused"),
+			code: codes.Internal,
+		},
+	}
+
+	for _, tc := range tcs {
+		t.Run(tc.key, func(t *testing.T) {
+			c := serve(t, failing{memory.New(), tc.err})
+
+			_, err := c.ticket.ListTickets(ctx, &flightsv1.ListTicketsRequest{})
+
+			st, ok := status.FromError(err)
+			require.True(t, ok, err)
+			assert.Equal(t, tc.code, st.Code())
+
+			errInfo, retryInfo := (*errdetails.ErrorInfo)(nil), (*errdetails.RetryInfo)(nil)
+			for _, detail := range st.Details() {
+				switch detail := detail.(type) {
+				case *errdetails.ErrorInfo:
+					errInfo = detail
+				case *errdetails.RetryInfo:
+					retryInfo = detail
+				}
+			}
+
+			if tc.code == codes.Internal {
+				// Internal errors never leak
+				assert.Equal(t, "Internal error", st.Message())
+				assert.Empty(t, st.Details())
+				return
+			}
+
+			require.NotNil(t, errInfo)
+			assert.Equal(t, tc.reason, errInfo.GetReason())
+			assert.Equal(t, "flights", errInfo.GetDomain())
+			assert.Equal(t, tc.retryable, retryInfo != nil)
+
+			if tc.key == "Wrapped" {
+				assert.Equal(t, `["a"]`, errInfo.GetMetadata()["ids"])
+			}
+		})
+	}
+}
+
+func TestHealth(t *testing.T) {
+	ctx := context.Background()
+	c := serve(t, memory.New())
+
+	health := healthpb.NewHealthClient(c.conn)
+
+	for _, service := range []string{
+		"",
+		flightsv1.TicketService_ServiceDesc.ServiceName,
+		flightsv1.PassengerService_ServiceDesc.ServiceName,
+		flightsv1.DocumentService_ServiceDesc.ServiceName,
+		flightsv1.ReportService_ServiceDesc.ServiceName,
+	} {
+		resp, err := health.Check(ctx, &healthpb.HealthCheckRequest{Service: service})
+		require.NoError(t, err, service)
+		assert.Equal(t, healthpb.HealthCheckResponse_SERVING, resp.GetStatus(), service)
+	}
+
+	_, err := health.Check(ctx, &healthpb.HealthCheckRequest{Service: "flights.v1.Unknown"})
+	assert.Equal(t, codes.NotFound, status.Code(err))
+
+	// Draining takes the server out of load balancing before it stops
+	c.health.Shutdown()
+
+	for _, service := range []string{"", flightsv1.TicketService_ServiceDesc.ServiceName} {
+		resp, err := health.Check(ctx, &healthpb.HealthCheckRequest{Service: service})
+		require.NoError(t, err, service)
+		assert.Equal(t, healthpb.HealthCheckResponse_NOT_SERVING, resp.GetStatus(), service)
+	}
+}
+
+func TestReflection(t *testing.T) {
+	ctx := context.Background()
+	c := serve(t, memory.New())
+
+	stream, err := reflectionpb.NewServerReflectionClient(c.conn).ServerReflectionInfo(ctx)
+	require.NoError(t, err)
+
+	require.NoError(t, stream.Send(&reflectionpb.ServerReflectionRequest{
+		MessageRequest: &reflectionpb.ServerReflectionRequest_ListServices{},
+	}))
+
+	resp, err := stream.Recv()
+	require.NoError(t, err)
+	require.NoError(t, stream.CloseSend())
+
+	services := []string{}
+	for _, service := range resp.GetListServicesResponse().GetService() {
+		services = append(services, service.GetName())
+	}
+
+	assert.Subset(t, services, []string{
+		flightsv1.TicketService_ServiceDesc.ServiceName,
+		flightsv1.PassengerService_ServiceDesc.ServiceName,
+		flightsv1.DocumentService_ServiceDesc.ServiceName,
+		flightsv1.ReportService_ServiceDesc.ServiceName,
+		healthpb.Health_ServiceDesc.ServiceName,
+	})
+}
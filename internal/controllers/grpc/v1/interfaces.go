@@ -0,0 +1,14 @@
+package v1
+
+import (
+	httpv1 "github.com/v1adhope/flights/internal/controllers/http/v1"
+)
+
+// The gRPC API is another transport over the same usecases as the HTTP one.
+type (
+	TicketUsecaser    = httpv1.TicketUsecaser
+	PassengerUsecaser = httpv1.PassengerUsecaser
+	DocumentUsecaser  = httpv1.DocumentUsecaser
+	ReportUsecaser    = httpv1.ReportUsecaser
+	Logger            = httpv1.Logger
+)
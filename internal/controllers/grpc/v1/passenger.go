@@ -0,0 +1,184 @@
+package v1
+
+import (
+	"context"
+
+	"github.com/v1adhope/flights/internal/entities"
+	flightsv1 "github.com/v1adhope/flights/pkg/pb/flights/v1"
+)
+
+type passengerService struct {
+	flightsv1.UnimplementedPassengerServiceServer
+	passengerU PassengerUsecaser
+}
+
+type passengerReq struct {
+	FirstName  string `proto:"first_name" binding:"required,max=255,names"`
+	LastName   string `proto:"last_name" binding:"required,max=255,names"`
+	MiddleName string `proto:"middle_name" binding:"required,max=255,names"`
+}
+
+type passengerBindingReq struct {
+	Id       string `proto:"id" binding:"required,uuid"`
+	TicketId string `proto:"ticket_id" binding:"required,uuid"`
+}
+
+func (s *passengerService) CreatePassenger(ctx context.Context, in *flightsv1.CreatePassengerRequest) (*flightsv1.CreatePassengerResponse, error) {
+	req := passengerReq{
+		FirstName:  in.GetFirstName(),
+		LastName:   in.GetLastName(),
+		MiddleName: in.GetMiddleName(),
+	}
+
+	if err := validateReq(req); err != nil {
+		return nil, err
+	}
+
+	id, err := s.passengerU.CreatePassenger(
+		ctx,
+		entities.Passenger{
+			FirstName:  req.FirstName,
+			LastName:   req.LastName,
+			MiddleName: req.MiddleName,
+		},
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	return &flightsv1.CreatePassengerResponse{Id: id.Value}, nil
+}
+
+func (s *passengerService) ReplacePassenger(ctx context.Context, in *flightsv1.ReplacePassengerRequest) (*flightsv1.ReplacePassengerResponse, error) {
+	params := idReq{in.GetId()}
+
+	if err := validateReq(params); err != nil {
+		return nil, err
+	}
+
+	req := passengerReq{
+		FirstName:  in.GetFirstName(),
+		LastName:   in.GetLastName(),
+		MiddleName: in.GetMiddleName(),
+	}
+
+	if err := validateReq(req); err != nil {
+		return nil, err
+	}
+
+	err := s.passengerU.ReplacePassenger(
+		ctx,
+		entities.Passenger{
+			Id:         params.Value,
+			FirstName:  req.FirstName,
+			LastName:   req.LastName,
+			MiddleName: req.MiddleName,
+		},
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	return &flightsv1.ReplacePassengerResponse{}, nil
+}
+
+func (s *passengerService) DeletePassenger(ctx context.Context, in *flightsv1.DeletePassengerRequest) (*flightsv1.DeletePassengerResponse, error) {
+	params := idReq{in.GetId()}
+
+	if err := validateReq(params); err != nil {
+		return nil, err
+	}
+
+	if err := s.passengerU.DeletePassenger(ctx, entities.Id{Value: params.Value}); err != nil {
+		return nil, err
+	}
+
+	return &flightsv1.DeletePassengerResponse{}, nil
+}
+
+func (s *passengerService) BindToTicket(ctx context.Context, in *flightsv1.BindToTicketRequest) (*flightsv1.BindToTicketResponse, error) {
+	req := passengerBindingReq{
+		Id:       in.GetId(),
+		TicketId: in.GetTicketId(),
+	}
+
+	if err := validateReq(req); err != nil {
+		return nil, err
+	}
+
+	err := s.passengerU.BoundToTicket(
+		ctx,
+		entities.Id{Value: req.Id},
+		entities.Id{Value: req.TicketId},
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	return &flightsv1.BindToTicketResponse{}, nil
+}
+
+func (s *passengerService) UnbindFromTicket(ctx context.Context, in *flightsv1.UnbindFromTicketRequest) (*flightsv1.UnbindFromTicketResponse, error) {
+	req := passengerBindingReq{
+		Id:       in.GetId(),
+		TicketId: in.GetTicketId(),
+	}
+
+	if err := validateReq(req); err != nil {
+		return nil, err
+	}
+
+	err := s.passengerU.UnboundToTicket(
+		ctx,
+		entities.Id{Value: req.Id},
+		entities.Id{Value: req.TicketId},
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	return &flightsv1.UnbindFromTicketResponse{}, nil
+}
+
+func (s *passengerService) ListPassengers(ctx context.Context, in *flightsv1.ListPassengersRequest) (*flightsv1.ListPassengersResponse, error) {
+	passengers, err := s.passengerU.GetPassengers(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	return &flightsv1.ListPassengersResponse{Passengers: toPbPassengers(passengers)}, nil
+}
+
+func (s *passengerService) ListPassengersByTicket(ctx context.Context, in *flightsv1.ListPassengersByTicketRequest) (*flightsv1.ListPassengersByTicketResponse, error) {
+	params := ticketIdReq{in.GetTicketId()}
+
+	if err := validateReq(params); err != nil {
+		return nil, err
+	}
+
+	passengers, err := s.passengerU.GetPassengersByTicketId(ctx, entities.Id{Value: params.Value})
+	if err != nil {
+		return nil, err
+	}
+
+	return &flightsv1.ListPassengersByTicketResponse{Passengers: toPbPassengers(passengers)}, nil
+}
+
+func toPbPassenger(passenger entities.Passenger) *flightsv1.Passenger {
+	return &flightsv1.Passenger{
+		Id:         passenger.Id,
+		FirstName:  passenger.FirstName,
+		LastName:   passenger.LastName,
+		MiddleName: passenger.MiddleName,
+	}
+}
+
+func toPbPassengers(passengers []entities.Passenger) []*flightsv1.Passenger {
+	pbPassengers := make([]*flightsv1.Passenger, 0, len(passengers))
+
+	for _, passenger := range passengers {
+		pbPassengers = append(pbPassengers, toPbPassenger(passenger))
+	}
+
+	return pbPassengers
+}
@@ -0,0 +1,68 @@
+package v1
+
+import (
+	"context"
+
+	"github.com/v1adhope/flights/internal/entities"
+	flightsv1 "github.com/v1adhope/flights/pkg/pb/flights/v1"
+)
+
+type reportService struct {
+	flightsv1.UnimplementedReportServiceServer
+	reportU ReportUsecaser
+}
+
+type reportReq struct {
+	From string `proto:"from" binding:"required"`
+	To   string `proto:"to" binding:"required"`
+}
+
+func (r reportReq) PeriodBounds() (string, string) {
+	return r.From, r.To
+}
+
+func (s *reportService) GetReportByPassengerForPeriod(ctx context.Context, in *flightsv1.GetReportByPassengerForPeriodRequest) (*flightsv1.GetReportByPassengerForPeriodResponse, error) {
+	params := passengerIdReq{in.GetPassengerId()}
+
+	if err := validateReq(params); err != nil {
+		return nil, err
+	}
+
+	req := reportReq{
+		From: in.GetFrom(),
+		To:   in.GetTo(),
+	}
+
+	if err := validateReq(req); err != nil {
+		return nil, err
+	}
+
+	rows, err := s.reportU.GetRowsByPassengerIdForPeriod(
+		ctx,
+		entities.Id{Value: params.Value},
+		entities.PeriodFilter{
+			From: req.From,
+			To:   req.To,
+		},
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	resp := &flightsv1.GetReportByPassengerForPeriodResponse{
+		Rows: make([]*flightsv1.ReportRowByPassengerForPeriod, 0, len(rows)),
+	}
+
+	for _, row := range rows {
+		resp.Rows = append(resp.Rows, &flightsv1.ReportRowByPassengerForPeriod{
+			DateOfIssue:     row.DateOfIssue,
+			FlyAt:           row.FlyAt,
+			TicketId:        row.TicketId,
+			FlyFrom:         row.FlyFrom,
+			FlyTo:           row.FlyTo,
+			ServiceProvided: row.ServiceProvided,
+		})
+	}
+
+	return resp, nil
+}
@@ -0,0 +1,69 @@
+package v1
+
+import (
+	"context"
+	"fmt"
+	"runtime/debug"
+
+	"github.com/v1adhope/flights/internal/usecases"
+	flightsv1 "github.com/v1adhope/flights/pkg/pb/flights/v1"
+	"github.com/v1adhope/flights/pkg/postgresql"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/health"
+	healthpb "google.golang.org/grpc/health/grpc_health_v1"
+	"google.golang.org/grpc/reflection"
+	"google.golang.org/grpc/status"
+)
+
+type Server struct {
+	Server   *grpc.Server
+	Usecases *usecases.Usecases
+}
+
+// Register returns the health server, its Shutdown reports every service as not serving once the server drains.
+func Register(s *Server) *health.Server {
+	flightsv1.RegisterTicketServiceServer(s.Server, &ticketService{ticketU: s.Usecases})
+	flightsv1.RegisterPassengerServiceServer(s.Server, &passengerService{passengerU: s.Usecases})
+	flightsv1.RegisterDocumentServiceServer(s.Server, &documentService{documentU: s.Usecases})
+	flightsv1.RegisterReportServiceServer(s.Server, &reportService{reportU: s.Usecases})
+
+	healthSrv := health.NewServer()
+	for name := range s.Server.GetServiceInfo() {
+		healthSrv.SetServingStatus(name, healthpb.HealthCheckResponse_SERVING)
+	}
+	healthpb.RegisterHealthServer(s.Server, healthSrv)
+
+	reflection.Register(s.Server)
+
+	return healthSrv
+}
+
+// UnaryInterceptors must be passed to grpc.NewServer of the server given to Register.
+func UnaryInterceptors(log Logger) []grpc.UnaryServerInterceptor {
+	return []grpc.UnaryServerInterceptor{
+		recoveryInterceptor(log),
+		errorsInterceptor(log),
+		readYourWritesInterceptor,
+	}
+}
+
+// recoveryInterceptor turns a panic of a handler into an Internal error, like gin.Recovery does in the HTTP API,
+// so one call can't take down the process serving both APIs.
+func recoveryInterceptor(log Logger) grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req any, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (resp any, err error) {
+		defer func() {
+			if r := recover(); r != nil {
+				log.ErrorCtx(ctx, fmt.Errorf("panic: %v\n%s", r, debug.Stack()), "%s: %s", info.FullMethod, "Internal")
+				resp, err = nil, status.Error(codes.Internal, "Internal error")
+			}
+		}()
+
+		return handler(ctx, req)
+	}
+}
+
+// readYourWritesInterceptor sends the reads of a call to the primary once the call wrote something.
+func readYourWritesInterceptor(ctx context.Context, req any, _ *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (any, error) {
+	return handler(postgresql.ReadYourWrites(ctx), req)
+}
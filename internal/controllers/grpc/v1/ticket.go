@@ -0,0 +1,164 @@
+package v1
+
+import (
+	"context"
+
+	"github.com/v1adhope/flights/internal/entities"
+	flightsv1 "github.com/v1adhope/flights/pkg/pb/flights/v1"
+)
+
+type ticketService struct {
+	flightsv1.UnimplementedTicketServiceServer
+	ticketU TicketUsecaser
+}
+
+type ticketReq struct {
+	Provider string `proto:"provider" binding:"required,max=255"`
+	FlyFrom  string `proto:"fly_from" binding:"required,max=255,names"`
+	FlyTo    string `proto:"fly_to" binding:"required,max=255,names"`
+	FlyAt    string `proto:"fly_at" binding:"required"`
+	ArriveAt string `proto:"arrive_at" binding:"required"`
+}
+
+func (r ticketReq) FlightTimes() (string, string) {
+	return r.FlyAt, r.ArriveAt
+}
+
+func (r *ticketReq) toEntity(id string) entities.Ticket {
+	return entities.Ticket{
+		Id:       id,
+		Provider: r.Provider,
+		FlyFrom:  r.FlyFrom,
+		FlyTo:    r.FlyTo,
+		FlyAt:    r.FlyAt,
+		ArriveAt: r.ArriveAt,
+	}
+}
+
+func (s *ticketService) CreateTicket(ctx context.Context, in *flightsv1.CreateTicketRequest) (*flightsv1.CreateTicketResponse, error) {
+	req := ticketReq{
+		Provider: in.GetProvider(),
+		FlyFrom:  in.GetFlyFrom(),
+		FlyTo:    in.GetFlyTo(),
+		FlyAt:    in.GetFlyAt(),
+		ArriveAt: in.GetArriveAt(),
+	}
+
+	if err := validateReq(req); err != nil {
+		return nil, err
+	}
+
+	id, err := s.ticketU.CreateTicket(ctx, req.toEntity(""))
+	if err != nil {
+		return nil, err
+	}
+
+	return &flightsv1.CreateTicketResponse{Id: id.Value}, nil
+}
+
+func (s *ticketService) ReplaceTicket(ctx context.Context, in *flightsv1.ReplaceTicketRequest) (*flightsv1.ReplaceTicketResponse, error) {
+	params := idReq{in.GetId()}
+
+	if err := validateReq(params); err != nil {
+		return nil, err
+	}
+
+	req := ticketReq{
+		Provider: in.GetProvider(),
+		FlyFrom:  in.GetFlyFrom(),
+		FlyTo:    in.GetFlyTo(),
+		FlyAt:    in.GetFlyAt(),
+		ArriveAt: in.GetArriveAt(),
+	}
+
+	if err := validateReq(req); err != nil {
+		return nil, err
+	}
+
+	if err := s.ticketU.ReplaceTicket(ctx, req.toEntity(params.Value)); err != nil {
+		return nil, err
+	}
+
+	return &flightsv1.ReplaceTicketResponse{}, nil
+}
+
+func (s *ticketService) DeleteTicket(ctx context.Context, in *flightsv1.DeleteTicketRequest) (*flightsv1.DeleteTicketResponse, error) {
+	params := idReq{in.GetId()}
+
+	if err := validateReq(params); err != nil {
+		return nil, err
+	}
+
+	if err := s.ticketU.DeleteTicket(ctx, entities.Id{Value: params.Value}); err != nil {
+		return nil, err
+	}
+
+	return &flightsv1.DeleteTicketResponse{}, nil
+}
+
+func (s *ticketService) ListTickets(ctx context.Context, in *flightsv1.ListTicketsRequest) (*flightsv1.ListTicketsResponse, error) {
+	tickets, err := s.ticketU.GetTickets(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	resp := &flightsv1.ListTicketsResponse{
+		Tickets: make([]*flightsv1.Ticket, 0, len(tickets)),
+	}
+
+	for _, ticket := range tickets {
+		resp.Tickets = append(resp.Tickets, toPbTicket(ticket))
+	}
+
+	return resp, nil
+}
+
+func (s *ticketService) GetTicketWholeInfo(ctx context.Context, in *flightsv1.GetTicketWholeInfoRequest) (*flightsv1.GetTicketWholeInfoResponse, error) {
+	params := idReq{in.GetId()}
+
+	if err := validateReq(params); err != nil {
+		return nil, err
+	}
+
+	info, err := s.ticketU.GetWholeInfoAboutTicket(ctx, entities.Id{Value: params.Value})
+	if err != nil {
+		return nil, err
+	}
+
+	pbInfo := &flightsv1.TicketWholeInfo{
+		Ticket:     toPbTicket(info.Ticket),
+		Passengers: make([]*flightsv1.PassengerWholeInfo, 0, len(info.Passengers)),
+	}
+
+	for _, passenger := range info.Passengers {
+		pbPassenger := &flightsv1.PassengerWholeInfo{
+			Passenger: toPbPassenger(passenger.Passenger),
+			Documents: make([]*flightsv1.Document, 0, len(passenger.Documents)),
+		}
+
+		for _, document := range passenger.Documents {
+			pbPassenger.Documents = append(pbPassenger.Documents, &flightsv1.Document{
+				Id:          document.Id,
+				Type:        document.Type,
+				Number:      document.Number,
+				PassengerId: passenger.Id,
+			})
+		}
+
+		pbInfo.Passengers = append(pbInfo.Passengers, pbPassenger)
+	}
+
+	return &flightsv1.GetTicketWholeInfoResponse{Info: pbInfo}, nil
+}
+
+func toPbTicket(ticket entities.Ticket) *flightsv1.Ticket {
+	return &flightsv1.Ticket{
+		Id:        ticket.Id,
+		Provider:  ticket.Provider,
+		FlyFrom:   ticket.FlyFrom,
+		FlyTo:     ticket.FlyTo,
+		FlyAt:     ticket.FlyAt,
+		ArriveAt:  ticket.ArriveAt,
+		CreatedAt: ticket.CreatedAt,
+	}
+}
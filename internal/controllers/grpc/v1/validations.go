@@ -0,0 +1,16 @@
+package v1
+
+import (
+	"github.com/v1adhope/flights/internal/controllers/http/validation"
+)
+
+// validate follows the rules of the HTTP API, the request structs reuse its binding tags and name fields as the proto messages do.
+var validate = validation.New(ticketReq{}, reportReq{})
+
+func validateReq(req any) error {
+	if err := validate.Struct(req); err != nil {
+		return &bindError{err}
+	}
+
+	return nil
+}
@@ -0,0 +1,110 @@
+// Package i18n holds the catalog of client-facing messages keyed by stable codes.
+package i18n
+
+import (
+	"embed"
+	"encoding/json"
+	"fmt"
+	"path"
+	"strings"
+
+	"github.com/v1adhope/flights/internal/entities"
+	"golang.org/x/text/language"
+)
+
+const (
+	English = "en"
+	Russian = "ru"
+	// Fallback is used when Accept-Language matches nothing and for keys missing in a locale.
+	Fallback = English
+)
+
+//go:embed locales/*.json
+var localesFS embed.FS
+
+var (
+	// supported starts with Fallback, the matcher returns the first tag on no match.
+	supported = []language.Tag{language.English, language.Russian}
+	matcher   = language.NewMatcher(supported)
+	catalog   = mustLoad()
+)
+
+func mustLoad() map[string]map[string]string {
+	c := map[string]map[string]string{}
+
+	for _, tag := range supported {
+		locale := tag.String()
+
+		data, err := localesFS.ReadFile(path.Join("locales", locale+".json"))
+		if err != nil {
+			panic(fmt.Sprintf("i18n: i18n: mustLoad: ReadFile: %v", err))
+		}
+
+		messages := map[string]string{}
+
+		if err := json.Unmarshal(data, &messages); err != nil {
+			panic(fmt.Sprintf("i18n: i18n: mustLoad: Unmarshal: %s: %v", locale, err))
+		}
+
+		c[locale] = messages
+	}
+
+	return c
+}
+
+// Negotiate picks the supported locale for an Accept-Language header value.
+func Negotiate(acceptLanguage string) string {
+	_, i := language.MatchStrings(matcher, acceptLanguage)
+
+	return supported[i].String()
+}
+
+// Message formats the message of the key, {name} placeholders are replaced with args given as name, value pairs.
+// Unknown keys are returned as is.
+func Message(locale, key string, args ...string) string {
+	message, ok := Lookup(locale, key, args...)
+	if !ok {
+		return key
+	}
+
+	return message
+}
+
+// Lookup is Message reporting whether the key is in the catalog.
+func Lookup(locale, key string, args ...string) (string, bool) {
+	message, ok := catalog[locale][key]
+	if !ok {
+		message, ok = catalog[Fallback][key]
+	}
+
+	if !ok {
+		return "", false
+	}
+
+	if len(args) == 0 {
+		return message, true
+	}
+
+	oldnew := make([]string, 0, len(args))
+
+	for i := 0; i+1 < len(args); i += 2 {
+		oldnew = append(oldnew, "{"+args[i]+"}", args[i+1])
+	}
+
+	return strings.NewReplacer(oldnew...).Replace(message), true
+}
+
+// ErrorMessage translates the entities error in the chain of err by its code, ok is false if there is none.
+// Errors missing in the catalog keep their English message.
+func ErrorMessage(locale string, err error) (string, bool) {
+	domainErr, ok := entities.AsError(err)
+	if !ok {
+		return "", false
+	}
+
+	if message, ok := Lookup(locale, "error."+domainErr.Code); ok {
+		return message, true
+	}
+
+	return domainErr.Message, true
+}
@@ -0,0 +1,28 @@
+// Package middleware holds the gin middlewares shared by every version of the HTTP API.
+package middleware
+
+import (
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/v1adhope/flights/pkg/metrics"
+)
+
+// _unmatchedRoute labels requests no route matched, so raw paths never become labels.
+const _unmatchedRoute = "unmatched"
+
+// Metrics records every request by the template of its route.
+func Metrics(m *metrics.Metrics) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		start := time.Now()
+
+		c.Next()
+
+		route := c.FullPath()
+		if route == "" {
+			route = _unmatchedRoute
+		}
+
+		m.ObserveRequest(c.Request.Method, route, c.Writer.Status(), time.Since(start))
+	}
+}
@@ -0,0 +1,110 @@
+package middleware
+
+import (
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/v1adhope/flights/pkg/postgresql"
+)
+
+// PrimaryCookie holds the time in Unix milliseconds until which the reads of a client go to the primary.
+const PrimaryCookie = "flights_primary_until"
+
+// ReadYourWrites sends the reads of a request to the primary once the request wrote something,
+// so a request never misses its own writes on a lagging replica.
+// The response to a request that wrote pins the client to the primary for window with PrimaryCookie,
+// so its next requests don't miss the write either. A zero window pins the request only.
+func ReadYourWrites(window time.Duration) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		ctx := c.Request.Context()
+
+		if window > 0 && pinnedUntil(c, window) {
+			ctx = postgresql.ForcePrimary(ctx)
+		} else {
+			ctx = postgresql.ReadYourWrites(ctx)
+		}
+
+		c.Request = c.Request.WithContext(ctx)
+
+		if window <= 0 {
+			c.Next()
+			return
+		}
+
+		w := &primaryWriter{ResponseWriter: c.Writer, c: c, window: window}
+		c.Writer = w
+
+		c.Next()
+
+		// Responses without a body, e.g. 204, are sent by gin after the handlers
+		w.pin()
+	}
+}
+
+// pinnedUntil reports whether the cookie pins the client now, times further than window ahead are ignored.
+func pinnedUntil(c *gin.Context, window time.Duration) bool {
+	value, err := c.Cookie(PrimaryCookie)
+	if err != nil {
+		return false
+	}
+
+	ms, err := strconv.ParseInt(value, 10, 64)
+	if err != nil {
+		return false
+	}
+
+	until, now := time.UnixMilli(ms), time.Now()
+
+	return until.After(now) && !until.After(now.Add(window))
+}
+
+// primaryWriter sets PrimaryCookie right before the header is sent if the request wrote.
+type primaryWriter struct {
+	gin.ResponseWriter
+	c      *gin.Context
+	window time.Duration
+	done   bool
+}
+
+func (w *primaryWriter) pin() {
+	if w.done || w.Written() {
+		return
+	}
+
+	w.done = true
+
+	if !postgresql.Wrote(w.c.Request.Context()) {
+		return
+	}
+
+	http.SetCookie(w, &http.Cookie{
+		Name:     PrimaryCookie,
+		Value:    strconv.FormatInt(time.Now().Add(w.window).UnixMilli(), 10),
+		Path:     "/",
+		MaxAge:   int(w.window.Round(time.Second).Seconds()) + 1,
+		HttpOnly: true,
+		SameSite: http.SameSiteLaxMode,
+	})
+}
+
+func (w *primaryWriter) WriteHeaderNow() {
+	w.pin()
+	w.ResponseWriter.WriteHeaderNow()
+}
+
+func (w *primaryWriter) Write(data []byte) (int, error) {
+	w.pin()
+	return w.ResponseWriter.Write(data)
+}
+
+func (w *primaryWriter) WriteString(s string) (int, error) {
+	w.pin()
+	return w.ResponseWriter.WriteString(s)
+}
+
+func (w *primaryWriter) Flush() {
+	w.pin()
+	w.ResponseWriter.Flush()
+}
@@ -0,0 +1,121 @@
+package middleware_test
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/v1adhope/flights/internal/controllers/http/middleware"
+	"github.com/v1adhope/flights/pkg/postgresql"
+)
+
+const _window = time.Second
+
+// newRouter serves POST and DELETE as writes and GET as a read reporting whether it goes to the primary.
+func newRouter(window time.Duration) *gin.Engine {
+	gin.SetMode(gin.TestMode)
+	router := gin.New()
+	router.Use(middleware.ReadYourWrites(window))
+
+	// Conn pins the scope of ctx without connecting
+	write := func(c *gin.Context) {
+		(&postgresql.Driver{}).Conn(c.Request.Context())
+	}
+
+	router.POST("/json", func(c *gin.Context) {
+		write(c)
+		c.JSON(http.StatusCreated, gin.H{})
+	})
+	router.DELETE("/empty", func(c *gin.Context) {
+		write(c)
+		c.Status(http.StatusNoContent)
+	})
+	router.GET("/read", func(c *gin.Context) {
+		c.String(http.StatusOK, "%t", postgresql.PrimaryPinned(c.Request.Context()))
+	})
+
+	return router
+}
+
+func serve(router *gin.Engine, method, path string, cookies ...*http.Cookie) *httptest.ResponseRecorder {
+	req := httptest.NewRequest(method, path, nil)
+	for _, cookie := range cookies {
+		req.AddCookie(cookie)
+	}
+
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+
+	return w
+}
+
+func primaryCookie(t *testing.T, w *httptest.ResponseRecorder) *http.Cookie {
+	for _, cookie := range w.Result().Cookies() {
+		if cookie.Name == middleware.PrimaryCookie {
+			return cookie
+		}
+	}
+
+	t.Fatalf("no %s cookie in %v", middleware.PrimaryCookie, w.Header())
+
+	return nil
+}
+
+func TestReadYourWritesPinsClient(t *testing.T) {
+	router := newRouter(_window)
+
+	for _, tc := range []struct{ method, path string }{
+		{http.MethodPost, "/json"},
+		{http.MethodDelete, "/empty"},
+	} {
+		before := time.Now()
+		w := serve(router, tc.method, tc.path)
+
+		cookie := primaryCookie(t, w)
+		assert.True(t, cookie.HttpOnly, tc.path)
+
+		ms, err := strconv.ParseInt(cookie.Value, 10, 64)
+		require.NoError(t, err, tc.path)
+		assert.WithinDuration(t, before.Add(_window), time.UnixMilli(ms), 100*time.Millisecond, tc.path)
+	}
+
+	assert.Equal(t, "false", serve(router, http.MethodGet, "/read").Body.String(), "a client without the cookie reads from replicas")
+}
+
+func TestReadYourWritesCookie(t *testing.T) {
+	router := newRouter(_window)
+
+	read := func(cookie *http.Cookie) string {
+		w := serve(router, http.MethodGet, "/read", cookie)
+
+		// Reads don't set the cookie, so they don't extend the window
+		assert.Empty(t, w.Result().Cookies())
+
+		return w.Body.String()
+	}
+
+	until := func(d time.Duration) *http.Cookie {
+		return &http.Cookie{Name: middleware.PrimaryCookie, Value: strconv.FormatInt(time.Now().Add(d).UnixMilli(), 10)}
+	}
+
+	assert.Equal(t, "true", read(until(_window/2)), "within the window")
+	assert.Equal(t, "false", read(until(-time.Millisecond)), "expired")
+	assert.Equal(t, "false", read(until(time.Hour)), "further than the window")
+	assert.Equal(t, "false", read(&http.Cookie{Name: middleware.PrimaryCookie, Value: "soon"}), "malformed")
+
+	// A write of a pinned client extends the window
+	w := serve(router, http.MethodPost, "/json", until(_window/2))
+	primaryCookie(t, w)
+}
+
+func TestReadYourWritesWithoutWindow(t *testing.T) {
+	w := serve(newRouter(0), http.MethodPost, "/json")
+
+	assert.Equal(t, http.StatusCreated, w.Code)
+	assert.Empty(t, w.Result().Cookies())
+}
@@ -0,0 +1,55 @@
+package middleware
+
+import (
+	"log/slog"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"github.com/v1adhope/flights/pkg/logger"
+)
+
+const (
+	RequestIDHeader = "X-Request-ID"
+
+	_requestIDMaxLen = 128
+	_requestIDKey    = "requestId"
+)
+
+// RequestID propagates the X-Request-ID of the client or generates one, it is sent back
+// and every log of the request carries it as request_id.
+func RequestID() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		id := c.GetHeader(RequestIDHeader)
+		if !validRequestID(id) {
+			id = uuid.NewString()
+		}
+
+		c.Header(RequestIDHeader, id)
+		c.Set(_requestIDKey, id)
+
+		ctx := logger.ContextWith(c.Request.Context(), slog.String("request_id", id))
+		c.Request = c.Request.WithContext(ctx)
+
+		c.Next()
+	}
+}
+
+// GetRequestID returns the id RequestID set for the request.
+func GetRequestID(c *gin.Context) string {
+	return c.GetString(_requestIDKey)
+}
+
+// validRequestID accepts visible ASCII only, so ids of clients can't forge log lines.
+func validRequestID(id string) bool {
+	if id == "" || len(id) > _requestIDMaxLen {
+		return false
+	}
+
+	for i := 0; i < len(id); i++ {
+		if id[i] < '!' || id[i] > '~' {
+			return false
+		}
+	}
+
+	return true
+}
@@ -0,0 +1,82 @@
+package middleware
+
+import (
+	"log/slog"
+	"math"
+	"math/rand/v2"
+	"net/http"
+	"sync/atomic"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/v1adhope/flights/pkg/logger"
+)
+
+// SampleRatio is the ratio of successful requests RequestLog logs, it may be changed while serving.
+type SampleRatio struct {
+	bits atomic.Uint64
+}
+
+func NewSampleRatio(ratio float64) *SampleRatio {
+	r := &SampleRatio{}
+	r.Set(ratio)
+
+	return r
+}
+
+func (r *SampleRatio) Set(ratio float64) {
+	r.bits.Store(math.Float64bits(ratio))
+}
+
+func (r *SampleRatio) Load() float64 {
+	return math.Float64frombits(r.bits.Load())
+}
+
+// RequestLog logs one line per request. Successful requests are sampled with sampleRatio,
+// client and server errors are always logged.
+func RequestLog(log *logger.Log, sampleRatio *SampleRatio) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		start := time.Now()
+
+		c.Next()
+
+		status := c.Writer.Status()
+
+		level := slog.LevelInfo
+		switch {
+		case status >= http.StatusInternalServerError:
+			level = slog.LevelError
+		case status >= http.StatusBadRequest:
+			level = slog.LevelWarn
+		}
+
+		if level == slog.LevelInfo && rand.Float64() >= sampleRatio.Load() {
+			return
+		}
+
+		route := c.FullPath()
+		if route == "" {
+			route = _unmatchedRoute
+		}
+
+		attrs := []slog.Attr{
+			slog.String("method", c.Request.Method),
+			slog.String("route", route),
+			slog.String("path", c.Request.URL.Path),
+			slog.Int("status", status),
+			slog.Float64("latency_ms", float64(time.Since(start).Microseconds())/1000),
+			slog.Int("bytes", max(c.Writer.Size(), 0)),
+			slog.String("client_ip", c.ClientIP()),
+		}
+
+		if user := c.GetString(gin.AuthUserKey); user != "" {
+			attrs = append(attrs, slog.String("user", user))
+		}
+
+		if len(c.Errors) > 0 {
+			attrs = append(attrs, slog.String("err", c.Errors.Last().Error()))
+		}
+
+		log.LogAttrs(c.Request.Context(), level, "request", attrs...)
+	}
+}
@@ -1,10 +1,11 @@
 package v1
 
 import (
-	"errors"
 	"net/http"
 
 	"github.com/gin-gonic/gin"
+	"github.com/v1adhope/flights/internal/controllers/http/i18n"
+	"github.com/v1adhope/flights/internal/controllers/http/validation"
 	"github.com/v1adhope/flights/internal/entities"
 )
 
@@ -16,47 +17,94 @@ func setAnyError(c *gin.Context, err error) {
 	c.Error(err).SetType(gin.ErrorTypeAny)
 }
 
-func abortWithErrorMsg(c *gin.Context, code int, msg string) {
-	c.AbortWithStatusJSON(code, gin.H{
+// locale negotiates the language of error messages, English unless the client asks otherwise.
+func locale(c *gin.Context) string {
+	locale := i18n.Negotiate(c.GetHeader("Accept-Language"))
+
+	c.Header("Content-Language", locale)
+	c.Header("Vary", "Accept-Language")
+
+	return locale
+}
+
+func abortWithErrorMsg(c *gin.Context, code int, domainErr *entities.Error) {
+	msg, _ := i18n.ErrorMessage(locale(c), domainErr)
+
+	body := gin.H{
 		"errMsg": msg,
+		"code":   domainErr.Code,
+	}
+
+	if len(domainErr.Details) > 0 {
+		body["details"] = domainErr.Details
+	}
+
+	c.AbortWithStatusJSON(code, body)
+}
+
+// abortWithBindError keeps errMsg for existing clients, errors lists the rejected fields.
+func abortWithBindError(c *gin.Context, err error) {
+	fieldErrs := validation.FieldErrors(err, locale(c))
+	if fieldErrs == nil {
+		fieldErrs = []validation.FieldError{}
+	}
+
+	c.AbortWithStatusJSON(http.StatusUnprocessableEntity, gin.H{
+		"errMsg": err.Error(),
+		"errors": fieldErrs,
 	})
 }
 
+// _statuses maps categories of entities errors to HTTP statuses, 204 is sent without a body.
+var _statuses = map[entities.Category]int{
+	entities.CategoryInvalid:          http.StatusUnprocessableEntity,
+	entities.CategoryNotFound:         http.StatusNoContent,
+	entities.CategoryMissingReference: http.StatusConflict,
+	entities.CategoryAlreadyExists:    http.StatusConflict,
+	entities.CategoryRuleViolation:    http.StatusForbidden,
+	entities.CategoryUnavailable:      http.StatusServiceUnavailable,
+	entities.CategoryInternal:         http.StatusInternalServerError,
+}
+
 func errorsHandler(log Logger) gin.HandlerFunc {
 	return func(c *gin.Context) {
 		c.Next()
 
 		for _, ginErr := range c.Errors {
-			err, errType := errors.Unwrap(ginErr.Err), ginErr.Type
-			_ = err
+			err, errType := ginErr.Err, ginErr.Type
 
 			switch errType {
 			case gin.ErrorTypeBind:
-				log.Debug(ginErr, "%s", "StatusUnprocessableEntity")
-				abortWithErrorMsg(c, http.StatusUnprocessableEntity, ginErr.Error())
+				log.DebugCtx(c.Request.Context(), ginErr, "%s", "StatusUnprocessableEntity")
+				abortWithBindError(c, err)
 				return
 			case gin.ErrorTypeAny:
-				switch {
-				case errors.Is(err, entities.ErrorNothingToChange),
-					errors.Is(err, entities.ErrorNothingToDelete),
-					errors.Is(err, entities.ErrorNothingFound):
-					log.Debug(ginErr, "%s", "StatusNoContent")
-					c.AbortWithStatus(http.StatusNoContent)
-					return
-				case errors.Is(err, entities.ErrorHasAlreadyExists),
-					errors.Is(err, entities.ErrorPassengerDoesNotExists),
-					errors.Is(err, entities.ErrorTicketDoesNotExists):
-					log.Debug(ginErr, "%s", "StatusConflict")
-					abortWithErrorMsg(c, http.StatusConflict, err.Error())
-					return
-				case errors.Is(err, entities.ErrorsThereArePassengersOnTheFlight):
-					log.Debug(ginErr, "%s", "StatusForbidden")
-					abortWithErrorMsg(c, http.StatusForbidden, err.Error())
+				domainErr, ok := entities.AsError(err)
+				if !ok || domainErr.Category == entities.CategoryInternal {
+					break
+				}
+
+				status, ok := _statuses[domainErr.Category]
+				if !ok {
+					break
+				}
+
+				log.DebugCtx(c.Request.Context(), ginErr, "%s", http.StatusText(status))
+
+				if domainErr.Retryable {
+					c.Header("Retry-After", "1")
+				}
+
+				if status == http.StatusNoContent {
+					c.AbortWithStatus(status)
 					return
 				}
+
+				abortWithErrorMsg(c, status, domainErr)
+				return
 			}
 
-			log.Error(ginErr, "%s", "StatusInternalServerError")
+			log.ErrorCtx(c.Request.Context(), ginErr, "%s", "StatusInternalServerError")
 			c.AbortWithStatus(http.StatusInternalServerError)
 			return
 		}
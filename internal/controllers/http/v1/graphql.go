@@ -0,0 +1,344 @@
+package v1
+
+import (
+	"context"
+	_ "embed"
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+	"github.com/go-playground/validator/v10"
+	"github.com/graph-gophers/graphql-go"
+	gqlerrors "github.com/graph-gophers/graphql-go/errors"
+	"github.com/graph-gophers/graphql-go/types"
+	"github.com/v1adhope/flights/internal/controllers/http/i18n"
+	"github.com/v1adhope/flights/internal/controllers/http/validation"
+	"github.com/v1adhope/flights/internal/entities"
+	"github.com/vektah/gqlparser/v2/ast"
+	"github.com/vektah/gqlparser/v2/parser"
+)
+
+const (
+	_graphqlMaxDepth      = 8
+	_graphqlMaxComplexity = 5000
+	// _graphqlListFactor is the assumed size of a list field when estimating complexity.
+	_graphqlListFactor = 10
+)
+
+//go:embed graphql.graphqls
+var graphqlSchema string
+
+type graphqlGroup struct {
+	rg            *gin.RouterGroup
+	uc            graphqlUsecaser
+	log           Logger
+	maxDepth      int
+	maxComplexity int
+}
+
+type graphqlUsecaser interface {
+	TicketUsecaser
+	PassengerUsecaser
+	DocumentUsecaser
+	ReportUsecaser
+	LoaderUsecaser
+}
+
+type graphqlHandler struct {
+	schema        *graphql.Schema
+	complexity    *graphqlComplexity
+	loaderU       LoaderUsecaser
+	log           Logger
+	maxComplexity int
+}
+
+func registerGraphqlGroup(group *graphqlGroup) {
+	if group.maxDepth <= 0 {
+		group.maxDepth = _graphqlMaxDepth
+	}
+
+	if group.maxComplexity <= 0 {
+		group.maxComplexity = _graphqlMaxComplexity
+	}
+
+	h := &graphqlHandler{
+		loaderU:       group.uc,
+		log:           group.log,
+		maxComplexity: group.maxComplexity,
+	}
+
+	h.schema = graphql.MustParseSchema(
+		graphqlSchema,
+		&graphqlResolver{
+			ticketU:    group.uc,
+			passengerU: group.uc,
+			documentU:  group.uc,
+			reportU:    group.uc,
+		},
+		graphql.MaxDepth(group.maxDepth),
+		graphql.MaxParallelism(_loaderMaxBatch),
+		graphql.PanicHandler(h),
+	)
+	h.complexity = newGraphqlComplexity(h.schema.ASTSchema())
+
+	group.rg.POST("/graphql", h.query)
+}
+
+type graphqlReq struct {
+	Query         string         `json:"query" binding:"required"`
+	OperationName string         `json:"operationName"`
+	Variables     map[string]any `json:"variables"`
+}
+
+// @tags GraphQL
+// @description Tickets, passengers, documents and reports as a graph, see graphql.graphqls for the schema.
+// @description Queries deeper than SERVICE_GRAPHQL_MAX_DEPTH levels or with estimated complexity over SERVICE_GRAPHQL_MAX_COMPLEXITY fields
+// @description are rejected, every list field counts as 10 items. Errors have extensions.code, one of
+// @description BAD_USER_INPUT, NOT_FOUND, CONFLICT, FORBIDDEN, UNAVAILABLE, QUERY_TOO_COMPLEX, INTERNAL,
+// @description domain errors also have extensions.reason with the code of the entities error.
+// @accept json
+// @produce json
+// @param query body graphqlReq true "GraphQL request"
+// @response 200
+// @response 422
+// @router /graphql [POST]
+func (h *graphqlHandler) query(c *gin.Context) {
+	req := graphqlReq{}
+
+	if err := c.ShouldBindJSON(&req); err != nil {
+		setBindError(c, err)
+		return
+	}
+
+	if complexity, ok := h.complexity.of(req.Query, req.OperationName, h.maxComplexity); !ok {
+		c.JSON(http.StatusOK, &graphql.Response{
+			Errors: []*gqlerrors.QueryError{{
+				Message: fmt.Sprintf("Query complexity exceeds %d, got at least %d", h.maxComplexity, complexity),
+				Extensions: map[string]any{
+					"code": "QUERY_TOO_COMPLEX",
+				},
+			}},
+		})
+		return
+	}
+
+	ctx := withGraphqlLoaders(c.Request.Context(), newGraphqlLoaders(h.loaderU))
+
+	resp := h.schema.Exec(ctx, req.Query, req.OperationName, req.Variables)
+
+	locale := i18n.Negotiate(c.GetHeader("Accept-Language"))
+
+	for _, queryErr := range resp.Errors {
+		h.present(ctx, locale, queryErr)
+	}
+
+	c.Header("Content-Language", locale)
+	c.Header("Vary", "Accept-Language")
+
+	c.JSON(http.StatusOK, resp)
+}
+
+// _graphqlCodes maps categories of entities errors to extensions.code.
+var _graphqlCodes = map[entities.Category]string{
+	entities.CategoryInvalid:          "BAD_USER_INPUT",
+	entities.CategoryNotFound:         "NOT_FOUND",
+	entities.CategoryMissingReference: "CONFLICT",
+	entities.CategoryAlreadyExists:    "CONFLICT",
+	entities.CategoryRuleViolation:    "FORBIDDEN",
+	entities.CategoryUnavailable:      "UNAVAILABLE",
+}
+
+// present maps resolver errors the way errorsHandler maps them to HTTP statuses and hides internal ones.
+func (h *graphqlHandler) present(ctx context.Context, locale string, queryErr *gqlerrors.QueryError) {
+	err := queryErr.ResolverError
+	if err == nil {
+		return
+	}
+
+	code := "INTERNAL"
+	fields := []validation.FieldError(nil)
+	validationErrs := validator.ValidationErrors{}
+	domainErr, isDomainErr := entities.AsError(err)
+
+	switch {
+	case errors.As(err, &validationErrs):
+		code = "BAD_USER_INPUT"
+		queryErr.Message = validationErrs.Error()
+		fields = validation.FieldErrors(validationErrs, locale)
+	case isDomainErr && _graphqlCodes[domainErr.Category] != "":
+		code = _graphqlCodes[domainErr.Category]
+		queryErr.Message, _ = i18n.ErrorMessage(locale, domainErr)
+	default:
+		h.log.ErrorCtx(ctx, err, "graphql: %v", queryErr.Path)
+		queryErr.Message = i18n.Message(locale, "problem.internal")
+	}
+
+	if queryErr.Extensions == nil {
+		queryErr.Extensions = map[string]any{}
+	}
+
+	queryErr.Extensions["code"] = code
+
+	if fields != nil {
+		queryErr.Extensions["fields"] = fields
+	}
+
+	if isDomainErr && code != "INTERNAL" {
+		queryErr.Extensions["reason"] = domainErr.Code
+		queryErr.Extensions["retryable"] = domainErr.Retryable
+
+		if len(domainErr.Details) > 0 {
+			queryErr.Extensions["details"] = domainErr.Details
+		}
+	}
+}
+
+func (h *graphqlHandler) MakePanicError(ctx context.Context, value any) *gqlerrors.QueryError {
+	h.log.ErrorCtx(ctx, fmt.Errorf("%v", value), "%s", "graphql: panic")
+
+	return &gqlerrors.QueryError{
+		Message: "Internal error",
+		Extensions: map[string]any{
+			"code": "INTERNAL",
+		},
+	}
+}
+
+type graphqlField struct {
+	typeName string
+	isList   bool
+}
+
+// graphqlComplexity estimates how many fields a query resolves before it is executed.
+type graphqlComplexity struct {
+	entryPoints map[string]string
+	fields      map[string]map[string]graphqlField
+}
+
+func newGraphqlComplexity(schema *types.Schema) *graphqlComplexity {
+	c := &graphqlComplexity{
+		entryPoints: map[string]string{},
+		fields:      map[string]map[string]graphqlField{},
+	}
+
+	for operation, t := range schema.EntryPoints {
+		c.entryPoints[operation] = t.TypeName()
+	}
+
+	for _, object := range schema.Objects {
+		fields := map[string]graphqlField{}
+
+		for _, field := range object.Fields {
+			info := graphqlField{}
+			t := field.Type
+
+			for {
+				switch v := t.(type) {
+				case *types.NonNull:
+					t = v.OfType
+					continue
+				case *types.List:
+					info.isList = true
+					t = v.OfType
+					continue
+				}
+
+				break
+			}
+
+			info.typeName = t.(types.NamedType).TypeName()
+			fields[field.Name] = info
+		}
+
+		c.fields[object.Name] = fields
+	}
+
+	return c
+}
+
+// of returns false with the complexity reached so far once it exceeds max.
+// Unparsable queries pass, the executor reports their errors.
+func (c *graphqlComplexity) of(query, operationName string, max int) (int, bool) {
+	doc, err := parser.ParseQuery(&ast.Source{Input: query})
+	if err != nil {
+		return 0, true
+	}
+
+	var op *ast.OperationDefinition
+
+	switch {
+	case operationName != "":
+		op = doc.Operations.ForName(operationName)
+	case len(doc.Operations) == 1:
+		op = doc.Operations[0]
+	}
+
+	if op == nil {
+		return 0, true
+	}
+
+	w := graphqlComplexityWalker{
+		c:       c,
+		doc:     doc,
+		max:     max,
+		visited: map[string]bool{},
+	}
+	w.selections(op.SelectionSet, c.entryPoints[string(op.Operation)], 1)
+
+	return w.total, w.total <= max
+}
+
+type graphqlComplexityWalker struct {
+	c       *graphqlComplexity
+	doc     *ast.QueryDocument
+	max     int
+	total   int
+	visited map[string]bool
+}
+
+func (w *graphqlComplexityWalker) selections(set ast.SelectionSet, typeName string, multiplier int) {
+	for _, selection := range set {
+		if w.total > w.max {
+			return
+		}
+
+		switch s := selection.(type) {
+		case *ast.Field:
+			w.total += multiplier
+
+			if strings.HasPrefix(s.Name, "__") {
+				continue
+			}
+
+			field, ok := w.c.fields[typeName][s.Name]
+			if !ok {
+				continue
+			}
+
+			childMultiplier := multiplier
+			if field.isList {
+				childMultiplier *= _graphqlListFactor
+			}
+
+			w.selections(s.SelectionSet, field.typeName, childMultiplier)
+		case *ast.InlineFragment:
+			fragmentType := typeName
+			if s.TypeCondition != "" {
+				fragmentType = s.TypeCondition
+			}
+
+			w.selections(s.SelectionSet, fragmentType, multiplier)
+		case *ast.FragmentSpread:
+			fragment := w.doc.Fragments.ForName(s.Name)
+			if fragment == nil || w.visited[s.Name] {
+				continue
+			}
+
+			w.visited[s.Name] = true
+			w.selections(fragment.SelectionSet, fragment.TypeCondition, multiplier)
+			delete(w.visited, s.Name)
+		}
+	}
+}
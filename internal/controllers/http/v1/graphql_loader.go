@@ -0,0 +1,162 @@
+package v1
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/v1adhope/flights/internal/entities"
+)
+
+const (
+	_loaderWait     = time.Millisecond
+	_loaderMaxBatch = 100
+	// _loaderTimeout bounds a batch read, it runs for every caller waiting on the batch.
+	_loaderTimeout = 10 * time.Second
+)
+
+// loader collects keys requested by concurrently resolved fields during a short wait
+// and fetches them with one batch read, so a list of N items costs one query instead of N.
+// Loaded values are cached for the request. A batch read doesn't stop when the caller that started it is canceled,
+// it keeps the values of its ctx but has its own timeout.
+type loader[V any] struct {
+	fetch func(ctx context.Context, keys []string) (map[string]V, error)
+
+	mu    sync.Mutex
+	batch *loaderBatch[V]
+	cache map[string]*loaderBatch[V]
+}
+
+type loaderBatch[V any] struct {
+	keys       []string
+	dispatched bool
+	done       chan struct{}
+	values     map[string]V
+	err        error
+}
+
+func newLoader[V any](fetch func(ctx context.Context, keys []string) (map[string]V, error)) *loader[V] {
+	return &loader[V]{
+		fetch: fetch,
+		cache: map[string]*loaderBatch[V]{},
+	}
+}
+
+// Load returns the zero value and ok false for a key the batch read did not find.
+func (l *loader[V]) Load(ctx context.Context, key string) (V, bool, error) {
+	l.mu.Lock()
+
+	b, ok := l.cache[key]
+	if !ok {
+		if l.batch == nil {
+			l.batch = &loaderBatch[V]{done: make(chan struct{})}
+
+			batch := l.batch
+			time.AfterFunc(_loaderWait, func() {
+				l.dispatch(ctx, batch)
+			})
+		}
+
+		b = l.batch
+		b.keys = append(b.keys, key)
+		l.cache[key] = b
+
+		if len(b.keys) >= _loaderMaxBatch {
+			go l.dispatch(ctx, b)
+		}
+	}
+
+	l.mu.Unlock()
+
+	var zero V
+
+	select {
+	case <-ctx.Done():
+		return zero, false, ctx.Err()
+	case <-b.done:
+	}
+
+	if b.err != nil {
+		return zero, false, b.err
+	}
+
+	value, ok := b.values[key]
+
+	return value, ok, nil
+}
+
+func (l *loader[V]) dispatch(ctx context.Context, b *loaderBatch[V]) {
+	l.mu.Lock()
+
+	if b.dispatched {
+		l.mu.Unlock()
+		return
+	}
+
+	b.dispatched = true
+
+	if l.batch == b {
+		l.batch = nil
+	}
+
+	l.mu.Unlock()
+
+	ctx, cancel := context.WithTimeout(context.WithoutCancel(ctx), _loaderTimeout)
+	defer cancel()
+
+	b.values, b.err = l.fetch(ctx, b.keys)
+
+	close(b.done)
+}
+
+type graphqlLoaders struct {
+	tickets              *loader[entities.Ticket]
+	passengers           *loader[entities.Passenger]
+	ticketsByPassenger   *loader[[]entities.Ticket]
+	passengersByTicket   *loader[[]entities.Passenger]
+	documentsByPassenger *loader[[]entities.Document]
+}
+
+func newGraphqlLoaders(loaderU LoaderUsecaser) *graphqlLoaders {
+	return &graphqlLoaders{
+		tickets: newLoader(func(ctx context.Context, ids []string) (map[string]entities.Ticket, error) {
+			tickets, err := loaderU.GetTicketsByIds(ctx, ids)
+			if err != nil {
+				return nil, err
+			}
+
+			byId := make(map[string]entities.Ticket, len(tickets))
+			for _, ticket := range tickets {
+				byId[ticket.Id] = ticket
+			}
+
+			return byId, nil
+		}),
+		passengers: newLoader(func(ctx context.Context, ids []string) (map[string]entities.Passenger, error) {
+			passengers, err := loaderU.GetPassengersByIds(ctx, ids)
+			if err != nil {
+				return nil, err
+			}
+
+			byId := make(map[string]entities.Passenger, len(passengers))
+			for _, passenger := range passengers {
+				byId[passenger.Id] = passenger
+			}
+
+			return byId, nil
+		}),
+		ticketsByPassenger:   newLoader(loaderU.GetTicketsByPassengerIds),
+		passengersByTicket:   newLoader(loaderU.GetPassengersByTicketIds),
+		documentsByPassenger: newLoader(loaderU.GetDocumentsByPassengerIds),
+	}
+}
+
+type graphqlLoadersKey struct{}
+
+func withGraphqlLoaders(ctx context.Context, loaders *graphqlLoaders) context.Context {
+	return context.WithValue(ctx, graphqlLoadersKey{}, loaders)
+}
+
+func graphqlLoadersFrom(ctx context.Context) *graphqlLoaders {
+	return ctx.Value(graphqlLoadersKey{}).(*graphqlLoaders)
+}
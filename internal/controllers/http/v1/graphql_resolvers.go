@@ -0,0 +1,473 @@
+package v1
+
+import (
+	"context"
+	"errors"
+
+	"github.com/gin-gonic/gin/binding"
+	"github.com/graph-gophers/graphql-go"
+	"github.com/v1adhope/flights/internal/entities"
+)
+
+type graphqlResolver struct {
+	ticketU    TicketUsecaser
+	passengerU PassengerUsecaser
+	documentU  DocumentUsecaser
+	reportU    ReportUsecaser
+}
+
+// INFO: queries
+
+func (r *graphqlResolver) Tickets(ctx context.Context) ([]*ticketResolver, error) {
+	tickets, err := r.ticketU.GetTickets(ctx)
+	if err != nil && !errors.Is(err, entities.ErrorNothingFound) {
+		return nil, err
+	}
+
+	return newTicketResolvers(tickets), nil
+}
+
+func (r *graphqlResolver) Ticket(ctx context.Context, args struct{ Id graphql.ID }) (*ticketResolver, error) {
+	params := id{string(args.Id)}
+
+	if err := binding.Validator.ValidateStruct(&params); err != nil {
+		return nil, err
+	}
+
+	ticket, ok, err := graphqlLoadersFrom(ctx).tickets.Load(ctx, params.Value)
+	if err != nil || !ok {
+		return nil, err
+	}
+
+	return &ticketResolver{ticket}, nil
+}
+
+func (r *graphqlResolver) Passengers(ctx context.Context) ([]*passengerResolver, error) {
+	passengers, err := r.passengerU.GetPassengers(ctx)
+	if err != nil && !errors.Is(err, entities.ErrorNothingFound) {
+		return nil, err
+	}
+
+	return newPassengerResolvers(passengers), nil
+}
+
+func (r *graphqlResolver) Passenger(ctx context.Context, args struct{ Id graphql.ID }) (*passengerResolver, error) {
+	params := id{string(args.Id)}
+
+	if err := binding.Validator.ValidateStruct(&params); err != nil {
+		return nil, err
+	}
+
+	passenger, ok, err := graphqlLoadersFrom(ctx).passengers.Load(ctx, params.Value)
+	if err != nil || !ok {
+		return nil, err
+	}
+
+	return &passengerResolver{passenger}, nil
+}
+
+func (r *graphqlResolver) ReportByPassengerForPeriod(ctx context.Context, args struct {
+	PassengerId graphql.ID
+	From        string
+	To          string
+}) ([]*reportRowResolver, error) {
+	params := id{string(args.PassengerId)}
+
+	if err := binding.Validator.ValidateStruct(&params); err != nil {
+		return nil, err
+	}
+
+	query := reportByPassengerIdForPeriodQuery{
+		From: args.From,
+		To:   args.To,
+	}
+
+	if err := binding.Validator.ValidateStruct(&query); err != nil {
+		return nil, err
+	}
+
+	rows, err := r.reportU.GetRowsByPassengerIdForPeriod(
+		ctx,
+		entities.Id{Value: params.Value},
+		entities.PeriodFilter{
+			From: query.From,
+			To:   query.To,
+		},
+	)
+	if err != nil && !errors.Is(err, entities.ErrorNothingFound) {
+		return nil, err
+	}
+
+	resolvers := make([]*reportRowResolver, 0, len(rows))
+	for _, row := range rows {
+		resolvers = append(resolvers, &reportRowResolver{row})
+	}
+
+	return resolvers, nil
+}
+
+// INFO: mutations
+
+// Replace, delete and unbind mutations return false when there was nothing to change,
+// as the HTTP API answers 204 in that case.
+func changed(err error) (bool, error) {
+	switch {
+	case err == nil:
+		return true, nil
+	case errors.Is(err, entities.ErrorNothingToChange),
+		errors.Is(err, entities.ErrorNothingToDelete),
+		errors.Is(err, entities.ErrorNothingFound):
+		return false, nil
+	}
+
+	return false, err
+}
+
+type ticketInput struct {
+	Provider string
+	FlyFrom  string
+	FlyTo    string
+	FlyAt    string
+	ArriveAt string
+}
+
+func (i *ticketInput) validated(ticketId string) (entities.Ticket, error) {
+	req := ticketCreateReq{
+		Provider: i.Provider,
+		FlyFrom:  i.FlyFrom,
+		FlyTo:    i.FlyTo,
+		FlyAt:    i.FlyAt,
+		ArriveAt: i.ArriveAt,
+	}
+
+	if err := binding.Validator.ValidateStruct(&req); err != nil {
+		return entities.Ticket{}, err
+	}
+
+	return entities.Ticket{
+		Id:       ticketId,
+		Provider: req.Provider,
+		FlyFrom:  req.FlyFrom,
+		FlyTo:    req.FlyTo,
+		FlyAt:    req.FlyAt,
+		ArriveAt: req.ArriveAt,
+	}, nil
+}
+
+func (r *graphqlResolver) CreateTicket(ctx context.Context, args struct{ Input ticketInput }) (graphql.ID, error) {
+	ticket, err := args.Input.validated("")
+	if err != nil {
+		return "", err
+	}
+
+	id, err := r.ticketU.CreateTicket(ctx, ticket)
+	if err != nil {
+		return "", err
+	}
+
+	return graphql.ID(id.Value), nil
+}
+
+func (r *graphqlResolver) ReplaceTicket(ctx context.Context, args struct {
+	Id    graphql.ID
+	Input ticketInput
+}) (bool, error) {
+	params := id{string(args.Id)}
+
+	if err := binding.Validator.ValidateStruct(&params); err != nil {
+		return false, err
+	}
+
+	ticket, err := args.Input.validated(params.Value)
+	if err != nil {
+		return false, err
+	}
+
+	return changed(r.ticketU.ReplaceTicket(ctx, ticket))
+}
+
+func (r *graphqlResolver) DeleteTicket(ctx context.Context, args struct{ Id graphql.ID }) (bool, error) {
+	params := id{string(args.Id)}
+
+	if err := binding.Validator.ValidateStruct(&params); err != nil {
+		return false, err
+	}
+
+	return changed(r.ticketU.DeleteTicket(ctx, entities.Id{Value: params.Value}))
+}
+
+type passengerInput struct {
+	FirstName  string
+	LastName   string
+	MiddleName string
+}
+
+func (i *passengerInput) validated(passengerId string) (entities.Passenger, error) {
+	req := passengerCreateReq{
+		FirstName:  i.FirstName,
+		LastName:   i.LastName,
+		MiddleName: i.MiddleName,
+	}
+
+	if err := binding.Validator.ValidateStruct(&req); err != nil {
+		return entities.Passenger{}, err
+	}
+
+	return entities.Passenger{
+		Id:         passengerId,
+		FirstName:  req.FirstName,
+		LastName:   req.LastName,
+		MiddleName: req.MiddleName,
+	}, nil
+}
+
+func (r *graphqlResolver) CreatePassenger(ctx context.Context, args struct{ Input passengerInput }) (graphql.ID, error) {
+	passenger, err := args.Input.validated("")
+	if err != nil {
+		return "", err
+	}
+
+	id, err := r.passengerU.CreatePassenger(ctx, passenger)
+	if err != nil {
+		return "", err
+	}
+
+	return graphql.ID(id.Value), nil
+}
+
+func (r *graphqlResolver) ReplacePassenger(ctx context.Context, args struct {
+	Id    graphql.ID
+	Input passengerInput
+}) (bool, error) {
+	params := id{string(args.Id)}
+
+	if err := binding.Validator.ValidateStruct(&params); err != nil {
+		return false, err
+	}
+
+	passenger, err := args.Input.validated(params.Value)
+	if err != nil {
+		return false, err
+	}
+
+	return changed(r.passengerU.ReplacePassenger(ctx, passenger))
+}
+
+func (r *graphqlResolver) DeletePassenger(ctx context.Context, args struct{ Id graphql.ID }) (bool, error) {
+	params := id{string(args.Id)}
+
+	if err := binding.Validator.ValidateStruct(&params); err != nil {
+		return false, err
+	}
+
+	return changed(r.passengerU.DeletePassenger(ctx, entities.Id{Value: params.Value}))
+}
+
+type bindingArgs struct {
+	PassengerId graphql.ID
+	TicketId    graphql.ID
+}
+
+func (a *bindingArgs) validated() (passengerBoundingTicketReq, error) {
+	req := passengerBoundingTicketReq{
+		Id:       string(a.PassengerId),
+		TicketId: string(a.TicketId),
+	}
+
+	if err := binding.Validator.ValidateStruct(&req); err != nil {
+		return passengerBoundingTicketReq{}, err
+	}
+
+	return req, nil
+}
+
+func (r *graphqlResolver) BindPassengerToTicket(ctx context.Context, args bindingArgs) (bool, error) {
+	req, err := args.validated()
+	if err != nil {
+		return false, err
+	}
+
+	err = r.passengerU.BoundToTicket(ctx, entities.Id{Value: req.Id}, entities.Id{Value: req.TicketId})
+	if err != nil {
+		return false, err
+	}
+
+	return true, nil
+}
+
+func (r *graphqlResolver) UnbindPassengerFromTicket(ctx context.Context, args bindingArgs) (bool, error) {
+	req, err := args.validated()
+	if err != nil {
+		return false, err
+	}
+
+	return changed(r.passengerU.UnboundToTicket(ctx, entities.Id{Value: req.Id}, entities.Id{Value: req.TicketId}))
+}
+
+type documentInput struct {
+	Type        string
+	Number      string
+	PassengerId graphql.ID
+}
+
+func (i *documentInput) validated(documentId string) (entities.Document, error) {
+	req := documentCreateReq{
+		Type:        i.Type,
+		Number:      i.Number,
+		PassengerId: string(i.PassengerId),
+	}
+
+	if err := binding.Validator.ValidateStruct(&req); err != nil {
+		return entities.Document{}, err
+	}
+
+	return entities.Document{
+		Id:          documentId,
+		Type:        req.Type,
+		Number:      req.Number,
+		PassengerId: req.PassengerId,
+	}, nil
+}
+
+func (r *graphqlResolver) CreateDocument(ctx context.Context, args struct{ Input documentInput }) (graphql.ID, error) {
+	document, err := args.Input.validated("")
+	if err != nil {
+		return "", err
+	}
+
+	id, err := r.documentU.CreateDocument(ctx, document)
+	if err != nil {
+		return "", err
+	}
+
+	return graphql.ID(id), nil
+}
+
+func (r *graphqlResolver) ReplaceDocument(ctx context.Context, args struct {
+	Id    graphql.ID
+	Input documentInput
+}) (bool, error) {
+	params := id{string(args.Id)}
+
+	if err := binding.Validator.ValidateStruct(&params); err != nil {
+		return false, err
+	}
+
+	document, err := args.Input.validated(params.Value)
+	if err != nil {
+		return false, err
+	}
+
+	return changed(r.documentU.ReplaceDocument(ctx, document))
+}
+
+func (r *graphqlResolver) DeleteDocument(ctx context.Context, args struct{ Id graphql.ID }) (bool, error) {
+	params := id{string(args.Id)}
+
+	if err := binding.Validator.ValidateStruct(&params); err != nil {
+		return false, err
+	}
+
+	return changed(r.documentU.DeleteDocument(ctx, entities.Id{Value: params.Value}))
+}
+
+// INFO: types
+
+type ticketResolver struct {
+	ticket entities.Ticket
+}
+
+func newTicketResolvers(tickets []entities.Ticket) []*ticketResolver {
+	resolvers := make([]*ticketResolver, 0, len(tickets))
+	for _, ticket := range tickets {
+		resolvers = append(resolvers, &ticketResolver{ticket})
+	}
+
+	return resolvers
+}
+
+func (r *ticketResolver) Id() graphql.ID    { return graphql.ID(r.ticket.Id) }
+func (r *ticketResolver) Provider() string  { return r.ticket.Provider }
+func (r *ticketResolver) FlyFrom() string   { return r.ticket.FlyFrom }
+func (r *ticketResolver) FlyTo() string     { return r.ticket.FlyTo }
+func (r *ticketResolver) FlyAt() string     { return r.ticket.FlyAt }
+func (r *ticketResolver) ArriveAt() string  { return r.ticket.ArriveAt }
+func (r *ticketResolver) CreatedAt() string { return r.ticket.CreatedAt }
+
+func (r *ticketResolver) Passengers(ctx context.Context) ([]*passengerResolver, error) {
+	passengers, _, err := graphqlLoadersFrom(ctx).passengersByTicket.Load(ctx, r.ticket.Id)
+	if err != nil {
+		return nil, err
+	}
+
+	return newPassengerResolvers(passengers), nil
+}
+
+type passengerResolver struct {
+	passenger entities.Passenger
+}
+
+func newPassengerResolvers(passengers []entities.Passenger) []*passengerResolver {
+	resolvers := make([]*passengerResolver, 0, len(passengers))
+	for _, passenger := range passengers {
+		resolvers = append(resolvers, &passengerResolver{passenger})
+	}
+
+	return resolvers
+}
+
+func (r *passengerResolver) Id() graphql.ID     { return graphql.ID(r.passenger.Id) }
+func (r *passengerResolver) FirstName() string  { return r.passenger.FirstName }
+func (r *passengerResolver) LastName() string   { return r.passenger.LastName }
+func (r *passengerResolver) MiddleName() string { return r.passenger.MiddleName }
+
+func (r *passengerResolver) Tickets(ctx context.Context) ([]*ticketResolver, error) {
+	tickets, _, err := graphqlLoadersFrom(ctx).ticketsByPassenger.Load(ctx, r.passenger.Id)
+	if err != nil {
+		return nil, err
+	}
+
+	return newTicketResolvers(tickets), nil
+}
+
+func (r *passengerResolver) Documents(ctx context.Context) ([]*documentResolver, error) {
+	documents, _, err := graphqlLoadersFrom(ctx).documentsByPassenger.Load(ctx, r.passenger.Id)
+	if err != nil {
+		return nil, err
+	}
+
+	resolvers := make([]*documentResolver, 0, len(documents))
+	for _, document := range documents {
+		resolvers = append(resolvers, &documentResolver{document})
+	}
+
+	return resolvers, nil
+}
+
+type documentResolver struct {
+	document entities.Document
+}
+
+func (r *documentResolver) Id() graphql.ID { return graphql.ID(r.document.Id) }
+func (r *documentResolver) Type() string   { return r.document.Type }
+func (r *documentResolver) Number() string { return r.document.Number }
+
+func (r *documentResolver) Passenger(ctx context.Context) (*passengerResolver, error) {
+	passenger, ok, err := graphqlLoadersFrom(ctx).passengers.Load(ctx, r.document.PassengerId)
+	if err != nil || !ok {
+		return nil, err
+	}
+
+	return &passengerResolver{passenger}, nil
+}
+
+type reportRowResolver struct {
+	row entities.ReportRowByPassengerForPeriod
+}
+
+func (r *reportRowResolver) DateOfIssue() string   { return r.row.DateOfIssue }
+func (r *reportRowResolver) FlyAt() string         { return r.row.FlyAt }
+func (r *reportRowResolver) TicketId() graphql.ID  { return graphql.ID(r.row.TicketId) }
+func (r *reportRowResolver) FlyFrom() string       { return r.row.FlyFrom }
+func (r *reportRowResolver) FlyTo() string         { return r.row.FlyTo }
+func (r *reportRowResolver) ServiceProvided() bool { return r.row.ServiceProvided }
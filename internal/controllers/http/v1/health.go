@@ -0,0 +1,61 @@
+package v1
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/v1adhope/flights/internal/entities"
+)
+
+type healthGroup struct {
+	root    *gin.Engine
+	rg      *gin.RouterGroup
+	healthU HealthUsecaser
+}
+
+// registerHealthGroup serves the probes of orchestrators at the root and the detailed health under /admin.
+func registerHealthGroup(group *healthGroup) {
+	group.root.GET("/healthz", group.live)
+	group.root.GET("/readyz", group.ready)
+
+	adminG := group.rg.Group("/admin")
+	{
+		adminG.GET("/health", group.health)
+	}
+}
+
+type healthResp struct {
+	Status string `json:"status" example:"up"`
+}
+
+// live answers while the process is up, it checks nothing else.
+func (g *healthGroup) live(c *gin.Context) {
+	c.JSON(http.StatusOK, healthResp{entities.HealthStatusUp})
+}
+
+// ready answers 503 when the database is unreachable or not migrated or the service is shutting down.
+func (g *healthGroup) ready(c *gin.Context) {
+	health := g.healthU.CheckHealth(c.Request.Context())
+
+	c.JSON(healthStatusCode(health), healthResp{health.Status})
+}
+
+// @tags Admin
+// @description Components are postgres (the pool is reachable), migrations (the database is at the expected version)
+// @description and shutdown (the service is not draining). Latencies are in milliseconds.
+// @response 200 {object} entities.Health
+// @response 503 {object} entities.Health
+// @router /admin/health [GET]
+func (g *healthGroup) health(c *gin.Context) {
+	health := g.healthU.CheckHealth(c.Request.Context())
+
+	c.JSON(healthStatusCode(health), health)
+}
+
+func healthStatusCode(health entities.Health) int {
+	if health.Status != entities.HealthStatusUp {
+		return http.StatusServiceUnavailable
+	}
+
+	return http.StatusOK
+}
@@ -0,0 +1,394 @@
+package v1
+
+import (
+	"bufio"
+	"context"
+	"encoding/csv"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+	"github.com/gin-gonic/gin/binding"
+	"github.com/v1adhope/flights/internal/controllers/http/i18n"
+	"github.com/v1adhope/flights/internal/controllers/http/validation"
+	"github.com/v1adhope/flights/internal/entities"
+)
+
+const (
+	_importFormatCsv    = "csv"
+	_importFormatNdjson = "ndjson"
+)
+
+var (
+	errImportUnknownFormat = errors.New("Unknown import file format, use csv or ndjson")
+	errImportMalformedFile = errors.New("Malformed import file")
+	errImportMalformedRow  = errors.New("Malformed import row")
+)
+
+type importGroup struct {
+	rg      *gin.RouterGroup
+	importU ImportUsecaser
+}
+
+func registerImportGroup(group *importGroup) {
+	importG := group.rg.Group("/imports")
+	{
+		importG.POST("/", group.create)
+	}
+}
+
+type importRowResult struct {
+	Row int    `json:"row" example:"1"`
+	Id  string `json:"id,omitempty" example:"uuid"`
+	// Error is why the row hasn't been imported, in the language of Accept-Language
+	Error string `json:"error,omitempty" example:"Has already exists"`
+	// Code is the code of the domain error, see entities
+	Code string `json:"code,omitempty" example:"already_exists"`
+	// Errors lists the rejected fields of an invalid row
+	Errors []validation.FieldError `json:"errors,omitempty"`
+}
+
+type importReport struct {
+	Mode    string            `json:"mode" example:"best-effort"`
+	Total   int               `json:"total" example:"2"`
+	Created int               `json:"created" example:"1"`
+	Failed  int               `json:"failed" example:"1"`
+	Rows    []importRowResult `json:"rows"`
+}
+
+type importQuery struct {
+	Entity string `form:"entity" binding:"required,oneof=tickets passengers documents bindings"`
+	Mode   string `form:"mode" binding:"omitempty,oneof=all-or-nothing best-effort"`
+	Format string `form:"format" binding:"omitempty,oneof=csv ndjson"`
+}
+
+// @tags Imports
+// @description CSV files must have a header row with the same field names as the JSON request entities.
+// @description Bindings rows are passengerBoundingTicketReq entities.
+// @description all-or-nothing mode writes nothing if any row is invalid or can't be stored, e.g. refers to a missing passenger.
+// @description Rows are numbered from 1, CSV rows by record after the header, NDJSON rows by line including blank ones.
+// @description The report lists the rows at fault with a localized error, the code of a domain error or the rejected fields.
+// @accept multipart/form-data
+// @param file formData file true "CSV or NDJSON file"
+// @param entity query string true "One of tickets, passengers, documents, bindings"
+// @param mode query string false "One of all-or-nothing (default), best-effort"
+// @param format query string false "One of csv, ndjson (by default detected from file extension)"
+// @response 200 {object} importReport
+// @response 201 {object} importReport
+// @response 409
+// @response 422 {object} importReport
+// @response 500
+// @router /imports/ [POST]
+func (g *importGroup) create(c *gin.Context) {
+	query := importQuery{}
+
+	if err := c.ShouldBindQuery(&query); err != nil {
+		setBindError(c, err)
+		return
+	}
+
+	if query.Mode == "" {
+		query.Mode = entities.ImportModeAllOrNothing
+	}
+
+	fileHeader, err := c.FormFile("file")
+	if err != nil {
+		setBindError(c, err)
+		return
+	}
+
+	if query.Format == "" {
+		query.Format = importFormatByFilename(fileHeader.Filename)
+	}
+
+	if query.Format == "" {
+		setBindError(c, errImportUnknownFormat)
+		return
+	}
+
+	file, err := fileHeader.Open()
+	if err != nil {
+		setAnyError(c, fmt.Errorf("v1: import: create: Open: %w", err))
+		return
+	}
+	defer file.Close()
+
+	var report importReport
+	locale := locale(c)
+
+	switch query.Entity {
+	case "tickets":
+		report, err = importRows(
+			c.Request.Context(),
+			file,
+			query,
+			locale,
+			func(req ticketCreateReq) entities.Ticket {
+				return entities.Ticket{
+					Provider: req.Provider,
+					FlyFrom:  req.FlyFrom,
+					FlyTo:    req.FlyTo,
+					FlyAt:    req.FlyAt,
+					ArriveAt: req.ArriveAt,
+				}
+			},
+			g.importU.ImportTickets,
+		)
+	case "passengers":
+		report, err = importRows(
+			c.Request.Context(),
+			file,
+			query,
+			locale,
+			func(req passengerCreateReq) entities.Passenger {
+				return entities.Passenger{
+					FirstName:  req.FirstName,
+					LastName:   req.LastName,
+					MiddleName: req.MiddleName,
+				}
+			},
+			g.importU.ImportPassengers,
+		)
+	case "documents":
+		report, err = importRows(
+			c.Request.Context(),
+			file,
+			query,
+			locale,
+			func(req documentCreateReq) entities.Document {
+				return entities.Document{
+					Type:        req.Type,
+					Number:      req.Number,
+					PassengerId: req.PassengerId,
+				}
+			},
+			g.importU.ImportDocuments,
+		)
+	case "bindings":
+		report, err = importRows(
+			c.Request.Context(),
+			file,
+			query,
+			locale,
+			func(req passengerBoundingTicketReq) entities.Binding {
+				return entities.Binding{
+					PassengerId: req.Id,
+					TicketId:    req.TicketId,
+				}
+			},
+			g.importU.ImportBindings,
+		)
+	}
+	if err != nil {
+		if errors.Is(err, errImportMalformedFile) {
+			setBindError(c, err)
+			return
+		}
+
+		setAnyError(c, err)
+		return
+	}
+
+	switch {
+	case report.Mode == entities.ImportModeAllOrNothing && report.Failed > 0:
+		c.JSON(http.StatusUnprocessableEntity, report)
+	case report.Mode == entities.ImportModeAllOrNothing:
+		c.JSON(http.StatusCreated, report)
+	default:
+		c.JSON(http.StatusOK, report)
+	}
+}
+
+type importFunc[T any] func(ctx context.Context, rows []entities.ImportRow[T], mode string) ([]entities.ImportRowResult, error)
+
+func importRows[Req any, T any](ctx context.Context, r io.Reader, query importQuery, locale string, toEntity func(Req) T, importF importFunc[T]) (importReport, error) {
+	results := []entities.ImportRowResult{}
+	rows := []entities.ImportRow[T]{}
+
+	err := decodeImportRows(r, query.Format, func(row int, req Req, err error) {
+		if err != nil {
+			results = append(results, entities.ImportRowResult{
+				Row: row,
+				Err: fmt.Errorf("%w: %w", errImportMalformedRow, err),
+			})
+			return
+		}
+
+		if err := binding.Validator.ValidateStruct(&req); err != nil {
+			results = append(results, entities.ImportRowResult{
+				Row: row,
+				Err: err,
+			})
+			return
+		}
+
+		rows = append(rows, entities.ImportRow[T]{
+			Row:   row,
+			Value: toEntity(req),
+		})
+	})
+	if err != nil {
+		return importReport{}, err
+	}
+
+	total := len(rows) + len(results)
+
+	// An invalid row fails an all-or-nothing import before anything is written
+	if len(rows) > 0 && (len(results) == 0 || query.Mode != entities.ImportModeAllOrNothing) {
+		imported, err := importF(ctx, rows, query.Mode)
+		if err != nil {
+			return importReport{}, err
+		}
+
+		results = append(results, imported...)
+	}
+
+	report := importReport{
+		Mode:  query.Mode,
+		Total: total,
+		Rows:  make([]importRowResult, 0, len(results)),
+	}
+
+	for _, result := range results {
+		if result.Err != nil {
+			report.Failed++
+		} else {
+			report.Created++
+		}
+
+		report.Rows = append(report.Rows, newImportRowResult(locale, result))
+	}
+
+	sort.Slice(report.Rows, func(i, j int) bool {
+		return report.Rows[i].Row < report.Rows[j].Row
+	})
+
+	return report, nil
+}
+
+// newImportRowResult explains the error of a row the way error responses do.
+func newImportRowResult(locale string, result entities.ImportRowResult) importRowResult {
+	row := importRowResult{
+		Row: result.Row,
+		Id:  result.Id,
+	}
+
+	if result.Err == nil {
+		return row
+	}
+
+	if fieldErrs := validation.FieldErrors(result.Err, locale); fieldErrs != nil {
+		row.Error = i18n.Message(locale, "problem.validation")
+		row.Errors = fieldErrs
+
+		return row
+	}
+
+	if domainErr, ok := entities.AsError(result.Err); ok && domainErr.Category != entities.CategoryInternal {
+		row.Error, _ = i18n.ErrorMessage(locale, domainErr)
+		row.Code = domainErr.Code
+
+		return row
+	}
+
+	if errors.Is(result.Err, errImportMalformedRow) {
+		row.Error = i18n.Message(locale, "problem.malformed_request")
+
+		return row
+	}
+
+	row.Error = i18n.Message(locale, "problem.internal")
+
+	return row
+}
+
+func decodeImportRows[Req any](r io.Reader, format string, fn func(row int, req Req, err error)) error {
+	switch format {
+	case _importFormatCsv:
+		return decodeImportCsvRows(r, fn)
+	case _importFormatNdjson:
+		return decodeImportNdjsonRows(r, fn)
+	}
+
+	return errImportUnknownFormat
+}
+
+func decodeImportCsvRows[Req any](r io.Reader, fn func(row int, req Req, err error)) error {
+	reader := csv.NewReader(r)
+	reader.TrimLeadingSpace = true
+
+	header, err := reader.Read()
+	if err != nil {
+		return fmt.Errorf("%w: header: %s", errImportMalformedFile, err)
+	}
+
+	for row := 1; ; row++ {
+		record, err := reader.Read()
+		if err == io.EOF {
+			return nil
+		}
+		if err != nil {
+			return fmt.Errorf("%w: row %d: %s", errImportMalformedFile, row, err)
+		}
+
+		fields := make(map[string]string, len(header))
+		for i, name := range header {
+			fields[strings.TrimSpace(name)] = record[i]
+		}
+
+		req, err := convertImportRow[Req](fields)
+		fn(row, req, err)
+	}
+}
+
+func decodeImportNdjsonRows[Req any](r io.Reader, fn func(row int, req Req, err error)) error {
+	scanner := bufio.NewScanner(r)
+
+	// A row is numbered by its line, blank lines are skipped but counted
+	for row := 1; scanner.Scan(); row++ {
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" {
+			continue
+		}
+
+		var req Req
+		err := json.Unmarshal([]byte(line), &req)
+		fn(row, req, err)
+	}
+
+	if err := scanner.Err(); err != nil {
+		return fmt.Errorf("%w: %s", errImportMalformedFile, err)
+	}
+
+	return nil
+}
+
+func convertImportRow[Req any](fields map[string]string) (Req, error) {
+	var req Req
+
+	data, err := json.Marshal(fields)
+	if err != nil {
+		return req, err
+	}
+
+	err = json.Unmarshal(data, &req)
+
+	return req, err
+}
+
+func importFormatByFilename(filename string) string {
+	switch strings.ToLower(filepath.Ext(filename)) {
+	case ".csv":
+		return _importFormatCsv
+	case ".ndjson", ".jsonl":
+		return _importFormatNdjson
+	}
+
+	return ""
+}
@@ -36,7 +36,38 @@ type ReportUsecaser interface {
 	GetRowsByPassengerIdForPeriod(ctx context.Context, id entities.Id, filter entities.PeriodFilter) ([]entities.ReportRowByPassengerForPeriod, error)
 }
 
+type ImportUsecaser interface {
+	ImportTickets(ctx context.Context, rows []entities.ImportRow[entities.Ticket], mode string) ([]entities.ImportRowResult, error)
+	ImportPassengers(ctx context.Context, rows []entities.ImportRow[entities.Passenger], mode string) ([]entities.ImportRowResult, error)
+	ImportDocuments(ctx context.Context, rows []entities.ImportRow[entities.Document], mode string) ([]entities.ImportRowResult, error)
+	ImportBindings(ctx context.Context, rows []entities.ImportRow[entities.Binding], mode string) ([]entities.ImportRowResult, error)
+}
+
+type WebhookUsecaser interface {
+	CreateWebhookSubscription(ctx context.Context, subscription entities.WebhookSubscription) (entities.WebhookSubscription, error)
+	ReplaceWebhookSubscription(ctx context.Context, subscription entities.WebhookSubscription) error
+	DeleteWebhookSubscription(ctx context.Context, id entities.Id) error
+	GetWebhookSubscriptions(ctx context.Context) ([]entities.WebhookSubscription, error)
+	GetWebhookSubscription(ctx context.Context, id entities.Id) (entities.WebhookSubscription, error)
+	GetWebhookDeliveries(ctx context.Context, subscriptionId entities.Id) ([]entities.WebhookDelivery, error)
+	RedeliverWebhookDelivery(ctx context.Context, subscriptionId, deliveryId entities.Id) error
+}
+
+// LoaderUsecaser is the batch reads behind GraphQL loaders.
+type LoaderUsecaser interface {
+	GetTicketsByIds(ctx context.Context, ids []string) ([]entities.Ticket, error)
+	GetTicketsByPassengerIds(ctx context.Context, ids []string) (map[string][]entities.Ticket, error)
+	GetPassengersByIds(ctx context.Context, ids []string) ([]entities.Passenger, error)
+	GetPassengersByTicketIds(ctx context.Context, ids []string) (map[string][]entities.Passenger, error)
+	GetDocumentsByPassengerIds(ctx context.Context, ids []string) (map[string][]entities.Document, error)
+}
+
+// Logger logs with the attributes of the context, e.g. the id of the request.
 type Logger interface {
-	Debug(err error, format string, msg ...any)
-	Error(err error, format string, msg ...any)
+	DebugCtx(ctx context.Context, err error, format string, msg ...any)
+	ErrorCtx(ctx context.Context, err error, format string, msg ...any)
+}
+
+type HealthUsecaser interface {
+	CheckHealth(ctx context.Context) entities.Health
 }
@@ -24,6 +24,10 @@ type reportByPassengerIdForPeriodQuery struct {
 	To   string `form:"to" binding:"required"`
 }
 
+func (r reportByPassengerIdForPeriodQuery) PeriodBounds() (string, string) {
+	return r.From, r.To
+}
+
 // @tags Reports
 // @param id path string true "Passenger id (uuid)"
 // @param from query string true "Perion start value" format(rfc3339Time)
@@ -2,11 +2,10 @@ package v1
 
 import (
 	"github.com/gin-gonic/gin"
-	"github.com/gin-gonic/gin/binding"
-	"github.com/go-playground/validator/v10"
 	swaggerfiles "github.com/swaggo/files"
 	ginSwagger "github.com/swaggo/gin-swagger"
 	docs "github.com/v1adhope/flights/docs"
+	"github.com/v1adhope/flights/internal/controllers/http/validation"
 	"github.com/v1adhope/flights/internal/usecases"
 	"github.com/v1adhope/flights/pkg/logger"
 )
@@ -15,16 +14,19 @@ type Router struct {
 	Handler  *gin.Engine
 	Usecases *usecases.Usecases
 	Log      *logger.Log
+	// Health endpoints are served only with a checker
+	Health HealthUsecaser
+	// Zero GraphQL limits fall back to defaults
+	GraphqlMaxDepth      int
+	GraphqlMaxComplexity int
 }
 
 func Register(r *Router) {
 	docs.SwaggerInfo.BasePath = "/v1"
 	docs.SwaggerInfo.Title = "Flights API"
 
-	if v, ok := binding.Validator.Engine().(*validator.Validate); ok {
-		v.RegisterValidation("names", names)
-		v.RegisterStructValidation(ticketCreateReqStructLevelValidation, ticketCreateReq{})
-		v.RegisterStructValidation(reportByPassengerIdForPeriodQueryStructLevelValidation, reportByPassengerIdForPeriodQuery{})
+	if v := validation.Gin(); v != nil {
+		validation.Register(v, ticketCreateReq{}, reportByPassengerIdForPeriodQuery{})
 	}
 
 	rg := r.Handler.Group("/v1")
@@ -36,6 +38,19 @@ func Register(r *Router) {
 		registgerPassengerGroup(&passengerGroup{rg, r.Usecases})
 		registerDocumentGroup(&documentGroup{rg, r.Usecases})
 		registerReportGroup(&reportGroup{rg, r.Usecases})
+		registerImportGroup(&importGroup{rg, r.Usecases})
+		registerWebhookGroup(&webhookGroup{rg, r.Usecases})
+		registerGraphqlGroup(&graphqlGroup{
+			rg:            rg,
+			uc:            r.Usecases,
+			log:           r.Log,
+			maxDepth:      r.GraphqlMaxDepth,
+			maxComplexity: r.GraphqlMaxComplexity,
+		})
+
+		if r.Health != nil {
+			registerHealthGroup(&healthGroup{r.Handler, rg, r.Health})
+		}
 	}
 }
 
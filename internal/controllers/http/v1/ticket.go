@@ -31,6 +31,10 @@ type ticketCreateReq struct {
 	ArriveAt string `json:"arriveAt" example:"3022-01-03T18:04:40+07:00" binding:"required"`
 }
 
+func (r ticketCreateReq) FlightTimes() (string, string) {
+	return r.FlyAt, r.ArriveAt
+}
+
 // @tags Tickets
 // @accept json
 // @param ticket body ticketCreateReq true "Ticket request entity"
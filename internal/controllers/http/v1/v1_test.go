@@ -1,72 +1,75 @@
 package v1_test
 
 import (
+	"bytes"
 	"context"
 	"encoding/json"
 	"fmt"
+	"io"
 	"log"
+	"mime/multipart"
 	"net/http"
 	"net/http/httptest"
 	"net/url"
 	"strings"
+	"sync/atomic"
 	"testing"
 	"time"
 
 	"github.com/gin-gonic/gin"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 	"github.com/stretchr/testify/suite"
+	"github.com/v1adhope/flights/db"
+	"github.com/v1adhope/flights/internal/controllers/http/middleware"
 	v1 "github.com/v1adhope/flights/internal/controllers/http/v1"
+	v2 "github.com/v1adhope/flights/internal/controllers/http/v2"
+	"github.com/v1adhope/flights/internal/entities"
 	"github.com/v1adhope/flights/internal/testhelpers"
 	"github.com/v1adhope/flights/internal/usecases"
-	"github.com/v1adhope/flights/internal/usecases/infrastructure/repository"
+	"github.com/v1adhope/flights/internal/usecases/infrastructure/memory"
+	"github.com/v1adhope/flights/internal/usecases/infrastructure/observer"
 	"github.com/v1adhope/flights/pkg/logger"
-	"github.com/v1adhope/flights/pkg/postgresql"
+	"github.com/v1adhope/flights/pkg/metrics"
+	"github.com/v1adhope/flights/pkg/migrator"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/propagation"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
 )
 
 const (
-	_pgMigrationsSourceUrl = "file://../../../../db/migrations"
-	_loggerLevel           = "debug"
-	_handlerMode           = gin.DebugMode
+	_loggerLevel = "debug"
+	_handlerMode = gin.DebugMode
 )
 
 type Suite struct {
 	suite.Suite
 	ctx    context.Context
-	pgC    *testhelpers.PostgresContainer
 	router *gin.Engine
 	utils  *testhelpers.Utils
+	repo   usecases.Reposer
+	spans  *tracetest.SpanRecorder
 }
 
 func (s *Suite) SetupSuite() {
-	t := s.T()
-
 	s.ctx = context.Background()
 
-	pgC, err := testhelpers.BuildContainer(s.ctx, _pgMigrationsSourceUrl)
-	if err != nil {
-		log.Fatalf("v1: v1_test: SetupSuite: BuildContainer: %v", err)
-	}
+	s.spans = tracetest.NewSpanRecorder()
+	tp := sdktrace.NewTracerProvider(sdktrace.WithSpanProcessor(s.spans))
+	otel.SetTextMapPropagator(propagation.TraceContext{})
 
-	s.pgC = pgC
+	repo := memory.New()
 
-	if err := s.pgC.MigrateUp(); err != nil {
-		log.Fatalf("v1: v1_test: SetupSuite: MigrateUp: %v", err)
-	}
+	mtr := metrics.New()
 
-	pd, err := postgresql.Build(
-		s.ctx,
-		postgresql.WithConnStr(pgC.ConnStr),
+	uc := usecases.New(
+		repo,
+		usecases.WithObserver(observer.NewMetrics(mtr)),
+		usecases.WithObserver(observer.NewTracing(tp)),
 	)
-	if err != nil {
-		log.Fatalf("v1: v1_test: SetupSuite: Build: %v", err)
-	}
-	t.Cleanup(func() {
-		pd.Close()
-	})
 
-	repo := repository.New(pd)
-
-	uc := usecases.New(repo)
+	s.repo = repo
 
 	log := logger.New(
 		logger.WithLevel(_loggerLevel),
@@ -74,22 +77,38 @@ func (s *Suite) SetupSuite() {
 
 	v1.SetMode(_handlerMode)
 	router := gin.New()
-	router.Use(gin.Logger(), gin.Recovery())
+	router.Use(
+		middleware.RequestID(),
+		middleware.Tracing(tp),
+		middleware.RequestLog(log, middleware.NewSampleRatio(1)),
+		gin.Recovery(),
+		middleware.Metrics(mtr),
+	)
+	router.GET("/metrics", gin.WrapH(mtr.Handler()))
 	v1.Register(&v1.Router{
 		Handler:  router,
 		Usecases: uc,
 		Log:      log,
+		Health:   newHealthChecker(repo),
+	})
+	v2.Register(&v2.Router{
+		Handler:  router,
+		Usecases: uc,
+		Log:      log,
 	})
 
 	s.router = router
 
-	s.utils = testhelpers.NewUtils(pd)
+	s.utils = testhelpers.NewUtils(repo)
 }
 
-func (s *Suite) TearDownSuite() {
-	if err := s.pgC.Terminate(s.ctx); err != nil {
-		log.Fatalf("v1: v1_test: TearDownSuite: Terminate: %v", err)
+func newHealthChecker(repo usecases.Reposer) *usecases.HealthChecker {
+	migrationVersion, err := migrator.Latest(db.Migrations)
+	if err != nil {
+		log.Fatalf("v1: v1_test: newHealthChecker: Latest: %v", err)
 	}
+
+	return usecases.NewHealthChecker(repo, migrationVersion, time.Second)
 }
 
 func TestSuite(t *testing.T) {
@@ -1176,6 +1195,7 @@ func (s *Suite) Test1sGetPassengersByTicketIdPositive() {
 // INFO: passanger,  ticket, document
 
 type ticketWholeInfo struct {
+	Id         string                     `json:"id"`
 	Provider   string                     `json:"provider"`
 	FlyFrom    string                     `json:"flyFrom"`
 	FlyTo      string                     `json:"flyTo"`
@@ -1214,6 +1234,7 @@ func (s *Suite) Test1tGetTicketWholeInfo() {
 			key: "1",
 			id:  s.utils.GetTicketByOffset(s.ctx, 0),
 			expected: ticketWholeInfo{
+				Id:       s.utils.GetTicketByOffset(s.ctx, 0),
 				Provider: "Emirates",
 				FlyFrom:  "Moscow",
 				FlyTo:    "Hanoi",
@@ -1408,3 +1429,1249 @@ func (s *Suite) Test1vGetReportByPassengerIdForPeriodNegative() {
 		}
 	})
 }
+
+// INFO: imports
+
+type importReport struct {
+	Mode    string            `json:"mode"`
+	Total   int               `json:"total"`
+	Created int               `json:"created"`
+	Failed  int               `json:"failed"`
+	Rows    []importRowResult `json:"rows"`
+}
+
+type importRowResult struct {
+	Row    int          `json:"row"`
+	Id     string       `json:"id"`
+	Error  string       `json:"error"`
+	Code   string       `json:"code"`
+	Errors []fieldError `json:"errors"`
+}
+
+func newImportRequest(query url.Values, filename, content string) (*http.Request, error) {
+	body := &bytes.Buffer{}
+	writer := multipart.NewWriter(body)
+
+	part, err := writer.CreateFormFile("file", filename)
+	if err != nil {
+		return nil, err
+	}
+
+	if _, err := part.Write([]byte(content)); err != nil {
+		return nil, err
+	}
+
+	if err := writer.Close(); err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequest(
+		http.MethodPost,
+		fmt.Sprintf("/v1/imports/?%s", query.Encode()),
+		body,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	req.Header.Set("Content-Type", writer.FormDataContentType())
+
+	return req, nil
+}
+
+func (s *Suite) Test1wImportPassengers() {
+	t := s.T()
+
+	tcs := []struct {
+		key      string
+		mode     string
+		filename string
+		content  string
+		code     int
+		created  int
+		failed   int
+	}{
+		{
+			key:      "All or nothing with invalid row",
+			mode:     "all-or-nothing",
+			filename: "passengers.csv",
+			content:  "firstName,lastName,middleName\nJordan,Hayes,Lee\nJordan,,Lee\n",
+			code:     http.StatusUnprocessableEntity,
+			created:  0,
+			failed:   1,
+		},
+		{
+			key:      "All or nothing",
+			mode:     "all-or-nothing",
+			filename: "passengers.csv",
+			content:  "firstName,lastName,middleName\nJordan,Hayes,Lee\nCasey,Moore,Quinn\n",
+			code:     http.StatusCreated,
+			created:  2,
+			failed:   0,
+		},
+		{
+			key:      "Best effort",
+			mode:     "best-effort",
+			filename: "passengers.ndjson",
+			content:  "{\"firstName\":\"Avery\",\"lastName\":\"Stone\",\"middleName\":\"Blake\"}\n{\"firstName\":\"Avery1\"}\n",
+			code:     http.StatusOK,
+			created:  1,
+			failed:   1,
+		},
+	}
+
+	t.Run("", func(t *testing.T) {
+		for _, tc := range tcs {
+			v := url.Values{}
+			v.Add("entity", "passengers")
+			v.Add("mode", tc.mode)
+
+			req, err := newImportRequest(v, tc.filename, tc.content)
+			assert.NoError(t, err, tc.key)
+
+			w := httptest.NewRecorder()
+
+			s.router.ServeHTTP(w, req)
+
+			assert.Equal(t, tc.code, w.Code, tc.key)
+
+			report := importReport{}
+			err = json.NewDecoder(w.Body).Decode(&report)
+			assert.NoError(t, err, tc.key)
+
+			assert.Equal(t, tc.created, report.Created, tc.key)
+			assert.Equal(t, tc.failed, report.Failed, tc.key)
+		}
+	})
+}
+
+func (s *Suite) importFile(t *testing.T, entity, mode, filename, content string) (int, importReport) {
+	v := url.Values{}
+	v.Add("entity", entity)
+	v.Add("mode", mode)
+
+	req, err := newImportRequest(v, filename, content)
+	require.NoError(t, err)
+
+	req.Header.Set("Accept-Language", "ru")
+
+	w := httptest.NewRecorder()
+
+	s.router.ServeHTTP(w, req)
+
+	report := importReport{}
+	require.NoError(t, json.NewDecoder(w.Body).Decode(&report))
+
+	return w.Code, report
+}
+
+// rowErrors lists the code or rejected fields of the failed rows.
+func rowErrors(report importReport) map[int]string {
+	errs := map[int]string{}
+
+	for _, row := range report.Rows {
+		switch {
+		case row.Code != "":
+			errs[row.Row] = row.Code
+		case len(row.Errors) > 0:
+			errs[row.Row] = row.Errors[0].Field + ":" + row.Errors[0].Rule
+		case row.Error != "":
+			errs[row.Row] = row.Error
+		}
+	}
+
+	return errs
+}
+
+func (s *Suite) Test1wImportRelated() {
+	t := s.T()
+
+	// Tickets, an invalid row is reported by its fields in the language asked
+	code, report := s.importFile(t, "tickets", "best-effort", "tickets.csv",
+		"provider,flyFrom,flyTo,flyAt,arriveAt\n"+
+			"Emirates,Moscow,Hanoi,3024-01-02T15:04:05Z,3024-01-03T15:04:05Z\n"+
+			"Emirates,Moscow,Hanoi,2020-01-02T15:04:05Z,3024-01-03T15:04:05Z\n",
+	)
+	require.Equal(t, http.StatusOK, code)
+	assert.Equal(t, 1, report.Created)
+	assert.Equal(t, map[int]string{2: "flyAt:flyght_before_now"}, rowErrors(report))
+	assert.Equal(t, "Ошибка валидации", report.Rows[1].Error)
+
+	ticketId := report.Rows[0].Id
+
+	// NDJSON rows are numbered by line, blank ones included
+	code, report = s.importFile(t, "tickets", "all-or-nothing", "tickets.ndjson",
+		`{"provider":"Emirates","flyFrom":"Moscow","flyTo":"Hanoi","flyAt":"3024-01-02T15:04:05Z","arriveAt":"3024-01-03T15:04:05Z"}`+"\n\n"+
+			`{"provider":1}`+"\n",
+	)
+	assert.Equal(t, http.StatusUnprocessableEntity, code)
+	assert.Equal(t, 2, report.Total)
+	assert.Equal(t, map[int]string{3: "provider:type"}, rowErrors(report))
+
+	code, report = s.importFile(t, "passengers", "all-or-nothing", "passengers.csv",
+		"firstName,lastName,middleName\nRowan,Hale,Lee\n",
+	)
+	require.Equal(t, http.StatusCreated, code)
+
+	passengerId := report.Rows[0].Id
+	missingId := "1ef9b8e1-8b6e-6a3e-8f4a-0242ac120002"
+
+	// Documents, rows conflicting with the stored data or with each other fail the whole file
+	documents := "type,number,passengerId\n" +
+		"Passport,7777111333," + passengerId + "\n" +
+		"Passport,7777111334," + missingId + "\n" +
+		"Passport,7777111333," + passengerId + "\n" +
+		"Visa,1," + passengerId + "\n"
+
+	code, report = s.importFile(t, "documents", "all-or-nothing", "documents.csv", documents)
+	assert.Equal(t, http.StatusUnprocessableEntity, code)
+	assert.Equal(t, 4, report.Total)
+	assert.Zero(t, report.Created)
+	assert.Equal(t, map[int]string{4: "type:oneof"}, rowErrors(report))
+
+	documents = strings.TrimSuffix(documents, "Visa,1,"+passengerId+"\n")
+
+	code, report = s.importFile(t, "documents", "all-or-nothing", "documents.csv", documents)
+	assert.Equal(t, http.StatusUnprocessableEntity, code)
+	assert.Zero(t, report.Created)
+	assert.Equal(t, 2, report.Failed)
+	assert.Equal(t, map[int]string{2: "passenger_does_not_exist", 3: "already_exists"}, rowErrors(report))
+	assert.Equal(t, "Пассажир не существует", report.Rows[0].Error)
+
+	req, err := http.NewRequest(http.MethodGet, fmt.Sprintf("/v1/documents/by-passenger/%s", passengerId), nil)
+	require.NoError(t, err)
+
+	w := httptest.NewRecorder()
+	s.router.ServeHTTP(w, req)
+	assert.Equal(t, http.StatusNoContent, w.Code, "nothing has been written")
+
+	code, report = s.importFile(t, "documents", "best-effort", "documents.csv", documents)
+	assert.Equal(t, http.StatusOK, code)
+	assert.Equal(t, 1, report.Created)
+	assert.Equal(t, map[int]string{2: "passenger_does_not_exist", 3: "already_exists"}, rowErrors(report))
+
+	// Bindings
+	bindings := "id,ticketId\n" +
+		passengerId + "," + ticketId + "\n" +
+		passengerId + "," + missingId + "\n" +
+		passengerId + "," + ticketId + "\n"
+
+	code, report = s.importFile(t, "bindings", "all-or-nothing", "bindings.csv", bindings)
+	assert.Equal(t, http.StatusUnprocessableEntity, code)
+	assert.Equal(t, map[int]string{2: "ticket_does_not_exist", 3: "already_exists"}, rowErrors(report))
+
+	code, report = s.importFile(t, "bindings", "all-or-nothing", "bindings.csv",
+		"id,ticketId\n"+passengerId+","+ticketId+"\n",
+	)
+	assert.Equal(t, http.StatusCreated, code)
+	assert.Equal(t, 1, report.Created)
+
+	code, report = s.importFile(t, "bindings", "best-effort", "bindings.csv", bindings)
+	assert.Equal(t, http.StatusOK, code)
+	assert.Zero(t, report.Created)
+	assert.Equal(t, map[int]string{1: "already_exists", 2: "ticket_does_not_exist", 3: "already_exists"}, rowErrors(report))
+}
+
+// INFO: webhooks
+
+type webhookSubscription struct {
+	Id         string   `json:"id"`
+	Url        string   `json:"url"`
+	Secret     string   `json:"secret"`
+	EventTypes []string `json:"eventTypes"`
+	Active     bool     `json:"active"`
+}
+
+func (s *Suite) Test1xWebhookSubscriptions() {
+	t := s.T()
+
+	tcs := []struct {
+		key  string
+		body string
+		code int
+	}{
+		{
+			key:  "Success",
+			body: `{"url":"https://partner.example.com/hooks","eventTypes":["TicketCreated","PassengerBound"]}`,
+			code: http.StatusCreated,
+		},
+		{
+			key:  "Wrong url",
+			body: `{"url":"partner.example.com"}`,
+			code: http.StatusUnprocessableEntity,
+		},
+		{
+			key:  "Unknown event type",
+			body: `{"url":"https://partner.example.com/hooks","eventTypes":["TicketLost"]}`,
+			code: http.StatusUnprocessableEntity,
+		},
+	}
+
+	t.Run("", func(t *testing.T) {
+		created := webhookSubscription{}
+
+		for _, tc := range tcs {
+			req, err := http.NewRequest(
+				http.MethodPost,
+				"/v1/webhooks/",
+				strings.NewReader(tc.body),
+			)
+			assert.NoError(t, err, tc.key)
+
+			w := httptest.NewRecorder()
+
+			s.router.ServeHTTP(w, req)
+
+			assert.Equal(t, tc.code, w.Code, tc.key)
+
+			if tc.code == http.StatusCreated {
+				err = json.NewDecoder(w.Body).Decode(&created)
+				assert.NoError(t, err, tc.key)
+				assert.NotEmpty(t, created.Secret, tc.key)
+				assert.True(t, created.Active, tc.key)
+			}
+		}
+
+		req, err := http.NewRequest(
+			http.MethodGet,
+			fmt.Sprintf("/v1/webhooks/%s", created.Id),
+			nil,
+		)
+		assert.NoError(t, err)
+
+		w := httptest.NewRecorder()
+
+		s.router.ServeHTTP(w, req)
+
+		assert.Equal(t, http.StatusOK, w.Code)
+
+		got := webhookSubscription{}
+		err = json.NewDecoder(w.Body).Decode(&got)
+		assert.NoError(t, err)
+		assert.Empty(t, got.Secret)
+		assert.Equal(t, created.EventTypes, got.EventTypes)
+
+		req, err = http.NewRequest(
+			http.MethodDelete,
+			fmt.Sprintf("/v1/webhooks/%s", created.Id),
+			nil,
+		)
+		assert.NoError(t, err)
+
+		w = httptest.NewRecorder()
+
+		s.router.ServeHTTP(w, req)
+
+		assert.Equal(t, http.StatusOK, w.Code)
+	})
+}
+
+// INFO: graphql
+
+type graphqlResp struct {
+	Data   map[string]json.RawMessage `json:"data"`
+	Errors []struct {
+		Message    string         `json:"message"`
+		Extensions map[string]any `json:"extensions"`
+	} `json:"errors"`
+}
+
+func (s *Suite) Test1yGraphql() {
+	t := s.T()
+
+	tcs := []struct {
+		key     string
+		query   string
+		errCode string
+	}{
+		{
+			key:   "Nested query",
+			query: `{ tickets { id passengers { firstName documents { type number } } } }`,
+		},
+		{
+			key:   "Passenger with tickets",
+			query: fmt.Sprintf(`{ passenger(id: "%s") { lastName tickets { flyFrom flyTo } } }`, s.utils.GetPassengerByOffset(s.ctx, 0)),
+		},
+		{
+			key:     "Invalid mutation input",
+			query:   `mutation { createPassenger(input: {firstName: "Jordan", lastName: "", middleName: "Lee"}) }`,
+			errCode: "BAD_USER_INPUT",
+		},
+		{
+			key:     "Too complex",
+			query:   `{ tickets { passengers { tickets { passengers { tickets { id provider flyAt } } } } } }`,
+			errCode: "QUERY_TOO_COMPLEX",
+		},
+		{
+			key:     "Invalid ticket id",
+			query:   `{ ticket(id: "1") { id } }`,
+			errCode: "BAD_USER_INPUT",
+		},
+		{
+			key:     "Invalid passenger id",
+			query:   `{ passenger(id: "1") { id } }`,
+			errCode: "BAD_USER_INPUT",
+		},
+	}
+
+	t.Run("", func(t *testing.T) {
+		for _, tc := range tcs {
+			body, err := json.Marshal(map[string]string{"query": tc.query})
+			assert.NoError(t, err, tc.key)
+
+			req, err := http.NewRequest(
+				http.MethodPost,
+				"/v1/graphql",
+				bytes.NewReader(body),
+			)
+			assert.NoError(t, err, tc.key)
+
+			w := httptest.NewRecorder()
+
+			s.router.ServeHTTP(w, req)
+
+			assert.Equal(t, http.StatusOK, w.Code, tc.key)
+
+			resp := graphqlResp{}
+			err = json.NewDecoder(w.Body).Decode(&resp)
+			assert.NoError(t, err, tc.key)
+
+			if tc.errCode == "" {
+				assert.Empty(t, resp.Errors, tc.key)
+				continue
+			}
+
+			if assert.NotEmpty(t, resp.Errors, tc.key) {
+				assert.Equal(t, tc.errCode, resp.Errors[0].Extensions["code"], tc.key)
+			}
+		}
+	})
+}
+
+// batchCounter counts the batch reads of children, a resolved list must cost one call whatever its size.
+type batchCounter struct {
+	usecases.Reposer
+	ticketsByPassenger   atomic.Int32
+	documentsByPassenger atomic.Int32
+}
+
+func (r *batchCounter) GetTicketsByPassengerIds(ctx context.Context, ids []string) (map[string][]entities.Ticket, error) {
+	r.ticketsByPassenger.Add(1)
+
+	return r.Reposer.GetTicketsByPassengerIds(ctx, ids)
+}
+
+func (r *batchCounter) GetDocumentsByPassengerIds(ctx context.Context, ids []string) (map[string][]entities.Document, error) {
+	r.documentsByPassenger.Add(1)
+
+	return r.Reposer.GetDocumentsByPassengerIds(ctx, ids)
+}
+
+func (s *Suite) Test1yGraphqlBatch() {
+	t := s.T()
+
+	t.Run("", func(t *testing.T) {
+		passengers, err := s.repo.GetPassengers(s.ctx)
+		assert.NoError(t, err)
+		assert.Greater(t, len(passengers), 1)
+
+		repo := &batchCounter{Reposer: s.repo}
+
+		router := gin.New()
+		v1.Register(&v1.Router{
+			Handler:  router,
+			Usecases: usecases.New(repo),
+			Log: logger.New(
+				logger.WithLevel(_loggerLevel),
+			),
+		})
+
+		body, err := json.Marshal(map[string]string{"query": `{ passengers { id tickets { id } documents { id } } }`})
+		assert.NoError(t, err)
+
+		req, err := http.NewRequest(http.MethodPost, "/v1/graphql", bytes.NewReader(body))
+		assert.NoError(t, err)
+
+		w := httptest.NewRecorder()
+
+		router.ServeHTTP(w, req)
+
+		resp := graphqlResp{}
+		err = json.NewDecoder(w.Body).Decode(&resp)
+		assert.NoError(t, err)
+		assert.Empty(t, resp.Errors)
+
+		got := []json.RawMessage{}
+		err = json.Unmarshal(resp.Data["passengers"], &got)
+		assert.NoError(t, err)
+		assert.Len(t, got, len(passengers))
+
+		assert.EqualValues(t, 1, repo.ticketsByPassenger.Load())
+		assert.EqualValues(t, 1, repo.documentsByPassenger.Load())
+	})
+}
+
+// INFO: validation
+
+type fieldError struct {
+	Field string `json:"field"`
+	Rule  string `json:"rule"`
+	Value any    `json:"value"`
+}
+
+func (s *Suite) Test1zFieldErrors() {
+	t := s.T()
+
+	tcs := []struct {
+		key    string
+		method string
+		url    string
+		body   string
+		errs   []fieldError
+	}{
+		{
+			key:    "JSON body",
+			method: http.MethodPost,
+			url:    "/v1/tickets/",
+			body:   `{"provider":"Emirates","flyFrom":"Moscow","flyTo":"Hanoi","flyAt":"3022-01-03T15:04:05+03:00","arriveAt":"3022-01-02T18:04:40+07:00"}`,
+			errs: []fieldError{
+				{Field: "arriveAt", Rule: "arrive_before_fly", Value: "3022-01-02T18:04:40+07:00"},
+			},
+		},
+		{
+			key:    "Query",
+			method: http.MethodGet,
+			url:    fmt.Sprintf("/v1/reports/by-passenger-id-for-period/%s?from=2020-01-02T15:04:05Z&to=2019-01-02T15:04:05Z", s.utils.GetPassengerByOffset(s.ctx, 0)),
+			errs: []fieldError{
+				{Field: "to", Rule: "from_after_to", Value: "2019-01-02T15:04:05Z"},
+			},
+		},
+		{
+			key:    "URI",
+			method: http.MethodDelete,
+			url:    "/v1/passengers/1",
+			errs: []fieldError{
+				{Field: "id", Rule: "uuid", Value: "1"},
+			},
+		},
+	}
+
+	t.Run("", func(t *testing.T) {
+		for _, tc := range tcs {
+			req, err := http.NewRequest(
+				tc.method,
+				tc.url,
+				strings.NewReader(tc.body),
+			)
+			assert.NoError(t, err, tc.key)
+
+			w := httptest.NewRecorder()
+
+			s.router.ServeHTTP(w, req)
+
+			assert.Equal(t, http.StatusUnprocessableEntity, w.Code, tc.key)
+
+			resp := struct {
+				Errors []fieldError `json:"errors"`
+			}{}
+			err = json.NewDecoder(w.Body).Decode(&resp)
+			assert.NoError(t, err, tc.key)
+
+			assert.Equal(t, tc.errs, resp.Errors, tc.key)
+		}
+	})
+}
+
+// INFO: v2
+
+type problem struct {
+	Type   string `json:"type"`
+	Status int    `json:"status"`
+}
+
+func (s *Suite) Test2aProblemDetails() {
+	t := s.T()
+
+	ticketId := s.utils.GetTicketByOffset(s.ctx, 0)
+
+	tcs := []struct {
+		key         string
+		method      string
+		url         string
+		body        string
+		ifMatch     string
+		code        int
+		problemType string
+	}{
+		{
+			key:         "Missing ticket",
+			method:      http.MethodGet,
+			url:         "/v2/tickets/1efa5f3d-2b9c-6d0e-8f1a-0242ac120002",
+			code:        http.StatusNotFound,
+			problemType: v2.ProblemNotFound,
+		},
+		{
+			key:         "Malformed id",
+			method:      http.MethodGet,
+			url:         "/v2/tickets/1",
+			code:        http.StatusUnprocessableEntity,
+			problemType: v2.ProblemValidation,
+		},
+		{
+			key:         "Invalid ticket",
+			method:      http.MethodPost,
+			url:         "/v2/tickets",
+			body:        `{"provider":"Emirates","flyFrom":"Moscow","flyTo":"Hanoi","flyAt":"2000-01-02T15:04:05+03:00","arriveAt":"3022-01-03T18:04:40+07:00"}`,
+			code:        http.StatusUnprocessableEntity,
+			problemType: v2.ProblemValidation,
+		},
+		{
+			key:         "Malformed body",
+			method:      http.MethodPost,
+			url:         "/v2/tickets",
+			body:        `{"provider":`,
+			code:        http.StatusBadRequest,
+			problemType: v2.ProblemMalformedRequest,
+		},
+		{
+			key:         "Stale If-Match",
+			method:      http.MethodDelete,
+			url:         fmt.Sprintf("/v2/tickets/%s", ticketId),
+			ifMatch:     `"stale"`,
+			code:        http.StatusPreconditionFailed,
+			problemType: v2.ProblemPreconditionFailed,
+		},
+		{
+			key:         "Passengers on board",
+			method:      http.MethodDelete,
+			url:         fmt.Sprintf("/v2/tickets/%s", ticketId),
+			code:        http.StatusConflict,
+			problemType: v2.ProblemPassengersOnBoard,
+		},
+		{
+			key:    "Existing ticket",
+			method: http.MethodGet,
+			url:    fmt.Sprintf("/v2/tickets/%s", ticketId),
+			code:   http.StatusOK,
+		},
+		{
+			key:    "Passengers of the ticket",
+			method: http.MethodGet,
+			url:    fmt.Sprintf("/v2/tickets/%s/passengers", ticketId),
+			code:   http.StatusOK,
+		},
+	}
+
+	t.Run("", func(t *testing.T) {
+		for _, tc := range tcs {
+			req, err := http.NewRequest(
+				tc.method,
+				tc.url,
+				strings.NewReader(tc.body),
+			)
+			assert.NoError(t, err, tc.key)
+
+			if tc.ifMatch != "" {
+				req.Header.Set("If-Match", tc.ifMatch)
+			}
+
+			w := httptest.NewRecorder()
+
+			s.router.ServeHTTP(w, req)
+
+			assert.Equal(t, tc.code, w.Code, tc.key)
+
+			if tc.problemType == "" {
+				continue
+			}
+
+			assert.Equal(t, "application/problem+json", w.Header().Get("Content-Type"), tc.key)
+
+			resp := problem{}
+			err = json.NewDecoder(w.Body).Decode(&resp)
+			assert.NoError(t, err, tc.key)
+
+			assert.Equal(t, tc.problemType, resp.Type, tc.key)
+			assert.Equal(t, tc.code, resp.Status, tc.key)
+		}
+	})
+}
+
+func (s *Suite) Test2bConditionalReplacePassenger() {
+	t := s.T()
+
+	url := fmt.Sprintf("/v2/passengers/%s", s.utils.GetPassengerByOffset(s.ctx, 0))
+
+	t.Run("", func(t *testing.T) {
+		req, err := http.NewRequest(http.MethodGet, url, nil)
+		assert.NoError(t, err)
+
+		w := httptest.NewRecorder()
+
+		s.router.ServeHTTP(w, req)
+
+		assert.Equal(t, http.StatusOK, w.Code)
+
+		etag := w.Header().Get("ETag")
+		assert.NotEmpty(t, etag)
+
+		for _, code := range []int{http.StatusNoContent, http.StatusPreconditionFailed} {
+			req, err := http.NewRequest(
+				http.MethodPut,
+				url,
+				strings.NewReader(`{"firstName":"Avery","lastName":"Quinn","middleName":"Rowan"}`),
+			)
+			assert.NoError(t, err)
+
+			req.Header.Set("If-Match", etag)
+
+			w := httptest.NewRecorder()
+
+			s.router.ServeHTTP(w, req)
+
+			assert.Equal(t, code, w.Code)
+		}
+	})
+}
+
+func (s *Suite) Test2cLocalizedProblems() {
+	t := s.T()
+
+	tcs := []struct {
+		key            string
+		acceptLanguage string
+		locale         string
+		title          string
+		message        string
+	}{
+		{
+			key:            "Russian",
+			acceptLanguage: "ru-RU,ru;q=0.9,en;q=0.8",
+			locale:         "ru",
+			title:          "Ошибка валидации",
+			message:        "должно быть UUID",
+		},
+		{
+			key:            "Fallback",
+			acceptLanguage: "fr-CH, fr;q=0.9",
+			locale:         "en",
+			title:          "Validation failed",
+			message:        "must be a UUID",
+		},
+		{
+			key:     "Without Accept-Language",
+			locale:  "en",
+			title:   "Validation failed",
+			message: "must be a UUID",
+		},
+	}
+
+	t.Run("", func(t *testing.T) {
+		for _, tc := range tcs {
+			req, err := http.NewRequest(http.MethodGet, "/v2/tickets/1", nil)
+			assert.NoError(t, err, tc.key)
+
+			if tc.acceptLanguage != "" {
+				req.Header.Set("Accept-Language", tc.acceptLanguage)
+			}
+
+			w := httptest.NewRecorder()
+
+			s.router.ServeHTTP(w, req)
+
+			assert.Equal(t, http.StatusUnprocessableEntity, w.Code, tc.key)
+			assert.Equal(t, tc.locale, w.Header().Get("Content-Language"), tc.key)
+
+			resp := struct {
+				Title  string `json:"title"`
+				Errors []struct {
+					Message string `json:"message"`
+				} `json:"errors"`
+			}{}
+			err = json.NewDecoder(w.Body).Decode(&resp)
+			assert.NoError(t, err, tc.key)
+
+			assert.Equal(t, tc.title, resp.Title, tc.key)
+
+			if assert.Len(t, resp.Errors, 1, tc.key) {
+				assert.Equal(t, tc.message, resp.Errors[0].Message, tc.key)
+			}
+		}
+	})
+}
+
+func (s *Suite) Test2dDomainErrorCodes() {
+	t := s.T()
+
+	t.Run("", func(t *testing.T) {
+		req, err := http.NewRequest(
+			http.MethodPost,
+			"/v2/passengers",
+			strings.NewReader(`{"firstName":"Morgan","lastName":"Hayes","middleName":"Blake"}`),
+		)
+		assert.NoError(t, err)
+
+		w := httptest.NewRecorder()
+
+		s.router.ServeHTTP(w, req)
+
+		assert.Equal(t, http.StatusCreated, w.Code)
+
+		passenger := id{}
+		err = json.NewDecoder(w.Body).Decode(&passenger)
+		assert.NoError(t, err)
+
+		req, err = http.NewRequest(
+			http.MethodPost,
+			fmt.Sprintf("/v2/passengers/%s/documents", passenger.Id),
+			strings.NewReader(`{"type":"Id card","number":"7700112233"}`),
+		)
+		assert.NoError(t, err)
+
+		w = httptest.NewRecorder()
+
+		s.router.ServeHTTP(w, req)
+
+		assert.Equal(t, http.StatusCreated, w.Code)
+
+		tcs := []struct {
+			key         string
+			url         string
+			code        int
+			problemType string
+		}{
+			{
+				key:  "v1",
+				url:  fmt.Sprintf("/v1/passengers/%s", passenger.Id),
+				code: http.StatusForbidden,
+			},
+			{
+				key:         "v2",
+				url:         fmt.Sprintf("/v2/passengers/%s", passenger.Id),
+				code:        http.StatusConflict,
+				problemType: v2.ProblemPassengerHasDocuments,
+			},
+		}
+
+		for _, tc := range tcs {
+			req, err := http.NewRequest(http.MethodDelete, tc.url, nil)
+			assert.NoError(t, err, tc.key)
+
+			w := httptest.NewRecorder()
+
+			s.router.ServeHTTP(w, req)
+
+			assert.Equal(t, tc.code, w.Code, tc.key)
+
+			resp := struct {
+				Type string `json:"type"`
+				Code string `json:"code"`
+			}{}
+			err = json.NewDecoder(w.Body).Decode(&resp)
+			assert.NoError(t, err, tc.key)
+
+			assert.Equal(t, "passenger_has_documents", resp.Code, tc.key)
+			assert.Equal(t, tc.problemType, resp.Type, tc.key)
+		}
+	})
+}
+
+func (s *Suite) Test2eIdempotencyKeys() {
+	t := s.T()
+
+	t.Run("", func(t *testing.T) {
+		post := func(body string) *httptest.ResponseRecorder {
+			req, err := http.NewRequest(http.MethodPost, "/v2/passengers", strings.NewReader(body))
+			assert.NoError(t, err)
+
+			req.Header.Set("Idempotency-Key", "test-2e-idempotency-key")
+
+			w := httptest.NewRecorder()
+
+			s.router.ServeHTTP(w, req)
+
+			return w
+		}
+
+		body := `{"firstName":"Casey","lastName":"Ward","middleName":"Ellis"}`
+
+		first := post(body)
+		assert.Equal(t, http.StatusCreated, first.Code)
+		assert.Empty(t, first.Header().Get("Idempotent-Replayed"))
+
+		retried := post(body)
+		assert.Equal(t, http.StatusCreated, retried.Code)
+		assert.Equal(t, "true", retried.Header().Get("Idempotent-Replayed"))
+		assert.Equal(t, first.Header().Get("Location"), retried.Header().Get("Location"))
+		assert.Equal(t, first.Body.String(), retried.Body.String())
+
+		reused := post(`{"firstName":"Casey","lastName":"Ward","middleName":"Quinn"}`)
+		assert.Equal(t, http.StatusUnprocessableEntity, reused.Code)
+
+		resp := struct {
+			Type string `json:"type"`
+		}{}
+		err := json.NewDecoder(reused.Body).Decode(&resp)
+		assert.NoError(t, err)
+
+		assert.Equal(t, v2.ProblemIdempotencyKeyReused, resp.Type)
+	})
+
+	t.Run("Panic releases the key", func(t *testing.T) {
+		repo := &panicOnce{Reposer: memory.New()}
+
+		router := gin.New()
+		router.Use(gin.RecoveryWithWriter(io.Discard))
+		v2.Register(&v2.Router{
+			Handler:  router,
+			Usecases: usecases.New(repo),
+			Log:      logger.New(logger.WithOutput(io.Discard)),
+		})
+
+		post := func() *httptest.ResponseRecorder {
+			req, err := http.NewRequest(http.MethodPost, "/v2/passengers", strings.NewReader(`{"firstName":"Casey","lastName":"Ward","middleName":"Ellis"}`))
+			require.NoError(t, err)
+
+			req.Header.Set("Idempotency-Key", "test-2e-panic")
+
+			w := httptest.NewRecorder()
+			router.ServeHTTP(w, req)
+
+			return w
+		}
+
+		assert.Equal(t, http.StatusInternalServerError, post().Code)
+
+		retried := post()
+		assert.Equal(t, http.StatusCreated, retried.Code)
+		assert.Empty(t, retried.Header().Get("Idempotent-Replayed"))
+	})
+}
+
+// panicOnce panics on the first passenger created.
+type panicOnce struct {
+	usecases.Reposer
+	panicked atomic.Bool
+}
+
+func (r *panicOnce) CreatePassenger(ctx context.Context, passenger entities.Passenger) error {
+	if !r.panicked.Swap(true) {
+		panic("broken repository")
+	}
+
+	return r.Reposer.CreatePassenger(ctx, passenger)
+}
+
+func (s *Suite) Test2fTicketsPagination() {
+	t := s.T()
+
+	t.Run("", func(t *testing.T) {
+		seen := map[string]bool{}
+		next := "/v2/tickets?limit=2"
+
+		for pages := 0; next != ""; pages++ {
+			assert.Less(t, pages, 1000)
+
+			req, err := http.NewRequest(http.MethodGet, next, nil)
+			assert.NoError(t, err)
+
+			w := httptest.NewRecorder()
+
+			s.router.ServeHTTP(w, req)
+
+			assert.Equal(t, http.StatusOK, w.Code)
+
+			tickets := []id{}
+			err = json.NewDecoder(w.Body).Decode(&tickets)
+			assert.NoError(t, err)
+			assert.LessOrEqual(t, len(tickets), 2)
+
+			for _, ticket := range tickets {
+				assert.False(t, seen[ticket.Id], ticket.Id)
+				seen[ticket.Id] = true
+			}
+
+			next = ""
+
+			if link := w.Header().Get("Link"); link != "" {
+				target, _, _ := strings.Cut(link, ";")
+				next = strings.Trim(target, "<>")
+			}
+		}
+
+		assert.True(t, seen[s.utils.GetTicketByOffset(s.ctx, 0)])
+
+		req, err := http.NewRequest(http.MethodGet, "/v2/tickets?limit=1001", nil)
+		assert.NoError(t, err)
+
+		w := httptest.NewRecorder()
+
+		s.router.ServeHTTP(w, req)
+
+		assert.Equal(t, http.StatusUnprocessableEntity, w.Code)
+	})
+}
+
+func (s *Suite) Test2gHealth() {
+	t := s.T()
+
+	tcs := []struct {
+		key        string
+		path       string
+		expectCode int
+	}{
+		{
+			key:        "Liveness",
+			path:       "/healthz",
+			expectCode: http.StatusOK,
+		},
+		{
+			key:        "Readiness",
+			path:       "/readyz",
+			expectCode: http.StatusOK,
+		},
+		{
+			key:        "Detailed",
+			path:       "/v1/admin/health",
+			expectCode: http.StatusOK,
+		},
+	}
+
+	for _, tc := range tcs {
+		t.Run(tc.key, func(t *testing.T) {
+			req, err := http.NewRequest(http.MethodGet, tc.path, nil)
+			assert.NoError(t, err, tc.key)
+
+			w := httptest.NewRecorder()
+
+			s.router.ServeHTTP(w, req)
+
+			assert.Equal(t, tc.expectCode, w.Code, tc.key)
+
+			health := entities.Health{}
+			err = json.NewDecoder(w.Body).Decode(&health)
+			assert.NoError(t, err, tc.key)
+			assert.Equal(t, entities.HealthStatusUp, health.Status, tc.key)
+
+			for _, component := range health.Components {
+				assert.Equal(t, entities.HealthStatusUp, component.Status, component.Name)
+			}
+		})
+	}
+
+	t.Run("Draining", func(t *testing.T) {
+		health := newHealthChecker(s.repo)
+
+		router := gin.New()
+		v1.Register(&v1.Router{
+			Handler:  router,
+			Usecases: usecases.New(s.repo),
+			Log: logger.New(
+				logger.WithLevel(_loggerLevel),
+			),
+			Health: health,
+		})
+
+		health.Drain()
+
+		for _, path := range []string{"/readyz", "/v1/admin/health"} {
+			req, err := http.NewRequest(http.MethodGet, path, nil)
+			assert.NoError(t, err, path)
+
+			w := httptest.NewRecorder()
+
+			router.ServeHTTP(w, req)
+
+			assert.Equal(t, http.StatusServiceUnavailable, w.Code, path)
+		}
+
+		req, err := http.NewRequest(http.MethodGet, "/healthz", nil)
+		assert.NoError(t, err)
+
+		w := httptest.NewRecorder()
+
+		router.ServeHTTP(w, req)
+
+		assert.Equal(t, http.StatusOK, w.Code)
+	})
+}
+
+func (s *Suite) Test2hMetrics() {
+	t := s.T()
+
+	t.Run("", func(t *testing.T) {
+		ticketId := s.utils.GetTicketByOffset(s.ctx, 0)
+
+		req, err := http.NewRequest(http.MethodGet, fmt.Sprintf("/v1/tickets/whole-info/%s", ticketId), nil)
+		assert.NoError(t, err)
+
+		s.router.ServeHTTP(httptest.NewRecorder(), req)
+
+		req, err = http.NewRequest(http.MethodGet, "/metrics", nil)
+		assert.NoError(t, err)
+
+		w := httptest.NewRecorder()
+
+		s.router.ServeHTTP(w, req)
+
+		assert.Equal(t, http.StatusOK, w.Code)
+
+		body := w.Body.String()
+
+		assert.Contains(t, body, `flights_http_requests_total{method="GET",route="/v1/tickets/whole-info/:id",status="200"}`)
+		assert.NotContains(t, body, ticketId)
+		assert.Contains(t, body, `flights_usecase_call_duration_seconds_count{usecase="GetWholeInfoAboutTicket"}`)
+		assert.Contains(t, body, "flights_tickets_created_total")
+		assert.Contains(t, body, "flights_passengers_bound_total")
+	})
+}
+
+func (s *Suite) Test2iTracing() {
+	t := s.T()
+
+	t.Run("", func(t *testing.T) {
+		const traceId = "4bf92f3577b34da6a3ce929d0e0e4736"
+
+		req, err := http.NewRequest(
+			http.MethodGet,
+			fmt.Sprintf("/v1/tickets/whole-info/%s", s.utils.GetTicketByOffset(s.ctx, 0)),
+			nil,
+		)
+		assert.NoError(t, err)
+
+		req.Header.Set("traceparent", "00-"+traceId+"-00f067aa0ba902b7-01")
+
+		w := httptest.NewRecorder()
+
+		s.router.ServeHTTP(w, req)
+
+		assert.Equal(t, http.StatusOK, w.Code)
+
+		names := map[string]bool{}
+
+		for _, span := range s.spans.Ended() {
+			if span.SpanContext().TraceID().String() == traceId {
+				names[span.Name()] = true
+			}
+		}
+
+		assert.True(t, names["GET /v1/tickets/whole-info/:id"], names)
+		assert.True(t, names["usecases.GetWholeInfoAboutTicket"], names)
+	})
+}
+
+func (s *Suite) Test2jRequestID() {
+	t := s.T()
+
+	tcs := []struct {
+		key       string
+		requestId string
+		expectSet bool
+	}{
+		{
+			key:       "Propagated",
+			requestId: "7f1c2e9a-client-id",
+			expectSet: true,
+		},
+		{
+			key:       "Generated",
+			requestId: "",
+		},
+		{
+			key:       "Forged",
+			requestId: "id\nlevel=ERROR",
+		},
+	}
+
+	for _, tc := range tcs {
+		t.Run(tc.key, func(t *testing.T) {
+			req, err := http.NewRequest(http.MethodGet, "/healthz", nil)
+			assert.NoError(t, err, tc.key)
+
+			if tc.requestId != "" {
+				req.Header.Set(middleware.RequestIDHeader, tc.requestId)
+			}
+
+			w := httptest.NewRecorder()
+
+			s.router.ServeHTTP(w, req)
+
+			got := w.Header().Get(middleware.RequestIDHeader)
+			assert.NotEmpty(t, got, tc.key)
+
+			if tc.expectSet {
+				assert.Equal(t, tc.requestId, got, tc.key)
+			} else {
+				assert.NotEqual(t, tc.requestId, got, tc.key)
+			}
+		})
+	}
+}
+
+func (s *Suite) Test2kRegisterPassenger() {
+	t := s.T()
+
+	ticketId := s.utils.GetTicketByOffset(s.ctx, 0)
+
+	countPassengers := func(t *testing.T) int {
+		passengers, err := s.repo.GetPassengers(s.ctx)
+		assert.NoError(t, err)
+
+		return len(passengers)
+	}
+
+	tcs := []struct {
+		key        string
+		ticketId   string
+		expectCode int
+	}{
+		{
+			key:        "UnknownTicket",
+			ticketId:   "1f0e0000-0000-6000-8000-000000000000",
+			expectCode: http.StatusNotFound,
+		},
+		{
+			key:        "Registered",
+			ticketId:   ticketId,
+			expectCode: http.StatusCreated,
+		},
+	}
+
+	for _, tc := range tcs {
+		t.Run(tc.key, func(t *testing.T) {
+			before := countPassengers(t)
+
+			req, err := http.NewRequest(
+				http.MethodPost,
+				"/v2/passengers",
+				strings.NewReader(fmt.Sprintf(
+					`{"firstName":"Avery","lastName":"Quinn","middleName":"Rowan","documents":[{"type":"Passport","number":"7777111222"}],"ticketIds":["%s"]}`,
+					tc.ticketId,
+				)),
+			)
+			assert.NoError(t, err, tc.key)
+
+			w := httptest.NewRecorder()
+
+			s.router.ServeHTTP(w, req)
+
+			assert.Equal(t, tc.expectCode, w.Code, tc.key)
+
+			if tc.expectCode != http.StatusCreated {
+				assert.Equal(t, before, countPassengers(t), tc.key)
+				return
+			}
+
+			created := id{}
+			assert.NoError(t, json.NewDecoder(w.Body).Decode(&created), tc.key)
+
+			documents, err := s.repo.GetDocumentsByPassengerId(s.ctx, entities.Id{Value: created.Id})
+			assert.NoError(t, err, tc.key)
+			assert.Len(t, documents, 1, tc.key)
+
+			passengers, err := s.repo.GetPassengersByTicketId(s.ctx, entities.Id{Value: tc.ticketId})
+			assert.NoError(t, err, tc.key)
+			assert.Contains(t, passengers, entities.Passenger{Id: created.Id, FirstName: "Avery", LastName: "Quinn", MiddleName: "Rowan"}, tc.key)
+		})
+	}
+}
@@ -0,0 +1,255 @@
+package v1
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/v1adhope/flights/internal/entities"
+)
+
+type webhookGroup struct {
+	rg       *gin.RouterGroup
+	webhookU WebhookUsecaser
+}
+
+func registerWebhookGroup(group *webhookGroup) {
+	webhookG := group.rg.Group("/webhooks")
+	{
+		webhookG.POST("/", group.create)
+		webhookG.PUT("/:id", group.replace)
+		webhookG.DELETE("/:id", group.delete)
+		webhookG.GET("/", group.all)
+		webhookG.GET("/:id", group.one)
+		webhookG.GET("/:id/deliveries", group.deliveries)
+		webhookG.POST("/:id/deliveries/:deliveryId/redeliver", group.redeliver)
+	}
+}
+
+type webhookCreateReq struct {
+	Url        string   `json:"url" example:"https://partner.example.com/hooks/flights" binding:"required,url,max=2048"`
+	Secret     string   `json:"secret" example:"" binding:"omitempty,min=16,max=255"`
+	EventTypes []string `json:"eventTypes" example:"TicketCreated,PassengerBound" binding:"omitempty,dive,oneof=TicketCreated TicketReplaced TicketDeleted PassengerCreated PassengerReplaced PassengerDeleted PassengerBound PassengerUnbound DocumentAdded DocumentReplaced DocumentDeleted"`
+	Active     *bool    `json:"active" example:"true"`
+}
+
+func (r *webhookCreateReq) toEntity(id string) entities.WebhookSubscription {
+	subscription := entities.WebhookSubscription{
+		Id:         id,
+		Url:        r.Url,
+		Secret:     r.Secret,
+		EventTypes: r.EventTypes,
+		Active:     true,
+	}
+
+	if subscription.EventTypes == nil {
+		subscription.EventTypes = []string{}
+	}
+
+	if r.Active != nil {
+		subscription.Active = *r.Active
+	}
+
+	return subscription
+}
+
+type webhookDeliveryUri struct {
+	Id         string `uri:"id" binding:"required,uuid"`
+	DeliveryId string `uri:"deliveryId" binding:"required,uuid"`
+}
+
+// @tags Webhooks
+// @description Empty eventTypes subscribes to all events. Without a secret a random one is generated.
+// @description The secret is returned only here, deliveries are signed with it as
+// @description X-Flights-Signature: t={unix seconds},v1={hex HMAC-SHA256 of "{t}.{body}"}
+// @accept json
+// @param webhook body webhookCreateReq true "Webhook subscription request entity"
+// @response 201 {object} entities.WebhookSubscription
+// @header 201 {string} location "Return /v1/webhooks/{id} resource"
+// @response 422
+// @response 500
+// @router /webhooks/ [POST]
+func (g *webhookGroup) create(c *gin.Context) {
+	req := webhookCreateReq{}
+
+	if err := c.ShouldBindJSON(&req); err != nil {
+		setBindError(c, err)
+		return
+	}
+
+	subscription, err := g.webhookU.CreateWebhookSubscription(
+		c.Request.Context(),
+		req.toEntity(""),
+	)
+	if err != nil {
+		setAnyError(c, err)
+		return
+	}
+
+	setLocationHeader(c, "/webhooks/", subscription.Id)
+
+	c.JSON(http.StatusCreated, subscription)
+}
+
+// @tags Webhooks
+// @description Empty secret keeps the current one
+// @accept json
+// @param webhook body webhookCreateReq true "Webhook subscription request entity"
+// @param id path string true "Webhook subscription id (uuid)"
+// @response 200
+// @response 204
+// @response 422
+// @response 500
+// @router /webhooks/{id} [PUT]
+func (g *webhookGroup) replace(c *gin.Context) {
+	params := id{}
+
+	if err := c.ShouldBindUri(&params); err != nil {
+		setBindError(c, err)
+		return
+	}
+
+	req := webhookCreateReq{}
+
+	if err := c.ShouldBindJSON(&req); err != nil {
+		setBindError(c, err)
+		return
+	}
+
+	err := g.webhookU.ReplaceWebhookSubscription(
+		c.Request.Context(),
+		req.toEntity(params.Value),
+	)
+	if err != nil {
+		setAnyError(c, err)
+		return
+	}
+
+	c.Status(http.StatusOK)
+}
+
+// @tags Webhooks
+// @param id path string true "Webhook subscription id (uuid)"
+// @response 200
+// @response 204
+// @response 422
+// @response 500
+// @router /webhooks/{id} [DELETE]
+func (g *webhookGroup) delete(c *gin.Context) {
+	params := id{}
+
+	if err := c.ShouldBindUri(&params); err != nil {
+		setBindError(c, err)
+		return
+	}
+
+	err := g.webhookU.DeleteWebhookSubscription(
+		c.Request.Context(),
+		entities.Id{Value: params.Value},
+	)
+	if err != nil {
+		setAnyError(c, err)
+		return
+	}
+
+	c.Status(http.StatusOK)
+}
+
+// @tags Webhooks
+// @response 200 {array} entities.WebhookSubscription
+// @response 204
+// @response 500
+// @router /webhooks/ [GET]
+func (g *webhookGroup) all(c *gin.Context) {
+	subscriptions, err := g.webhookU.GetWebhookSubscriptions(c.Request.Context())
+	if err != nil {
+		setAnyError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, subscriptions)
+}
+
+// @tags Webhooks
+// @param id path string true "Webhook subscription id (uuid)"
+// @response 200 {object} entities.WebhookSubscription
+// @response 204
+// @response 422
+// @response 500
+// @router /webhooks/{id} [GET]
+func (g *webhookGroup) one(c *gin.Context) {
+	params := id{}
+
+	if err := c.ShouldBindUri(&params); err != nil {
+		setBindError(c, err)
+		return
+	}
+
+	subscription, err := g.webhookU.GetWebhookSubscription(
+		c.Request.Context(),
+		entities.Id{Value: params.Value},
+	)
+	if err != nil {
+		setAnyError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, subscription)
+}
+
+// @tags Webhooks
+// @description Delivery log of the subscription, newest first
+// @param id path string true "Webhook subscription id (uuid)"
+// @response 200 {array} entities.WebhookDelivery
+// @response 204
+// @response 422
+// @response 500
+// @router /webhooks/{id}/deliveries [GET]
+func (g *webhookGroup) deliveries(c *gin.Context) {
+	params := id{}
+
+	if err := c.ShouldBindUri(&params); err != nil {
+		setBindError(c, err)
+		return
+	}
+
+	deliveries, err := g.webhookU.GetWebhookDeliveries(
+		c.Request.Context(),
+		entities.Id{Value: params.Value},
+	)
+	if err != nil {
+		setAnyError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, deliveries)
+}
+
+// @tags Webhooks
+// @description Schedules the delivery (dead or delivered too) to be sent again as soon as possible
+// @param id path string true "Webhook subscription id (uuid)"
+// @param deliveryId path string true "Webhook delivery id (uuid)"
+// @response 202
+// @response 204
+// @response 422
+// @response 500
+// @router /webhooks/{id}/deliveries/{deliveryId}/redeliver [POST]
+func (g *webhookGroup) redeliver(c *gin.Context) {
+	params := webhookDeliveryUri{}
+
+	if err := c.ShouldBindUri(&params); err != nil {
+		setBindError(c, err)
+		return
+	}
+
+	err := g.webhookU.RedeliverWebhookDelivery(
+		c.Request.Context(),
+		entities.Id{Value: params.Id},
+		entities.Id{Value: params.DeliveryId},
+	)
+	if err != nil {
+		setAnyError(c, err)
+		return
+	}
+
+	c.Status(http.StatusAccepted)
+}
@@ -0,0 +1,155 @@
+package v2
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/v1adhope/flights/internal/entities"
+)
+
+type documentGroup struct {
+	rg        *gin.RouterGroup
+	documentU DocumentUsecaser
+}
+
+func registerDocumentGroup(group *documentGroup) {
+	group.rg.POST("/passengers/:id/documents", group.create)
+
+	documentG := group.rg.Group("/documents")
+	{
+		documentG.PUT("/:id", group.replace)
+		documentG.DELETE("/:id", group.delete)
+	}
+}
+
+type documentCreateReq struct {
+	Type   string `json:"type" example:"Passport" binding:"required,oneof='Passport' 'Id card' 'International passport'"`
+	Number string `json:"number" example:"5555444444" binding:"required,max=255,number"`
+}
+
+type documentReplaceReq struct {
+	Type        string `json:"type" example:"Passport" binding:"required,oneof='Passport' 'Id card' 'International passport'"`
+	Number      string `json:"number" example:"5555444444" binding:"required,max=255,number"`
+	PassengerId string `json:"passengerId" example:"uuid" binding:"required,uuid"`
+}
+
+// @tags Documents
+// @description One of Passport, Id card, International passport
+// @accept json
+// @produce json,application/problem+json
+// @param id path string true "Passenger id (uuid)"
+// @param document body documentCreateReq true "Document request entity"
+// @param Idempotency-Key header string false "Retries with the same key get the first response, kept for 24 hours"
+// @success 201 {object} entities.Id
+// @header 201 {string} location "/v2/documents/{id}"
+// @failure 400 {object} problem
+// @failure 404 {object} problem "Passenger not found"
+// @failure 409 {object} problem "The document has already exists or a request with the same Idempotency-Key is in progress"
+// @failure 422 {object} problem
+// @failure 500 {object} problem
+// @router /passengers/{id}/documents [POST]
+func (g *documentGroup) create(c *gin.Context) {
+	params := id{}
+
+	if err := c.ShouldBindUri(&params); err != nil {
+		setBindError(c, err)
+		return
+	}
+
+	req := documentCreateReq{}
+
+	if err := c.ShouldBindJSON(&req); err != nil {
+		setBindError(c, err)
+		return
+	}
+
+	documentId, err := g.documentU.CreateDocument(
+		c.Request.Context(),
+		entities.Document{
+			Type:        req.Type,
+			Number:      req.Number,
+			PassengerId: params.Value,
+		},
+	)
+	if err != nil {
+		setAnyError(c, err)
+		return
+	}
+
+	setLocationHeader(c, "/documents/", documentId)
+
+	c.JSON(http.StatusCreated, entities.Id{Value: documentId})
+}
+
+// @tags Documents
+// @description One of Passport, Id card, International passport
+// @accept json
+// @produce application/problem+json
+// @param id path string true "Document id (uuid)"
+// @param document body documentReplaceReq true "Document request entity"
+// @success 204
+// @failure 400 {object} problem
+// @failure 404 {object} problem
+// @failure 409 {object} problem "The document has already exists"
+// @failure 422 {object} problem
+// @failure 500 {object} problem
+// @router /documents/{id} [PUT]
+func (g *documentGroup) replace(c *gin.Context) {
+	params := id{}
+
+	if err := c.ShouldBindUri(&params); err != nil {
+		setBindError(c, err)
+		return
+	}
+
+	req := documentReplaceReq{}
+
+	if err := c.ShouldBindJSON(&req); err != nil {
+		setBindError(c, err)
+		return
+	}
+
+	err := g.documentU.ReplaceDocument(
+		c.Request.Context(),
+		entities.Document{
+			Id:          params.Value,
+			Type:        req.Type,
+			Number:      req.Number,
+			PassengerId: req.PassengerId,
+		},
+	)
+	if err != nil {
+		setAnyError(c, err)
+		return
+	}
+
+	c.Status(http.StatusNoContent)
+}
+
+// @tags Documents
+// @produce application/problem+json
+// @param id path string true "Document id (uuid)"
+// @success 204
+// @failure 404 {object} problem
+// @failure 422 {object} problem
+// @failure 500 {object} problem
+// @router /documents/{id} [DELETE]
+func (g *documentGroup) delete(c *gin.Context) {
+	params := id{}
+
+	if err := c.ShouldBindUri(&params); err != nil {
+		setBindError(c, err)
+		return
+	}
+
+	err := g.documentU.DeleteDocument(
+		c.Request.Context(),
+		entities.Id{Value: params.Value},
+	)
+	if err != nil {
+		setAnyError(c, err)
+		return
+	}
+
+	c.Status(http.StatusNoContent)
+}
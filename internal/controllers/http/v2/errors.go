@@ -0,0 +1,204 @@
+package v2
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/go-playground/validator/v10"
+	"github.com/v1adhope/flights/internal/controllers/http/i18n"
+	"github.com/v1adhope/flights/internal/controllers/http/validation"
+	"github.com/v1adhope/flights/internal/entities"
+)
+
+const _problemContentType = "application/problem+json"
+
+// Problem types are stable, clients may switch on them.
+const (
+	ProblemMalformedRequest      = "urn:flights:problem:malformed-request"
+	ProblemValidation            = "urn:flights:problem:validation"
+	ProblemNotFound              = "urn:flights:problem:not-found"
+	ProblemTicketNotFound        = "urn:flights:problem:ticket-not-found"
+	ProblemPassengerNotFound     = "urn:flights:problem:passenger-not-found"
+	ProblemAlreadyExists         = "urn:flights:problem:already-exists"
+	ProblemPassengersOnBoard     = "urn:flights:problem:passengers-on-board"
+	ProblemPassengerHasDocuments = "urn:flights:problem:passenger-has-documents"
+	ProblemIdempotencyKeyReused  = "urn:flights:problem:idempotency-key-reused"
+	ProblemRequestInProgress     = "urn:flights:problem:request-in-progress"
+	ProblemRuleViolation         = "urn:flights:problem:rule-violation"
+	ProblemPreconditionFailed    = "urn:flights:problem:precondition-failed"
+	ProblemUnavailable           = "urn:flights:problem:unavailable"
+	ProblemInternal              = "urn:flights:problem:internal"
+)
+
+type problemKind struct {
+	status      int
+	problemType string
+	titleKey    string
+}
+
+// _problemKinds maps categories of entities errors to problems.
+var _problemKinds = map[entities.Category]problemKind{
+	entities.CategoryInvalid:          {http.StatusUnprocessableEntity, ProblemValidation, "problem.validation"},
+	entities.CategoryNotFound:         {http.StatusNotFound, ProblemNotFound, "problem.not_found"},
+	entities.CategoryMissingReference: {http.StatusNotFound, ProblemNotFound, "problem.not_found"},
+	entities.CategoryAlreadyExists:    {http.StatusConflict, ProblemAlreadyExists, "problem.already_exists"},
+	entities.CategoryRuleViolation:    {http.StatusConflict, ProblemRuleViolation, "problem.rule_violation"},
+	entities.CategoryUnavailable:      {http.StatusServiceUnavailable, ProblemUnavailable, "problem.unavailable"},
+}
+
+// _problemKindsByCode narrows the problem of errors clients handle specifically.
+var _problemKindsByCode = map[string]problemKind{
+	entities.ErrorTicketDoesNotExists.Code:            {http.StatusNotFound, ProblemTicketNotFound, "problem.ticket_not_found"},
+	entities.ErrorPassengerDoesNotExists.Code:         {http.StatusNotFound, ProblemPassengerNotFound, "problem.passenger_not_found"},
+	entities.ErrorsThereArePassengersOnTheFlight.Code: {http.StatusConflict, ProblemPassengersOnBoard, "problem.passengers_on_board"},
+	entities.ErrorPassengerHasDocuments.Code:          {http.StatusConflict, ProblemPassengerHasDocuments, "problem.passenger_has_documents"},
+	entities.ErrorIdempotencyKeyReused.Code:           {http.StatusUnprocessableEntity, ProblemIdempotencyKeyReused, "problem.idempotency_key_reused"},
+	entities.ErrorIdempotentRequestInProgress.Code:    {http.StatusConflict, ProblemRequestInProgress, "problem.idempotent_request_in_progress"},
+}
+
+func problemKindOf(domainErr *entities.Error) (problemKind, bool) {
+	if kind, ok := _problemKindsByCode[domainErr.Code]; ok {
+		return kind, true
+	}
+
+	kind, ok := _problemKinds[domainErr.Category]
+
+	return kind, ok
+}
+
+var errPreconditionFailed = errors.New("If-Match does not match the current ETag")
+
+// problem is an RFC 7807 problem details object.
+type problem struct {
+	Type     string `json:"type" example:"urn:flights:problem:not-found"`
+	Title    string `json:"title" example:"Not found"`
+	Status   int    `json:"status" example:"404"`
+	Detail   string `json:"detail,omitempty" example:"Nothing found"`
+	Instance string `json:"instance,omitempty" example:"/v2/tickets/1efa5f3d-2b9c-6d0e-8f1a-0242ac120002"`
+	// Code is the code of the domain error, see entities
+	Code    string         `json:"code,omitempty" example:"nothing_found"`
+	Details map[string]any `json:"details,omitempty"`
+	// Errors lists the rejected fields of validation and malformed-request problems
+	Errors []validation.FieldError `json:"errors,omitempty"`
+}
+
+func setBindError(c *gin.Context, err error) {
+	c.Error(err).SetType(gin.ErrorTypeBind)
+}
+
+func setAnyError(c *gin.Context, err error) {
+	c.Error(err).SetType(gin.ErrorTypeAny)
+}
+
+func abortWithDomainProblem(c *gin.Context, kind problemKind, domainErr *entities.Error) {
+	locale := locale(c)
+
+	detail, _ := i18n.ErrorMessage(locale, domainErr)
+
+	if domainErr.Retryable {
+		c.Header("Retry-After", "1")
+	}
+
+	abortWith(c, problem{
+		Type:    kind.problemType,
+		Title:   i18n.Message(locale, kind.titleKey),
+		Status:  kind.status,
+		Detail:  detail,
+		Code:    domainErr.Code,
+		Details: domainErr.Details,
+	})
+}
+
+func abortWith(c *gin.Context, p problem) {
+	p.Instance = c.Request.URL.Path
+
+	c.Header("Content-Type", _problemContentType)
+	c.AbortWithStatusJSON(p.Status, p)
+}
+
+func locale(c *gin.Context) string {
+	locale := i18n.Negotiate(c.GetHeader("Accept-Language"))
+
+	c.Header("Content-Language", locale)
+	c.Header("Vary", "Accept-Language")
+
+	return locale
+}
+
+func errorsHandler(log Logger) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		c.Next()
+
+		abortWithErrors(c, log)
+	}
+}
+
+// abortWithErrors responds with the problem of the first error set by handlers.
+func abortWithErrors(c *gin.Context, log Logger) {
+	for _, ginErr := range c.Errors {
+		err, errType := ginErr.Err, ginErr.Type
+
+		switch errType {
+		case gin.ErrorTypeBind:
+			locale := locale(c)
+			validationErrs := validator.ValidationErrors{}
+
+			if errors.As(err, &validationErrs) {
+				log.DebugCtx(c.Request.Context(), ginErr, "%s", "StatusUnprocessableEntity")
+				abortWith(c, problem{
+					Type:   ProblemValidation,
+					Title:  i18n.Message(locale, "problem.validation"),
+					Status: http.StatusUnprocessableEntity,
+					Detail: i18n.Message(locale, "problem.validation.detail"),
+					Errors: validation.FieldErrors(err, locale),
+				})
+				return
+			}
+
+			log.DebugCtx(c.Request.Context(), ginErr, "%s", "StatusBadRequest")
+			abortWith(c, problem{
+				Type:   ProblemMalformedRequest,
+				Title:  i18n.Message(locale, "problem.malformed_request"),
+				Status: http.StatusBadRequest,
+				Detail: err.Error(),
+				Errors: validation.FieldErrors(err, locale),
+			})
+			return
+		case gin.ErrorTypeAny:
+			if errors.Is(err, errPreconditionFailed) {
+				log.DebugCtx(c.Request.Context(), ginErr, "%s", "StatusPreconditionFailed")
+				locale := locale(c)
+				abortWith(c, problem{
+					Type:   ProblemPreconditionFailed,
+					Title:  i18n.Message(locale, "problem.precondition_failed"),
+					Status: http.StatusPreconditionFailed,
+					Detail: i18n.Message(locale, "problem.precondition_failed.detail"),
+				})
+				return
+			}
+
+			domainErr, ok := entities.AsError(err)
+			if !ok {
+				break
+			}
+
+			kind, ok := problemKindOf(domainErr)
+			if !ok {
+				break
+			}
+
+			log.DebugCtx(c.Request.Context(), ginErr, "%s", http.StatusText(kind.status))
+			abortWithDomainProblem(c, kind, domainErr)
+			return
+		}
+
+		log.ErrorCtx(c.Request.Context(), ginErr, "%s", "StatusInternalServerError")
+		abortWith(c, problem{
+			Type:   ProblemInternal,
+			Title:  i18n.Message(locale(c), "problem.internal"),
+			Status: http.StatusInternalServerError,
+		})
+		return
+	}
+}
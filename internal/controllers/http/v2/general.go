@@ -0,0 +1,88 @@
+package v2
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"net/url"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+	"github.com/v1adhope/flights/internal/entities"
+)
+
+const (
+	_pageDefaultLimit = 100
+	_pageMaxLimit     = 1000
+)
+
+type id struct {
+	Value string `uri:"id" binding:"required,uuid"`
+}
+
+type pageQuery struct {
+	Limit uint64 `form:"limit" binding:"omitempty,min=1,max=1000"`
+	After string `form:"after" binding:"omitempty,uuid"`
+}
+
+func (q *pageQuery) toEntity() entities.Page {
+	limit := q.Limit
+	if limit == 0 {
+		limit = _pageDefaultLimit
+	}
+
+	return entities.Page{
+		After: q.After,
+		Limit: min(limit, _pageMaxLimit),
+	}
+}
+
+func setLocationHeader(c *gin.Context, url, id string) {
+	c.Header("location", fmt.Sprintf("/v2%s%s", url, id))
+}
+
+// setNextLinkHeader links a full page to the next one, a page may be full and still the last one.
+func setNextLinkHeader(c *gin.Context, path, after string, limit uint64) {
+	query := url.Values{}
+	query.Set("after", after)
+	query.Set("limit", fmt.Sprint(limit))
+
+	c.Header("Link", fmt.Sprintf(`</v2%s?%s>; rel="next"`, path, query.Encode()))
+}
+
+// etag is a strong validator of the JSON representation.
+func etag(v any) string {
+	data, _ := json.Marshal(v)
+	sum := sha256.Sum256(data)
+
+	return `"` + hex.EncodeToString(sum[:16]) + `"`
+}
+
+func setETagHeader(c *gin.Context, v any) {
+	c.Header("ETag", etag(v))
+}
+
+// ifMatch lets a client update or delete only the representation it has read.
+// The usecase checks the precondition against the current value locked for the write,
+// without If-Match the request is unconditional.
+func ifMatch[T any](c *gin.Context) func(current T) error {
+	header := c.GetHeader("If-Match")
+	if header == "" {
+		return nil
+	}
+
+	return func(current T) error {
+		currentTag := etag(current)
+
+		for _, tag := range strings.Split(header, ",") {
+			tag = strings.TrimSpace(tag)
+
+			if tag == "*" || tag == currentTag {
+				return nil
+			}
+		}
+
+		return errPreconditionFailed
+	}
+}
@@ -0,0 +1,136 @@
+package v2
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"io"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/v1adhope/flights/internal/controllers/http/i18n"
+	"github.com/v1adhope/flights/internal/entities"
+)
+
+const (
+	_idempotencyKeyHeader    = "Idempotency-Key"
+	_idempotencyKeyMaxLength = 255
+	// _idempotentReplayedHeader marks responses replayed from the stored ones.
+	_idempotentReplayedHeader = "Idempotent-Replayed"
+)
+
+// idempotencyWriter keeps a copy of the body for replay.
+type idempotencyWriter struct {
+	gin.ResponseWriter
+	body bytes.Buffer
+}
+
+func (w *idempotencyWriter) Write(data []byte) (int, error) {
+	w.body.Write(data)
+
+	return w.ResponseWriter.Write(data)
+}
+
+func (w *idempotencyWriter) WriteString(s string) (int, error) {
+	w.body.WriteString(s)
+
+	return w.ResponseWriter.WriteString(s)
+}
+
+// idempotency replays the response to a POST retried with the same Idempotency-Key, so creations are safe to retry.
+// It runs before errorsHandler to store problems as well.
+func idempotency(uc IdempotencyUsecaser, log Logger) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		key := c.GetHeader(_idempotencyKeyHeader)
+		if c.Request.Method != http.MethodPost || key == "" {
+			c.Next()
+			return
+		}
+
+		if len(key) > _idempotencyKeyMaxLength {
+			locale := locale(c)
+			abortWith(c, problem{
+				Type:   ProblemMalformedRequest,
+				Title:  i18n.Message(locale, "problem.malformed_request"),
+				Status: http.StatusBadRequest,
+				Detail: fmt.Sprintf("%s is longer than %d characters", _idempotencyKeyHeader, _idempotencyKeyMaxLength),
+			})
+			return
+		}
+
+		body, err := io.ReadAll(c.Request.Body)
+		if err != nil {
+			setBindError(c, err)
+			c.Abort()
+			abortWithErrors(c, log)
+			return
+		}
+
+		c.Request.Body = io.NopCloser(bytes.NewReader(body))
+
+		stored, err := uc.StartIdempotentRequest(c.Request.Context(), key, requestHash(c.Request, body))
+		if err != nil {
+			setAnyError(c, err)
+			c.Abort()
+			abortWithErrors(c, log)
+			return
+		}
+
+		if stored.Status != 0 {
+			replay(c, stored)
+			return
+		}
+
+		w := &idempotencyWriter{ResponseWriter: c.Writer}
+		c.Writer = w
+
+		// Deferred, so a panicking handler releases the key like a server error and its retries aren't in progress.
+		// The response is sent already, a client gone away must not keep the key reserved.
+		handled := false
+		defer func() {
+			resp := entities.IdempotentResponse{
+				Key:    key,
+				Status: http.StatusInternalServerError,
+			}
+
+			if handled {
+				resp.Status = w.Status()
+				resp.Location = w.Header().Get("Location")
+				resp.Body = w.body.Bytes()
+			}
+
+			if err := uc.FinishIdempotentRequest(context.WithoutCancel(c.Request.Context()), resp); err != nil {
+				log.ErrorCtx(c.Request.Context(), err, "%s", "idempotency: FinishIdempotentRequest")
+			}
+		}()
+
+		c.Next()
+		handled = true
+	}
+}
+
+func requestHash(r *http.Request, body []byte) string {
+	h := sha256.New()
+
+	fmt.Fprintf(h, "%s %s\n", r.Method, r.URL.Path)
+	h.Write(body)
+
+	return hex.EncodeToString(h.Sum(nil))
+}
+
+func replay(c *gin.Context, stored entities.IdempotentResponse) {
+	contentType := gin.MIMEJSON
+	if stored.Status >= http.StatusBadRequest {
+		contentType = _problemContentType
+	}
+
+	if stored.Location != "" {
+		c.Header("Location", stored.Location)
+	}
+
+	c.Header(_idempotentReplayedHeader, "true")
+	c.Data(stored.Status, contentType, stored.Body)
+	c.Abort()
+}
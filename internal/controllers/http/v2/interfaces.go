@@ -0,0 +1,52 @@
+package v2
+
+import (
+	"context"
+
+	"github.com/v1adhope/flights/internal/entities"
+)
+
+type TicketUsecaser interface {
+	CreateTicket(ctx context.Context, ticket entities.Ticket) (entities.Id, error)
+	ReplaceTicketIf(ctx context.Context, ticket entities.Ticket, precondition func(current entities.Ticket) error) error
+	DeleteTicketIf(ctx context.Context, id entities.Id, precondition func(current entities.Ticket) error) error
+	GetTickets(ctx context.Context) ([]entities.Ticket, error)
+	GetTicketsPage(ctx context.Context, page entities.Page) ([]entities.Ticket, error)
+	GetTicketsByIds(ctx context.Context, ids []string) ([]entities.Ticket, error)
+	GetTicketsByPassengerIds(ctx context.Context, ids []string) (map[string][]entities.Ticket, error)
+	GetWholeInfoAboutTicket(ctx context.Context, id entities.Id) (entities.TicketWholeInfo, error)
+}
+
+type PassengerUsecaser interface {
+	CreatePassenger(ctx context.Context, passenger entities.Passenger) (entities.Id, error)
+	RegisterPassenger(ctx context.Context, passenger entities.Passenger, documents []entities.Document, ticketIds []entities.Id) (entities.Id, error)
+	ReplacePassengerIf(ctx context.Context, passenger entities.Passenger, precondition func(current entities.Passenger) error) error
+	DeletePassengerIf(ctx context.Context, id entities.Id, precondition func(current entities.Passenger) error) error
+	BoundToTicket(ctx context.Context, id entities.Id, ticketId entities.Id) error
+	UnboundToTicket(ctx context.Context, id entities.Id, ticketId entities.Id) error
+	GetPassengers(ctx context.Context) ([]entities.Passenger, error)
+	GetPassengersByIds(ctx context.Context, ids []string) ([]entities.Passenger, error)
+	GetPassengersByTicketIds(ctx context.Context, ids []string) (map[string][]entities.Passenger, error)
+}
+
+type DocumentUsecaser interface {
+	CreateDocument(ctx context.Context, document entities.Document) (string, error)
+	ReplaceDocument(ctx context.Context, document entities.Document) error
+	DeleteDocument(ctx context.Context, id entities.Id) error
+	GetDocumentsByPassengerIds(ctx context.Context, ids []string) (map[string][]entities.Document, error)
+}
+
+type ReportUsecaser interface {
+	GetRowsByPassengerIdForPeriod(ctx context.Context, id entities.Id, filter entities.PeriodFilter) ([]entities.ReportRowByPassengerForPeriod, error)
+}
+
+type IdempotencyUsecaser interface {
+	StartIdempotentRequest(ctx context.Context, key, requestHash string) (entities.IdempotentResponse, error)
+	FinishIdempotentRequest(ctx context.Context, resp entities.IdempotentResponse) error
+}
+
+// Logger logs with the attributes of the context, e.g. the id of the request.
+type Logger interface {
+	DebugCtx(ctx context.Context, err error, format string, msg ...any)
+	ErrorCtx(ctx context.Context, err error, format string, msg ...any)
+}
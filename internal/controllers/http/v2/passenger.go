@@ -0,0 +1,372 @@
+package v2
+
+import (
+	"context"
+	"errors"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/v1adhope/flights/internal/entities"
+)
+
+type passengerGroup struct {
+	rg *gin.RouterGroup
+	uc passengerGroupUsecaser
+}
+
+type passengerGroupUsecaser interface {
+	TicketUsecaser
+	PassengerUsecaser
+	DocumentUsecaser
+	ReportUsecaser
+}
+
+func registerPassengerGroup(group *passengerGroup) {
+	passengerG := group.rg.Group("/passengers")
+	{
+		passengerG.POST("", group.create)
+		passengerG.GET("/:id", group.one)
+		passengerG.PUT("/:id", group.replace)
+		passengerG.DELETE("/:id", group.delete)
+		passengerG.GET("/:id/tickets", group.tickets)
+		passengerG.GET("/:id/documents", group.documents)
+		passengerG.GET("/:id/report", group.report)
+
+		if gin.Mode() == gin.DebugMode {
+			passengerG.GET("", group.all)
+		}
+	}
+}
+
+type passengerReq struct {
+	FirstName  string `json:"firstName" example:"Riley" binding:"required,max=255,names"`
+	LastName   string `json:"lastName" example:"Scott" binding:"required,max=255,names"`
+	MiddleName string `json:"middleName" example:"Reed" binding:"required,max=255,names"`
+}
+
+func (r *passengerReq) toEntity(id string) entities.Passenger {
+	return entities.Passenger{
+		Id:         id,
+		FirstName:  r.FirstName,
+		LastName:   r.LastName,
+		MiddleName: r.MiddleName,
+	}
+}
+
+// passengerCreateReq registers the passenger with its documents and tickets in one transaction.
+type passengerCreateReq struct {
+	passengerReq
+	Documents []documentCreateReq `json:"documents" binding:"max=10,dive"`
+	TicketIds []string            `json:"ticketIds" binding:"max=10,dive,uuid"`
+}
+
+func (r *passengerCreateReq) documents() []entities.Document {
+	documents := make([]entities.Document, 0, len(r.Documents))
+
+	for _, document := range r.Documents {
+		documents = append(documents, entities.Document{
+			Type:   document.Type,
+			Number: document.Number,
+		})
+	}
+
+	return documents
+}
+
+func (r *passengerCreateReq) ticketIds() []entities.Id {
+	ids := make([]entities.Id, 0, len(r.TicketIds))
+
+	for _, ticketId := range r.TicketIds {
+		ids = append(ids, entities.Id{Value: ticketId})
+	}
+
+	return ids
+}
+
+type reportQuery struct {
+	From string `form:"from" binding:"required"`
+	To   string `form:"to" binding:"required"`
+}
+
+func (r reportQuery) PeriodBounds() (string, string) {
+	return r.From, r.To
+}
+
+// @tags Passengers
+// @description Documents and tickets are optional, the passenger is created only if every document is added and every ticket is bound
+// @accept json
+// @produce json,application/problem+json
+// @param passenger body passengerCreateReq true "Passenger request entity"
+// @param Idempotency-Key header string false "Retries with the same key get the first response, kept for 24 hours"
+// @success 201 {object} entities.Id
+// @header 201 {string} location "/v2/passengers/{id}"
+// @failure 400 {object} problem
+// @failure 404 {object} problem "Ticket not found"
+// @failure 409 {object} problem "The document has already exists or a request with the same Idempotency-Key is in progress"
+// @failure 422 {object} problem
+// @failure 500 {object} problem
+// @router /passengers [POST]
+func (g *passengerGroup) create(c *gin.Context) {
+	req := passengerCreateReq{}
+
+	if err := c.ShouldBindJSON(&req); err != nil {
+		setBindError(c, err)
+		return
+	}
+
+	id, err := g.uc.RegisterPassenger(c.Request.Context(), req.toEntity(""), req.documents(), req.ticketIds())
+	if err != nil {
+		setAnyError(c, err)
+		return
+	}
+
+	setLocationHeader(c, "/passengers/", id.Value)
+
+	c.JSON(http.StatusCreated, id)
+}
+
+// @tags Passengers
+// @description Support endpoint (not by terms). Avaible only within gin debug
+// @produce json,application/problem+json
+// @success 200 {array} entities.Passenger
+// @failure 500 {object} problem
+// @router /passengers [GET]
+func (g *passengerGroup) all(c *gin.Context) {
+	passengers, err := g.uc.GetPassengers(c.Request.Context())
+	if err != nil && !errors.Is(err, entities.ErrorNothingFound) {
+		setAnyError(c, err)
+		return
+	}
+
+	if passengers == nil {
+		passengers = []entities.Passenger{}
+	}
+
+	c.JSON(http.StatusOK, passengers)
+}
+
+// @tags Passengers
+// @produce json,application/problem+json
+// @param id path string true "Passenger id (uuid)"
+// @success 200 {object} entities.Passenger
+// @header 200 {string} ETag "Pass as If-Match to replace or delete exactly this version"
+// @failure 404 {object} problem
+// @failure 422 {object} problem
+// @failure 500 {object} problem
+// @router /passengers/{id} [GET]
+func (g *passengerGroup) one(c *gin.Context) {
+	params := id{}
+
+	if err := c.ShouldBindUri(&params); err != nil {
+		setBindError(c, err)
+		return
+	}
+
+	passenger, err := g.passenger(c.Request.Context(), params.Value)
+	if err != nil {
+		setAnyError(c, err)
+		return
+	}
+
+	setETagHeader(c, passenger)
+
+	c.JSON(http.StatusOK, passenger)
+}
+
+// @tags Passengers
+// @accept json
+// @produce application/problem+json
+// @param passenger body passengerReq true "Passenger request entity"
+// @param id path string true "Passenger id (uuid)"
+// @param If-Match header string false "ETag of the passenger"
+// @success 204
+// @failure 400 {object} problem
+// @failure 404 {object} problem
+// @failure 412 {object} problem
+// @failure 422 {object} problem
+// @failure 500 {object} problem
+// @router /passengers/{id} [PUT]
+func (g *passengerGroup) replace(c *gin.Context) {
+	params := id{}
+
+	if err := c.ShouldBindUri(&params); err != nil {
+		setBindError(c, err)
+		return
+	}
+
+	req := passengerReq{}
+
+	if err := c.ShouldBindJSON(&req); err != nil {
+		setBindError(c, err)
+		return
+	}
+
+	if err := g.uc.ReplacePassengerIf(c.Request.Context(), req.toEntity(params.Value), ifMatch[entities.Passenger](c)); err != nil {
+		setAnyError(c, err)
+		return
+	}
+
+	c.Status(http.StatusNoContent)
+}
+
+// @tags Passengers
+// @produce application/problem+json
+// @param id path string true "Passenger id (uuid)"
+// @param If-Match header string false "ETag of the passenger"
+// @success 204
+// @failure 404 {object} problem
+// @failure 412 {object} problem
+// @failure 422 {object} problem
+// @failure 500 {object} problem
+// @router /passengers/{id} [DELETE]
+func (g *passengerGroup) delete(c *gin.Context) {
+	params := id{}
+
+	if err := c.ShouldBindUri(&params); err != nil {
+		setBindError(c, err)
+		return
+	}
+
+	if err := g.uc.DeletePassengerIf(c.Request.Context(), entities.Id{Value: params.Value}, ifMatch[entities.Passenger](c)); err != nil {
+		setAnyError(c, err)
+		return
+	}
+
+	c.Status(http.StatusNoContent)
+}
+
+// @tags Passengers
+// @produce json,application/problem+json
+// @param id path string true "Passenger id (uuid)"
+// @success 200 {array} entities.Ticket
+// @failure 404 {object} problem
+// @failure 422 {object} problem
+// @failure 500 {object} problem
+// @router /passengers/{id}/tickets [GET]
+func (g *passengerGroup) tickets(c *gin.Context) {
+	params := id{}
+
+	if err := c.ShouldBindUri(&params); err != nil {
+		setBindError(c, err)
+		return
+	}
+
+	byPassenger, err := g.uc.GetTicketsByPassengerIds(c.Request.Context(), []string{params.Value})
+	if err != nil {
+		setAnyError(c, err)
+		return
+	}
+
+	tickets := byPassenger[params.Value]
+
+	if len(tickets) == 0 {
+		if _, err := g.passenger(c.Request.Context(), params.Value); err != nil {
+			setAnyError(c, err)
+			return
+		}
+
+		tickets = []entities.Ticket{}
+	}
+
+	c.JSON(http.StatusOK, tickets)
+}
+
+// @tags Passengers
+// @produce json,application/problem+json
+// @param id path string true "Passenger id (uuid)"
+// @success 200 {array} entities.Document
+// @failure 404 {object} problem
+// @failure 422 {object} problem
+// @failure 500 {object} problem
+// @router /passengers/{id}/documents [GET]
+func (g *passengerGroup) documents(c *gin.Context) {
+	params := id{}
+
+	if err := c.ShouldBindUri(&params); err != nil {
+		setBindError(c, err)
+		return
+	}
+
+	byPassenger, err := g.uc.GetDocumentsByPassengerIds(c.Request.Context(), []string{params.Value})
+	if err != nil {
+		setAnyError(c, err)
+		return
+	}
+
+	documents := byPassenger[params.Value]
+
+	if len(documents) == 0 {
+		if _, err := g.passenger(c.Request.Context(), params.Value); err != nil {
+			setAnyError(c, err)
+			return
+		}
+
+		documents = []entities.Document{}
+	}
+
+	c.JSON(http.StatusOK, documents)
+}
+
+// @tags Passengers
+// @produce json,application/problem+json
+// @param id path string true "Passenger id (uuid)"
+// @param from query string true "Start of the period (RFC 3339)"
+// @param to query string true "End of the period (RFC 3339)"
+// @success 200 {array} entities.ReportRowByPassengerForPeriod
+// @failure 400 {object} problem
+// @failure 404 {object} problem
+// @failure 422 {object} problem
+// @failure 500 {object} problem
+// @router /passengers/{id}/report [GET]
+func (g *passengerGroup) report(c *gin.Context) {
+	params := id{}
+
+	if err := c.ShouldBindUri(&params); err != nil {
+		setBindError(c, err)
+		return
+	}
+
+	query := reportQuery{}
+
+	if err := c.ShouldBindQuery(&query); err != nil {
+		setBindError(c, err)
+		return
+	}
+
+	rows, err := g.uc.GetRowsByPassengerIdForPeriod(
+		c.Request.Context(),
+		entities.Id{Value: params.Value},
+		entities.PeriodFilter{
+			From: query.From,
+			To:   query.To,
+		},
+	)
+	if err != nil {
+		if !errors.Is(err, entities.ErrorNothingFound) {
+			setAnyError(c, err)
+			return
+		}
+
+		if _, err := g.passenger(c.Request.Context(), params.Value); err != nil {
+			setAnyError(c, err)
+			return
+		}
+
+		rows = []entities.ReportRowByPassengerForPeriod{}
+	}
+
+	c.JSON(http.StatusOK, rows)
+}
+
+func (g *passengerGroup) passenger(ctx context.Context, id string) (entities.Passenger, error) {
+	passengers, err := g.uc.GetPassengersByIds(ctx, []string{id})
+	if err != nil {
+		return entities.Passenger{}, err
+	}
+
+	if len(passengers) == 0 {
+		return entities.Passenger{}, entities.ErrorNothingFound
+	}
+
+	return passengers[0], nil
+}
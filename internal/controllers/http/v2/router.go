@@ -0,0 +1,37 @@
+package v2
+
+import (
+	"github.com/gin-gonic/gin"
+	swaggerfiles "github.com/swaggo/files"
+	ginSwagger "github.com/swaggo/gin-swagger"
+	docs "github.com/v1adhope/flights/docs/v2"
+	"github.com/v1adhope/flights/internal/controllers/http/validation"
+	"github.com/v1adhope/flights/internal/usecases"
+	"github.com/v1adhope/flights/pkg/logger"
+)
+
+type Router struct {
+	Handler  *gin.Engine
+	Usecases *usecases.Usecases
+	Log      *logger.Log
+}
+
+func Register(r *Router) {
+	docs.SwaggerInfov2.BasePath = "/v2"
+	docs.SwaggerInfov2.Title = "Flights API"
+	docs.SwaggerInfov2.Version = "2.0"
+
+	if v := validation.Gin(); v != nil {
+		validation.Register(v, ticketReq{}, reportQuery{})
+	}
+
+	rg := r.Handler.Group("/v2")
+	rg.Use(idempotency(r.Usecases, r.Log), errorsHandler(r.Log))
+	{
+		rg.GET("/swagger/*any", ginSwagger.WrapHandler(swaggerfiles.Handler, ginSwagger.InstanceName(docs.SwaggerInfov2.InstanceName())))
+
+		registerTicketGroup(&ticketGroup{rg, r.Usecases})
+		registerPassengerGroup(&passengerGroup{rg, r.Usecases})
+		registerDocumentGroup(&documentGroup{rg, r.Usecases})
+	}
+}
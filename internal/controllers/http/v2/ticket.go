@@ -0,0 +1,362 @@
+package v2
+
+import (
+	"context"
+	"errors"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/v1adhope/flights/internal/entities"
+)
+
+type ticketGroup struct {
+	rg *gin.RouterGroup
+	uc ticketGroupUsecaser
+}
+
+type ticketGroupUsecaser interface {
+	TicketUsecaser
+	PassengerUsecaser
+}
+
+func registerTicketGroup(group *ticketGroup) {
+	ticketG := group.rg.Group("/tickets")
+	{
+		ticketG.POST("", group.create)
+		ticketG.GET("", group.all)
+		ticketG.GET("/:id", group.one)
+		ticketG.PUT("/:id", group.replace)
+		ticketG.DELETE("/:id", group.delete)
+		ticketG.GET("/:id/whole-info", group.wholeInfo)
+		ticketG.GET("/:id/passengers", group.passengers)
+		ticketG.PUT("/:id/passengers/:passengerId", group.bindPassenger)
+		ticketG.DELETE("/:id/passengers/:passengerId", group.unbindPassenger)
+	}
+}
+
+type ticketReq struct {
+	Provider string `json:"provider" example:"Emirates" binding:"required,max=255"`
+	FlyFrom  string `json:"flyFrom" example:"Moscow" binding:"required,max=255,names"`
+	FlyTo    string `json:"flyTo" example:"Hanoi" binding:"required,max=255,names"`
+	FlyAt    string `json:"flyAt" example:"3022-01-02T15:04:05+03:00" binding:"required"`
+	ArriveAt string `json:"arriveAt" example:"3022-01-03T18:04:40+07:00" binding:"required"`
+}
+
+func (r ticketReq) FlightTimes() (string, string) {
+	return r.FlyAt, r.ArriveAt
+}
+
+func (r *ticketReq) toEntity(id string) entities.Ticket {
+	return entities.Ticket{
+		Id:       id,
+		Provider: r.Provider,
+		FlyFrom:  r.FlyFrom,
+		FlyTo:    r.FlyTo,
+		FlyAt:    r.FlyAt,
+		ArriveAt: r.ArriveAt,
+	}
+}
+
+type ticketPassengerUri struct {
+	Id          string `uri:"id" binding:"required,uuid"`
+	PassengerId string `uri:"passengerId" binding:"required,uuid"`
+}
+
+// @tags Tickets
+// @accept json
+// @produce json,application/problem+json
+// @param ticket body ticketReq true "Ticket request entity"
+// @param Idempotency-Key header string false "Retries with the same key get the first response, kept for 24 hours"
+// @success 201 {object} entities.Id
+// @header 201 {string} location "/v2/tickets/{id}"
+// @failure 400 {object} problem
+// @failure 409 {object} problem "A request with the same Idempotency-Key is in progress"
+// @failure 422 {object} problem
+// @failure 500 {object} problem
+// @router /tickets [POST]
+func (g *ticketGroup) create(c *gin.Context) {
+	req := ticketReq{}
+
+	if err := c.ShouldBindJSON(&req); err != nil {
+		setBindError(c, err)
+		return
+	}
+
+	id, err := g.uc.CreateTicket(c.Request.Context(), req.toEntity(""))
+	if err != nil {
+		setAnyError(c, err)
+		return
+	}
+
+	setLocationHeader(c, "/tickets/", id.Value)
+
+	c.JSON(http.StatusCreated, id)
+}
+
+// @tags Tickets
+// @description Tickets in creation order. Pass the URL of the Link header with rel="next" to get the next page,
+// @description there is no such header on the last page.
+// @produce json,application/problem+json
+// @param limit query int false "Page size" minimum(1) maximum(1000) default(100)
+// @param after query string false "Id of the last ticket of the previous page (uuid)"
+// @success 200 {array} entities.Ticket
+// @header 200 {string} Link "</v2/tickets?after={id}&limit={limit}>; rel=\"next\""
+// @failure 422 {object} problem
+// @failure 500 {object} problem
+// @router /tickets [GET]
+func (g *ticketGroup) all(c *gin.Context) {
+	query := pageQuery{}
+
+	if err := c.ShouldBindQuery(&query); err != nil {
+		setBindError(c, err)
+		return
+	}
+
+	page := query.toEntity()
+
+	tickets, err := g.uc.GetTicketsPage(c.Request.Context(), page)
+	if err != nil && !errors.Is(err, entities.ErrorNothingFound) {
+		setAnyError(c, err)
+		return
+	}
+
+	if tickets == nil {
+		tickets = []entities.Ticket{}
+	}
+
+	if uint64(len(tickets)) == page.Limit {
+		setNextLinkHeader(c, "/tickets", tickets[len(tickets)-1].Id, page.Limit)
+	}
+
+	c.JSON(http.StatusOK, tickets)
+}
+
+// @tags Tickets
+// @produce json,application/problem+json
+// @param id path string true "Ticket id (uuid)"
+// @success 200 {object} entities.Ticket
+// @header 200 {string} ETag "Pass as If-Match to replace or delete exactly this version"
+// @failure 404 {object} problem
+// @failure 422 {object} problem
+// @failure 500 {object} problem
+// @router /tickets/{id} [GET]
+func (g *ticketGroup) one(c *gin.Context) {
+	params := id{}
+
+	if err := c.ShouldBindUri(&params); err != nil {
+		setBindError(c, err)
+		return
+	}
+
+	ticket, err := g.ticket(c.Request.Context(), params.Value)
+	if err != nil {
+		setAnyError(c, err)
+		return
+	}
+
+	setETagHeader(c, ticket)
+
+	c.JSON(http.StatusOK, ticket)
+}
+
+// @tags Tickets
+// @accept json
+// @produce application/problem+json
+// @param ticket body ticketReq true "Ticket request entity"
+// @param id path string true "Ticket id (uuid)"
+// @param If-Match header string false "ETag of the ticket"
+// @success 204
+// @failure 400 {object} problem
+// @failure 404 {object} problem
+// @failure 412 {object} problem
+// @failure 422 {object} problem
+// @failure 500 {object} problem
+// @router /tickets/{id} [PUT]
+func (g *ticketGroup) replace(c *gin.Context) {
+	params := id{}
+
+	if err := c.ShouldBindUri(&params); err != nil {
+		setBindError(c, err)
+		return
+	}
+
+	req := ticketReq{}
+
+	if err := c.ShouldBindJSON(&req); err != nil {
+		setBindError(c, err)
+		return
+	}
+
+	if err := g.uc.ReplaceTicketIf(c.Request.Context(), req.toEntity(params.Value), ifMatch[entities.Ticket](c)); err != nil {
+		setAnyError(c, err)
+		return
+	}
+
+	c.Status(http.StatusNoContent)
+}
+
+// @tags Tickets
+// @produce application/problem+json
+// @param id path string true "Ticket id (uuid)"
+// @param If-Match header string false "ETag of the ticket"
+// @success 204
+// @failure 404 {object} problem
+// @failure 409 {object} problem "There are passengers on the flight"
+// @failure 412 {object} problem
+// @failure 422 {object} problem
+// @failure 500 {object} problem
+// @router /tickets/{id} [DELETE]
+func (g *ticketGroup) delete(c *gin.Context) {
+	params := id{}
+
+	if err := c.ShouldBindUri(&params); err != nil {
+		setBindError(c, err)
+		return
+	}
+
+	if err := g.uc.DeleteTicketIf(c.Request.Context(), entities.Id{Value: params.Value}, ifMatch[entities.Ticket](c)); err != nil {
+		setAnyError(c, err)
+		return
+	}
+
+	c.Status(http.StatusNoContent)
+}
+
+// @tags Tickets
+// @description Passengers are ordered by last name, the documents of a passenger by type.
+// @produce json,application/problem+json
+// @param id path string true "Ticket id (uuid)"
+// @success 200 {object} entities.TicketWholeInfo
+// @failure 404 {object} problem
+// @failure 422 {object} problem
+// @failure 500 {object} problem
+// @router /tickets/{id}/whole-info [GET]
+func (g *ticketGroup) wholeInfo(c *gin.Context) {
+	params := id{}
+
+	if err := c.ShouldBindUri(&params); err != nil {
+		setBindError(c, err)
+		return
+	}
+
+	wholeInfo, err := g.uc.GetWholeInfoAboutTicket(
+		c.Request.Context(),
+		entities.Id{Value: params.Value},
+	)
+	if err != nil {
+		setAnyError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, wholeInfo)
+}
+
+// @tags Tickets
+// @produce json,application/problem+json
+// @param id path string true "Ticket id (uuid)"
+// @success 200 {array} entities.Passenger
+// @failure 404 {object} problem
+// @failure 422 {object} problem
+// @failure 500 {object} problem
+// @router /tickets/{id}/passengers [GET]
+func (g *ticketGroup) passengers(c *gin.Context) {
+	params := id{}
+
+	if err := c.ShouldBindUri(&params); err != nil {
+		setBindError(c, err)
+		return
+	}
+
+	byTicket, err := g.uc.GetPassengersByTicketIds(c.Request.Context(), []string{params.Value})
+	if err != nil {
+		setAnyError(c, err)
+		return
+	}
+
+	passengers := byTicket[params.Value]
+
+	if len(passengers) == 0 {
+		if _, err := g.ticket(c.Request.Context(), params.Value); err != nil {
+			setAnyError(c, err)
+			return
+		}
+
+		passengers = []entities.Passenger{}
+	}
+
+	c.JSON(http.StatusOK, passengers)
+}
+
+// @tags Tickets
+// @description Idempotent, binding an already bound passenger succeeds
+// @produce application/problem+json
+// @param id path string true "Ticket id (uuid)"
+// @param passengerId path string true "Passenger id (uuid)"
+// @success 204
+// @failure 404 {object} problem "Ticket or passenger not found"
+// @failure 422 {object} problem
+// @failure 500 {object} problem
+// @router /tickets/{id}/passengers/{passengerId} [PUT]
+func (g *ticketGroup) bindPassenger(c *gin.Context) {
+	params := ticketPassengerUri{}
+
+	if err := c.ShouldBindUri(&params); err != nil {
+		setBindError(c, err)
+		return
+	}
+
+	err := g.uc.BoundToTicket(
+		c.Request.Context(),
+		entities.Id{Value: params.PassengerId},
+		entities.Id{Value: params.Id},
+	)
+	if err != nil && !errors.Is(err, entities.ErrorHasAlreadyExists) {
+		setAnyError(c, err)
+		return
+	}
+
+	c.Status(http.StatusNoContent)
+}
+
+// @tags Tickets
+// @produce application/problem+json
+// @param id path string true "Ticket id (uuid)"
+// @param passengerId path string true "Passenger id (uuid)"
+// @success 204
+// @failure 404 {object} problem "The passenger is not bound to the ticket"
+// @failure 422 {object} problem
+// @failure 500 {object} problem
+// @router /tickets/{id}/passengers/{passengerId} [DELETE]
+func (g *ticketGroup) unbindPassenger(c *gin.Context) {
+	params := ticketPassengerUri{}
+
+	if err := c.ShouldBindUri(&params); err != nil {
+		setBindError(c, err)
+		return
+	}
+
+	err := g.uc.UnboundToTicket(
+		c.Request.Context(),
+		entities.Id{Value: params.PassengerId},
+		entities.Id{Value: params.Id},
+	)
+	if err != nil {
+		setAnyError(c, err)
+		return
+	}
+
+	c.Status(http.StatusNoContent)
+}
+
+func (g *ticketGroup) ticket(ctx context.Context, id string) (entities.Ticket, error) {
+	tickets, err := g.uc.GetTicketsByIds(ctx, []string{id})
+	if err != nil {
+		return entities.Ticket{}, err
+	}
+
+	if len(tickets) == 0 {
+		return entities.Ticket{}, entities.ErrorNothingFound
+	}
+
+	return tickets[0], nil
+}
@@ -0,0 +1,127 @@
+package validation
+
+import (
+	"regexp"
+	"sync"
+	"time"
+
+	"github.com/gin-gonic/gin/binding"
+	"github.com/go-playground/validator/v10"
+)
+
+// Flight is a request with departure and arrival times, see FlightStructLevel.
+type Flight interface {
+	FlightTimes() (flyAt, arriveAt string)
+}
+
+// Period is a request for a time range, see PeriodStructLevel.
+type Period interface {
+	PeriodBounds() (from, to string)
+}
+
+var _names = regexp.MustCompile("^[A-z ,.'-]+$")
+
+var names validator.Func = func(fl validator.FieldLevel) bool {
+	return _names.MatchString(fl.Field().String())
+}
+
+// FlightStructLevel reports times that aren't RFC 3339, a departure in the past and an arrival before the departure.
+func FlightStructLevel(sl validator.StructLevel) {
+	flight := sl.Current().Interface().(Flight)
+	rawFlyAt, rawArriveAt := flight.FlightTimes()
+
+	flyAt, err := time.Parse(time.RFC3339, rawFlyAt)
+	if err != nil {
+		reportError(sl, rawFlyAt, "FlyAt", "rfc3339Time")
+	}
+
+	arriveAt, err := time.Parse(time.RFC3339, rawArriveAt)
+	if err != nil {
+		reportError(sl, rawArriveAt, "ArriveAt", "rfc3339Time")
+	}
+
+	if flyAt.Before(time.Now()) {
+		reportError(sl, rawFlyAt, "FlyAt", "flyght_before_now")
+	}
+
+	if arriveAt.Before(flyAt) {
+		reportError(sl, rawArriveAt, "ArriveAt", "arrive_before_fly")
+	}
+}
+
+// PeriodStructLevel reports bounds that aren't RFC 3339 and a period ending before it starts.
+func PeriodStructLevel(sl validator.StructLevel) {
+	period := sl.Current().Interface().(Period)
+	rawFrom, rawTo := period.PeriodBounds()
+
+	from, err := time.Parse(time.RFC3339, rawFrom)
+	if err != nil {
+		reportError(sl, rawFrom, "From", "rfc3339Time")
+	}
+
+	to, err := time.Parse(time.RFC3339, rawTo)
+	if err != nil {
+		reportError(sl, rawTo, "To", "rfc3339Time")
+	}
+
+	if to.Before(from) {
+		reportError(sl, rawTo, "To", "from_after_to")
+	}
+}
+
+// reportError names the struct field the way the client sent it, like errors of field-level rules.
+func reportError(sl validator.StructLevel, value any, structField, rule string) {
+	name := structField
+
+	if field, ok := sl.Current().Type().FieldByName(structField); ok {
+		name = requestFieldName(field)
+	}
+
+	sl.ReportError(value, name, structField, rule, "")
+}
+
+// New returns a validator reading binding tags like gin does with the shared rules, for transports other than gin.
+func New(requests ...any) *validator.Validate {
+	v := validator.New()
+	v.SetTagName("binding")
+
+	setup(v)
+	Register(v, requests...)
+
+	return v
+}
+
+var ginOnce sync.Once
+
+// Gin returns the binding engine of gin shared by every HTTP API version, the shared rules are set up on the first call.
+// It returns nil if gin validates with another engine.
+func Gin() *validator.Validate {
+	v, ok := binding.Validator.Engine().(*validator.Validate)
+	if !ok {
+		return nil
+	}
+
+	ginOnce.Do(func() {
+		setup(v)
+	})
+
+	return v
+}
+
+func setup(v *validator.Validate) {
+	UseRequestFieldNames(v)
+	v.RegisterValidation("names", names)
+}
+
+// Register adds the struct-level rules of requests implementing Flight or Period to v.
+func Register(v *validator.Validate, requests ...any) {
+	for _, req := range requests {
+		if _, ok := req.(Flight); ok {
+			v.RegisterStructValidation(FlightStructLevel, req)
+		}
+
+		if _, ok := req.(Period); ok {
+			v.RegisterStructValidation(PeriodStructLevel, req)
+		}
+	}
+}
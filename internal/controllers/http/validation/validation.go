@@ -0,0 +1,96 @@
+// Package validation turns binding errors into field errors clients can match on.
+package validation
+
+import (
+	"encoding/json"
+	"errors"
+	"reflect"
+	"strings"
+
+	"github.com/go-playground/validator/v10"
+	"github.com/v1adhope/flights/internal/controllers/http/i18n"
+)
+
+// FieldError is one rejected field of a request.
+type FieldError struct {
+	// Field is the path of the field as the client sent it: JSON name, query or URI parameter
+	Field   string `json:"field" example:"flyAt"`
+	Rule    string `json:"rule" example:"rfc3339Time"`
+	Message string `json:"message" example:"must be an RFC 3339 time"`
+	Value   any    `json:"value" swaggertype:"string" example:"tomorrow"`
+}
+
+// UseRequestFieldNames makes the validator report fields by their json, form, uri, header or proto tag.
+func UseRequestFieldNames(v *validator.Validate) {
+	v.RegisterTagNameFunc(requestFieldName)
+}
+
+func requestFieldName(field reflect.StructField) string {
+	for _, key := range []string{"json", "form", "uri", "header", "proto"} {
+		name, _, _ := strings.Cut(field.Tag.Get(key), ",")
+
+		switch name {
+		case "":
+			continue
+		case "-":
+			return ""
+		}
+
+		return name
+	}
+
+	return field.Name
+}
+
+// FieldErrors returns nil for errors not related to a particular field, e.g. malformed JSON.
+// Messages are in the locale, see i18n.Negotiate.
+func FieldErrors(err error, locale string) []FieldError {
+	validationErrs := validator.ValidationErrors{}
+
+	if errors.As(err, &validationErrs) {
+		fieldErrs := make([]FieldError, 0, len(validationErrs))
+
+		for _, fe := range validationErrs {
+			fieldErrs = append(fieldErrs, FieldError{
+				Field:   fieldPath(fe.Namespace()),
+				Rule:    fe.Tag(),
+				Message: message(locale, fe.Tag(), fe.Param()),
+				Value:   fe.Value(),
+			})
+		}
+
+		return fieldErrs
+	}
+
+	typeErr := &json.UnmarshalTypeError{}
+
+	if errors.As(err, &typeErr) && typeErr.Field != "" {
+		return []FieldError{{
+			Field:   typeErr.Field,
+			Rule:    "type",
+			Message: message(locale, "type", typeErr.Type.Kind().String()),
+			Value:   typeErr.Value,
+		}}
+	}
+
+	return nil
+}
+
+// fieldPath drops the request struct name from the namespace.
+func fieldPath(namespace string) string {
+	_, path, ok := strings.Cut(namespace, ".")
+	if !ok {
+		return namespace
+	}
+
+	return path
+}
+
+func message(locale, rule, param string) string {
+	message, ok := i18n.Lookup(locale, "validation."+rule, "param", param)
+	if !ok {
+		return i18n.Message(locale, "validation.unknown", "rule", rule)
+	}
+
+	return message
+}
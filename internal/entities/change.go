@@ -0,0 +1,17 @@
+package entities
+
+const (
+	ChangeTicket    = "ticket"
+	ChangePassenger = "passenger"
+	ChangeDocument  = "document"
+	ChangeBinding   = "binding"
+)
+
+// Change of an entity is broadcast to every service replica once it is committed.
+// Documents carry their passenger, bindings carry both sides and no id.
+type Change struct {
+	Entity      string `json:"entity"`
+	Id          string `json:"id,omitempty"`
+	PassengerId string `json:"passengerId,omitempty"`
+	TicketId    string `json:"ticketId,omitempty"`
+}
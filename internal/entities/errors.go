@@ -1,13 +1,130 @@
 package entities
 
-import "errors"
+import (
+	"errors"
+	"maps"
+)
+
+// Category groups domain errors every API reports the same way, see the mapping tables of the controllers.
+type Category string
+
+const (
+	CategoryInvalid          Category = "invalid"
+	CategoryNotFound         Category = "not_found"
+	CategoryMissingReference Category = "missing_reference"
+	CategoryAlreadyExists    Category = "already_exists"
+	CategoryRuleViolation    Category = "rule_violation"
+	CategoryUnavailable      Category = "unavailable"
+	CategoryInternal         Category = "internal"
+)
+
+// Error is a domain error, its message and details are safe to show to clients.
+type Error struct {
+	// Code is stable, clients and translations are keyed by it
+	Code      string
+	Category  Category
+	Message   string
+	Details   map[string]any
+	Retryable bool
+}
+
+func (e *Error) Error() string {
+	return e.Message
+}
+
+// Is matches errors with the same code, so the sentinels below match their copies with details.
+func (e *Error) Is(target error) bool {
+	t, ok := target.(*Error)
+
+	return ok && t.Code == e.Code
+}
+
+// WithDetails returns a copy of the error with the details added.
+func (e *Error) WithDetails(details map[string]any) *Error {
+	copied := *e
+	copied.Details = maps.Clone(e.Details)
+
+	if copied.Details == nil {
+		copied.Details = make(map[string]any, len(details))
+	}
+
+	maps.Copy(copied.Details, details)
+
+	return &copied
+}
+
+// AsError finds the domain error in the chain of err.
+func AsError(err error) (*Error, bool) {
+	var domainErr *Error
+
+	if errors.As(err, &domainErr) {
+		return domainErr, true
+	}
+
+	return nil, false
+}
 
 var (
-	ErrorNothingToChange                = errors.New("Nothing to change")
-	ErrorNothingToDelete                = errors.New("Nothing to delete")
-	ErrorNothingFound                   = errors.New("Nothing found")
-	ErrorHasAlreadyExists               = errors.New("Has already exists")
-	ErrorPassengerDoesNotExists         = errors.New("Passenger doesn't exist")
-	ErrorTicketDoesNotExists            = errors.New("Ticket doesn't exist")
-	ErrorsThereArePassengersOnTheFlight = errors.New("There are passengers on the flight")
+	ErrorNothingToChange = &Error{
+		Code:     "nothing_to_change",
+		Category: CategoryNotFound,
+		Message:  "Nothing to change",
+	}
+	ErrorNothingToDelete = &Error{
+		Code:     "nothing_to_delete",
+		Category: CategoryNotFound,
+		Message:  "Nothing to delete",
+	}
+	ErrorNothingFound = &Error{
+		Code:     "nothing_found",
+		Category: CategoryNotFound,
+		Message:  "Nothing found",
+	}
+	ErrorHasAlreadyExists = &Error{
+		Code:     "already_exists",
+		Category: CategoryAlreadyExists,
+		Message:  "Has already exists",
+	}
+	ErrorPassengerDoesNotExists = &Error{
+		Code:     "passenger_does_not_exist",
+		Category: CategoryMissingReference,
+		Message:  "Passenger doesn't exist",
+	}
+	ErrorTicketDoesNotExists = &Error{
+		Code:     "ticket_does_not_exist",
+		Category: CategoryMissingReference,
+		Message:  "Ticket doesn't exist",
+	}
+	ErrorWebhookSubscriptionDoesNotExist = &Error{
+		Code:     "webhook_subscription_does_not_exist",
+		Category: CategoryMissingReference,
+		Message:  "Webhook subscription doesn't exist",
+	}
+	ErrorsThereArePassengersOnTheFlight = &Error{
+		Code:     "passengers_on_the_flight",
+		Category: CategoryRuleViolation,
+		Message:  "There are passengers on the flight",
+	}
+	ErrorPassengerHasDocuments = &Error{
+		Code:     "passenger_has_documents",
+		Category: CategoryRuleViolation,
+		Message:  "Passenger has documents",
+	}
+	ErrorIdempotencyKeyReused = &Error{
+		Code:     "idempotency_key_reused",
+		Category: CategoryInvalid,
+		Message:  "Idempotency key has been used with another request",
+	}
+	ErrorIdempotentRequestInProgress = &Error{
+		Code:      "idempotent_request_in_progress",
+		Category:  CategoryUnavailable,
+		Message:   "Request with the same idempotency key is in progress",
+		Retryable: true,
+	}
+	ErrorTryAgain = &Error{
+		Code:      "try_again",
+		Category:  CategoryUnavailable,
+		Message:   "Try again later",
+		Retryable: true,
+	}
 )
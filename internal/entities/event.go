@@ -0,0 +1,38 @@
+package entities
+
+import "encoding/json"
+
+const (
+	AggregateTicket    = "ticket"
+	AggregatePassenger = "passenger"
+	AggregateDocument  = "document"
+)
+
+const (
+	EventTicketCreated     = "TicketCreated"
+	EventTicketReplaced    = "TicketReplaced"
+	EventTicketDeleted     = "TicketDeleted"
+	EventPassengerCreated  = "PassengerCreated"
+	EventPassengerReplaced = "PassengerReplaced"
+	EventPassengerDeleted  = "PassengerDeleted"
+	EventPassengerBound    = "PassengerBound"
+	EventPassengerUnbound  = "PassengerUnbound"
+	EventDocumentAdded     = "DocumentAdded"
+	EventDocumentReplaced  = "DocumentReplaced"
+	EventDocumentDeleted   = "DocumentDeleted"
+)
+
+type Event struct {
+	Id            string          `json:"id" example:"uuid"`
+	Seq           int64           `json:"seq" example:"1"`
+	Type          string          `json:"type" example:"TicketCreated"`
+	AggregateType string          `json:"aggregateType" example:"ticket"`
+	AggregateId   string          `json:"aggregateId" example:"uuid"`
+	Payload       json.RawMessage `json:"payload" swaggertype:"object"`
+	OccurredAt    string          `json:"occurredAt" example:"2024-01-02T15:04:05Z"`
+}
+
+type BindingEventPayload struct {
+	PassengerId string `json:"passengerId"`
+	TicketId    string `json:"ticketId"`
+}
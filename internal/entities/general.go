@@ -8,3 +8,9 @@ type PeriodFilter struct {
 	From string
 	To   string
 }
+
+// Page is a keyset page, After is the last id of the previous page.
+type Page struct {
+	After string
+	Limit uint64
+}
@@ -0,0 +1,20 @@
+package entities
+
+const (
+	HealthStatusUp   = "up"
+	HealthStatusDown = "down"
+)
+
+// Health is the status of the service, it is up when all of its components are.
+type Health struct {
+	Status     string            `json:"status" example:"up"`
+	Components []HealthComponent `json:"components"`
+}
+
+type HealthComponent struct {
+	Name   string `json:"name" example:"postgres"`
+	Status string `json:"status" example:"up"`
+	// Latency of the check in milliseconds
+	Latency float64 `json:"latency" example:"1.25"`
+	Error   string  `json:"error,omitempty" example:""`
+}
@@ -0,0 +1,11 @@
+package entities
+
+// IdempotentResponse is the stored response to a request with an Idempotency-Key, retries of the request get it back.
+type IdempotentResponse struct {
+	Key         string
+	RequestHash string
+	Status      int
+	Location    string
+	Body        []byte
+	CreatedAt   string
+}
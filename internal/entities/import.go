@@ -0,0 +1,23 @@
+package entities
+
+const (
+	ImportModeAllOrNothing = "all-or-nothing"
+	ImportModeBestEffort   = "best-effort"
+)
+
+type Binding struct {
+	PassengerId string
+	TicketId    string
+}
+
+type ImportRowResult struct {
+	Row int
+	Id  string
+	// Err is why the row hasn't been imported, nil if it has
+	Err error
+}
+
+type ImportRow[T any] struct {
+	Row   int
+	Value T
+}
@@ -0,0 +1,48 @@
+package entities
+
+import "encoding/json"
+
+const (
+	WebhookDeliveryPending   = "pending"
+	WebhookDeliveryDelivered = "delivered"
+	WebhookDeliveryDead      = "dead"
+)
+
+type WebhookSubscription struct {
+	Id         string   `json:"id" example:"uuid"`
+	Url        string   `json:"url" example:"https://partner.example.com/hooks/flights"`
+	Secret     string   `json:"secret,omitempty" example:"5f0c9c1d..."`
+	EventTypes []string `json:"eventTypes" example:"TicketCreated,PassengerBound"`
+	Active     bool     `json:"active" example:"true"`
+	CreatedAt  string   `json:"createdAt" example:"timestampz"`
+}
+
+// Matches reports whether the subscription wants the event, no event types means all of them.
+func (s *WebhookSubscription) Matches(eventType string) bool {
+	if len(s.EventTypes) == 0 {
+		return true
+	}
+
+	for _, t := range s.EventTypes {
+		if t == eventType {
+			return true
+		}
+	}
+
+	return false
+}
+
+type WebhookDelivery struct {
+	Id             string          `json:"id" example:"uuid"`
+	SubscriptionId string          `json:"subscriptionId" example:"uuid"`
+	EventId        string          `json:"eventId" example:"uuid"`
+	EventType      string          `json:"eventType" example:"TicketCreated"`
+	Payload        json.RawMessage `json:"-"`
+	Status         string          `json:"status" example:"pending"`
+	Attempts       int             `json:"attempts" example:"1"`
+	NextAttemptAt  string          `json:"nextAttemptAt,omitempty" example:"timestampz"`
+	LastStatusCode int             `json:"lastStatusCode,omitempty" example:"500"`
+	LastError      string          `json:"lastError,omitempty" example:"unexpected status 500"`
+	CreatedAt      string          `json:"createdAt" example:"timestampz"`
+	DeliveredAt    string          `json:"deliveredAt,omitempty" example:"timestampz"`
+}
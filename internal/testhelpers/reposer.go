@@ -0,0 +1,857 @@
+package testhelpers
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"slices"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/v1adhope/flights/db"
+	"github.com/v1adhope/flights/internal/entities"
+	"github.com/v1adhope/flights/internal/usecases"
+	"github.com/v1adhope/flights/pkg/migrator"
+)
+
+// RunReposerSuite checks a usecases.Reposer behaves as the Postgres repository does,
+// newRepo returns an empty repository to every test.
+// Postgres leaves the order of some reads undefined, the suite checks only the order the queries ask for.
+func RunReposerSuite(t *testing.T, newRepo func(t *testing.T) usecases.Reposer) {
+	tcs := []struct {
+		name string
+		fn   func(t *testing.T, repo usecases.Reposer)
+	}{
+		{"Tickets", reposerTickets},
+		{"TicketsPage", reposerTicketsPage},
+		{"Passengers", reposerPassengers},
+		{"Bindings", reposerBindings},
+		{"Documents", reposerDocuments},
+		{"WholeInfo", reposerWholeInfo},
+		{"Report", reposerReport},
+		{"Import", reposerImport},
+		{"Tx", reposerTx},
+		{"Outbox", reposerOutbox},
+		{"OutboxConcurrentWriters", reposerOutboxConcurrentWriters},
+		{"Webhooks", reposerWebhooks},
+		{"Idempotency", reposerIdempotency},
+		{"Health", reposerHealth},
+	}
+
+	for _, tc := range tcs {
+		t.Run(tc.name, func(t *testing.T) {
+			tc.fn(t, newRepo(t))
+		})
+	}
+}
+
+func newTicket(createdAt string) entities.Ticket {
+	return entities.Ticket{
+		Id:        uuid.NewString(),
+		Provider:  "Emirates",
+		FlyFrom:   "Moscow",
+		FlyTo:     "Hanoi",
+		FlyAt:     "3022-01-02T15:04:05+03:00",
+		ArriveAt:  "3022-01-03T18:04:40+07:00",
+		CreatedAt: createdAt,
+	}
+}
+
+func newPassenger(lastName string) entities.Passenger {
+	return entities.Passenger{
+		Id:         uuid.NewString(),
+		FirstName:  "Wendi",
+		LastName:   lastName,
+		MiddleName: "Mejia",
+	}
+}
+
+func newDocument(passengerId, docType, number string) entities.Document {
+	return entities.Document{
+		Id:          uuid.NewString(),
+		Type:        docType,
+		Number:      number,
+		PassengerId: passengerId,
+	}
+}
+
+// utcTicket makes the times of tickets comparable, repositories format them in the local time zone.
+func utcTicket(t *testing.T, ticket entities.Ticket) entities.Ticket {
+	ticket.FlyAt = utc(t, ticket.FlyAt)
+	ticket.ArriveAt = utc(t, ticket.ArriveAt)
+	ticket.CreatedAt = utc(t, ticket.CreatedAt)
+
+	return ticket
+}
+
+func utcTickets(t *testing.T, tickets []entities.Ticket) []entities.Ticket {
+	utcs := make([]entities.Ticket, 0, len(tickets))
+	for _, ticket := range tickets {
+		utcs = append(utcs, utcTicket(t, ticket))
+	}
+
+	return utcs
+}
+
+func utc(t *testing.T, s string) string {
+	if s == "" {
+		return ""
+	}
+
+	timeT, err := time.Parse(time.RFC3339Nano, s)
+	require.NoError(t, err)
+
+	return timeT.UTC().Format(time.RFC3339)
+}
+
+func ids[T any](values []T, id func(T) string) []string {
+	ids := make([]string, 0, len(values))
+	for _, v := range values {
+		ids = append(ids, id(v))
+	}
+
+	return ids
+}
+
+func ticketId(ticket entities.Ticket) string {
+	return ticket.Id
+}
+
+func documentId(document entities.Document) string {
+	return document.Id
+}
+
+func reposerTickets(t *testing.T, repo usecases.Reposer) {
+	ctx := context.Background()
+
+	_, err := repo.GetTickets(ctx)
+	assert.ErrorIs(t, err, entities.ErrorNothingFound)
+
+	ticket := newTicket("2024-01-02T15:04:05+03:00")
+	require.NoError(t, repo.CreateTicket(ctx, ticket))
+	assert.ErrorIs(t, repo.CreateTicket(ctx, ticket), entities.ErrorHasAlreadyExists)
+
+	replaced := ticket
+	replaced.Provider = "China Airlines"
+	replaced.FlyAt = "3023-04-16T21:00:00+08:00"
+	replaced.CreatedAt = ""
+	require.NoError(t, repo.ReplaceTicket(ctx, replaced))
+
+	missing := newTicket("2024-01-02T15:04:05+03:00")
+	assert.ErrorIs(t, repo.ReplaceTicket(ctx, missing), entities.ErrorNothingToChange)
+	assert.ErrorIs(t, repo.DeleteTicket(ctx, entities.Id{Value: missing.Id}), entities.ErrorNothingToDelete)
+
+	tickets, err := repo.GetTickets(ctx)
+	require.NoError(t, err)
+
+	// created_at is the date of issue, replacing keeps it
+	replaced.CreatedAt = ticket.CreatedAt
+	assert.Equal(t, []entities.Ticket{utcTicket(t, replaced)}, utcTickets(t, tickets))
+
+	tickets, err = repo.GetTicketsByIds(ctx, []string{ticket.Id, missing.Id})
+	require.NoError(t, err)
+	assert.Equal(t, []entities.Ticket{utcTicket(t, replaced)}, utcTickets(t, tickets))
+
+	err = repo.WithinTx(ctx, func(ctx context.Context) error {
+		locked, err := repo.LockTicket(ctx, entities.Id{Value: ticket.Id})
+		require.NoError(t, err)
+		assert.Equal(t, utcTicket(t, replaced), utcTicket(t, locked))
+
+		_, err = repo.LockTicket(ctx, entities.Id{Value: missing.Id})
+		assert.ErrorIs(t, err, entities.ErrorNothingFound)
+
+		return nil
+	})
+	require.NoError(t, err)
+
+	require.NoError(t, repo.DeleteTicket(ctx, entities.Id{Value: ticket.Id}))
+
+	_, err = repo.GetTickets(ctx)
+	assert.ErrorIs(t, err, entities.ErrorNothingFound)
+}
+
+func reposerTicketsPage(t *testing.T, repo usecases.Reposer) {
+	ctx := context.Background()
+
+	tickets := []entities.Ticket{}
+	for range 5 {
+		ticket := newTicket("2024-01-02T15:04:05Z")
+		require.NoError(t, repo.CreateTicket(ctx, ticket))
+		tickets = append(tickets, ticket)
+	}
+
+	want := ids(tickets, ticketId)
+	slices.Sort(want)
+
+	page, err := repo.GetTicketsPage(ctx, entities.Page{Limit: 2})
+	require.NoError(t, err)
+	assert.Equal(t, want[:2], ids(page, ticketId))
+
+	page, err = repo.GetTicketsPage(ctx, entities.Page{After: want[1], Limit: 2})
+	require.NoError(t, err)
+	assert.Equal(t, want[2:4], ids(page, ticketId))
+
+	page, err = repo.GetTicketsPage(ctx, entities.Page{After: want[3], Limit: 2})
+	require.NoError(t, err)
+	assert.Equal(t, want[4:], ids(page, ticketId))
+}
+
+func reposerPassengers(t *testing.T, repo usecases.Reposer) {
+	ctx := context.Background()
+
+	passenger := newPassenger("Reyes")
+	require.NoError(t, repo.CreatePassenger(ctx, passenger))
+	assert.ErrorIs(t, repo.CreatePassenger(ctx, passenger), entities.ErrorHasAlreadyExists)
+
+	passenger.LastName = "Mejia"
+	require.NoError(t, repo.ReplacePassenger(ctx, passenger))
+
+	missing := newPassenger("Reyes")
+	assert.ErrorIs(t, repo.ReplacePassenger(ctx, missing), entities.ErrorNothingToChange)
+	assert.ErrorIs(t, repo.DeletePassenger(ctx, entities.Id{Value: missing.Id}), entities.ErrorNothingToDelete)
+
+	passengers, err := repo.GetPassengers(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, []entities.Passenger{passenger}, passengers)
+
+	passengers, err = repo.GetPassengersByIds(ctx, []string{passenger.Id, missing.Id})
+	require.NoError(t, err)
+	assert.Equal(t, []entities.Passenger{passenger}, passengers)
+
+	err = repo.WithinTx(ctx, func(ctx context.Context) error {
+		locked, err := repo.LockPassenger(ctx, entities.Id{Value: passenger.Id})
+		require.NoError(t, err)
+		assert.Equal(t, passenger, locked)
+
+		_, err = repo.LockPassenger(ctx, entities.Id{Value: missing.Id})
+		assert.ErrorIs(t, err, entities.ErrorNothingFound)
+
+		return nil
+	})
+	require.NoError(t, err)
+
+	require.NoError(t, repo.DeletePassenger(ctx, entities.Id{Value: passenger.Id}))
+
+	passengers, err = repo.GetPassengersByIds(ctx, []string{passenger.Id})
+	require.NoError(t, err)
+	assert.Empty(t, passengers)
+}
+
+func reposerBindings(t *testing.T, repo usecases.Reposer) {
+	ctx := context.Background()
+
+	ticket := newTicket("2024-01-02T15:04:05Z")
+	other := newTicket("2024-01-02T15:04:05Z")
+	other.FlyAt = "3021-01-02T15:04:05Z"
+	first, second := newPassenger("Reyes"), newPassenger("Mejia")
+
+	for _, tk := range []entities.Ticket{ticket, other} {
+		require.NoError(t, repo.CreateTicket(ctx, tk))
+	}
+
+	for _, p := range []entities.Passenger{first, second} {
+		require.NoError(t, repo.CreatePassenger(ctx, p))
+	}
+
+	ref := entities.Id{Value: ticket.Id}
+
+	require.NoError(t, repo.BoundToTicket(ctx, entities.Id{Value: first.Id}, ref))
+	require.NoError(t, repo.BoundToTicket(ctx, entities.Id{Value: second.Id}, ref))
+	require.NoError(t, repo.BoundToTicket(ctx, entities.Id{Value: first.Id}, entities.Id{Value: other.Id}))
+
+	assert.ErrorIs(t, repo.BoundToTicket(ctx, entities.Id{Value: first.Id}, ref), entities.ErrorHasAlreadyExists)
+	assert.ErrorIs(t, repo.BoundToTicket(ctx, entities.Id{Value: uuid.NewString()}, ref), entities.ErrorPassengerDoesNotExists)
+	assert.ErrorIs(t, repo.BoundToTicket(ctx, entities.Id{Value: first.Id}, entities.Id{Value: uuid.NewString()}), entities.ErrorTicketDoesNotExists)
+
+	passengers, err := repo.GetPassengersByTicketId(ctx, ref)
+	require.NoError(t, err)
+	assert.ElementsMatch(t, []entities.Passenger{first, second}, passengers)
+
+	passengersByTicket, err := repo.GetPassengersByTicketIds(ctx, []string{ticket.Id, other.Id})
+	require.NoError(t, err)
+	assert.ElementsMatch(t, []entities.Passenger{first, second}, passengersByTicket[ticket.Id])
+	assert.Equal(t, []entities.Passenger{first}, passengersByTicket[other.Id])
+
+	// Tickets of a passenger are ordered by departure
+	ticketsByPassenger, err := repo.GetTicketsByPassengerIds(ctx, []string{first.Id, second.Id})
+	require.NoError(t, err)
+	assert.Equal(t, []string{other.Id, ticket.Id}, ids(ticketsByPassenger[first.Id], ticketId))
+	assert.Equal(t, []string{ticket.Id}, ids(ticketsByPassenger[second.Id], ticketId))
+
+	assert.ErrorIs(t, repo.DeleteTicket(ctx, ref), entities.ErrorsThereArePassengersOnTheFlight)
+
+	require.NoError(t, repo.UnboundToTicket(ctx, entities.Id{Value: second.Id}, ref))
+	assert.ErrorIs(t, repo.UnboundToTicket(ctx, entities.Id{Value: second.Id}, ref), entities.ErrorNothingToDelete)
+
+	// Deleting a passenger drops the bindings
+	require.NoError(t, repo.DeletePassenger(ctx, entities.Id{Value: first.Id}))
+	require.NoError(t, repo.DeleteTicket(ctx, ref))
+	require.NoError(t, repo.DeleteTicket(ctx, entities.Id{Value: other.Id}))
+}
+
+func reposerDocuments(t *testing.T, repo usecases.Reposer) {
+	ctx := context.Background()
+
+	passenger := newPassenger("Reyes")
+	require.NoError(t, repo.CreatePassenger(ctx, passenger))
+
+	_, err := repo.GetDocumentsByPassengerId(ctx, entities.Id{Value: passenger.Id})
+	assert.ErrorIs(t, err, entities.ErrorNothingFound)
+
+	passport := newDocument(passenger.Id, "Passport", "3333777111")
+	visa := newDocument(passenger.Id, "Visa", "1")
+
+	require.NoError(t, repo.CreateDocument(ctx, passport))
+	require.NoError(t, repo.CreateDocument(ctx, visa))
+
+	assert.ErrorIs(t, repo.CreateDocument(ctx, passport), entities.ErrorHasAlreadyExists)
+	assert.ErrorIs(t, repo.CreateDocument(ctx, newDocument(passenger.Id, "Passport", "3333777111")), entities.ErrorHasAlreadyExists)
+	assert.ErrorIs(t, repo.CreateDocument(ctx, newDocument(uuid.NewString(), "Passport", "2")), entities.ErrorPassengerDoesNotExists)
+
+	err = repo.CreateDocument(ctx, newDocument(passenger.Id, "Visa", "1"))
+	var entityErr *entities.Error
+	require.True(t, errors.As(err, &entityErr))
+	assert.Equal(t, map[string]any{"fields": []string{"type", "number"}}, entityErr.Details)
+
+	visa.Number = "2"
+	require.NoError(t, repo.ReplaceDocument(ctx, visa))
+	assert.ErrorIs(t, repo.ReplaceDocument(ctx, newDocument(passenger.Id, "Visa", "3")), entities.ErrorNothingToChange)
+
+	replaced := visa
+	replaced.Number = passport.Number
+	replaced.Type = passport.Type
+	assert.ErrorIs(t, repo.ReplaceDocument(ctx, replaced), entities.ErrorHasAlreadyExists)
+
+	documents, err := repo.GetDocumentsByPassengerId(ctx, entities.Id{Value: passenger.Id})
+	require.NoError(t, err)
+	assert.ElementsMatch(t, []string{passport.Id, visa.Id}, ids(documents, documentId))
+
+	documentsByPassenger, err := repo.GetDocumentsByPassengerIds(ctx, []string{passenger.Id, uuid.NewString()})
+	require.NoError(t, err)
+	assert.ElementsMatch(t, []entities.Document{passport, visa}, documentsByPassenger[passenger.Id])
+	assert.Len(t, documentsByPassenger, 1)
+
+	assert.ErrorIs(t, repo.DeletePassenger(ctx, entities.Id{Value: passenger.Id}), entities.ErrorPassengerHasDocuments)
+
+	require.NoError(t, repo.DeleteDocument(ctx, entities.Id{Value: passport.Id}))
+	assert.ErrorIs(t, repo.DeleteDocument(ctx, entities.Id{Value: passport.Id}), entities.ErrorNothingToDelete)
+	require.NoError(t, repo.DeleteDocument(ctx, entities.Id{Value: visa.Id}))
+	require.NoError(t, repo.DeletePassenger(ctx, entities.Id{Value: passenger.Id}))
+}
+
+func reposerWholeInfo(t *testing.T, repo usecases.Reposer) {
+	ctx := context.Background()
+
+	_, err := repo.GetWholeInfoAboutTicket(ctx, entities.Id{Value: uuid.NewString()})
+	assert.ErrorIs(t, err, entities.ErrorNothingFound)
+
+	ticket := newTicket("2024-01-02T15:04:05Z")
+	require.NoError(t, repo.CreateTicket(ctx, ticket))
+
+	info, err := repo.GetWholeInfoAboutTicket(ctx, entities.Id{Value: ticket.Id})
+	require.NoError(t, err)
+	assert.Empty(t, info.Passengers)
+
+	// Bound and added out of order, passengers come by last name and documents by type.
+	// Names compare by bytes, a collation of the database would put Ávila first
+	withDocuments, withoutDocuments, accented := newPassenger("Reyes"), newPassenger("Mejia"), newPassenger("Ávila")
+	visa := newDocument(withDocuments.Id, "Visa", "1")
+	passport := newDocument(withDocuments.Id, "Passport", "3333777111")
+
+	for _, p := range []entities.Passenger{withDocuments, accented, withoutDocuments} {
+		require.NoError(t, repo.CreatePassenger(ctx, p))
+		require.NoError(t, repo.BoundToTicket(ctx, entities.Id{Value: p.Id}, entities.Id{Value: ticket.Id}))
+	}
+
+	for _, d := range []entities.Document{visa, passport} {
+		require.NoError(t, repo.CreateDocument(ctx, d))
+	}
+
+	info, err = repo.GetWholeInfoAboutTicket(ctx, entities.Id{Value: ticket.Id})
+	require.NoError(t, err)
+
+	assert.Equal(t, utcTicket(t, ticket), utcTicket(t, info.Ticket))
+	assert.Equal(t, []entities.PassengerTicketWholeInfo{
+		{
+			Passenger: withoutDocuments,
+		},
+		{
+			Passenger: withDocuments,
+			Documents: []entities.DocumentTicketWholeInfo{
+				{Id: passport.Id, Type: passport.Type, Number: passport.Number},
+				{Id: visa.Id, Type: visa.Type, Number: visa.Number},
+			},
+		},
+		{
+			Passenger: accented,
+		},
+	}, info.Passengers)
+}
+
+func reposerReport(t *testing.T, repo usecases.Reposer) {
+	ctx := context.Background()
+
+	passenger := newPassenger("Reyes")
+	require.NoError(t, repo.CreatePassenger(ctx, passenger))
+
+	filter := entities.PeriodFilter{
+		From: "2024-02-01T00:00:00Z",
+		To:   "2024-03-01T00:00:00Z",
+	}
+
+	_, err := repo.GetRowsByPassengerIdForPeriod(ctx, entities.Id{Value: passenger.Id}, filter)
+	assert.ErrorIs(t, err, entities.ErrorNothingFound)
+
+	flown := newTicket("2024-01-10T00:00:00Z")
+	flown.FlyAt = "2024-01-20T00:00:00Z"
+	flown.ArriveAt = "2024-01-21T00:00:00Z"
+
+	pending := newTicket("2024-02-10T00:00:00Z")
+	pending.FlyAt = "2024-04-01T00:00:00Z"
+	pending.ArriveAt = "2024-04-02T00:00:00Z"
+
+	// Issued before the period to fly after it, the report has no row for it
+	early := newTicket("2024-01-10T00:00:00Z")
+	early.FlyAt = "2024-04-01T00:00:00Z"
+	early.ArriveAt = "2024-04-02T00:00:00Z"
+
+	for _, ticket := range []entities.Ticket{flown, pending, early} {
+		require.NoError(t, repo.CreateTicket(ctx, ticket))
+		require.NoError(t, repo.BoundToTicket(ctx, entities.Id{Value: passenger.Id}, entities.Id{Value: ticket.Id}))
+	}
+
+	rows, err := repo.GetRowsByPassengerIdForPeriod(ctx, entities.Id{Value: passenger.Id}, filter)
+	require.NoError(t, err)
+
+	for i := range rows {
+		rows[i].DateOfIssue = utc(t, rows[i].DateOfIssue)
+		rows[i].FlyAt = utc(t, rows[i].FlyAt)
+	}
+
+	assert.ElementsMatch(t, []entities.ReportRowByPassengerForPeriod{
+		{
+			DateOfIssue:     utc(t, flown.CreatedAt),
+			FlyAt:           utc(t, flown.FlyAt),
+			TicketId:        flown.Id,
+			FlyFrom:         flown.FlyFrom,
+			FlyTo:           flown.FlyTo,
+			ServiceProvided: true,
+		},
+		{
+			DateOfIssue:     utc(t, pending.CreatedAt),
+			FlyAt:           utc(t, pending.FlyAt),
+			TicketId:        pending.Id,
+			FlyFrom:         pending.FlyFrom,
+			FlyTo:           pending.FlyTo,
+			ServiceProvided: false,
+		},
+	}, rows)
+}
+
+func reposerImport(t *testing.T, repo usecases.Reposer) {
+	ctx := context.Background()
+
+	ticket := newTicket("2024-01-02T15:04:05Z")
+	passenger := newPassenger("Reyes")
+	document := newDocument(passenger.Id, "Passport", "3333777111")
+
+	require.NoError(t, repo.CopyTickets(ctx, []entities.Ticket{ticket}))
+	require.NoError(t, repo.CopyPassengers(ctx, []entities.Passenger{passenger}))
+	require.NoError(t, repo.CopyDocuments(ctx, []entities.Document{document}))
+	require.NoError(t, repo.CopyBindings(ctx, []entities.Binding{{PassengerId: passenger.Id, TicketId: ticket.Id}}))
+
+	// A failing row fails the whole batch
+	added := newTicket("2024-01-02T15:04:05Z")
+	assert.ErrorIs(t, repo.CopyTickets(ctx, []entities.Ticket{added, ticket}), entities.ErrorHasAlreadyExists)
+	assert.ErrorIs(t, repo.CopyPassengers(ctx, []entities.Passenger{newPassenger("Mejia"), passenger}), entities.ErrorHasAlreadyExists)
+	assert.ErrorIs(t, repo.CopyDocuments(ctx, []entities.Document{newDocument(passenger.Id, "Visa", "1"), newDocument(passenger.Id, "Passport", "3333777111")}), entities.ErrorHasAlreadyExists)
+	assert.ErrorIs(t, repo.CopyBindings(ctx, []entities.Binding{{PassengerId: passenger.Id, TicketId: uuid.NewString()}}), entities.ErrorTicketDoesNotExists)
+
+	tickets, err := repo.GetTickets(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, []entities.Ticket{utcTicket(t, ticket)}, utcTickets(t, tickets))
+
+	passengers, err := repo.GetPassengers(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, []entities.Passenger{passenger}, passengers)
+
+	documents, err := repo.GetDocumentsByPassengerId(ctx, entities.Id{Value: passenger.Id})
+	require.NoError(t, err)
+	assert.Equal(t, []string{document.Id}, ids(documents, documentId))
+
+	passengers, err = repo.GetPassengersByTicketId(ctx, entities.Id{Value: ticket.Id})
+	require.NoError(t, err)
+	assert.Equal(t, []entities.Passenger{passenger}, passengers)
+}
+
+func reposerTx(t *testing.T, repo usecases.Reposer) {
+	ctx := context.Background()
+
+	committed, rolledBack := newPassenger("Reyes"), newPassenger("Mejia")
+	errRollback := errors.New("rollback")
+
+	require.NoError(t, repo.WithinTx(ctx, func(ctx context.Context) error {
+		return repo.CreatePassenger(ctx, committed)
+	}))
+
+	err := repo.WithinTx(ctx, func(ctx context.Context) error {
+		if err := repo.CreatePassenger(ctx, rolledBack); err != nil {
+			return err
+		}
+
+		// Nested calls join the outer transaction
+		return repo.WithinTx(ctx, func(ctx context.Context) error {
+			replaced := committed
+			replaced.FirstName = "Lynn"
+
+			if err := repo.ReplacePassenger(ctx, replaced); err != nil {
+				return err
+			}
+
+			if err := repo.DeletePassenger(ctx, entities.Id{Value: committed.Id}); err != nil {
+				return err
+			}
+
+			return errRollback
+		})
+	})
+	assert.ErrorIs(t, err, errRollback)
+
+	passengers, err := repo.GetPassengers(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, []entities.Passenger{committed}, passengers)
+}
+
+func newEvent(aggregateId string) entities.Event {
+	return entities.Event{
+		Id:            uuid.NewString(),
+		Type:          entities.EventTicketCreated,
+		AggregateType: entities.AggregateTicket,
+		AggregateId:   aggregateId,
+		Payload:       json.RawMessage(`{"id": "` + aggregateId + `"}`),
+		OccurredAt:    "2024-01-02T15:04:05Z",
+	}
+}
+
+func reposerOutbox(t *testing.T, repo usecases.Reposer) {
+	ctx := context.Background()
+
+	aggregateId := uuid.NewString()
+	first, second, third := newEvent(aggregateId), newEvent(aggregateId), newEvent(uuid.NewString())
+
+	require.NoError(t, repo.AddEvents(ctx, first, second))
+	require.NoError(t, repo.AddEvents(ctx, third))
+	assert.ErrorIs(t, repo.AddEvents(ctx, first), entities.ErrorHasAlreadyExists)
+
+	eventIds := func(events []entities.Event) []string {
+		return ids(events, func(e entities.Event) string { return e.Id })
+	}
+
+	// The first events of every aggregate come first
+	events, err := repo.ClaimUnpublishedEvents(ctx, 2, time.Hour)
+	require.NoError(t, err)
+	require.Equal(t, []string{first.Id, third.Id}, eventIds(events))
+	assert.Less(t, events[0].Seq, events[1].Seq)
+	assert.Equal(t, first.AggregateId, events[0].AggregateId)
+	assert.JSONEq(t, string(first.Payload), string(events[0].Payload))
+	assert.Equal(t, first.OccurredAt, events[0].OccurredAt)
+
+	firstSeq, thirdSeq := events[0].Seq, events[1].Seq
+
+	// Aggregates with claimed events are held until they are published or the lease is over
+	events, err = repo.ClaimUnpublishedEvents(ctx, 10, time.Hour)
+	require.NoError(t, err)
+	assert.Empty(t, events)
+
+	require.NoError(t, repo.MarkEventsPublished(ctx, []int64{firstSeq}))
+
+	events, err = repo.ClaimUnpublishedEvents(ctx, 10, time.Hour)
+	require.NoError(t, err)
+	require.Equal(t, []string{second.Id}, eventIds(events))
+
+	require.NoError(t, repo.MarkEventsPublished(ctx, []int64{events[0].Seq, thirdSeq}))
+
+	// An expired lease makes the events claimable again
+	fourth := newEvent(uuid.NewString())
+	require.NoError(t, repo.AddEvents(ctx, fourth))
+
+	events, err = repo.ClaimUnpublishedEvents(ctx, 10, 0)
+	require.NoError(t, err)
+	require.Equal(t, []string{fourth.Id}, eventIds(events))
+
+	time.Sleep(10 * time.Millisecond)
+
+	events, err = repo.ClaimUnpublishedEvents(ctx, 10, time.Hour)
+	require.NoError(t, err)
+	require.Equal(t, []string{fourth.Id}, eventIds(events))
+
+	require.NoError(t, repo.MarkEventsPublished(ctx, []int64{events[0].Seq}))
+
+	replayed, err := repo.ReplayEvents(ctx, 0, aggregateId)
+	require.NoError(t, err)
+	assert.Equal(t, int64(2), replayed)
+
+	events, err = repo.ClaimUnpublishedEvents(ctx, 10, time.Hour)
+	require.NoError(t, err)
+	assert.Equal(t, []string{first.Id, second.Id}, eventIds(events))
+
+	replayed, err = repo.ReplayEvents(ctx, 0, "")
+	require.NoError(t, err)
+	assert.Equal(t, int64(2), replayed)
+}
+
+// reposerOutboxConcurrentWriters checks a writer of an aggregate waits for the transaction of another one,
+// an event committed before an event with a lower seq would be relayed first.
+func reposerOutboxConcurrentWriters(t *testing.T, repo usecases.Reposer) {
+	ctx := context.Background()
+
+	aggregateId := uuid.NewString()
+	first, second := newEvent(aggregateId), newEvent(aggregateId)
+
+	added, release := make(chan struct{}), make(chan struct{})
+	firstDone, secondDone := make(chan error, 1), make(chan error, 1)
+
+	go func() {
+		firstDone <- repo.WithinTx(ctx, func(ctx context.Context) error {
+			if err := repo.AddEvents(ctx, first); err != nil {
+				return err
+			}
+
+			close(added)
+			<-release
+
+			return nil
+		})
+	}()
+
+	<-added
+
+	go func() {
+		secondDone <- repo.AddEvents(ctx, second)
+	}()
+
+	select {
+	case err := <-secondDone:
+		t.Fatalf("the second writer committed while the first one is in progress: %v", err)
+	case <-time.After(100 * time.Millisecond):
+	}
+
+	close(release)
+	require.NoError(t, <-firstDone)
+	require.NoError(t, <-secondDone)
+
+	events, err := repo.ClaimUnpublishedEvents(ctx, 10, time.Hour)
+	require.NoError(t, err)
+	require.Equal(t, []string{first.Id, second.Id}, ids(events, func(e entities.Event) string { return e.Id }))
+	assert.Less(t, events[0].Seq, events[1].Seq)
+}
+
+func reposerWebhooks(t *testing.T, repo usecases.Reposer) {
+	ctx := context.Background()
+
+	_, err := repo.GetWebhookSubscriptions(ctx)
+	assert.ErrorIs(t, err, entities.ErrorNothingFound)
+
+	subscription := entities.WebhookSubscription{
+		Id:         uuid.NewString(),
+		Url:        "https://partner.example.com/hooks/flights",
+		Secret:     "secret",
+		EventTypes: []string{entities.EventTicketCreated},
+		Active:     true,
+		CreatedAt:  "2024-01-02T15:04:05Z",
+	}
+	require.NoError(t, repo.CreateWebhookSubscription(ctx, subscription))
+	assert.ErrorIs(t, repo.CreateWebhookSubscription(ctx, subscription), entities.ErrorHasAlreadyExists)
+
+	// No secret keeps the old one
+	replaced := subscription
+	replaced.Secret = ""
+	replaced.Active = false
+	require.NoError(t, repo.ReplaceWebhookSubscription(ctx, replaced))
+
+	got, err := repo.GetWebhookSubscription(ctx, entities.Id{Value: subscription.Id})
+	require.NoError(t, err)
+	assert.Equal(t, subscription.Secret, got.Secret)
+	assert.False(t, got.Active)
+	assert.Equal(t, subscription.EventTypes, got.EventTypes)
+	assert.Equal(t, utc(t, subscription.CreatedAt), utc(t, got.CreatedAt))
+
+	active, err := repo.GetActiveWebhookSubscriptions(ctx)
+	require.NoError(t, err)
+	assert.Empty(t, active)
+
+	_, err = repo.GetWebhookSubscription(ctx, entities.Id{Value: uuid.NewString()})
+	assert.ErrorIs(t, err, entities.ErrorNothingFound)
+
+	due := entities.WebhookDelivery{
+		Id:             uuid.NewString(),
+		SubscriptionId: subscription.Id,
+		EventId:        uuid.NewString(),
+		EventType:      entities.EventTicketCreated,
+		Payload:        json.RawMessage(`{"id": 1}`),
+		Status:         entities.WebhookDeliveryPending,
+		NextAttemptAt:  time.Now().Add(-time.Minute).Format(time.RFC3339),
+		CreatedAt:      "2024-01-02T15:04:05Z",
+	}
+	later := due
+	later.Id = uuid.NewString()
+	later.EventId = uuid.NewString()
+	later.NextAttemptAt = time.Now().Add(time.Hour).Format(time.RFC3339)
+	later.CreatedAt = "2024-01-03T15:04:05Z"
+
+	require.NoError(t, repo.AddWebhookDeliveries(ctx, due, later))
+
+	// The same event is enqueued once
+	duplicate := due
+	duplicate.Id = uuid.NewString()
+	require.NoError(t, repo.AddWebhookDeliveries(ctx, duplicate))
+
+	orphan := due
+	orphan.Id = uuid.NewString()
+	orphan.SubscriptionId = uuid.NewString()
+	assert.ErrorIs(t, repo.AddWebhookDeliveries(ctx, orphan), entities.ErrorWebhookSubscriptionDoesNotExist)
+
+	deliveries, err := repo.GetWebhookDeliveries(ctx, entities.Id{Value: subscription.Id})
+	require.NoError(t, err)
+	assert.Equal(t, []string{later.Id, due.Id}, ids(deliveries, deliveryId))
+
+	// Deliveries of inactive subscriptions are held back
+	claimed, err := repo.ClaimDueWebhookDeliveries(ctx, 10, time.Minute)
+	require.NoError(t, err)
+	assert.Empty(t, claimed)
+
+	require.NoError(t, repo.ReplaceWebhookSubscription(ctx, subscription))
+
+	claimed, err = repo.ClaimDueWebhookDeliveries(ctx, 10, time.Minute)
+	require.NoError(t, err)
+	require.Equal(t, []string{due.Id}, ids(claimed, deliveryId))
+	assert.JSONEq(t, string(due.Payload), string(claimed[0].Payload))
+
+	// Claimed deliveries wait for the lease to run out
+	claimed, err = repo.ClaimDueWebhookDeliveries(ctx, 10, time.Minute)
+	require.NoError(t, err)
+	assert.Empty(t, claimed)
+
+	delivered := due
+	delivered.Status = entities.WebhookDeliveryDelivered
+	delivered.Attempts = 1
+	delivered.NextAttemptAt = ""
+	delivered.LastStatusCode = 200
+	delivered.DeliveredAt = "2024-01-02T15:05:05Z"
+	require.NoError(t, repo.UpdateWebhookDelivery(ctx, delivered))
+
+	orphan.SubscriptionId = subscription.Id
+	assert.ErrorIs(t, repo.UpdateWebhookDelivery(ctx, orphan), entities.ErrorNothingToChange)
+
+	deliveries, err = repo.GetWebhookDeliveries(ctx, entities.Id{Value: subscription.Id})
+	require.NoError(t, err)
+	require.Len(t, deliveries, 2)
+	assert.Equal(t, entities.WebhookDeliveryDelivered, deliveries[1].Status)
+	assert.Equal(t, 200, deliveries[1].LastStatusCode)
+	assert.Empty(t, deliveries[1].NextAttemptAt)
+	assert.Equal(t, utc(t, delivered.DeliveredAt), utc(t, deliveries[1].DeliveredAt))
+
+	require.NoError(t, repo.RedeliverWebhookDelivery(ctx, entities.Id{Value: subscription.Id}, entities.Id{Value: due.Id}))
+	assert.ErrorIs(t, repo.RedeliverWebhookDelivery(ctx, entities.Id{Value: uuid.NewString()}, entities.Id{Value: due.Id}), entities.ErrorNothingToChange)
+
+	claimed, err = repo.ClaimDueWebhookDeliveries(ctx, 10, time.Minute)
+	require.NoError(t, err)
+	require.Equal(t, []string{due.Id}, ids(claimed, deliveryId))
+	assert.Equal(t, entities.WebhookDeliveryPending, claimed[0].Status)
+	assert.Zero(t, claimed[0].Attempts)
+	assert.Empty(t, claimed[0].DeliveredAt)
+
+	// Deleting a subscription drops its deliveries
+	require.NoError(t, repo.DeleteWebhookSubscription(ctx, entities.Id{Value: subscription.Id}))
+	assert.ErrorIs(t, repo.DeleteWebhookSubscription(ctx, entities.Id{Value: subscription.Id}), entities.ErrorNothingToDelete)
+
+	_, err = repo.GetWebhookDeliveries(ctx, entities.Id{Value: subscription.Id})
+	assert.ErrorIs(t, err, entities.ErrorNothingFound)
+}
+
+func deliveryId(delivery entities.WebhookDelivery) string {
+	return delivery.Id
+}
+
+func reposerIdempotency(t *testing.T, repo usecases.Reposer) {
+	ctx := context.Background()
+
+	_, err := repo.GetIdempotentResponse(ctx, "key")
+	assert.ErrorIs(t, err, entities.ErrorNothingFound)
+
+	reserved := entities.IdempotentResponse{
+		Key:         "key",
+		RequestHash: "hash",
+		Body:        []byte{},
+		CreatedAt:   time.Now().Format(time.RFC3339),
+	}
+	require.NoError(t, repo.CreateIdempotentResponse(ctx, reserved))
+	assert.ErrorIs(t, repo.CreateIdempotentResponse(ctx, reserved), entities.ErrorHasAlreadyExists)
+
+	finished := reserved
+	finished.Status = 201
+	finished.Location = "/v1/tickets/1"
+	finished.Body = []byte(`{"id":"1"}`)
+	require.NoError(t, repo.ReplaceIdempotentResponse(ctx, finished))
+	assert.ErrorIs(t, repo.ReplaceIdempotentResponse(ctx, entities.IdempotentResponse{Key: "missing", Body: []byte{}}), entities.ErrorNothingToChange)
+
+	got, err := repo.GetIdempotentResponse(ctx, "key")
+	require.NoError(t, err)
+	assert.Equal(t, finished.RequestHash, got.RequestHash)
+	assert.Equal(t, finished.Status, got.Status)
+	assert.Equal(t, finished.Location, got.Location)
+	assert.Equal(t, finished.Body, got.Body)
+	assert.Equal(t, utc(t, finished.CreatedAt), utc(t, got.CreatedAt))
+
+	// A finished or recent request isn't taken over, an abandoned one is by a single caller
+	startedBefore := time.Now().Add(-time.Minute).Format(time.RFC3339)
+	takeOver := entities.IdempotentResponse{Key: "key", CreatedAt: time.Now().Format(time.RFC3339)}
+	assert.ErrorIs(t, repo.TakeOverIdempotentResponse(ctx, takeOver, startedBefore), entities.ErrorNothingToChange)
+
+	abandoned := reserved
+	abandoned.Key = "abandoned"
+	abandoned.CreatedAt = time.Now().Add(-2 * time.Minute).Format(time.RFC3339)
+	require.NoError(t, repo.CreateIdempotentResponse(ctx, abandoned))
+
+	takeOver.Key = "abandoned"
+	require.NoError(t, repo.TakeOverIdempotentResponse(ctx, takeOver, startedBefore))
+	assert.ErrorIs(t, repo.TakeOverIdempotentResponse(ctx, takeOver, startedBefore), entities.ErrorNothingToChange)
+
+	got, err = repo.GetIdempotentResponse(ctx, "abandoned")
+	require.NoError(t, err)
+	assert.Zero(t, got.Status)
+	assert.Equal(t, utc(t, takeOver.CreatedAt), utc(t, got.CreatedAt))
+
+	stale := reserved
+	stale.Key = "stale"
+	stale.CreatedAt = time.Now().Add(-48 * time.Hour).Format(time.RFC3339)
+	require.NoError(t, repo.CreateIdempotentResponse(ctx, stale))
+
+	require.NoError(t, repo.DeleteIdempotentResponses(ctx, time.Now().Add(-24*time.Hour).Format(time.RFC3339)))
+
+	_, err = repo.GetIdempotentResponse(ctx, "stale")
+	assert.ErrorIs(t, err, entities.ErrorNothingFound)
+
+	require.NoError(t, repo.DeleteIdempotentResponse(ctx, "key"))
+	require.NoError(t, repo.DeleteIdempotentResponse(ctx, "key"))
+
+	_, err = repo.GetIdempotentResponse(ctx, "key")
+	assert.ErrorIs(t, err, entities.ErrorNothingFound)
+}
+
+func reposerHealth(t *testing.T, repo usecases.Reposer) {
+	ctx := context.Background()
+
+	require.NoError(t, repo.Ping(ctx))
+
+	latest, err := migrator.Latest(db.Migrations)
+	require.NoError(t, err)
+
+	version, dirty, err := repo.GetMigrationVersion(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, latest, version)
+	assert.False(t, dirty)
+}
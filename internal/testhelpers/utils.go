@@ -4,44 +4,68 @@ import (
 	"context"
 	"log"
 
-	"github.com/v1adhope/flights/pkg/postgresql"
+	"github.com/v1adhope/flights/internal/usecases"
 )
 
+// Utils finds rows by their offset in the order the repository keeps them, both storages list rows in insertion order.
 type Utils struct {
-	*postgresql.Driver
+	repo usecases.Reposer
 }
 
-func NewUtils(d *postgresql.Driver) *Utils {
-	return &Utils{d}
+func NewUtils(repo usecases.Reposer) *Utils {
+	return &Utils{repo}
 }
 
-func (u *Utils) getByOffset(ctx context.Context, offset uint64, table string, cols ...string) string {
-	sql, args, err := u.Builder.Select(cols...).
-		From(table).
-		Offset(offset).
-		Limit(1).
-		ToSql()
+func (u *Utils) GetTicketByOffset(ctx context.Context, offset uint64) string {
+	tickets, err := u.repo.GetTickets(ctx)
 	if err != nil {
-		log.Printf("testhelpers: utils: getByOffset: Select: %s: %v", table, err)
+		log.Printf("testhelpers: utils: GetTicketByOffset: GetTickets: %v", err)
 	}
 
-	id := ""
-
-	if err := u.Pool.QueryRow(ctx, sql, args...).Scan(&id); err != nil {
-		log.Printf("testhelpers: utils: getByOffset: QueryRow: %s: %v", table, err)
+	if offset >= uint64(len(tickets)) {
+		return ""
 	}
 
-	return id
-}
-
-func (u *Utils) GetTicketByOffset(ctx context.Context, offset uint64) string {
-	return u.getByOffset(ctx, offset, "tickets", "ticket_id")
+	return tickets[offset].Id
 }
 
 func (u *Utils) GetPassengerByOffset(ctx context.Context, offset uint64) string {
-	return u.getByOffset(ctx, offset, "passengers", "passenger_id")
+	passengers, err := u.repo.GetPassengers(ctx)
+	if err != nil {
+		log.Printf("testhelpers: utils: GetPassengerByOffset: GetPassengers: %v", err)
+	}
+
+	if offset >= uint64(len(passengers)) {
+		return ""
+	}
+
+	return passengers[offset].Id
 }
 
+// GetDocumentByOffset counts documents passenger by passenger.
 func (u *Utils) GetDocumentByOffset(ctx context.Context, offset uint64) string {
-	return u.getByOffset(ctx, offset, "documents", "document_id")
+	passengers, err := u.repo.GetPassengers(ctx)
+	if err != nil {
+		log.Printf("testhelpers: utils: GetDocumentByOffset: GetPassengers: %v", err)
+	}
+
+	ids := make([]string, 0, len(passengers))
+	for _, passenger := range passengers {
+		ids = append(ids, passenger.Id)
+	}
+
+	documents, err := u.repo.GetDocumentsByPassengerIds(ctx, ids)
+	if err != nil {
+		log.Printf("testhelpers: utils: GetDocumentByOffset: GetDocumentsByPassengerIds: %v", err)
+	}
+
+	for _, id := range ids {
+		if offset < uint64(len(documents[id])) {
+			return documents[id][offset].Id
+		}
+
+		offset -= uint64(len(documents[id]))
+	}
+
+	return ""
 }
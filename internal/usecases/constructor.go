@@ -1,11 +1,34 @@
 package usecases
 
+import "context"
+
 type Usecases struct {
-	repos Reposer
+	repos     Reposer
+	observers []Observer
+}
+
+type Option func(*Usecases)
+
+// WithObserver reports every usecase call and business counter to o.
+func WithObserver(o Observer) Option {
+	return func(u *Usecases) {
+		u.observers = append(u.observers, o)
+	}
 }
 
-func New(r Reposer) *Usecases {
-	return &Usecases{
+func New(r Reposer, opts ...Option) *Usecases {
+	u := &Usecases{
 		repos: r,
 	}
+
+	for _, opt := range opts {
+		opt(u)
+	}
+
+	return u
+}
+
+// WithinTx makes every repository call inside fn share one transaction.
+func (u *Usecases) WithinTx(ctx context.Context, fn func(ctx context.Context) error) error {
+	return u.repos.WithinTx(ctx, fn)
 }
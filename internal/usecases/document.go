@@ -8,7 +8,10 @@ import (
 	"github.com/v1adhope/flights/internal/entities"
 )
 
-func (u *Usecases) CreateDocument(ctx context.Context, document entities.Document) (string, error) {
+func (u *Usecases) CreateDocument(ctx context.Context, document entities.Document) (_ string, err error) {
+	ctx, done := u.observe(ctx, "CreateDocument")
+	defer done(&err)
+
 	id, err := uuid.NewV6()
 	if err != nil {
 		return "", fmt.Errorf("usecases: document: CreateDocument: NewV6: %w", err)
@@ -16,30 +19,60 @@ func (u *Usecases) CreateDocument(ctx context.Context, document entities.Documen
 
 	document.Id = id.String()
 
-	if err := u.repos.CreateDocument(ctx, document); err != nil {
+	err = u.changeWithEvent(
+		ctx,
+		func(ctx context.Context) error {
+			return u.repos.CreateDocument(ctx, document)
+		},
+		entities.EventDocumentAdded, entities.AggregateDocument, document.Id, document,
+	)
+	if err != nil {
 		return "", err
 	}
 
 	return document.Id, nil
 }
 
-func (u *Usecases) ReplaceDocument(ctx context.Context, document entities.Document) error {
-	if err := u.repos.ReplaceDocument(ctx, document); err != nil {
+func (u *Usecases) ReplaceDocument(ctx context.Context, document entities.Document) (err error) {
+	ctx, done := u.observe(ctx, "ReplaceDocument")
+	defer done(&err)
+
+	err = u.changeWithEvent(
+		ctx,
+		func(ctx context.Context) error {
+			return u.repos.ReplaceDocument(ctx, document)
+		},
+		entities.EventDocumentReplaced, entities.AggregateDocument, document.Id, document,
+	)
+	if err != nil {
 		return err
 	}
 
 	return nil
 }
 
-func (u *Usecases) DeleteDocument(ctx context.Context, id entities.Id) error {
-	if err := u.repos.DeleteDocument(ctx, id); err != nil {
+func (u *Usecases) DeleteDocument(ctx context.Context, id entities.Id) (err error) {
+	ctx, done := u.observe(ctx, "DeleteDocument")
+	defer done(&err)
+
+	err = u.changeWithEvent(
+		ctx,
+		func(ctx context.Context) error {
+			return u.repos.DeleteDocument(ctx, id)
+		},
+		entities.EventDocumentDeleted, entities.AggregateDocument, id.Value, id,
+	)
+	if err != nil {
 		return err
 	}
 
 	return nil
 }
 
-func (u *Usecases) GetDocumentsByPassengerId(ctx context.Context, id entities.Id) ([]entities.Document, error) {
+func (u *Usecases) GetDocumentsByPassengerId(ctx context.Context, id entities.Id) (_ []entities.Document, err error) {
+	ctx, done := u.observe(ctx, "GetDocumentsByPassengerId")
+	defer done(&err)
+
 	documents, err := u.repos.GetDocumentsByPassengerId(ctx, id)
 	if err != nil {
 		return []entities.Document{}, err
@@ -47,3 +80,15 @@ func (u *Usecases) GetDocumentsByPassengerId(ctx context.Context, id entities.Id
 
 	return documents, nil
 }
+
+func (u *Usecases) GetDocumentsByPassengerIds(ctx context.Context, ids []string) (_ map[string][]entities.Document, err error) {
+	ctx, done := u.observe(ctx, "GetDocumentsByPassengerIds")
+	defer done(&err)
+
+	documents, err := u.repos.GetDocumentsByPassengerIds(ctx, ids)
+	if err != nil {
+		return map[string][]entities.Document{}, err
+	}
+
+	return documents, nil
+}
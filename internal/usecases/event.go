@@ -0,0 +1,64 @@
+package usecases
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/v1adhope/flights/internal/entities"
+)
+
+func newEvent(eventType, aggregateType, aggregateId string, payload any) (entities.Event, error) {
+	id, err := uuid.NewV6()
+	if err != nil {
+		return entities.Event{}, fmt.Errorf("usecases: event: newEvent: NewV6: %w", err)
+	}
+
+	data, err := json.Marshal(payload)
+	if err != nil {
+		return entities.Event{}, fmt.Errorf("usecases: event: newEvent: Marshal: %w", err)
+	}
+
+	return entities.Event{
+		Id:            id.String(),
+		Type:          eventType,
+		AggregateType: aggregateType,
+		AggregateId:   aggregateId,
+		Payload:       data,
+		OccurredAt:    time.Now().UTC().Format(time.RFC3339Nano),
+	}, nil
+}
+
+// changeWithEvent stores the change, its domain event and webhook deliveries in one transaction.
+func (u *Usecases) changeWithEvent(ctx context.Context, change func(ctx context.Context) error, eventType, aggregateType, aggregateId string, payload any) error {
+	return u.repos.WithinTx(ctx, func(ctx context.Context) error {
+		if err := change(ctx); err != nil {
+			return err
+		}
+
+		event, err := newEvent(eventType, aggregateType, aggregateId, payload)
+		if err != nil {
+			return err
+		}
+
+		if err := u.repos.AddEvents(ctx, event); err != nil {
+			return err
+		}
+
+		return enqueueWebhooks(ctx, u.repos, event)
+	})
+}
+
+func (u *Usecases) ReplayEvents(ctx context.Context, fromSeq int64, aggregateId string) (_ int64, err error) {
+	ctx, done := u.observe(ctx, "ReplayEvents")
+	defer done(&err)
+
+	n, err := u.repos.ReplayEvents(ctx, fromSeq, aggregateId)
+	if err != nil {
+		return 0, err
+	}
+
+	return n, nil
+}
@@ -0,0 +1,98 @@
+package usecases
+
+import (
+	"context"
+	"fmt"
+	"sync/atomic"
+	"time"
+
+	"github.com/v1adhope/flights/internal/entities"
+)
+
+// HealthChecker checks the components the service needs to serve requests.
+type HealthChecker struct {
+	repos            Reposer
+	migrationVersion uint
+	timeout          time.Duration
+	draining         atomic.Bool
+}
+
+// NewHealthChecker builds a checker expecting the database at migrationVersion, every check is limited by timeout.
+func NewHealthChecker(r Reposer, migrationVersion uint, timeout time.Duration) *HealthChecker {
+	return &HealthChecker{
+		repos:            r,
+		migrationVersion: migrationVersion,
+		timeout:          timeout,
+	}
+}
+
+// Drain marks the service as shutting down, it is not ready from now on.
+func (h *HealthChecker) Drain() {
+	h.draining.Store(true)
+}
+
+// CheckHealth runs every check, the service is up only when all of them pass.
+func (h *HealthChecker) CheckHealth(ctx context.Context) entities.Health {
+	health := entities.Health{
+		Status: entities.HealthStatusUp,
+		Components: []entities.HealthComponent{
+			h.check(ctx, "postgres", h.repos.Ping),
+			h.check(ctx, "migrations", h.checkMigrations),
+			h.check(ctx, "shutdown", h.checkDraining),
+		},
+	}
+
+	for _, component := range health.Components {
+		if component.Status != entities.HealthStatusUp {
+			health.Status = entities.HealthStatusDown
+		}
+	}
+
+	return health
+}
+
+func (h *HealthChecker) check(ctx context.Context, name string, fn func(ctx context.Context) error) entities.HealthComponent {
+	ctx, cancel := context.WithTimeout(ctx, h.timeout)
+	defer cancel()
+
+	start := time.Now()
+	err := fn(ctx)
+
+	component := entities.HealthComponent{
+		Name:    name,
+		Status:  entities.HealthStatusUp,
+		Latency: float64(time.Since(start).Microseconds()) / 1000,
+	}
+
+	if err != nil {
+		component.Status = entities.HealthStatusDown
+		component.Error = err.Error()
+	}
+
+	return component
+}
+
+func (h *HealthChecker) checkMigrations(ctx context.Context) error {
+	version, dirty, err := h.repos.GetMigrationVersion(ctx)
+	if err != nil {
+		return err
+	}
+
+	if dirty {
+		return fmt.Errorf("database is dirty at version %d", version)
+	}
+
+	if version != h.migrationVersion {
+		return fmt.Errorf("database is at version %d, expected %d", version, h.migrationVersion)
+	}
+
+	return nil
+}
+
+func (h *HealthChecker) checkDraining(_ context.Context) error {
+	if h.draining.Load() {
+		return fmt.Errorf("service is shutting down")
+	}
+
+	return nil
+}
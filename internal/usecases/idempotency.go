@@ -0,0 +1,104 @@
+package usecases
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/v1adhope/flights/internal/entities"
+)
+
+const (
+	// IdempotencyKeyTTL is how long a response is replayed to retries of its request.
+	IdempotencyKeyTTL = 24 * time.Hour
+	// IdempotentRequestLease is how long a request stays in progress, a retry takes over the key of a request
+	// abandoned by a crashed process after it.
+	IdempotentRequestLease = time.Minute
+)
+
+// StartIdempotentRequest reserves the key for the request with the hash.
+// A stored response is returned for replay, a zero Status means the request has to be handled
+// and then passed to FinishIdempotentRequest.
+func (u *Usecases) StartIdempotentRequest(ctx context.Context, key, requestHash string) (_ entities.IdempotentResponse, err error) {
+	ctx, done := u.observe(ctx, "StartIdempotentRequest")
+	defer done(&err)
+
+	now := time.Now().UTC()
+
+	resp, err := u.repos.GetIdempotentResponse(ctx, key)
+	switch {
+	case errors.Is(err, entities.ErrorNothingFound):
+	case err != nil:
+		return entities.IdempotentResponse{}, err
+	default:
+		createdAt, err := time.Parse(time.RFC3339, resp.CreatedAt)
+		if err != nil {
+			return entities.IdempotentResponse{}, fmt.Errorf("usecases: idempotency: StartIdempotentRequest: Parse: %w", err)
+		}
+
+		if now.Sub(createdAt) <= IdempotencyKeyTTL {
+			if resp.RequestHash != requestHash {
+				return entities.IdempotentResponse{}, fmt.Errorf("usecases: idempotency: StartIdempotentRequest: %w", entities.ErrorIdempotencyKeyReused)
+			}
+
+			if resp.Status == 0 {
+				return u.takeOverIdempotentRequest(ctx, key, now, createdAt)
+			}
+
+			return resp, nil
+		}
+	}
+
+	// Expired responses are dropped here, so the table stays small without a separate job.
+	if err := u.repos.DeleteIdempotentResponses(ctx, now.Add(-IdempotencyKeyTTL).Format(time.RFC3339)); err != nil {
+		return entities.IdempotentResponse{}, err
+	}
+
+	err = u.repos.CreateIdempotentResponse(ctx, entities.IdempotentResponse{
+		Key:         key,
+		RequestHash: requestHash,
+		Body:        []byte{},
+		CreatedAt:   now.Format(time.RFC3339),
+	})
+	if errors.Is(err, entities.ErrorHasAlreadyExists) {
+		return entities.IdempotentResponse{}, fmt.Errorf("usecases: idempotency: StartIdempotentRequest: %w", entities.ErrorIdempotentRequestInProgress)
+	}
+	if err != nil {
+		return entities.IdempotentResponse{}, err
+	}
+
+	return entities.IdempotentResponse{}, nil
+}
+
+// takeOverIdempotentRequest restarts a request in progress for longer than its lease.
+func (u *Usecases) takeOverIdempotentRequest(ctx context.Context, key string, now, startedAt time.Time) (entities.IdempotentResponse, error) {
+	if now.Sub(startedAt) <= IdempotentRequestLease {
+		return entities.IdempotentResponse{}, fmt.Errorf("usecases: idempotency: takeOverIdempotentRequest: %w", entities.ErrorIdempotentRequestInProgress)
+	}
+
+	err := u.repos.TakeOverIdempotentResponse(ctx, entities.IdempotentResponse{
+		Key:       key,
+		CreatedAt: now.Format(time.RFC3339),
+	}, now.Add(-IdempotentRequestLease).Format(time.RFC3339))
+	if errors.Is(err, entities.ErrorNothingToChange) {
+		return entities.IdempotentResponse{}, fmt.Errorf("usecases: idempotency: takeOverIdempotentRequest: %w", entities.ErrorIdempotentRequestInProgress)
+	}
+	if err != nil {
+		return entities.IdempotentResponse{}, err
+	}
+
+	return entities.IdempotentResponse{}, nil
+}
+
+// FinishIdempotentRequest stores the response for replay, server errors release the key so the request can be retried.
+func (u *Usecases) FinishIdempotentRequest(ctx context.Context, resp entities.IdempotentResponse) (err error) {
+	ctx, done := u.observe(ctx, "FinishIdempotentRequest")
+	defer done(&err)
+
+	if resp.Status >= 500 {
+		return u.repos.DeleteIdempotentResponse(ctx, resp.Key)
+	}
+
+	return u.repos.ReplaceIdempotentResponse(ctx, resp)
+}
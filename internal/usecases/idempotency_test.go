@@ -0,0 +1,49 @@
+package usecases_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/v1adhope/flights/internal/entities"
+	"github.com/v1adhope/flights/internal/usecases"
+	"github.com/v1adhope/flights/internal/usecases/infrastructure/memory"
+)
+
+func TestIdempotentRequestLease(t *testing.T) {
+	ctx := context.Background()
+	repo := memory.New()
+	uc := usecases.New(repo)
+
+	_, err := uc.StartIdempotentRequest(ctx, "recent", "hash")
+	require.NoError(t, err)
+
+	_, err = uc.StartIdempotentRequest(ctx, "recent", "hash")
+	assert.ErrorIs(t, err, entities.ErrorIdempotentRequestInProgress)
+
+	// Reserved by a process which crashed before finishing the request
+	require.NoError(t, repo.CreateIdempotentResponse(ctx, entities.IdempotentResponse{
+		Key:         "abandoned",
+		RequestHash: "hash",
+		Body:        []byte{},
+		CreatedAt:   time.Now().Add(-usecases.IdempotentRequestLease - time.Minute).Format(time.RFC3339),
+	}))
+
+	_, err = uc.StartIdempotentRequest(ctx, "abandoned", "other")
+	assert.ErrorIs(t, err, entities.ErrorIdempotencyKeyReused)
+
+	resp, err := uc.StartIdempotentRequest(ctx, "abandoned", "hash")
+	require.NoError(t, err)
+	assert.Zero(t, resp.Status, "the retry handles the request")
+
+	_, err = uc.StartIdempotentRequest(ctx, "abandoned", "hash")
+	assert.ErrorIs(t, err, entities.ErrorIdempotentRequestInProgress, "the lease starts over")
+
+	require.NoError(t, uc.FinishIdempotentRequest(ctx, entities.IdempotentResponse{Key: "abandoned", Status: 201, Body: []byte(`{}`)}))
+
+	resp, err = uc.StartIdempotentRequest(ctx, "abandoned", "hash")
+	require.NoError(t, err)
+	assert.Equal(t, 201, resp.Status)
+}
@@ -0,0 +1,425 @@
+package usecases
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"slices"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/v1adhope/flights/internal/entities"
+)
+
+// _importChunk is the number of rows a best-effort import copies at once.
+const _importChunk = 500
+
+// _importMaxConflicts bounds the rows located after an all-or-nothing import has failed,
+// each one costs a few copies rolled back.
+const _importMaxConflicts = 20
+
+var errImportDryRun = errors.New("dry run")
+
+func (u *Usecases) ImportTickets(ctx context.Context, rows []entities.ImportRow[entities.Ticket], mode string) (_ []entities.ImportRowResult, err error) {
+	ctx, done := u.observe(ctx, "ImportTickets")
+	defer done(&err)
+
+	createdAt := time.Now().UTC().Format(time.RFC3339)
+	tickets := make([]entities.Ticket, 0, len(rows))
+
+	for _, row := range rows {
+		id, err := uuid.NewV6()
+		if err != nil {
+			return []entities.ImportRowResult{}, fmt.Errorf("usecases: import: ImportTickets: NewV6: %w", err)
+		}
+
+		row.Value.Id = id.String()
+		row.Value.CreatedAt = createdAt
+		tickets = append(tickets, row.Value)
+	}
+
+	write := func(ctx context.Context, tickets []entities.Ticket) error {
+		if err := u.repos.CopyTickets(ctx, tickets); err != nil {
+			return err
+		}
+
+		return addImportEvents(ctx, u.repos, tickets, entities.EventTicketCreated, entities.AggregateTicket, func(ticket entities.Ticket) (string, any) {
+			return ticket.Id, ticket
+		})
+	}
+
+	if mode == entities.ImportModeBestEffort {
+		results, written := importBestEffort(ctx, u.repos, rows, tickets, write, func(ticket entities.Ticket) string {
+			return ticket.Id
+		})
+		u.count(ctx, CountTicketsCreated, written)
+
+		return results, nil
+	}
+
+	conflicts, err := importCopy(ctx, u.repos, rows, tickets, u.repos.CopyTickets, func(ctx context.Context) error {
+		return write(ctx, tickets)
+	})
+	if err != nil {
+		return []entities.ImportRowResult{}, err
+	}
+
+	if len(conflicts) > 0 {
+		return conflicts, nil
+	}
+
+	u.count(ctx, CountTicketsCreated, len(tickets))
+
+	return importResults(rows, func(i int) string {
+		return tickets[i].Id
+	}), nil
+}
+
+func (u *Usecases) ImportPassengers(ctx context.Context, rows []entities.ImportRow[entities.Passenger], mode string) (_ []entities.ImportRowResult, err error) {
+	ctx, done := u.observe(ctx, "ImportPassengers")
+	defer done(&err)
+
+	passengers := make([]entities.Passenger, 0, len(rows))
+
+	for _, row := range rows {
+		id, err := uuid.NewV6()
+		if err != nil {
+			return []entities.ImportRowResult{}, fmt.Errorf("usecases: import: ImportPassengers: NewV6: %w", err)
+		}
+
+		row.Value.Id = id.String()
+		passengers = append(passengers, row.Value)
+	}
+
+	write := func(ctx context.Context, passengers []entities.Passenger) error {
+		if err := u.repos.CopyPassengers(ctx, passengers); err != nil {
+			return err
+		}
+
+		return addImportEvents(ctx, u.repos, passengers, entities.EventPassengerCreated, entities.AggregatePassenger, func(passenger entities.Passenger) (string, any) {
+			return passenger.Id, passenger
+		})
+	}
+
+	if mode == entities.ImportModeBestEffort {
+		results, _ := importBestEffort(ctx, u.repos, rows, passengers, write, func(passenger entities.Passenger) string {
+			return passenger.Id
+		})
+
+		return results, nil
+	}
+
+	conflicts, err := importCopy(ctx, u.repos, rows, passengers, u.repos.CopyPassengers, func(ctx context.Context) error {
+		return write(ctx, passengers)
+	})
+	if err != nil {
+		return []entities.ImportRowResult{}, err
+	}
+
+	if len(conflicts) > 0 {
+		return conflicts, nil
+	}
+
+	return importResults(rows, func(i int) string {
+		return passengers[i].Id
+	}), nil
+}
+
+func (u *Usecases) ImportDocuments(ctx context.Context, rows []entities.ImportRow[entities.Document], mode string) (_ []entities.ImportRowResult, err error) {
+	ctx, done := u.observe(ctx, "ImportDocuments")
+	defer done(&err)
+
+	documents := make([]entities.Document, 0, len(rows))
+
+	for _, row := range rows {
+		id, err := uuid.NewV6()
+		if err != nil {
+			return []entities.ImportRowResult{}, fmt.Errorf("usecases: import: ImportDocuments: NewV6: %w", err)
+		}
+
+		row.Value.Id = id.String()
+		documents = append(documents, row.Value)
+	}
+
+	write := func(ctx context.Context, documents []entities.Document) error {
+		if err := u.repos.CopyDocuments(ctx, documents); err != nil {
+			return err
+		}
+
+		return addImportEvents(ctx, u.repos, documents, entities.EventDocumentAdded, entities.AggregateDocument, func(document entities.Document) (string, any) {
+			return document.Id, document
+		})
+	}
+
+	if mode == entities.ImportModeBestEffort {
+		results, _ := importBestEffort(ctx, u.repos, rows, documents, write, func(document entities.Document) string {
+			return document.Id
+		})
+
+		return results, nil
+	}
+
+	conflicts, err := importCopy(ctx, u.repos, rows, documents, u.repos.CopyDocuments, func(ctx context.Context) error {
+		return write(ctx, documents)
+	})
+	if err != nil {
+		return []entities.ImportRowResult{}, err
+	}
+
+	if len(conflicts) > 0 {
+		return conflicts, nil
+	}
+
+	return importResults(rows, func(i int) string {
+		return documents[i].Id
+	}), nil
+}
+
+func (u *Usecases) ImportBindings(ctx context.Context, rows []entities.ImportRow[entities.Binding], mode string) (_ []entities.ImportRowResult, err error) {
+	ctx, done := u.observe(ctx, "ImportBindings")
+	defer done(&err)
+
+	bindings := make([]entities.Binding, 0, len(rows))
+
+	for _, row := range rows {
+		bindings = append(bindings, row.Value)
+	}
+
+	write := func(ctx context.Context, bindings []entities.Binding) error {
+		if err := u.repos.CopyBindings(ctx, bindings); err != nil {
+			return err
+		}
+
+		return addImportEvents(ctx, u.repos, bindings, entities.EventPassengerBound, entities.AggregatePassenger, func(binding entities.Binding) (string, any) {
+			return binding.PassengerId, entities.BindingEventPayload{
+				PassengerId: binding.PassengerId,
+				TicketId:    binding.TicketId,
+			}
+		})
+	}
+
+	if mode == entities.ImportModeBestEffort {
+		results, written := importBestEffort(ctx, u.repos, rows, bindings, write, func(entities.Binding) string {
+			return ""
+		})
+		u.count(ctx, CountPassengersBound, written)
+
+		return results, nil
+	}
+
+	conflicts, err := importCopy(ctx, u.repos, rows, bindings, u.repos.CopyBindings, func(ctx context.Context) error {
+		return write(ctx, bindings)
+	})
+	if err != nil {
+		return []entities.ImportRowResult{}, err
+	}
+
+	if len(conflicts) > 0 {
+		return conflicts, nil
+	}
+
+	u.count(ctx, CountPassengersBound, len(bindings))
+
+	return importResults(rows, func(i int) string {
+		return ""
+	}), nil
+}
+
+// importBestEffort writes values by chunks of _importChunk rows, each copied with its events in a transaction.
+// Only the rows of a failing chunk are written one by one, so a failed row does not fail the others.
+// It returns the result of every row and the number of rows written.
+func importBestEffort[T any](
+	ctx context.Context,
+	repos Reposer,
+	rows []entities.ImportRow[T],
+	values []T,
+	write func(ctx context.Context, values []T) error,
+	id func(T) string,
+) ([]entities.ImportRowResult, int) {
+	results := make([]entities.ImportRowResult, 0, len(rows))
+	written := 0
+
+	for start := 0; start < len(values); start += _importChunk {
+		end := min(start+_importChunk, len(values))
+
+		err := repos.WithinTx(ctx, func(ctx context.Context) error {
+			return write(ctx, values[start:end])
+		})
+		if err == nil {
+			for i := start; i < end; i++ {
+				results = append(results, entities.ImportRowResult{
+					Row: rows[i].Row,
+					Id:  id(values[i]),
+				})
+			}
+			written += end - start
+
+			continue
+		}
+
+		for i := start; i < end; i++ {
+			err := repos.WithinTx(ctx, func(ctx context.Context) error {
+				return write(ctx, values[i:i+1])
+			})
+			if err != nil {
+				results = append(results, entities.ImportRowResult{
+					Row: rows[i].Row,
+					Err: err,
+				})
+				continue
+			}
+
+			results = append(results, entities.ImportRowResult{
+				Row: rows[i].Row,
+				Id:  id(values[i]),
+			})
+			written++
+		}
+	}
+
+	return results, written
+}
+
+func importResults[T any](rows []entities.ImportRow[T], id func(i int) string) []entities.ImportRowResult {
+	results := make([]entities.ImportRowResult, 0, len(rows))
+
+	for i, row := range rows {
+		results = append(results, entities.ImportRowResult{
+			Row: row.Row,
+			Id:  id(i),
+		})
+	}
+
+	return results
+}
+
+// importCopy runs write, which copies values and adds their events, in a transaction.
+// If a row conflicts with the stored data or another row, nothing is written
+// and the conflicting rows are returned instead of the error, see importConflicts.
+func importCopy[T any](
+	ctx context.Context,
+	repos Reposer,
+	rows []entities.ImportRow[T],
+	values []T,
+	copyF func(ctx context.Context, values []T) error,
+	write func(ctx context.Context) error,
+) ([]entities.ImportRowResult, error) {
+	err := repos.WithinTx(ctx, write)
+	if err == nil || !isImportConflict(err) {
+		return nil, err
+	}
+
+	conflicts, locateErr := importConflicts(ctx, repos, rows, values, copyF)
+	if locateErr != nil {
+		return nil, fmt.Errorf("usecases: import: importCopy: %w", locateErr)
+	}
+
+	// The conflicting data may have changed meanwhile
+	if len(conflicts) == 0 {
+		return nil, err
+	}
+
+	return conflicts, nil
+}
+
+// importConflicts locates up to _importMaxConflicts rows a copy of values fails on.
+// Copies are tried in transactions rolled back: the shortest failing prefix of the rows,
+// found by bisection, ends with a conflicting row, which is left out of the next tries.
+func importConflicts[T any](
+	ctx context.Context,
+	repos Reposer,
+	rows []entities.ImportRow[T],
+	values []T,
+	copyF func(ctx context.Context, values []T) error,
+) ([]entities.ImportRowResult, error) {
+	try := func(indexes []int) error {
+		subset := make([]T, 0, len(indexes))
+		for _, i := range indexes {
+			subset = append(subset, values[i])
+		}
+
+		err := repos.WithinTx(ctx, func(ctx context.Context) error {
+			if err := copyF(ctx, subset); err != nil {
+				return err
+			}
+
+			return errImportDryRun
+		})
+		if errors.Is(err, errImportDryRun) {
+			return nil
+		}
+
+		return err
+	}
+
+	remaining := make([]int, 0, len(values))
+	for i := range values {
+		remaining = append(remaining, i)
+	}
+
+	conflicts := []entities.ImportRowResult{}
+
+	for len(conflicts) < _importMaxConflicts {
+		conflict := try(remaining)
+		if conflict == nil {
+			break
+		}
+
+		if !isImportConflict(conflict) {
+			return nil, conflict
+		}
+
+		lo, hi := 1, len(remaining)
+
+		for lo < hi {
+			mid := (lo + hi) / 2
+
+			err := try(remaining[:mid])
+
+			switch {
+			case err == nil:
+				lo = mid + 1
+			case isImportConflict(err):
+				hi, conflict = mid, err
+			default:
+				return nil, err
+			}
+		}
+
+		conflicts = append(conflicts, entities.ImportRowResult{
+			Row: rows[remaining[hi-1]].Row,
+			Err: conflict,
+		})
+		remaining = slices.Delete(remaining, hi-1, hi)
+	}
+
+	return conflicts, nil
+}
+
+// isImportConflict reports errors caused by the rows rather than by the storage.
+func isImportConflict(err error) bool {
+	domainErr, ok := entities.AsError(err)
+
+	return ok && domainErr.Category != entities.CategoryInternal && !domainErr.Retryable
+}
+
+func addImportEvents[T any](ctx context.Context, repos Reposer, values []T, eventType, aggregateType string, event func(T) (string, any)) error {
+	events := make([]entities.Event, 0, len(values))
+
+	for _, value := range values {
+		aggregateId, payload := event(value)
+
+		e, err := newEvent(eventType, aggregateType, aggregateId, payload)
+		if err != nil {
+			return err
+		}
+
+		events = append(events, e)
+	}
+
+	if err := repos.AddEvents(ctx, events...); err != nil {
+		return err
+	}
+
+	return enqueueWebhooks(ctx, repos, events...)
+}
@@ -0,0 +1,332 @@
+// Package cache keeps whole ticket info and passenger documents in front of the repository.
+package cache
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/v1adhope/flights/internal/entities"
+	"github.com/v1adhope/flights/internal/usecases"
+	"github.com/v1adhope/flights/pkg/postgresql"
+)
+
+const (
+	CacheTicketWholeInfo    = "ticket_whole_info"
+	CachePassengerDocuments = "passenger_documents"
+)
+
+// Observer counts cache hits and misses, *metrics.Metrics implements it.
+type Observer interface {
+	ObserveCache(cache string, hit bool)
+}
+
+// Repository caches GetWholeInfoAboutTicket and GetDocumentsByPassengerId of the wrapped repository,
+// every entry is tagged with the tickets, passengers and documents it was built from,
+// writes through Repository invalidate their tags once they are committed.
+type Repository struct {
+	usecases.Reposer
+
+	store    Store
+	log      usecases.Logger
+	ttl      time.Duration
+	observer Observer
+
+	// epoch changes on every invalidation, a fill started before it is not stored
+	mu    sync.RWMutex
+	epoch atomic.Uint64
+}
+
+type Option func(*Repository)
+
+// WithTtl bounds how long a missed invalidation, e.g. of another service replica, can be served.
+func WithTtl(ttl time.Duration) Option {
+	return func(r *Repository) {
+		r.ttl = ttl
+	}
+}
+
+func WithObserver(o Observer) Option {
+	return func(r *Repository) {
+		r.observer = o
+	}
+}
+
+func New(next usecases.Reposer, store Store, log usecases.Logger, opts ...Option) *Repository {
+	r := &Repository{
+		Reposer: next,
+		store:   store,
+		log:     log,
+		ttl:     time.Minute,
+	}
+
+	for _, opt := range opts {
+		opt(r)
+	}
+
+	return r
+}
+
+func ticketTag(id string) string {
+	return "ticket:" + id
+}
+
+func passengerTag(id string) string {
+	return "passenger:" + id
+}
+
+func documentTag(id string) string {
+	return "document:" + id
+}
+
+type txKey struct{}
+
+// txTags collects the tags written inside a transaction of Repository.
+type txTags struct {
+	mu   sync.Mutex
+	tags []string
+}
+
+// WithinTx invalidates the tags written by fn after the commit,
+// reads inside fn go to the repository, they must see the uncommitted writes.
+func (r *Repository) WithinTx(ctx context.Context, fn func(ctx context.Context) error) error {
+	if _, ok := ctx.Value(txKey{}).(*txTags); ok {
+		return r.Reposer.WithinTx(ctx, fn)
+	}
+
+	written := &txTags{}
+
+	err := r.Reposer.WithinTx(context.WithValue(ctx, txKey{}, written), fn)
+
+	// The transaction may have been committed, better to drop too much
+	if err == nil || errors.Is(err, postgresql.ErrCommitUnknown) {
+		r.invalidate(ctx, written.tags...)
+	}
+
+	return err
+}
+
+func (r *Repository) inTx(ctx context.Context) bool {
+	_, ok := ctx.Value(txKey{}).(*txTags)
+
+	return ok
+}
+
+// written invalidates tags of a successful write, inside a transaction it postpones them until the commit.
+func (r *Repository) written(ctx context.Context, err error, tags ...string) error {
+	if err != nil {
+		return err
+	}
+
+	if written, ok := ctx.Value(txKey{}).(*txTags); ok {
+		written.mu.Lock()
+		written.tags = append(written.tags, tags...)
+		written.mu.Unlock()
+
+		return nil
+	}
+
+	r.invalidate(ctx, tags...)
+
+	return nil
+}
+
+func (r *Repository) invalidate(ctx context.Context, tags ...string) {
+	if len(tags) == 0 {
+		return
+	}
+
+	r.mu.Lock()
+	r.epoch.Add(1)
+	r.mu.Unlock()
+
+	if err := r.store.Invalidate(ctx, tags...); err != nil {
+		r.log.Error(err, "cache: Invalidate: %v", tags)
+	}
+}
+
+// Changed drops the entries built from change, e.g. committed by another service replica.
+func (r *Repository) Changed(ctx context.Context, change entities.Change) {
+	switch change.Entity {
+	case entities.ChangeTicket:
+		r.invalidate(ctx, ticketTag(change.Id))
+	case entities.ChangePassenger:
+		r.invalidate(ctx, passengerTag(change.Id))
+	case entities.ChangeDocument:
+		r.invalidate(ctx, documentTag(change.Id), passengerTag(change.PassengerId))
+	case entities.ChangeBinding:
+		r.invalidate(ctx, ticketTag(change.TicketId))
+	}
+}
+
+// Resync drops the entries which may have missed changes, e.g. while the subscription was lost.
+func (r *Repository) Resync(ctx context.Context) {
+	r.mu.Lock()
+	r.epoch.Add(1)
+	r.mu.Unlock()
+
+	if err := r.store.Clear(ctx); err != nil {
+		r.log.Error(err, "%s", "cache: Clear")
+	}
+}
+
+// cached returns the entry of key or loads it, store errors only make it a miss.
+func cached[T any](ctx context.Context, r *Repository, cache, key string, load func(ctx context.Context) (T, []string, error)) (T, error) {
+	if r.inTx(ctx) {
+		value, _, err := load(ctx)
+		return value, err
+	}
+
+	key = cache + ":" + key
+
+	raw, ok, err := r.store.Get(ctx, key)
+	if err != nil {
+		r.log.Error(err, "cache: Get: %s", key)
+	}
+
+	var value T
+
+	if ok {
+		if err := json.Unmarshal(raw, &value); err == nil {
+			r.observe(cache, true)
+			return value, nil
+		}
+	}
+
+	r.observe(cache, false)
+
+	epoch := r.epoch.Load()
+
+	// A replica may not have the write that invalidated the entry yet
+	value, tags, err := load(postgresql.ForcePrimary(ctx))
+	if err != nil {
+		return value, err
+	}
+
+	raw, err = json.Marshal(value)
+	if err != nil {
+		r.log.Error(err, "cache: Marshal: %s", key)
+		return value, nil
+	}
+
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	if r.epoch.Load() != epoch {
+		return value, nil
+	}
+
+	if err := r.store.Set(ctx, key, raw, tags, r.ttl); err != nil {
+		r.log.Error(err, "cache: Set: %s", key)
+	}
+
+	return value, nil
+}
+
+func (r *Repository) observe(cache string, hit bool) {
+	if r.observer != nil {
+		r.observer.ObserveCache(cache, hit)
+	}
+}
+
+func (r *Repository) GetWholeInfoAboutTicket(ctx context.Context, id entities.Id) (entities.TicketWholeInfo, error) {
+	return cached(ctx, r, CacheTicketWholeInfo, id.Value, func(ctx context.Context) (entities.TicketWholeInfo, []string, error) {
+		info, err := r.Reposer.GetWholeInfoAboutTicket(ctx, id)
+		if err != nil {
+			return info, nil, err
+		}
+
+		tags := []string{ticketTag(id.Value)}
+		for _, passenger := range info.Passengers {
+			tags = append(tags, passengerTag(passenger.Id))
+
+			for _, document := range passenger.Documents {
+				tags = append(tags, documentTag(document.Id))
+			}
+		}
+
+		return info, tags, nil
+	})
+}
+
+func (r *Repository) GetDocumentsByPassengerId(ctx context.Context, id entities.Id) ([]entities.Document, error) {
+	return cached(ctx, r, CachePassengerDocuments, id.Value, func(ctx context.Context) ([]entities.Document, []string, error) {
+		documents, err := r.Reposer.GetDocumentsByPassengerId(ctx, id)
+		if err != nil {
+			return documents, nil, err
+		}
+
+		tags := []string{passengerTag(id.Value)}
+		for _, document := range documents {
+			tags = append(tags, documentTag(document.Id))
+		}
+
+		return documents, tags, nil
+	})
+}
+
+func (r *Repository) CreateTicket(ctx context.Context, ticket entities.Ticket) error {
+	return r.written(ctx, r.Reposer.CreateTicket(ctx, ticket), ticketTag(ticket.Id))
+}
+
+func (r *Repository) ReplaceTicket(ctx context.Context, ticket entities.Ticket) error {
+	return r.written(ctx, r.Reposer.ReplaceTicket(ctx, ticket), ticketTag(ticket.Id))
+}
+
+func (r *Repository) DeleteTicket(ctx context.Context, id entities.Id) error {
+	return r.written(ctx, r.Reposer.DeleteTicket(ctx, id), ticketTag(id.Value))
+}
+
+func (r *Repository) CreatePassenger(ctx context.Context, passenger entities.Passenger) error {
+	return r.written(ctx, r.Reposer.CreatePassenger(ctx, passenger), passengerTag(passenger.Id))
+}
+
+func (r *Repository) ReplacePassenger(ctx context.Context, passenger entities.Passenger) error {
+	return r.written(ctx, r.Reposer.ReplacePassenger(ctx, passenger), passengerTag(passenger.Id))
+}
+
+func (r *Repository) DeletePassenger(ctx context.Context, id entities.Id) error {
+	return r.written(ctx, r.Reposer.DeletePassenger(ctx, id), passengerTag(id.Value))
+}
+
+func (r *Repository) BoundToTicket(ctx context.Context, id entities.Id, ticketId entities.Id) error {
+	return r.written(ctx, r.Reposer.BoundToTicket(ctx, id, ticketId), ticketTag(ticketId.Value))
+}
+
+func (r *Repository) UnboundToTicket(ctx context.Context, id entities.Id, ticketId entities.Id) error {
+	return r.written(ctx, r.Reposer.UnboundToTicket(ctx, id, ticketId), ticketTag(ticketId.Value))
+}
+
+func (r *Repository) CreateDocument(ctx context.Context, document entities.Document) error {
+	return r.written(ctx, r.Reposer.CreateDocument(ctx, document), passengerTag(document.PassengerId))
+}
+
+// ReplaceDocument may move the document to another passenger, the entries of the old one are tagged with the document.
+func (r *Repository) ReplaceDocument(ctx context.Context, document entities.Document) error {
+	return r.written(ctx, r.Reposer.ReplaceDocument(ctx, document), documentTag(document.Id), passengerTag(document.PassengerId))
+}
+
+func (r *Repository) DeleteDocument(ctx context.Context, id entities.Id) error {
+	return r.written(ctx, r.Reposer.DeleteDocument(ctx, id), documentTag(id.Value))
+}
+
+func (r *Repository) CopyDocuments(ctx context.Context, documents []entities.Document) error {
+	tags := make([]string, 0, len(documents))
+	for _, document := range documents {
+		tags = append(tags, passengerTag(document.PassengerId))
+	}
+
+	return r.written(ctx, r.Reposer.CopyDocuments(ctx, documents), tags...)
+}
+
+func (r *Repository) CopyBindings(ctx context.Context, bindings []entities.Binding) error {
+	tags := make([]string, 0, len(bindings))
+	for _, binding := range bindings {
+		tags = append(tags, ticketTag(binding.TicketId))
+	}
+
+	return r.written(ctx, r.Reposer.CopyBindings(ctx, bindings), tags...)
+}
@@ -0,0 +1,234 @@
+package cache_test
+
+import (
+	"context"
+	"errors"
+	"net"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/v1adhope/flights/internal/entities"
+	"github.com/v1adhope/flights/internal/usecases"
+	"github.com/v1adhope/flights/internal/usecases/infrastructure/cache"
+	"github.com/v1adhope/flights/pkg/resp"
+)
+
+func stores(t *testing.T) map[string]cache.Store {
+	ln, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+
+	srv := resp.NewServer()
+	go srv.Serve(ln)
+	t.Cleanup(func() { srv.Close() })
+
+	client := resp.New(resp.WithAddr(ln.Addr().String()))
+	t.Cleanup(func() { client.Close() })
+
+	return map[string]cache.Store{
+		"memory": cache.NewMemory(100),
+		"redis":  cache.NewRedis(client, "test:"),
+	}
+}
+
+func TestStore(t *testing.T) {
+	ctx := context.Background()
+
+	for name, store := range stores(t) {
+		t.Run(name, func(t *testing.T) {
+			require.NoError(t, store.Set(ctx, "a", []byte("1"), []string{"ticket:1", "passenger:1"}, time.Minute))
+			require.NoError(t, store.Set(ctx, "b", []byte("2"), []string{"passenger:1"}, time.Minute))
+			require.NoError(t, store.Set(ctx, "c", []byte("3"), []string{"passenger:2"}, time.Minute))
+
+			value, ok, err := store.Get(ctx, "a")
+			require.NoError(t, err)
+			assert.True(t, ok)
+			assert.Equal(t, []byte("1"), value)
+
+			require.NoError(t, store.Invalidate(ctx, "passenger:1", "missing"))
+
+			for key, want := range map[string]bool{"a": false, "b": false, "c": true} {
+				_, ok, err := store.Get(ctx, key)
+				require.NoError(t, err)
+				assert.Equal(t, want, ok, key)
+			}
+		})
+	}
+}
+
+func TestMemoryEvict(t *testing.T) {
+	ctx := context.Background()
+	store := cache.NewMemory(1)
+
+	require.NoError(t, store.Set(ctx, "a", []byte("1"), []string{"ticket:1"}, time.Minute))
+	require.NoError(t, store.Set(ctx, "b", []byte("2"), []string{"ticket:1"}, time.Minute))
+
+	_, ok, _ := store.Get(ctx, "a")
+	assert.False(t, ok)
+
+	require.NoError(t, store.Invalidate(ctx, "ticket:1"))
+
+	_, ok, _ = store.Get(ctx, "b")
+	assert.False(t, ok)
+}
+
+type fakeRepo struct {
+	usecases.Reposer
+
+	info      entities.TicketWholeInfo
+	documents []entities.Document
+	loads     int
+}
+
+func (r *fakeRepo) WithinTx(ctx context.Context, fn func(ctx context.Context) error) error {
+	return fn(ctx)
+}
+
+func (r *fakeRepo) GetWholeInfoAboutTicket(_ context.Context, _ entities.Id) (entities.TicketWholeInfo, error) {
+	r.loads++
+	return r.info, nil
+}
+
+func (r *fakeRepo) GetDocumentsByPassengerId(_ context.Context, _ entities.Id) ([]entities.Document, error) {
+	r.loads++
+	return r.documents, nil
+}
+
+func (r *fakeRepo) ReplacePassenger(_ context.Context, _ entities.Passenger) error { return nil }
+func (r *fakeRepo) ReplaceTicket(_ context.Context, _ entities.Ticket) error       { return nil }
+func (r *fakeRepo) DeleteDocument(_ context.Context, _ entities.Id) error          { return nil }
+func (r *fakeRepo) CreateDocument(_ context.Context, _ entities.Document) error    { return nil }
+func (r *fakeRepo) BoundToTicket(_ context.Context, _, _ entities.Id) error        { return nil }
+
+type nopLogger struct{}
+
+func (nopLogger) Info(string, ...any)         {}
+func (nopLogger) Error(error, string, ...any) {}
+
+type observer map[bool]int
+
+func (o observer) ObserveCache(_ string, hit bool) {
+	o[hit]++
+}
+
+func newRepo() *fakeRepo {
+	return &fakeRepo{
+		info: entities.TicketWholeInfo{
+			Ticket: entities.Ticket{Id: "t1", Provider: "Emirates"},
+			Passengers: []entities.PassengerTicketWholeInfo{{
+				Passenger: entities.Passenger{Id: "p1", LastName: "Reyes"},
+				Documents: []entities.DocumentTicketWholeInfo{{Id: "d1", Type: "Passport"}},
+			}},
+		},
+		documents: []entities.Document{{Id: "d1", Type: "Passport", PassengerId: "p1"}},
+	}
+}
+
+func TestRepositoryInvalidation(t *testing.T) {
+	ctx := context.Background()
+	ticket := entities.Id{Value: "t1"}
+	passenger := entities.Id{Value: "p1"}
+
+	cases := []struct {
+		name       string
+		write      func(r *cache.Repository) error
+		info, docs bool
+	}{
+		{"replace ticket", func(r *cache.Repository) error { return r.ReplaceTicket(ctx, entities.Ticket{Id: "t1"}) }, true, false},
+		{"replace other ticket", func(r *cache.Repository) error { return r.ReplaceTicket(ctx, entities.Ticket{Id: "t2"}) }, false, false},
+		{"replace passenger", func(r *cache.Repository) error { return r.ReplacePassenger(ctx, entities.Passenger{Id: "p1"}) }, true, true},
+		{"delete document", func(r *cache.Repository) error { return r.DeleteDocument(ctx, entities.Id{Value: "d1"}) }, true, true},
+		{"create document", func(r *cache.Repository) error {
+			return r.CreateDocument(ctx, entities.Document{Id: "d2", PassengerId: "p1"})
+		}, true, true},
+		{"bound", func(r *cache.Repository) error { return r.BoundToTicket(ctx, entities.Id{Value: "p2"}, ticket) }, true, false},
+		{"changed binding", func(r *cache.Repository) error {
+			r.Changed(ctx, entities.Change{Entity: entities.ChangeBinding, PassengerId: "p2", TicketId: "t1"})
+			return nil
+		}, true, false},
+		{"changed document", func(r *cache.Repository) error {
+			r.Changed(ctx, entities.Change{Entity: entities.ChangeDocument, Id: "d1"})
+			return nil
+		}, true, true},
+		{"changed other passenger", func(r *cache.Repository) error {
+			r.Changed(ctx, entities.Change{Entity: entities.ChangePassenger, Id: "p2"})
+			return nil
+		}, false, false},
+		{"resync", func(r *cache.Repository) error {
+			r.Resync(ctx)
+			return nil
+		}, true, true},
+		{"within tx", func(r *cache.Repository) error {
+			return r.WithinTx(ctx, func(ctx context.Context) error {
+				return r.ReplaceTicket(ctx, entities.Ticket{Id: "t1"})
+			})
+		}, true, false},
+	}
+
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			repo := newRepo()
+			obs := observer{}
+			r := cache.New(repo, cache.NewMemory(100), nopLogger{}, cache.WithObserver(obs))
+
+			info, err := r.GetWholeInfoAboutTicket(ctx, ticket)
+			require.NoError(t, err)
+			assert.Equal(t, repo.info, info)
+
+			docs, err := r.GetDocumentsByPassengerId(ctx, passenger)
+			require.NoError(t, err)
+			assert.Equal(t, repo.documents, docs)
+
+			require.NoError(t, c.write(r))
+			repo.loads = 0
+
+			info, err = r.GetWholeInfoAboutTicket(ctx, ticket)
+			require.NoError(t, err)
+			assert.Equal(t, repo.info, info)
+
+			_, err = r.GetDocumentsByPassengerId(ctx, passenger)
+			require.NoError(t, err)
+
+			want := 0
+			if c.info {
+				want++
+			}
+			if c.docs {
+				want++
+			}
+
+			assert.Equal(t, want, repo.loads)
+			assert.Equal(t, 2-want, obs[true])
+		})
+	}
+}
+
+func TestRepositoryTx(t *testing.T) {
+	ctx := context.Background()
+	ticket := entities.Id{Value: "t1"}
+	repo := newRepo()
+	r := cache.New(repo, cache.NewMemory(100), nopLogger{})
+
+	_, err := r.GetWholeInfoAboutTicket(ctx, ticket)
+	require.NoError(t, err)
+
+	errRollback := errors.New("rollback")
+
+	err = r.WithinTx(ctx, func(ctx context.Context) error {
+		require.NoError(t, r.ReplaceTicket(ctx, entities.Ticket{Id: "t1"}))
+
+		// Reads inside a transaction must see its writes
+		_, err := r.GetWholeInfoAboutTicket(ctx, ticket)
+		require.NoError(t, err)
+		assert.Equal(t, 2, repo.loads)
+
+		return errRollback
+	})
+	require.ErrorIs(t, err, errRollback)
+
+	// Nothing was committed, the entry is still valid
+	_, err = r.GetWholeInfoAboutTicket(ctx, ticket)
+	require.NoError(t, err)
+	assert.Equal(t, 2, repo.loads)
+}
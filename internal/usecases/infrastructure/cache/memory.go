@@ -0,0 +1,104 @@
+package cache
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/v1adhope/flights/pkg/lru"
+)
+
+// Memory is an in-process LRU store, every service replica has its own.
+type Memory struct {
+	entries *lru.Cache[string, memoryEntry]
+
+	mu   sync.Mutex
+	tags map[string]map[string]struct{}
+}
+
+type memoryEntry struct {
+	value []byte
+	tags  []string
+}
+
+// NewMemory keeps at most capacity entries.
+func NewMemory(capacity int) *Memory {
+	m := &Memory{
+		tags: map[string]map[string]struct{}{},
+	}
+
+	m.entries = lru.New(
+		lru.WithCapacity[string, memoryEntry](capacity),
+		lru.WithOnEvict(m.untag),
+	)
+
+	return m
+}
+
+func (m *Memory) Get(_ context.Context, key string) ([]byte, bool, error) {
+	entry, ok := m.entries.Get(key)
+
+	return entry.value, ok, nil
+}
+
+func (m *Memory) Set(_ context.Context, key string, value []byte, tags []string, ttl time.Duration) error {
+	m.entries.SetWithTtl(key, memoryEntry{value, tags}, ttl)
+
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	for _, tag := range tags {
+		if m.tags[tag] == nil {
+			m.tags[tag] = map[string]struct{}{}
+		}
+
+		m.tags[tag][key] = struct{}{}
+	}
+
+	return nil
+}
+
+func (m *Memory) Invalidate(_ context.Context, tags ...string) error {
+	var keys []string
+
+	m.mu.Lock()
+	for _, tag := range tags {
+		for key := range m.tags[tag] {
+			keys = append(keys, key)
+		}
+
+		delete(m.tags, tag)
+	}
+	m.mu.Unlock()
+
+	// Deleting calls untag, so the tag index must be unlocked
+	for _, key := range keys {
+		m.entries.Delete(key)
+	}
+
+	return nil
+}
+
+func (m *Memory) Clear(_ context.Context) error {
+	m.entries.Purge()
+
+	return nil
+}
+
+// untag keeps the tag index from growing with entries the LRU dropped.
+func (m *Memory) untag(key string, entry memoryEntry) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	for _, tag := range entry.tags {
+		delete(m.tags[tag], key)
+
+		if len(m.tags[tag]) == 0 {
+			delete(m.tags, tag)
+		}
+	}
+}
+
+func (m *Memory) Close() error {
+	return nil
+}
@@ -0,0 +1,90 @@
+package cache
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"time"
+
+	"github.com/v1adhope/flights/pkg/resp"
+)
+
+// Redis is a store shared by every service replica, tags are Redis sets of the keys they mark.
+type Redis struct {
+	client *resp.Client
+	prefix string
+}
+
+// NewRedis prefixes every key with prefix, so several services can share a Redis database.
+func NewRedis(client *resp.Client, prefix string) *Redis {
+	return &Redis{
+		client: client,
+		prefix: prefix,
+	}
+}
+
+func (r *Redis) Get(ctx context.Context, key string) ([]byte, bool, error) {
+	reply, err := r.client.Do(ctx, "GET", r.prefix+key)
+	if err != nil {
+		return nil, false, fmt.Errorf("cache: redis: Get: %w", err)
+	}
+
+	value, ok := reply.(string)
+
+	return []byte(value), ok, nil
+}
+
+func (r *Redis) Set(ctx context.Context, key string, value []byte, tags []string, ttl time.Duration) error {
+	px := strconv.FormatInt(ttl.Milliseconds(), 10)
+
+	if _, err := r.client.Do(ctx, "SET", r.prefix+key, string(value), "PX", px); err != nil {
+		return fmt.Errorf("cache: redis: Set: %w", err)
+	}
+
+	// Tag sets live as long as their newest entry
+	for _, tag := range tags {
+		if _, err := r.client.Do(ctx, "SADD", r.tagKey(tag), r.prefix+key); err != nil {
+			return fmt.Errorf("cache: redis: Set: %w", err)
+		}
+
+		if _, err := r.client.Do(ctx, "PEXPIRE", r.tagKey(tag), px); err != nil {
+			return fmt.Errorf("cache: redis: Set: %w", err)
+		}
+	}
+
+	return nil
+}
+
+func (r *Redis) Invalidate(ctx context.Context, tags ...string) error {
+	for _, tag := range tags {
+		reply, err := r.client.Do(ctx, "SMEMBERS", r.tagKey(tag))
+		if err != nil {
+			return fmt.Errorf("cache: redis: Invalidate: %w", err)
+		}
+
+		keys := []string{"DEL", r.tagKey(tag)}
+		for _, member := range reply.([]any) {
+			keys = append(keys, member.(string))
+		}
+
+		if _, err := r.client.Do(ctx, keys...); err != nil {
+			return fmt.Errorf("cache: redis: Invalidate: %w", err)
+		}
+	}
+
+	return nil
+}
+
+// Clear keeps the entries, every service replica invalidates them in Redis itself, so none were missed.
+func (r *Redis) Clear(_ context.Context) error {
+	return nil
+}
+
+// Close closes the client.
+func (r *Redis) Close() error {
+	return r.client.Close()
+}
+
+func (r *Redis) tagKey(tag string) string {
+	return r.prefix + "tag:" + tag
+}
@@ -0,0 +1,16 @@
+package cache
+
+import (
+	"context"
+	"time"
+)
+
+// Store keeps serialized entries, every entry carries tags, invalidating a tag drops all entries with it.
+// Memory and Redis implement it.
+type Store interface {
+	Get(ctx context.Context, key string) ([]byte, bool, error)
+	Set(ctx context.Context, key string, value []byte, tags []string, ttl time.Duration) error
+	Invalidate(ctx context.Context, tags ...string) error
+	// Clear drops the entries which may have missed invalidations
+	Clear(ctx context.Context) error
+}
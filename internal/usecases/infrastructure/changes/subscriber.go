@@ -0,0 +1,75 @@
+// Package changes passes the entity changes committed by every service replica to in-process consumers.
+package changes
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+
+	"github.com/v1adhope/flights/internal/entities"
+	"github.com/v1adhope/flights/internal/usecases"
+	"github.com/v1adhope/flights/pkg/postgresql"
+)
+
+// Consumer is e.g. a local cache, its methods are called from one goroutine.
+type Consumer interface {
+	Changed(ctx context.Context, change entities.Change)
+	// Resync is called once the subscription starts and again after it was lost, the changes in between are not delivered.
+	Resync(ctx context.Context)
+}
+
+// Listener is implemented by *postgresql.Driver.
+type Listener interface {
+	Listen(ctx context.Context, channel string, h postgresql.ListenHandler)
+}
+
+type Subscriber struct {
+	listener  Listener
+	channel   string
+	log       usecases.Logger
+	consumers []Consumer
+}
+
+func NewSubscriber(l Listener, channel string, log usecases.Logger, consumers ...Consumer) *Subscriber {
+	return &Subscriber{
+		listener:  l,
+		channel:   channel,
+		log:       log,
+		consumers: consumers,
+	}
+}
+
+// Run passes changes to the consumers until ctx is done, the subscription is restored whenever it is lost.
+func (s *Subscriber) Run(ctx context.Context) {
+	s.listener.Listen(ctx, s.channel, handler{s})
+}
+
+// handler keeps postgresql.ListenHandler out of the methods of Subscriber.
+type handler struct {
+	s *Subscriber
+}
+
+func (h handler) Listening(ctx context.Context) {
+	h.s.log.Info("changes: subscriber: listening on %s", h.s.channel)
+
+	for _, c := range h.s.consumers {
+		c.Resync(ctx)
+	}
+}
+
+func (h handler) Notified(ctx context.Context, payload string) {
+	var change entities.Change
+
+	if err := json.Unmarshal([]byte(payload), &change); err != nil {
+		h.s.log.Error(fmt.Errorf("changes: subscriber: Notified: Unmarshal: %w", err), "%s", payload)
+		return
+	}
+
+	for _, c := range h.s.consumers {
+		c.Changed(ctx, change)
+	}
+}
+
+func (h handler) Disconnected(err error) {
+	h.s.log.Error(err, "changes: subscriber: lost %s, reconnecting", h.s.channel)
+}
@@ -0,0 +1,76 @@
+package changes_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/v1adhope/flights/internal/entities"
+	"github.com/v1adhope/flights/internal/usecases/infrastructure/changes"
+	"github.com/v1adhope/flights/pkg/postgresql"
+)
+
+// listener delivers payloads, losing the connection before every nil one.
+type listener struct {
+	payloads []*string
+}
+
+func (l listener) Listen(ctx context.Context, _ string, h postgresql.ListenHandler) {
+	h.Listening(ctx)
+
+	for _, payload := range l.payloads {
+		if payload == nil {
+			h.Disconnected(errors.New("connection lost"))
+			h.Listening(ctx)
+
+			continue
+		}
+
+		h.Notified(ctx, *payload)
+	}
+}
+
+type consumer struct {
+	changes []entities.Change
+	resyncs int
+}
+
+func (c *consumer) Changed(_ context.Context, change entities.Change) {
+	c.changes = append(c.changes, change)
+}
+
+func (c *consumer) Resync(_ context.Context) {
+	c.resyncs++
+}
+
+type nopLogger struct{}
+
+func (nopLogger) Info(string, ...any)         {}
+func (nopLogger) Error(error, string, ...any) {}
+
+func payload(s string) *string {
+	return &s
+}
+
+func TestSubscriber(t *testing.T) {
+	l := listener{[]*string{
+		payload(`{"entity":"ticket","id":"t1"}`),
+		payload(`not json`),
+		nil,
+		payload(`{"entity":"binding","passengerId":"p1","ticketId":"t1"}`),
+	}}
+	c1, c2 := &consumer{}, &consumer{}
+
+	changes.NewSubscriber(l, "flights_changes", nopLogger{}, c1, c2).Run(context.Background())
+
+	want := []entities.Change{
+		{Entity: entities.ChangeTicket, Id: "t1"},
+		{Entity: entities.ChangeBinding, PassengerId: "p1", TicketId: "t1"},
+	}
+
+	for _, c := range []*consumer{c1, c2} {
+		assert.Equal(t, want, c.changes)
+		assert.Equal(t, 2, c.resyncs, "on start and after the reconnect")
+	}
+}
@@ -0,0 +1,112 @@
+// Package memory is a usecases.Reposer kept in process memory for tests and demos,
+// it reproduces the constraints, errors and ordering of the Postgres repository.
+package memory
+
+import (
+	"context"
+	"slices"
+	"sync"
+)
+
+// Repository serializes transactions, so they behave as serializable ones never failing to serialize.
+type Repository struct {
+	mu   sync.Mutex
+	data data
+	// undo restores the rows changed by the transaction in progress, it is nil outside transactions
+	undo []func()
+	// seq orders rows the way Postgres scans a heap, an updated row moves to the end
+	seq int64
+	// outboxSeq is not rolled back, just as a Postgres sequence
+	outboxSeq int64
+}
+
+func New() *Repository {
+	return &Repository{
+		data: newData(),
+	}
+}
+
+type txKey struct{}
+
+// WithinTx runs fn holding the repository, every change of fn is undone if it fails.
+// Nested calls join the outer transaction.
+func (r *Repository) WithinTx(ctx context.Context, fn func(ctx context.Context) error) (err error) {
+	if r.inTx(ctx) {
+		return fn(ctx)
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	r.undo = []func(){}
+	committed := false
+
+	defer func() {
+		if !committed {
+			for _, undo := range slices.Backward(r.undo) {
+				undo()
+			}
+		}
+
+		r.undo = nil
+	}()
+
+	if err := fn(context.WithValue(ctx, txKey{}, r)); err != nil {
+		return err
+	}
+
+	committed = true
+
+	return nil
+}
+
+func (r *Repository) inTx(ctx context.Context) bool {
+	tx, ok := ctx.Value(txKey{}).(*Repository)
+
+	return ok && tx == r
+}
+
+// lock serializes a statement with transactions, the statements of a transaction already hold the lock.
+func (r *Repository) lock(ctx context.Context) func() {
+	if r.inTx(ctx) {
+		return func() {}
+	}
+
+	r.mu.Lock()
+
+	return r.mu.Unlock
+}
+
+// put stores the row of key in table, a transaction in progress restores the former row on rollback.
+func put[K comparable, V any](r *Repository, table map[K]V, key K, row V) {
+	remember(r, table, key)
+	table[key] = row
+}
+
+// remove deletes the row of key from table, a transaction in progress restores it on rollback.
+func remove[K comparable, V any](r *Repository, table map[K]V, key K) {
+	remember(r, table, key)
+	delete(table, key)
+}
+
+func remember[K comparable, V any](r *Repository, table map[K]V, key K) {
+	if r.undo == nil {
+		return
+	}
+
+	former, existed := table[key]
+
+	r.undo = append(r.undo, func() {
+		if existed {
+			table[key] = former
+		} else {
+			delete(table, key)
+		}
+	})
+}
+
+func (r *Repository) nextSeq() int64 {
+	r.seq++
+
+	return r.seq
+}
@@ -0,0 +1,144 @@
+package memory
+
+import (
+	"cmp"
+	"maps"
+	"slices"
+	"time"
+)
+
+// data holds the tables, rows are values and their slices are replaced, never changed in place,
+// so the undo log of a transaction keeps former rows as they were.
+type data struct {
+	tickets       map[string]ticketRow
+	passengers    map[string]passengerRow
+	documents     map[string]documentRow
+	bindings      map[binding]bindingRow
+	outbox        map[int64]eventRow
+	subscriptions map[string]subscriptionRow
+	deliveries    map[string]deliveryRow
+	idempotency   map[string]idempotencyRow
+}
+
+func newData() data {
+	return data{
+		tickets:       map[string]ticketRow{},
+		passengers:    map[string]passengerRow{},
+		documents:     map[string]documentRow{},
+		bindings:      map[binding]bindingRow{},
+		outbox:        map[int64]eventRow{},
+		subscriptions: map[string]subscriptionRow{},
+		deliveries:    map[string]deliveryRow{},
+		idempotency:   map[string]idempotencyRow{},
+	}
+}
+
+type heapRow interface {
+	heapSeq() int64
+}
+
+// row keeps the position of a row in the heap.
+type row struct {
+	seq int64
+}
+
+func (r row) heapSeq() int64 {
+	return r.seq
+}
+
+// scan returns the rows of a table in heap order, the order of Postgres queries without order by.
+func scan[K comparable, V heapRow](table map[K]V) []V {
+	rows := slices.Collect(maps.Values(table))
+
+	slices.SortFunc(rows, func(a, b V) int {
+		return cmp.Compare(a.heapSeq(), b.heapSeq())
+	})
+
+	return rows
+}
+
+type ticketRow struct {
+	row
+	id       string
+	provider string
+	flyFrom  string
+	flyTo    string
+	flyAt    time.Time
+	arriveAt time.Time
+	// createdAt is the date of issue
+	createdAt time.Time
+}
+
+type passengerRow struct {
+	row
+	id         string
+	firstName  string
+	lastName   string
+	middleName string
+}
+
+type documentRow struct {
+	row
+	id          string
+	docType     string
+	number      string
+	passengerId string
+}
+
+type binding struct {
+	ticketId    string
+	passengerId string
+}
+
+type bindingRow struct {
+	row
+	binding
+}
+
+type eventRow struct {
+	row
+	id            string
+	eventType     string
+	aggregateType string
+	aggregateId   string
+	payload       []byte
+	occurredAt    time.Time
+	publishedAt   time.Time
+	claimedUntil  time.Time
+}
+
+type subscriptionRow struct {
+	row
+	id         string
+	url        string
+	secret     string
+	eventTypes []string
+	active     bool
+	createdAt  time.Time
+}
+
+type deliveryRow struct {
+	row
+	id             string
+	subscriptionId string
+	eventId        string
+	eventType      string
+	payload        []byte
+	status         string
+	attempts       int
+	nextAttemptAt  time.Time
+	lastStatusCode int
+	lastError      string
+	createdAt      time.Time
+	deliveredAt    time.Time
+}
+
+type idempotencyRow struct {
+	row
+	key         string
+	requestHash string
+	status      int
+	location    string
+	body        []byte
+	createdAt   time.Time
+}
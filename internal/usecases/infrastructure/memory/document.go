@@ -0,0 +1,189 @@
+package memory
+
+import (
+	"context"
+	"fmt"
+	"slices"
+
+	"github.com/v1adhope/flights/internal/entities"
+)
+
+func newDocumentRow(document entities.Document) (documentRow, error) {
+	id, err := parseUuid(document.Id)
+	if err != nil {
+		return documentRow{}, err
+	}
+
+	passengerId, err := parseUuid(document.PassengerId)
+	if err != nil {
+		return documentRow{}, err
+	}
+
+	if err := varchar(255, document.Type, document.Number); err != nil {
+		return documentRow{}, err
+	}
+
+	return documentRow{
+		id:          id,
+		docType:     document.Type,
+		number:      document.Number,
+		passengerId: passengerId,
+	}, nil
+}
+
+// addDocuments checks the constraints of documents in the order Postgres does, unique ones first.
+// A replaced document skips the checks against its old row.
+func (r *Repository) addDocuments(replaced bool, rows ...documentRow) error {
+	ids := map[string]struct{}{}
+	numbers := map[[2]string]struct{}{}
+
+	for _, row := range rows {
+		if _, ok := r.data.documents[row.id]; ok && !replaced {
+			return violation("pk_documents_document_id", entities.ErrorHasAlreadyExists)
+		}
+		if _, ok := ids[row.id]; ok {
+			return violation("pk_documents_document_id", entities.ErrorHasAlreadyExists)
+		}
+
+		ids[row.id] = struct{}{}
+	}
+
+	for _, row := range rows {
+		number := [2]string{row.docType, row.number}
+
+		_, repeated := numbers[number]
+
+		exists := false
+		for _, d := range r.data.documents {
+			if d.id != row.id && d.docType == row.docType && d.number == row.number {
+				exists = true
+			}
+		}
+
+		if exists || repeated {
+			return violation("uq_documents_type_number", entities.ErrorHasAlreadyExists.WithDetails(map[string]any{
+				"fields": []string{"type", "number"},
+			}))
+		}
+
+		numbers[number] = struct{}{}
+	}
+
+	for _, row := range rows {
+		if _, ok := r.data.passengers[row.passengerId]; !ok {
+			return violation("fk_document_passenger_passenger_id", entities.ErrorPassengerDoesNotExists)
+		}
+	}
+
+	for _, row := range rows {
+		row.seq = r.nextSeq()
+		put(r, r.data.documents, row.id, row)
+	}
+
+	return nil
+}
+
+func (r *Repository) CreateDocument(ctx context.Context, document entities.Document) error {
+	defer r.lock(ctx)()
+
+	row, err := newDocumentRow(document)
+	if err != nil {
+		return fmt.Errorf("memory: document: CreateDocument: %w", err)
+	}
+
+	if err := r.addDocuments(false, row); err != nil {
+		return fmt.Errorf("memory: document: CreateDocument: %w", err)
+	}
+
+	return nil
+}
+
+func (r *Repository) ReplaceDocument(ctx context.Context, document entities.Document) error {
+	defer r.lock(ctx)()
+
+	row, err := newDocumentRow(document)
+	if err != nil {
+		return fmt.Errorf("memory: document: ReplaceDocument: %w", err)
+	}
+
+	if _, ok := r.data.documents[row.id]; !ok {
+		return fmt.Errorf("memory: document: ReplaceDocument: %w", entities.ErrorNothingToChange)
+	}
+
+	if err := r.addDocuments(true, row); err != nil {
+		return fmt.Errorf("memory: document: ReplaceDocument: %w", err)
+	}
+
+	return nil
+}
+
+func (r *Repository) DeleteDocument(ctx context.Context, id entities.Id) error {
+	defer r.lock(ctx)()
+
+	documentId, err := parseUuid(id.Value)
+	if err != nil {
+		return fmt.Errorf("memory: document: DeleteDocument: %w", err)
+	}
+
+	if _, ok := r.data.documents[documentId]; !ok {
+		return fmt.Errorf("memory: document: DeleteDocument: %w", entities.ErrorNothingToDelete)
+	}
+
+	remove(r, r.data.documents, documentId)
+
+	return nil
+}
+
+// GetDocumentsByPassengerId leaves PassengerId empty as the Postgres repository does.
+func (r *Repository) GetDocumentsByPassengerId(ctx context.Context, id entities.Id) ([]entities.Document, error) {
+	defer r.lock(ctx)()
+
+	passengerId, err := parseUuid(id.Value)
+	if err != nil {
+		return []entities.Document{}, fmt.Errorf("memory: document: GetDocumentsByPassengerId: %w", err)
+	}
+
+	documents := []entities.Document{}
+	for _, row := range scan(r.data.documents) {
+		if row.passengerId == passengerId {
+			document := row.toEntity()
+			document.PassengerId = ""
+
+			documents = append(documents, document)
+		}
+	}
+
+	if len(documents) == 0 {
+		return []entities.Document{}, fmt.Errorf("memory: document: GetDocumentsByPassengerId: len: %w", entities.ErrorNothingFound)
+	}
+
+	return documents, nil
+}
+
+// GetDocumentsByPassengerIds is a batch read for loaders, the result is keyed by passenger id.
+func (r *Repository) GetDocumentsByPassengerIds(ctx context.Context, ids []string) (map[string][]entities.Document, error) {
+	defer r.lock(ctx)()
+
+	passengerIds, err := parseUuids(ids)
+	if err != nil {
+		return map[string][]entities.Document{}, fmt.Errorf("memory: document: GetDocumentsByPassengerIds: %w", err)
+	}
+
+	documentsByPassenger := map[string][]entities.Document{}
+	for _, row := range scan(r.data.documents) {
+		if slices.Contains(passengerIds, row.passengerId) {
+			documentsByPassenger[row.passengerId] = append(documentsByPassenger[row.passengerId], row.toEntity())
+		}
+	}
+
+	return documentsByPassenger, nil
+}
+
+func (d documentRow) toEntity() entities.Document {
+	return entities.Document{
+		Id:          d.id,
+		Type:        d.docType,
+		Number:      d.number,
+		PassengerId: d.passengerId,
+	}
+}
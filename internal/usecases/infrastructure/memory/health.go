@@ -0,0 +1,23 @@
+package memory
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/v1adhope/flights/db"
+	"github.com/v1adhope/flights/pkg/migrator"
+)
+
+func (r *Repository) Ping(ctx context.Context) error {
+	return nil
+}
+
+// GetMigrationVersion reports the latest migration, the repository always has the current schema.
+func (r *Repository) GetMigrationVersion(ctx context.Context) (uint, bool, error) {
+	version, err := migrator.Latest(db.Migrations)
+	if err != nil {
+		return 0, false, fmt.Errorf("memory: health: GetMigrationVersion: %w", err)
+	}
+
+	return version, false, nil
+}
@@ -0,0 +1,137 @@
+package memory
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"time"
+
+	"github.com/v1adhope/flights/internal/entities"
+)
+
+func (r *Repository) GetIdempotentResponse(ctx context.Context, key string) (entities.IdempotentResponse, error) {
+	defer r.lock(ctx)()
+
+	row, ok := r.data.idempotency[key]
+	if !ok {
+		return entities.IdempotentResponse{}, fmt.Errorf("memory: idempotency: GetIdempotentResponse: %w", entities.ErrorNothingFound)
+	}
+
+	return entities.IdempotentResponse{
+		Key:         row.key,
+		RequestHash: row.requestHash,
+		Status:      row.status,
+		Location:    row.location,
+		Body:        slices.Clone(row.body),
+		CreatedAt:   row.createdAt.Local().Format(time.RFC3339),
+	}, nil
+}
+
+// CreateIdempotentResponse reserves the key, a second reservation fails with ErrorHasAlreadyExists.
+func (r *Repository) CreateIdempotentResponse(ctx context.Context, resp entities.IdempotentResponse) error {
+	defer r.lock(ctx)()
+
+	if err := varchar(255, resp.Key); err != nil {
+		return fmt.Errorf("memory: idempotency: CreateIdempotentResponse: %w", err)
+	}
+
+	if err := varchar(64, resp.RequestHash); err != nil {
+		return fmt.Errorf("memory: idempotency: CreateIdempotentResponse: %w", err)
+	}
+
+	if err := varchar(2048, resp.Location); err != nil {
+		return fmt.Errorf("memory: idempotency: CreateIdempotentResponse: %w", err)
+	}
+
+	createdAt, err := parseTimestamptz(resp.CreatedAt)
+	if err != nil {
+		return fmt.Errorf("memory: idempotency: CreateIdempotentResponse: %w", err)
+	}
+
+	if _, ok := r.data.idempotency[resp.Key]; ok {
+		return fmt.Errorf("memory: idempotency: CreateIdempotentResponse: %w", violation("pk_idempotency_keys_idempotency_key", entities.ErrorHasAlreadyExists))
+	}
+
+	put(r, r.data.idempotency, resp.Key, idempotencyRow{
+		row:         row{r.nextSeq()},
+		key:         resp.Key,
+		requestHash: resp.RequestHash,
+		status:      resp.Status,
+		location:    resp.Location,
+		body:        append([]byte{}, resp.Body...),
+		createdAt:   createdAt,
+	})
+
+	return nil
+}
+
+func (r *Repository) ReplaceIdempotentResponse(ctx context.Context, resp entities.IdempotentResponse) error {
+	defer r.lock(ctx)()
+
+	if err := varchar(2048, resp.Location); err != nil {
+		return fmt.Errorf("memory: idempotency: ReplaceIdempotentResponse: %w", err)
+	}
+
+	old, ok := r.data.idempotency[resp.Key]
+	if !ok {
+		return fmt.Errorf("memory: idempotency: ReplaceIdempotentResponse: %w", entities.ErrorNothingToChange)
+	}
+
+	old.status = resp.Status
+	old.location = resp.Location
+	old.body = append([]byte{}, resp.Body...)
+	old.seq = r.nextSeq()
+	put(r, r.data.idempotency, resp.Key, old)
+
+	return nil
+}
+
+func (r *Repository) TakeOverIdempotentResponse(ctx context.Context, resp entities.IdempotentResponse, startedBefore string) error {
+	defer r.lock(ctx)()
+
+	createdAt, err := parseTimestamptz(resp.CreatedAt)
+	if err != nil {
+		return fmt.Errorf("memory: idempotency: TakeOverIdempotentResponse: %w", err)
+	}
+
+	before, err := parseTimestamptz(startedBefore)
+	if err != nil {
+		return fmt.Errorf("memory: idempotency: TakeOverIdempotentResponse: %w", err)
+	}
+
+	old, ok := r.data.idempotency[resp.Key]
+	if !ok || old.status != 0 || !old.createdAt.Before(before) {
+		return fmt.Errorf("memory: idempotency: TakeOverIdempotentResponse: %w", entities.ErrorNothingToChange)
+	}
+
+	old.createdAt = createdAt
+	old.seq = r.nextSeq()
+	put(r, r.data.idempotency, resp.Key, old)
+
+	return nil
+}
+
+func (r *Repository) DeleteIdempotentResponse(ctx context.Context, key string) error {
+	defer r.lock(ctx)()
+
+	remove(r, r.data.idempotency, key)
+
+	return nil
+}
+
+func (r *Repository) DeleteIdempotentResponses(ctx context.Context, olderThan string) error {
+	defer r.lock(ctx)()
+
+	before, err := parseTimestamptz(olderThan)
+	if err != nil {
+		return fmt.Errorf("memory: idempotency: DeleteIdempotentResponses: %w", err)
+	}
+
+	for key, row := range r.data.idempotency {
+		if row.createdAt.Before(before) {
+			remove(r, r.data.idempotency, key)
+		}
+	}
+
+	return nil
+}
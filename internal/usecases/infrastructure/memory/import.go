@@ -0,0 +1,119 @@
+package memory
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/v1adhope/flights/internal/entities"
+)
+
+// The Copy methods are single statements, nothing is written when a row fails.
+
+func (r *Repository) CopyTickets(ctx context.Context, tickets []entities.Ticket) error {
+	defer r.lock(ctx)()
+
+	rows := make([]ticketRow, 0, len(tickets))
+	ids := map[string]struct{}{}
+
+	for _, ticket := range tickets {
+		row, err := newTicketRow(ticket)
+		if err != nil {
+			return fmt.Errorf("memory: import: CopyTickets: %w", err)
+		}
+
+		row.createdAt, err = parseTimestamptz(ticket.CreatedAt)
+		if err != nil {
+			return fmt.Errorf("memory: import: CopyTickets: %w", err)
+		}
+
+		_, exists := r.data.tickets[row.id]
+		_, repeated := ids[row.id]
+
+		if exists || repeated {
+			return fmt.Errorf("memory: import: CopyTickets: %w", violation("pk_tickets_ticket_id", entities.ErrorHasAlreadyExists))
+		}
+
+		ids[row.id] = struct{}{}
+		rows = append(rows, row)
+	}
+
+	for _, row := range rows {
+		row.seq = r.nextSeq()
+		put(r, r.data.tickets, row.id, row)
+	}
+
+	return nil
+}
+
+func (r *Repository) CopyPassengers(ctx context.Context, passengers []entities.Passenger) error {
+	defer r.lock(ctx)()
+
+	rows := make([]passengerRow, 0, len(passengers))
+	ids := map[string]struct{}{}
+
+	for _, passenger := range passengers {
+		row, err := newPassengerRow(passenger)
+		if err != nil {
+			return fmt.Errorf("memory: import: CopyPassengers: %w", err)
+		}
+
+		_, exists := r.data.passengers[row.id]
+		_, repeated := ids[row.id]
+
+		if exists || repeated {
+			return fmt.Errorf("memory: import: CopyPassengers: %w", violation("pk_passengers_passenger_id", entities.ErrorHasAlreadyExists))
+		}
+
+		ids[row.id] = struct{}{}
+		rows = append(rows, row)
+	}
+
+	for _, row := range rows {
+		row.seq = r.nextSeq()
+		put(r, r.data.passengers, row.id, row)
+	}
+
+	return nil
+}
+
+func (r *Repository) CopyDocuments(ctx context.Context, documents []entities.Document) error {
+	defer r.lock(ctx)()
+
+	rows := make([]documentRow, 0, len(documents))
+
+	for _, document := range documents {
+		row, err := newDocumentRow(document)
+		if err != nil {
+			return fmt.Errorf("memory: import: CopyDocuments: %w", err)
+		}
+
+		rows = append(rows, row)
+	}
+
+	if err := r.addDocuments(false, rows...); err != nil {
+		return fmt.Errorf("memory: import: CopyDocuments: %w", err)
+	}
+
+	return nil
+}
+
+func (r *Repository) CopyBindings(ctx context.Context, bindings []entities.Binding) error {
+	defer r.lock(ctx)()
+
+	rows := make([]binding, 0, len(bindings))
+
+	for _, b := range bindings {
+		row, err := newBinding(b.PassengerId, b.TicketId)
+		if err != nil {
+			return fmt.Errorf("memory: import: CopyBindings: %w", err)
+		}
+
+		rows = append(rows, row)
+	}
+
+	if err := r.bind(rows...); err != nil {
+		return fmt.Errorf("memory: import: CopyBindings: %w", err)
+	}
+
+	return nil
+}
@@ -0,0 +1,15 @@
+package memory_test
+
+import (
+	"testing"
+
+	"github.com/v1adhope/flights/internal/testhelpers"
+	"github.com/v1adhope/flights/internal/usecases"
+	"github.com/v1adhope/flights/internal/usecases/infrastructure/memory"
+)
+
+func TestReposer(t *testing.T) {
+	testhelpers.RunReposerSuite(t, func(t *testing.T) usecases.Reposer {
+		return memory.New()
+	})
+}
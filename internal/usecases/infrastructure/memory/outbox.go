@@ -0,0 +1,179 @@
+package memory
+
+import (
+	"cmp"
+	"context"
+	"fmt"
+	"slices"
+	"time"
+
+	"github.com/v1adhope/flights/internal/entities"
+)
+
+// AddEvents keeps payloads as written, Postgres normalizes jsonb.
+func (r *Repository) AddEvents(ctx context.Context, events ...entities.Event) error {
+	defer r.lock(ctx)()
+
+	rows := make([]eventRow, 0, len(events))
+	ids := map[string]struct{}{}
+
+	for _, event := range events {
+		id, err := parseUuid(event.Id)
+		if err != nil {
+			return fmt.Errorf("memory: outbox: AddEvents: %w", err)
+		}
+
+		aggregateId, err := parseUuid(event.AggregateId)
+		if err != nil {
+			return fmt.Errorf("memory: outbox: AddEvents: %w", err)
+		}
+
+		if err := varchar(255, event.Type, event.AggregateType); err != nil {
+			return fmt.Errorf("memory: outbox: AddEvents: %w", err)
+		}
+
+		if err := checkJson(event.Payload); err != nil {
+			return fmt.Errorf("memory: outbox: AddEvents: %w", err)
+		}
+
+		occurredAt, err := parseTimestamptz(event.OccurredAt)
+		if err != nil {
+			return fmt.Errorf("memory: outbox: AddEvents: %w", err)
+		}
+
+		_, repeated := ids[id]
+		exists := false
+		for _, row := range r.data.outbox {
+			exists = exists || row.id == id
+		}
+
+		if exists || repeated {
+			return fmt.Errorf("memory: outbox: AddEvents: %w", violation("uq_outbox_event_id", entities.ErrorHasAlreadyExists))
+		}
+
+		ids[id] = struct{}{}
+		rows = append(rows, eventRow{
+			id:            id,
+			eventType:     event.Type,
+			aggregateType: event.AggregateType,
+			aggregateId:   aggregateId,
+			payload:       slices.Clone(event.Payload),
+			occurredAt:    occurredAt,
+		})
+	}
+
+	for _, row := range rows {
+		r.outboxSeq++
+		row.seq = r.outboxSeq
+		put(r, r.data.outbox, row.seq, row)
+	}
+
+	return nil
+}
+
+// ClaimUnpublishedEvents holds up to limit unpublished events for lease and returns them in outbox order.
+// Claimed events are claimed again once the lease is over, so events not marked published in time are delivered again.
+func (r *Repository) ClaimUnpublishedEvents(ctx context.Context, limit uint64, lease time.Duration) ([]entities.Event, error) {
+	defer r.lock(ctx)()
+
+	now := time.Now()
+	rows := scan(r.data.outbox)
+
+	held := map[string]struct{}{}
+	for _, row := range rows {
+		if row.publishedAt.IsZero() && row.claimedUntil.After(now) {
+			held[row.aggregateType+":"+row.aggregateId] = struct{}{}
+		}
+	}
+
+	// The first events of every aggregate come first, so aggregates with many events can't fill the batch
+	claimable := []eventRow{}
+	positions := map[int64]int{}
+	counts := map[string]int{}
+
+	// Events are numbered by the sequence, so their heap order is seq
+	for _, row := range rows {
+		key := row.aggregateType + ":" + row.aggregateId
+
+		if _, ok := held[key]; ok || !row.publishedAt.IsZero() {
+			continue
+		}
+
+		counts[key]++
+		positions[row.seq] = counts[key]
+		claimable = append(claimable, row)
+	}
+
+	slices.SortStableFunc(claimable, func(a, b eventRow) int {
+		return cmp.Compare(positions[a.seq], positions[b.seq])
+	})
+
+	claimed := claimable[:min(uint64(len(claimable)), limit)]
+
+	slices.SortFunc(claimed, func(a, b eventRow) int {
+		return cmp.Compare(a.seq, b.seq)
+	})
+
+	events := make([]entities.Event, 0, len(claimed))
+
+	for _, row := range claimed {
+		row.claimedUntil = now.Add(lease)
+		put(r, r.data.outbox, row.seq, row)
+
+		events = append(events, entities.Event{
+			Id:            row.id,
+			Seq:           row.seq,
+			Type:          row.eventType,
+			AggregateType: row.aggregateType,
+			AggregateId:   row.aggregateId,
+			Payload:       slices.Clone(row.payload),
+			OccurredAt:    row.occurredAt.UTC().Format(time.RFC3339Nano),
+		})
+	}
+
+	return events, nil
+}
+
+func (r *Repository) MarkEventsPublished(ctx context.Context, seqs []int64) error {
+	defer r.lock(ctx)()
+
+	now := time.Now()
+
+	for _, seq := range seqs {
+		if row, ok := r.data.outbox[seq]; ok {
+			row.publishedAt = now
+			put(r, r.data.outbox, seq, row)
+		}
+	}
+
+	return nil
+}
+
+// ReplayEvents makes already published events with seq >= fromSeq unpublished again, optionally for one aggregate only.
+func (r *Repository) ReplayEvents(ctx context.Context, fromSeq int64, aggregateId string) (int64, error) {
+	defer r.lock(ctx)()
+
+	if aggregateId != "" {
+		id, err := parseUuid(aggregateId)
+		if err != nil {
+			return 0, fmt.Errorf("memory: outbox: ReplayEvents: %w", err)
+		}
+
+		aggregateId = id
+	}
+
+	replayed := int64(0)
+
+	for seq, row := range r.data.outbox {
+		if seq < fromSeq || row.publishedAt.IsZero() || aggregateId != "" && row.aggregateId != aggregateId {
+			continue
+		}
+
+		row.publishedAt = time.Time{}
+		row.claimedUntil = time.Time{}
+		put(r, r.data.outbox, seq, row)
+		replayed++
+	}
+
+	return replayed, nil
+}
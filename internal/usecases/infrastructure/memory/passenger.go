@@ -0,0 +1,275 @@
+package memory
+
+import (
+	"context"
+	"fmt"
+	"slices"
+
+	"github.com/v1adhope/flights/internal/entities"
+)
+
+func newPassengerRow(passenger entities.Passenger) (passengerRow, error) {
+	id, err := parseUuid(passenger.Id)
+	if err != nil {
+		return passengerRow{}, err
+	}
+
+	if err := varchar(255, passenger.FirstName, passenger.LastName, passenger.MiddleName); err != nil {
+		return passengerRow{}, err
+	}
+
+	return passengerRow{
+		id:         id,
+		firstName:  passenger.FirstName,
+		lastName:   passenger.LastName,
+		middleName: passenger.MiddleName,
+	}, nil
+}
+
+func (p passengerRow) toEntity() entities.Passenger {
+	return entities.Passenger{
+		Id:         p.id,
+		FirstName:  p.firstName,
+		LastName:   p.lastName,
+		MiddleName: p.middleName,
+	}
+}
+
+func (r *Repository) CreatePassenger(ctx context.Context, passenger entities.Passenger) error {
+	defer r.lock(ctx)()
+
+	row, err := newPassengerRow(passenger)
+	if err != nil {
+		return fmt.Errorf("memory: passenger: CreatePassenger: %w", err)
+	}
+
+	if _, ok := r.data.passengers[row.id]; ok {
+		return fmt.Errorf("memory: passenger: CreatePassenger: %w", violation("pk_passengers_passenger_id", entities.ErrorHasAlreadyExists))
+	}
+
+	row.seq = r.nextSeq()
+	put(r, r.data.passengers, row.id, row)
+
+	return nil
+}
+
+func (r *Repository) ReplacePassenger(ctx context.Context, passenger entities.Passenger) error {
+	defer r.lock(ctx)()
+
+	row, err := newPassengerRow(passenger)
+	if err != nil {
+		return fmt.Errorf("memory: passenger: ReplacePassenger: %w", err)
+	}
+
+	if _, ok := r.data.passengers[row.id]; !ok {
+		return fmt.Errorf("memory: passenger: ReplacePassenger: %w", entities.ErrorNothingToChange)
+	}
+
+	row.seq = r.nextSeq()
+	put(r, r.data.passengers, row.id, row)
+
+	return nil
+}
+
+// DeletePassenger deletes the bindings of the passenger too, documents have to be deleted first.
+func (r *Repository) DeletePassenger(ctx context.Context, id entities.Id) error {
+	defer r.lock(ctx)()
+
+	passengerId, err := parseUuid(id.Value)
+	if err != nil {
+		return fmt.Errorf("memory: passenger: DeletePassenger: %w", err)
+	}
+
+	if _, ok := r.data.passengers[passengerId]; !ok {
+		return fmt.Errorf("memory: passenger: DeletePassenger: %w", entities.ErrorNothingToDelete)
+	}
+
+	for _, document := range r.data.documents {
+		if document.passengerId == passengerId {
+			return fmt.Errorf("memory: passenger: DeletePassenger: %w", violation("fk_document_passenger_passenger_id", entities.ErrorPassengerHasDocuments))
+		}
+	}
+
+	for b := range r.data.bindings {
+		if b.passengerId == passengerId {
+			remove(r, r.data.bindings, b)
+		}
+	}
+
+	remove(r, r.data.passengers, passengerId)
+
+	return nil
+}
+
+func (r *Repository) GetPassengers(ctx context.Context) ([]entities.Passenger, error) {
+	defer r.lock(ctx)()
+
+	passengers := []entities.Passenger{}
+	for _, row := range scan(r.data.passengers) {
+		passengers = append(passengers, row.toEntity())
+	}
+
+	if len(passengers) == 0 {
+		return []entities.Passenger{}, fmt.Errorf("memory: passenger: GetPassengers: len: %w", entities.ErrorNothingFound)
+	}
+
+	return passengers, nil
+}
+
+// bind checks the constraints of passenger_ticket in the order Postgres does, unique ones first.
+func (r *Repository) bind(bindings ...binding) error {
+	batch := map[binding]struct{}{}
+
+	for _, b := range bindings {
+		_, exists := r.data.bindings[b]
+		_, repeated := batch[b]
+
+		if exists || repeated {
+			return violation("pk_ticket_passenger_ticket_id_passenger_id", entities.ErrorHasAlreadyExists)
+		}
+
+		batch[b] = struct{}{}
+	}
+
+	for _, b := range bindings {
+		if _, ok := r.data.passengers[b.passengerId]; !ok {
+			return violation("fk_ticket_passenger_passenger_passenger_id", entities.ErrorPassengerDoesNotExists)
+		}
+
+		if _, ok := r.data.tickets[b.ticketId]; !ok {
+			return violation("fk_ticket_passenger_tickets_ticket_id", entities.ErrorTicketDoesNotExists)
+		}
+	}
+
+	for _, b := range bindings {
+		put(r, r.data.bindings, b, bindingRow{row{r.nextSeq()}, b})
+	}
+
+	return nil
+}
+
+func newBinding(passengerId, ticketId string) (binding, error) {
+	passengerId, err := parseUuid(passengerId)
+	if err != nil {
+		return binding{}, err
+	}
+
+	ticketId, err = parseUuid(ticketId)
+	if err != nil {
+		return binding{}, err
+	}
+
+	return binding{
+		ticketId:    ticketId,
+		passengerId: passengerId,
+	}, nil
+}
+
+func (r *Repository) BoundToTicket(ctx context.Context, id entities.Id, ticketId entities.Id) error {
+	defer r.lock(ctx)()
+
+	b, err := newBinding(id.Value, ticketId.Value)
+	if err != nil {
+		return fmt.Errorf("memory: passenger: BoundToTicket: %w", err)
+	}
+
+	if err := r.bind(b); err != nil {
+		return fmt.Errorf("memory: passenger: BoundToTicket: %w", err)
+	}
+
+	return nil
+}
+
+func (r *Repository) UnboundToTicket(ctx context.Context, id entities.Id, ticketId entities.Id) error {
+	defer r.lock(ctx)()
+
+	b, err := newBinding(id.Value, ticketId.Value)
+	if err != nil {
+		return fmt.Errorf("memory: passenger: UnboundToTicket: %w", err)
+	}
+
+	if _, ok := r.data.bindings[b]; !ok {
+		return fmt.Errorf("memory: passenger: UnboundToTicket: %w", entities.ErrorNothingToDelete)
+	}
+
+	remove(r, r.data.bindings, b)
+
+	return nil
+}
+
+func (r *Repository) GetPassengersByTicketId(ctx context.Context, id entities.Id) ([]entities.Passenger, error) {
+	defer r.lock(ctx)()
+
+	ticketId, err := parseUuid(id.Value)
+	if err != nil {
+		return []entities.Passenger{}, fmt.Errorf("memory: passenger: GetPassengersByTicketId: %w", err)
+	}
+
+	passengers := []entities.Passenger{}
+	for _, b := range scan(r.data.bindings) {
+		if b.ticketId == ticketId {
+			passengers = append(passengers, r.data.passengers[b.passengerId].toEntity())
+		}
+	}
+
+	if len(passengers) == 0 {
+		return []entities.Passenger{}, fmt.Errorf("memory: passenger: GetPassengersByTicketId: len: %w", entities.ErrorNothingFound)
+	}
+
+	return passengers, nil
+}
+
+// GetPassengersByIds is a batch read for loaders, missing passengers are just absent from the result.
+func (r *Repository) GetPassengersByIds(ctx context.Context, ids []string) ([]entities.Passenger, error) {
+	defer r.lock(ctx)()
+
+	passengerIds, err := parseUuids(ids)
+	if err != nil {
+		return []entities.Passenger{}, fmt.Errorf("memory: passenger: GetPassengersByIds: %w", err)
+	}
+
+	passengers := []entities.Passenger{}
+	for _, row := range scan(r.data.passengers) {
+		if slices.Contains(passengerIds, row.id) {
+			passengers = append(passengers, row.toEntity())
+		}
+	}
+
+	return passengers, nil
+}
+
+// GetPassengersByTicketIds is a batch read for loaders, the result is keyed by ticket id.
+func (r *Repository) GetPassengersByTicketIds(ctx context.Context, ids []string) (map[string][]entities.Passenger, error) {
+	defer r.lock(ctx)()
+
+	ticketIds, err := parseUuids(ids)
+	if err != nil {
+		return map[string][]entities.Passenger{}, fmt.Errorf("memory: passenger: GetPassengersByTicketIds: %w", err)
+	}
+
+	passengersByTicket := map[string][]entities.Passenger{}
+	for _, b := range scan(r.data.bindings) {
+		if slices.Contains(ticketIds, b.ticketId) {
+			passengersByTicket[b.ticketId] = append(passengersByTicket[b.ticketId], r.data.passengers[b.passengerId].toEntity())
+		}
+	}
+
+	return passengersByTicket, nil
+}
+
+// LockPassenger reads the passenger, transactions hold the lock of the whole storage anyway.
+func (r *Repository) LockPassenger(ctx context.Context, id entities.Id) (entities.Passenger, error) {
+	defer r.lock(ctx)()
+
+	passengerId, err := parseUuid(id.Value)
+	if err != nil {
+		return entities.Passenger{}, fmt.Errorf("memory: passenger: LockPassenger: %w", err)
+	}
+
+	row, ok := r.data.passengers[passengerId]
+	if !ok {
+		return entities.Passenger{}, fmt.Errorf("memory: passenger: LockPassenger: %w", entities.ErrorNothingFound)
+	}
+
+	return row.toEntity(), nil
+}
@@ -0,0 +1,69 @@
+package memory
+
+import (
+	"cmp"
+	"context"
+	"fmt"
+	"slices"
+
+	"github.com/v1adhope/flights/internal/entities"
+)
+
+// GetRowsByPassengerIdForPeriod reports tickets issued up to filter.To, which arrived by then as provided,
+// and tickets issued within the period arriving later as not provided yet.
+func (r *Repository) GetRowsByPassengerIdForPeriod(ctx context.Context, id entities.Id, filter entities.PeriodFilter) ([]entities.ReportRowByPassengerForPeriod, error) {
+	defer r.lock(ctx)()
+
+	passengerId, err := parseUuid(id.Value)
+	if err != nil {
+		return []entities.ReportRowByPassengerForPeriod{}, fmt.Errorf("memory: report: GetRowsByPassengerIdForPeriod: %w", err)
+	}
+
+	from, err := parseTimestamptz(filter.From)
+	if err != nil {
+		return []entities.ReportRowByPassengerForPeriod{}, fmt.Errorf("memory: report: GetRowsByPassengerIdForPeriod: %w", err)
+	}
+
+	to, err := parseTimestamptz(filter.To)
+	if err != nil {
+		return []entities.ReportRowByPassengerForPeriod{}, fmt.Errorf("memory: report: GetRowsByPassengerIdForPeriod: %w", err)
+	}
+
+	tickets := []ticketRow{}
+	for b := range r.data.bindings {
+		if b.passengerId == passengerId {
+			tickets = append(tickets, r.data.tickets[b.ticketId])
+		}
+	}
+
+	// Postgres returns the union in no particular order
+	slices.SortFunc(tickets, func(a, b ticketRow) int {
+		return cmp.Or(a.createdAt.Compare(b.createdAt), cmp.Compare(a.id, b.id))
+	})
+
+	reportRows := []entities.ReportRowByPassengerForPeriod{}
+
+	for _, ticket := range tickets {
+		provided := !ticket.createdAt.After(to) && !ticket.arriveAt.After(to)
+		pending := !ticket.createdAt.Before(from) && !ticket.createdAt.After(to) && ticket.arriveAt.After(to)
+
+		if !provided && !pending {
+			continue
+		}
+
+		reportRows = append(reportRows, entities.ReportRowByPassengerForPeriod{
+			DateOfIssue:     formatTimestamptz(ticket.createdAt),
+			FlyAt:           formatTimestamptz(ticket.flyAt),
+			TicketId:        ticket.id,
+			FlyFrom:         ticket.flyFrom,
+			FlyTo:           ticket.flyTo,
+			ServiceProvided: provided,
+		})
+	}
+
+	if len(reportRows) == 0 {
+		return []entities.ReportRowByPassengerForPeriod{}, fmt.Errorf("memory: report: GetRowsByPassengerIdForPeriod: len: %w", entities.ErrorNothingFound)
+	}
+
+	return reportRows, nil
+}
@@ -0,0 +1,299 @@
+package memory
+
+import (
+	"cmp"
+	"context"
+	"fmt"
+	"slices"
+
+	"github.com/v1adhope/flights/internal/entities"
+)
+
+// newTicketRow reads every column but created_at, which is never replaced.
+func newTicketRow(ticket entities.Ticket) (ticketRow, error) {
+	id, err := parseUuid(ticket.Id)
+	if err != nil {
+		return ticketRow{}, err
+	}
+
+	if err := varchar(255, ticket.Provider, ticket.FlyFrom, ticket.FlyTo); err != nil {
+		return ticketRow{}, err
+	}
+
+	flyAt, err := parseTimestamptz(ticket.FlyAt)
+	if err != nil {
+		return ticketRow{}, err
+	}
+
+	arriveAt, err := parseTimestamptz(ticket.ArriveAt)
+	if err != nil {
+		return ticketRow{}, err
+	}
+
+	return ticketRow{
+		id:       id,
+		provider: ticket.Provider,
+		flyFrom:  ticket.FlyFrom,
+		flyTo:    ticket.FlyTo,
+		flyAt:    flyAt,
+		arriveAt: arriveAt,
+	}, nil
+}
+
+func (t ticketRow) toEntity() entities.Ticket {
+	return entities.Ticket{
+		Id:        t.id,
+		Provider:  t.provider,
+		FlyFrom:   t.flyFrom,
+		FlyTo:     t.flyTo,
+		FlyAt:     formatTimestamptz(t.flyAt),
+		ArriveAt:  formatTimestamptz(t.arriveAt),
+		CreatedAt: formatTimestamptz(t.createdAt),
+	}
+}
+
+func (r *Repository) CreateTicket(ctx context.Context, ticket entities.Ticket) error {
+	defer r.lock(ctx)()
+
+	row, err := newTicketRow(ticket)
+	if err != nil {
+		return fmt.Errorf("memory: ticket: CreateTicket: %w", err)
+	}
+
+	row.createdAt, err = parseTimestamptz(ticket.CreatedAt)
+	if err != nil {
+		return fmt.Errorf("memory: ticket: CreateTicket: %w", err)
+	}
+
+	if _, ok := r.data.tickets[row.id]; ok {
+		return fmt.Errorf("memory: ticket: CreateTicket: %w", violation("pk_tickets_ticket_id", entities.ErrorHasAlreadyExists))
+	}
+
+	row.seq = r.nextSeq()
+	put(r, r.data.tickets, row.id, row)
+
+	return nil
+}
+
+func (r *Repository) ReplaceTicket(ctx context.Context, ticket entities.Ticket) error {
+	defer r.lock(ctx)()
+
+	id, err := parseUuid(ticket.Id)
+	if err != nil {
+		return fmt.Errorf("memory: ticket: ReplaceTicket: %w", err)
+	}
+
+	old, ok := r.data.tickets[id]
+	if !ok {
+		return fmt.Errorf("memory: ticket: ReplaceTicket: %w", entities.ErrorNothingToChange)
+	}
+
+	row, err := newTicketRow(ticket)
+	if err != nil {
+		return fmt.Errorf("memory: ticket: ReplaceTicket: %w", err)
+	}
+
+	row.createdAt = old.createdAt
+	row.seq = r.nextSeq()
+	put(r, r.data.tickets, id, row)
+
+	return nil
+}
+
+func (r *Repository) DeleteTicket(ctx context.Context, id entities.Id) error {
+	defer r.lock(ctx)()
+
+	ticketId, err := parseUuid(id.Value)
+	if err != nil {
+		return fmt.Errorf("memory: ticket: DeleteTicket: %w", err)
+	}
+
+	if _, ok := r.data.tickets[ticketId]; !ok {
+		return fmt.Errorf("memory: ticket: DeleteTicket: %w", entities.ErrorNothingToDelete)
+	}
+
+	for b := range r.data.bindings {
+		if b.ticketId == ticketId {
+			return fmt.Errorf("memory: ticket: DeleteTicket: %w", violation("fk_ticket_passenger_tickets_ticket_id", entities.ErrorsThereArePassengersOnTheFlight))
+		}
+	}
+
+	remove(r, r.data.tickets, ticketId)
+
+	return nil
+}
+
+func (r *Repository) GetTickets(ctx context.Context) ([]entities.Ticket, error) {
+	defer r.lock(ctx)()
+
+	tickets := []entities.Ticket{}
+	for _, row := range scan(r.data.tickets) {
+		tickets = append(tickets, row.toEntity())
+	}
+
+	if len(tickets) == 0 {
+		return []entities.Ticket{}, fmt.Errorf("memory: ticket: GetTickets: len: %w", entities.ErrorNothingFound)
+	}
+
+	return tickets, nil
+}
+
+// GetTicketsPage pages tickets by id, the canonical text of UUIDs sorts as their bytes do in Postgres.
+func (r *Repository) GetTicketsPage(ctx context.Context, page entities.Page) ([]entities.Ticket, error) {
+	defer r.lock(ctx)()
+
+	after := ""
+	if page.After != "" {
+		id, err := parseUuid(page.After)
+		if err != nil {
+			return []entities.Ticket{}, fmt.Errorf("memory: ticket: GetTicketsPage: %w", err)
+		}
+
+		after = id
+	}
+
+	rows := scan(r.data.tickets)
+	slices.SortFunc(rows, func(a, b ticketRow) int {
+		return cmp.Compare(a.id, b.id)
+	})
+
+	tickets := []entities.Ticket{}
+	for _, row := range rows {
+		if uint64(len(tickets)) == page.Limit {
+			break
+		}
+
+		if row.id > after {
+			tickets = append(tickets, row.toEntity())
+		}
+	}
+
+	return tickets, nil
+}
+
+// GetWholeInfoAboutTicket returns passengers ordered by last name and their documents ordered by type.
+// Strings are compared bytewise, Postgres compares them by the collation of the database.
+func (r *Repository) GetWholeInfoAboutTicket(ctx context.Context, id entities.Id) (entities.TicketWholeInfo, error) {
+	defer r.lock(ctx)()
+
+	ticketId, err := parseUuid(id.Value)
+	if err != nil {
+		return entities.TicketWholeInfo{}, fmt.Errorf("memory: ticket: GetWholeInfoAboutTicket: %w", err)
+	}
+
+	ticket, ok := r.data.tickets[ticketId]
+	if !ok {
+		return entities.TicketWholeInfo{}, fmt.Errorf("memory: ticket: GetWholeInfoAboutTicket: %w", entities.ErrorNothingFound)
+	}
+
+	passengers := []passengerRow{}
+	for b := range r.data.bindings {
+		if b.ticketId == ticketId {
+			passengers = append(passengers, r.data.passengers[b.passengerId])
+		}
+	}
+
+	// Strings compare by bytes, as the collate "C" of the Postgres query
+	slices.SortFunc(passengers, func(a, b passengerRow) int {
+		return cmp.Or(
+			cmp.Compare(a.lastName, b.lastName),
+			cmp.Compare(a.firstName, b.firstName),
+			cmp.Compare(a.id, b.id),
+		)
+	})
+
+	documents := map[string][]entities.DocumentTicketWholeInfo{}
+	for _, p := range passengers {
+		documents[p.id] = nil
+	}
+
+	for _, document := range r.data.documents {
+		if docs, ok := documents[document.passengerId]; ok {
+			documents[document.passengerId] = append(docs, entities.DocumentTicketWholeInfo{
+				Id:     document.id,
+				Type:   document.docType,
+				Number: document.number,
+			})
+		}
+	}
+
+	info := entities.TicketWholeInfo{
+		Ticket:     ticket.toEntity(),
+		Passengers: []entities.PassengerTicketWholeInfo{},
+	}
+
+	for _, p := range passengers {
+		docs := documents[p.id]
+
+		slices.SortFunc(docs, func(a, b entities.DocumentTicketWholeInfo) int {
+			return cmp.Or(cmp.Compare(a.Type, b.Type), cmp.Compare(a.Number, b.Number))
+		})
+
+		info.Passengers = append(info.Passengers, entities.PassengerTicketWholeInfo{
+			Passenger: p.toEntity(),
+			Documents: docs,
+		})
+	}
+
+	return info, nil
+}
+
+// GetTicketsByIds is a batch read for loaders, missing tickets are just absent from the result.
+func (r *Repository) GetTicketsByIds(ctx context.Context, ids []string) ([]entities.Ticket, error) {
+	defer r.lock(ctx)()
+
+	ticketIds, err := parseUuids(ids)
+	if err != nil {
+		return []entities.Ticket{}, fmt.Errorf("memory: ticket: GetTicketsByIds: %w", err)
+	}
+
+	tickets := []entities.Ticket{}
+	for _, row := range scan(r.data.tickets) {
+		if slices.Contains(ticketIds, row.id) {
+			tickets = append(tickets, row.toEntity())
+		}
+	}
+
+	return tickets, nil
+}
+
+// GetTicketsByPassengerIds is a batch read for loaders, the result is keyed by passenger id.
+func (r *Repository) GetTicketsByPassengerIds(ctx context.Context, ids []string) (map[string][]entities.Ticket, error) {
+	defer r.lock(ctx)()
+
+	passengerIds, err := parseUuids(ids)
+	if err != nil {
+		return map[string][]entities.Ticket{}, fmt.Errorf("memory: ticket: GetTicketsByPassengerIds: %w", err)
+	}
+
+	bindings := scan(r.data.bindings)
+	slices.SortStableFunc(bindings, func(a, b bindingRow) int {
+		return r.data.tickets[a.ticketId].flyAt.Compare(r.data.tickets[b.ticketId].flyAt)
+	})
+
+	ticketsByPassenger := map[string][]entities.Ticket{}
+	for _, b := range bindings {
+		if slices.Contains(passengerIds, b.passengerId) {
+			ticketsByPassenger[b.passengerId] = append(ticketsByPassenger[b.passengerId], r.data.tickets[b.ticketId].toEntity())
+		}
+	}
+
+	return ticketsByPassenger, nil
+}
+
+// LockTicket reads the ticket, transactions hold the lock of the whole storage anyway.
+func (r *Repository) LockTicket(ctx context.Context, id entities.Id) (entities.Ticket, error) {
+	defer r.lock(ctx)()
+
+	ticketId, err := parseUuid(id.Value)
+	if err != nil {
+		return entities.Ticket{}, fmt.Errorf("memory: ticket: LockTicket: %w", err)
+	}
+
+	row, ok := r.data.tickets[ticketId]
+	if !ok {
+		return entities.Ticket{}, fmt.Errorf("memory: ticket: LockTicket: %w", entities.ErrorNothingFound)
+	}
+
+	return row.toEntity(), nil
+}
@@ -0,0 +1,88 @@
+package memory
+
+import (
+	"encoding/json"
+	"fmt"
+	"time"
+	"unicode/utf8"
+
+	"github.com/google/uuid"
+	"github.com/v1adhope/flights/internal/entities"
+)
+
+// violation wraps the error of the Postgres constraint, see the constraints of the Postgres repository.
+func violation(constraint string, err *entities.Error) error {
+	return fmt.Errorf("%s: %w", constraint, err)
+}
+
+// parseUuid accepts what Postgres does and returns the lower case form it prints.
+func parseUuid(s string) (string, error) {
+	id, err := uuid.Parse(s)
+	if err != nil {
+		return "", fmt.Errorf("invalid input syntax for type uuid: %q", s)
+	}
+
+	return id.String(), nil
+}
+
+func parseUuids(ss []string) ([]string, error) {
+	ids := make([]string, 0, len(ss))
+
+	for _, s := range ss {
+		id, err := parseUuid(s)
+		if err != nil {
+			return nil, err
+		}
+
+		ids = append(ids, id)
+	}
+
+	return ids, nil
+}
+
+// parseTimestamptz keeps the microsecond precision of Postgres.
+func parseTimestamptz(s string) (time.Time, error) {
+	t, err := time.Parse(time.RFC3339Nano, s)
+	if err != nil {
+		return time.Time{}, fmt.Errorf("invalid input syntax for type timestamp with time zone: %q", s)
+	}
+
+	return t.Round(time.Microsecond), nil
+}
+
+// parseNullTimestamptz is the zero time for NULL, written as an empty string.
+func parseNullTimestamptz(s string) (time.Time, error) {
+	if s == "" {
+		return time.Time{}, nil
+	}
+
+	return parseTimestamptz(s)
+}
+
+// formatTimestamptz formats like the Postgres repository, pgx scans timestamps in the local time zone.
+func formatTimestamptz(t time.Time) string {
+	if t.IsZero() {
+		return ""
+	}
+
+	return t.Local().Format(time.RFC3339)
+}
+
+// varchar checks the values fit into a varchar(n) column.
+func varchar(n int, values ...string) error {
+	for _, v := range values {
+		if utf8.RuneCountInString(v) > n {
+			return fmt.Errorf("value too long for type character varying(%d)", n)
+		}
+	}
+
+	return nil
+}
+
+func checkJson(payload []byte) error {
+	if !json.Valid(payload) {
+		return fmt.Errorf("invalid input syntax for type json")
+	}
+
+	return nil
+}
@@ -0,0 +1,427 @@
+package memory
+
+import (
+	"cmp"
+	"context"
+	"fmt"
+	"slices"
+	"time"
+
+	"github.com/v1adhope/flights/internal/entities"
+)
+
+func newSubscriptionRow(subscription entities.WebhookSubscription) (subscriptionRow, error) {
+	id, err := parseUuid(subscription.Id)
+	if err != nil {
+		return subscriptionRow{}, err
+	}
+
+	if err := varchar(2048, subscription.Url); err != nil {
+		return subscriptionRow{}, err
+	}
+
+	if err := varchar(255, subscription.Secret); err != nil {
+		return subscriptionRow{}, err
+	}
+
+	if err := varchar(255, subscription.EventTypes...); err != nil {
+		return subscriptionRow{}, err
+	}
+
+	return subscriptionRow{
+		id:         id,
+		url:        subscription.Url,
+		secret:     subscription.Secret,
+		eventTypes: append([]string{}, subscription.EventTypes...),
+		active:     subscription.Active,
+	}, nil
+}
+
+func (s subscriptionRow) toEntity() entities.WebhookSubscription {
+	return entities.WebhookSubscription{
+		Id:         s.id,
+		Url:        s.url,
+		Secret:     s.secret,
+		EventTypes: append([]string{}, s.eventTypes...),
+		Active:     s.active,
+		CreatedAt:  formatTimestamptz(s.createdAt),
+	}
+}
+
+func (r *Repository) CreateWebhookSubscription(ctx context.Context, subscription entities.WebhookSubscription) error {
+	defer r.lock(ctx)()
+
+	row, err := newSubscriptionRow(subscription)
+	if err != nil {
+		return fmt.Errorf("memory: webhook: CreateWebhookSubscription: %w", err)
+	}
+
+	row.createdAt, err = parseTimestamptz(subscription.CreatedAt)
+	if err != nil {
+		return fmt.Errorf("memory: webhook: CreateWebhookSubscription: %w", err)
+	}
+
+	if _, ok := r.data.subscriptions[row.id]; ok {
+		return fmt.Errorf("memory: webhook: CreateWebhookSubscription: %w", violation("pk_webhook_subscriptions_subscription_id", entities.ErrorHasAlreadyExists))
+	}
+
+	row.seq = r.nextSeq()
+	put(r, r.data.subscriptions, row.id, row)
+
+	return nil
+}
+
+// ReplaceWebhookSubscription keeps the secret when the subscription has none.
+func (r *Repository) ReplaceWebhookSubscription(ctx context.Context, subscription entities.WebhookSubscription) error {
+	defer r.lock(ctx)()
+
+	row, err := newSubscriptionRow(subscription)
+	if err != nil {
+		return fmt.Errorf("memory: webhook: ReplaceWebhookSubscription: %w", err)
+	}
+
+	old, ok := r.data.subscriptions[row.id]
+	if !ok {
+		return fmt.Errorf("memory: webhook: ReplaceWebhookSubscription: %w", entities.ErrorNothingToChange)
+	}
+
+	if row.secret == "" {
+		row.secret = old.secret
+	}
+
+	row.createdAt = old.createdAt
+	row.seq = r.nextSeq()
+	put(r, r.data.subscriptions, row.id, row)
+
+	return nil
+}
+
+func (r *Repository) DeleteWebhookSubscription(ctx context.Context, id entities.Id) error {
+	defer r.lock(ctx)()
+
+	subscriptionId, err := parseUuid(id.Value)
+	if err != nil {
+		return fmt.Errorf("memory: webhook: DeleteWebhookSubscription: %w", err)
+	}
+
+	if _, ok := r.data.subscriptions[subscriptionId]; !ok {
+		return fmt.Errorf("memory: webhook: DeleteWebhookSubscription: %w", entities.ErrorNothingToDelete)
+	}
+
+	remove(r, r.data.subscriptions, subscriptionId)
+
+	for deliveryId, delivery := range r.data.deliveries {
+		if delivery.subscriptionId == subscriptionId {
+			remove(r, r.data.deliveries, deliveryId)
+		}
+	}
+
+	return nil
+}
+
+func (r *Repository) GetWebhookSubscriptions(ctx context.Context) ([]entities.WebhookSubscription, error) {
+	defer r.lock(ctx)()
+
+	rows := scan(r.data.subscriptions)
+
+	slices.SortStableFunc(rows, func(a, b subscriptionRow) int {
+		return a.createdAt.Compare(b.createdAt)
+	})
+
+	subscriptions := []entities.WebhookSubscription{}
+	for _, row := range rows {
+		subscriptions = append(subscriptions, row.toEntity())
+	}
+
+	if len(subscriptions) == 0 {
+		return []entities.WebhookSubscription{}, fmt.Errorf("memory: webhook: GetWebhookSubscriptions: len: %w", entities.ErrorNothingFound)
+	}
+
+	return subscriptions, nil
+}
+
+func (r *Repository) GetActiveWebhookSubscriptions(ctx context.Context) ([]entities.WebhookSubscription, error) {
+	defer r.lock(ctx)()
+
+	subscriptions := []entities.WebhookSubscription{}
+
+	for _, row := range scan(r.data.subscriptions) {
+		if row.active {
+			subscriptions = append(subscriptions, row.toEntity())
+		}
+	}
+
+	return subscriptions, nil
+}
+
+func (r *Repository) GetWebhookSubscription(ctx context.Context, id entities.Id) (entities.WebhookSubscription, error) {
+	defer r.lock(ctx)()
+
+	subscriptionId, err := parseUuid(id.Value)
+	if err != nil {
+		return entities.WebhookSubscription{}, fmt.Errorf("memory: webhook: GetWebhookSubscription: %w", err)
+	}
+
+	row, ok := r.data.subscriptions[subscriptionId]
+	if !ok {
+		return entities.WebhookSubscription{}, fmt.Errorf("memory: webhook: GetWebhookSubscription: %w", entities.ErrorNothingFound)
+	}
+
+	return row.toEntity(), nil
+}
+
+func (d deliveryRow) toEntity() entities.WebhookDelivery {
+	return entities.WebhookDelivery{
+		Id:             d.id,
+		SubscriptionId: d.subscriptionId,
+		EventId:        d.eventId,
+		EventType:      d.eventType,
+		Payload:        slices.Clone(d.payload),
+		Status:         d.status,
+		Attempts:       d.attempts,
+		NextAttemptAt:  formatTimestamptz(d.nextAttemptAt),
+		LastStatusCode: d.lastStatusCode,
+		LastError:      d.lastError,
+		CreatedAt:      formatTimestamptz(d.createdAt),
+		DeliveredAt:    formatTimestamptz(d.deliveredAt),
+	}
+}
+
+// AddWebhookDeliveries skips deliveries of an event already enqueued for the subscription, the relay may publish an event twice.
+func (r *Repository) AddWebhookDeliveries(ctx context.Context, deliveries ...entities.WebhookDelivery) error {
+	defer r.lock(ctx)()
+
+	rows := make([]deliveryRow, 0, len(deliveries))
+
+	for _, delivery := range deliveries {
+		row, err := newDeliveryRow(delivery)
+		if err != nil {
+			return fmt.Errorf("memory: webhook: AddWebhookDeliveries: %w", err)
+		}
+
+		rows = append(rows, row)
+	}
+
+	added := map[string]deliveryRow{}
+
+	for _, row := range rows {
+		if _, ok := r.data.subscriptions[row.subscriptionId]; !ok {
+			return fmt.Errorf("memory: webhook: AddWebhookDeliveries: %w", violation("fk_webhook_deliveries_webhook_subscriptions_subscription_id", entities.ErrorWebhookSubscriptionDoesNotExist))
+		}
+
+		_, exists := r.data.deliveries[row.id]
+		_, repeated := added[row.id]
+
+		if exists || repeated {
+			return fmt.Errorf("memory: webhook: AddWebhookDeliveries: %w", violation("pk_webhook_deliveries_delivery_id", entities.ErrorHasAlreadyExists))
+		}
+
+		if r.enqueued(row, added) {
+			continue
+		}
+
+		added[row.id] = row
+	}
+
+	for _, row := range rows {
+		if _, ok := added[row.id]; ok {
+			row.seq = r.nextSeq()
+			put(r, r.data.deliveries, row.id, row)
+		}
+	}
+
+	return nil
+}
+
+// enqueued reports whether the subscription already has a delivery of the event.
+func (r *Repository) enqueued(row deliveryRow, added map[string]deliveryRow) bool {
+	for _, deliveries := range []map[string]deliveryRow{r.data.deliveries, added} {
+		for _, d := range deliveries {
+			if d.subscriptionId == row.subscriptionId && d.eventId == row.eventId {
+				return true
+			}
+		}
+	}
+
+	return false
+}
+
+func newDeliveryRow(delivery entities.WebhookDelivery) (deliveryRow, error) {
+	id, err := parseUuid(delivery.Id)
+	if err != nil {
+		return deliveryRow{}, err
+	}
+
+	subscriptionId, err := parseUuid(delivery.SubscriptionId)
+	if err != nil {
+		return deliveryRow{}, err
+	}
+
+	eventId, err := parseUuid(delivery.EventId)
+	if err != nil {
+		return deliveryRow{}, err
+	}
+
+	if err := varchar(255, delivery.EventType); err != nil {
+		return deliveryRow{}, err
+	}
+
+	if err := varchar(32, delivery.Status); err != nil {
+		return deliveryRow{}, err
+	}
+
+	if err := checkJson(delivery.Payload); err != nil {
+		return deliveryRow{}, err
+	}
+
+	nextAttemptAt, err := parseNullTimestamptz(delivery.NextAttemptAt)
+	if err != nil {
+		return deliveryRow{}, err
+	}
+
+	createdAt, err := parseTimestamptz(delivery.CreatedAt)
+	if err != nil {
+		return deliveryRow{}, err
+	}
+
+	return deliveryRow{
+		id:             id,
+		subscriptionId: subscriptionId,
+		eventId:        eventId,
+		eventType:      delivery.EventType,
+		payload:        slices.Clone([]byte(delivery.Payload)),
+		status:         delivery.Status,
+		attempts:       delivery.Attempts,
+		nextAttemptAt:  nextAttemptAt,
+		createdAt:      createdAt,
+	}, nil
+}
+
+// ClaimDueWebhookDeliveries postpones due pending deliveries of active subscriptions by lease and returns them.
+func (r *Repository) ClaimDueWebhookDeliveries(ctx context.Context, limit uint64, lease time.Duration) ([]entities.WebhookDelivery, error) {
+	defer r.lock(ctx)()
+
+	now := time.Now().Round(time.Microsecond)
+	due := []deliveryRow{}
+
+	for _, row := range scan(r.data.deliveries) {
+		if row.status == entities.WebhookDeliveryPending && !row.nextAttemptAt.IsZero() && !row.nextAttemptAt.After(now) &&
+			r.data.subscriptions[row.subscriptionId].active {
+			due = append(due, row)
+		}
+	}
+
+	slices.SortStableFunc(due, func(a, b deliveryRow) int {
+		return a.nextAttemptAt.Compare(b.nextAttemptAt)
+	})
+
+	deliveries := []entities.WebhookDelivery{}
+
+	for _, row := range due[:min(uint64(len(due)), limit)] {
+		row.nextAttemptAt = now.Add(lease).Round(time.Microsecond)
+		row.seq = r.nextSeq()
+		put(r, r.data.deliveries, row.id, row)
+
+		deliveries = append(deliveries, row.toEntity())
+	}
+
+	return deliveries, nil
+}
+
+func (r *Repository) UpdateWebhookDelivery(ctx context.Context, delivery entities.WebhookDelivery) error {
+	defer r.lock(ctx)()
+
+	id, err := parseUuid(delivery.Id)
+	if err != nil {
+		return fmt.Errorf("memory: webhook: UpdateWebhookDelivery: %w", err)
+	}
+
+	if err := varchar(32, delivery.Status); err != nil {
+		return fmt.Errorf("memory: webhook: UpdateWebhookDelivery: %w", err)
+	}
+
+	nextAttemptAt, err := parseNullTimestamptz(delivery.NextAttemptAt)
+	if err != nil {
+		return fmt.Errorf("memory: webhook: UpdateWebhookDelivery: %w", err)
+	}
+
+	deliveredAt, err := parseNullTimestamptz(delivery.DeliveredAt)
+	if err != nil {
+		return fmt.Errorf("memory: webhook: UpdateWebhookDelivery: %w", err)
+	}
+
+	row, ok := r.data.deliveries[id]
+	if !ok {
+		return fmt.Errorf("memory: webhook: UpdateWebhookDelivery: %w", entities.ErrorNothingToChange)
+	}
+
+	row.status = delivery.Status
+	row.attempts = delivery.Attempts
+	row.nextAttemptAt = nextAttemptAt
+	row.lastStatusCode = delivery.LastStatusCode
+	row.lastError = delivery.LastError
+	row.deliveredAt = deliveredAt
+	row.seq = r.nextSeq()
+	put(r, r.data.deliveries, id, row)
+
+	return nil
+}
+
+func (r *Repository) GetWebhookDeliveries(ctx context.Context, subscriptionId entities.Id) ([]entities.WebhookDelivery, error) {
+	defer r.lock(ctx)()
+
+	id, err := parseUuid(subscriptionId.Value)
+	if err != nil {
+		return []entities.WebhookDelivery{}, fmt.Errorf("memory: webhook: GetWebhookDeliveries: %w", err)
+	}
+
+	rows := []deliveryRow{}
+	for _, row := range scan(r.data.deliveries) {
+		if row.subscriptionId == id {
+			rows = append(rows, row)
+		}
+	}
+
+	slices.SortStableFunc(rows, func(a, b deliveryRow) int {
+		return cmp.Compare(b.createdAt.UnixMicro(), a.createdAt.UnixMicro())
+	})
+
+	deliveries := []entities.WebhookDelivery{}
+	for _, row := range rows {
+		deliveries = append(deliveries, row.toEntity())
+	}
+
+	if len(deliveries) == 0 {
+		return []entities.WebhookDelivery{}, fmt.Errorf("memory: webhook: GetWebhookDeliveries: len: %w", entities.ErrorNothingFound)
+	}
+
+	return deliveries, nil
+}
+
+func (r *Repository) RedeliverWebhookDelivery(ctx context.Context, subscriptionId, deliveryId entities.Id) error {
+	defer r.lock(ctx)()
+
+	subscription, err := parseUuid(subscriptionId.Value)
+	if err != nil {
+		return fmt.Errorf("memory: webhook: RedeliverWebhookDelivery: %w", err)
+	}
+
+	id, err := parseUuid(deliveryId.Value)
+	if err != nil {
+		return fmt.Errorf("memory: webhook: RedeliverWebhookDelivery: %w", err)
+	}
+
+	row, ok := r.data.deliveries[id]
+	if !ok || row.subscriptionId != subscription {
+		return fmt.Errorf("memory: webhook: RedeliverWebhookDelivery: %w", entities.ErrorNothingToChange)
+	}
+
+	row.status = entities.WebhookDeliveryPending
+	row.attempts = 0
+	row.nextAttemptAt = time.Now().Round(time.Microsecond)
+	row.deliveredAt = time.Time{}
+	row.seq = r.nextSeq()
+	put(r, r.data.deliveries, id, row)
+
+	return nil
+}
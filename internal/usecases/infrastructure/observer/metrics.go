@@ -0,0 +1,44 @@
+// Package observer reports usecase calls to metrics and tracing.
+package observer
+
+import (
+	"context"
+	"time"
+
+	"github.com/v1adhope/flights/internal/entities"
+	"github.com/v1adhope/flights/pkg/metrics"
+)
+
+// Metrics records usecase calls with the category of their errors.
+type Metrics struct {
+	metrics *metrics.Metrics
+}
+
+func NewMetrics(m *metrics.Metrics) *Metrics {
+	return &Metrics{m}
+}
+
+func (o *Metrics) StartCall(ctx context.Context, name string) (context.Context, func(err error)) {
+	start := time.Now()
+
+	return ctx, func(err error) {
+		o.metrics.ObserveCall(name, time.Since(start), errorCategory(err))
+	}
+}
+
+func (o *Metrics) Count(_ context.Context, name string, n int) {
+	o.metrics.Add(name, n)
+}
+
+// errorCategory is empty for nil, errors that are not domain errors are internal.
+func errorCategory(err error) string {
+	if err == nil {
+		return ""
+	}
+
+	if domainErr, ok := entities.AsError(err); ok {
+		return string(domainErr.Category)
+	}
+
+	return string(entities.CategoryInternal)
+}
@@ -0,0 +1,45 @@
+package observer
+
+import (
+	"context"
+
+	"github.com/v1adhope/flights/internal/entities"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/trace"
+)
+
+const _usecasesInstrumentation = "github.com/v1adhope/flights/internal/usecases"
+
+// Tracing wraps every usecase call in a span, business counters become events of the current span.
+type Tracing struct {
+	tracer trace.Tracer
+}
+
+func NewTracing(tp trace.TracerProvider) *Tracing {
+	return &Tracing{tp.Tracer(_usecasesInstrumentation)}
+}
+
+func (o *Tracing) StartCall(ctx context.Context, name string) (context.Context, func(err error)) {
+	ctx, span := o.tracer.Start(ctx, "usecases."+name)
+
+	return ctx, func(err error) {
+		if err != nil {
+			category := errorCategory(err)
+
+			span.RecordError(err)
+			span.SetAttributes(attribute.String("error.category", category))
+
+			// Domain errors are answers to clients, only internal ones fail the span
+			if category == string(entities.CategoryInternal) {
+				span.SetStatus(codes.Error, err.Error())
+			}
+		}
+
+		span.End()
+	}
+}
+
+func (o *Tracing) Count(ctx context.Context, name string, n int) {
+	trace.SpanFromContext(ctx).AddEvent(name, trace.WithAttributes(attribute.Int("count", n)))
+}
@@ -0,0 +1,99 @@
+package publisher
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"time"
+
+	"github.com/v1adhope/flights/internal/entities"
+)
+
+const _kafkaRestContentType = "application/vnd.kafka.json.v2+json"
+
+// Kafka publishes events through a Kafka REST Proxy (Confluent REST v2 API, also served by Redpanda).
+// Records are keyed by aggregate id, so events of one aggregate share a partition and keep order.
+type Kafka struct {
+	client   *http.Client
+	topicUrl string
+}
+
+type kafkaRecords struct {
+	Records []kafkaRecord `json:"records"`
+}
+
+type kafkaRecord struct {
+	Key   string         `json:"key"`
+	Value entities.Event `json:"value"`
+}
+
+type kafkaOffsets struct {
+	Offsets []struct {
+		Partition int    `json:"partition"`
+		ErrorCode *int   `json:"error_code"`
+		Error     string `json:"error"`
+	} `json:"offsets"`
+}
+
+func NewKafka(restProxyUrl, topic string) *Kafka {
+	return &Kafka{
+		client: &http.Client{
+			Timeout: 10 * time.Second,
+		},
+		topicUrl: fmt.Sprintf("%s/topics/%s", restProxyUrl, url.PathEscape(topic)),
+	}
+}
+
+func (k *Kafka) Publish(ctx context.Context, event entities.Event) error {
+	data, err := json.Marshal(kafkaRecords{
+		Records: []kafkaRecord{
+			{
+				Key:   event.AggregateId,
+				Value: event,
+			},
+		},
+	})
+	if err != nil {
+		return fmt.Errorf("publisher: kafka: Publish: Marshal: %w", err)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, k.topicUrl, bytes.NewReader(data))
+	if err != nil {
+		return fmt.Errorf("publisher: kafka: Publish: NewRequestWithContext: %w", err)
+	}
+
+	req.Header.Set("Content-Type", _kafkaRestContentType)
+	req.Header.Set("Accept", "application/vnd.kafka.v2+json")
+
+	resp, err := k.client.Do(req)
+	if err != nil {
+		return fmt.Errorf("publisher: kafka: Publish: Do: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return fmt.Errorf("publisher: kafka: Publish: unexpected status %d", resp.StatusCode)
+	}
+
+	offsets := kafkaOffsets{}
+
+	if err := json.NewDecoder(resp.Body).Decode(&offsets); err != nil {
+		return fmt.Errorf("publisher: kafka: Publish: Decode: %w", err)
+	}
+
+	for _, offset := range offsets.Offsets {
+		if offset.ErrorCode != nil {
+			return fmt.Errorf("publisher: kafka: Publish: partition %d: %s", offset.Partition, offset.Error)
+		}
+	}
+
+	return nil
+}
+
+func (k *Kafka) Close() error {
+	k.client.CloseIdleConnections()
+	return nil
+}
@@ -0,0 +1,55 @@
+package publisher
+
+import (
+	"context"
+	"sync"
+
+	"github.com/v1adhope/flights/internal/entities"
+)
+
+// Memory is an in-process sink, it keeps published events and fans them out to subscribers.
+type Memory struct {
+	mu          sync.RWMutex
+	events      []entities.Event
+	subscribers []func(ctx context.Context, event entities.Event) error
+}
+
+func NewMemory() *Memory {
+	return &Memory{}
+}
+
+func (m *Memory) Publish(ctx context.Context, event entities.Event) error {
+	m.mu.RLock()
+	subscribers := m.subscribers
+	m.mu.RUnlock()
+
+	for _, subscriber := range subscribers {
+		if err := subscriber(ctx, event); err != nil {
+			return err
+		}
+	}
+
+	m.mu.Lock()
+	m.events = append(m.events, event)
+	m.mu.Unlock()
+
+	return nil
+}
+
+// Subscribe registers fn to be called for every published event, an error fails the publish.
+func (m *Memory) Subscribe(fn func(ctx context.Context, event entities.Event) error) {
+	m.mu.Lock()
+	m.subscribers = append(m.subscribers, fn)
+	m.mu.Unlock()
+}
+
+func (m *Memory) Events() []entities.Event {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	return append([]entities.Event{}, m.events...)
+}
+
+func (m *Memory) Close() error {
+	return nil
+}
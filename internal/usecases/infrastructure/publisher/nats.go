@@ -0,0 +1,60 @@
+package publisher
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+
+	"github.com/nats-io/nats.go"
+	"github.com/nats-io/nats.go/jetstream"
+	"github.com/v1adhope/flights/internal/entities"
+)
+
+// Nats publishes events to <subjectPrefix>.<aggregateType>.<eventType> through JetStream,
+// a stream must capture these subjects. An event is published once the stream acknowledged it,
+// Nats-Msg-Id is the event id, so the stream deduplicates redeliveries.
+type Nats struct {
+	conn          *nats.Conn
+	js            jetstream.JetStream
+	subjectPrefix string
+}
+
+func NewNats(url, subjectPrefix string) (*Nats, error) {
+	conn, err := nats.Connect(url)
+	if err != nil {
+		return nil, fmt.Errorf("publisher: nats: NewNats: Connect: %w", err)
+	}
+
+	js, err := jetstream.New(conn)
+	if err != nil {
+		conn.Close()
+		return nil, fmt.Errorf("publisher: nats: NewNats: jetstream.New: %w", err)
+	}
+
+	return &Nats{
+		conn:          conn,
+		js:            js,
+		subjectPrefix: subjectPrefix,
+	}, nil
+}
+
+func (n *Nats) Publish(ctx context.Context, event entities.Event) error {
+	data, err := json.Marshal(event)
+	if err != nil {
+		return fmt.Errorf("publisher: nats: Publish: Marshal: %w", err)
+	}
+
+	msg := nats.NewMsg(fmt.Sprintf("%s.%s.%s", n.subjectPrefix, event.AggregateType, event.Type))
+	msg.Data = data
+
+	// A duplicate is acknowledged too, the stream has the event already
+	if _, err := n.js.PublishMsg(ctx, msg, jetstream.WithMsgID(event.Id)); err != nil {
+		return fmt.Errorf("publisher: nats: Publish: PublishMsg: %w", err)
+	}
+
+	return nil
+}
+
+func (n *Nats) Close() error {
+	return n.conn.Drain()
+}
@@ -0,0 +1,29 @@
+package repository
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+
+	"github.com/v1adhope/flights/internal/entities"
+)
+
+// ChangesChannel is the Postgres channel every committed entities.Change is notified on.
+const ChangesChannel = "flights_changes"
+
+// notify broadcasts changes with the write of ctx, it must run in the transaction of the write
+// so listeners hear only of committed changes and a failed notify rolls the write back.
+func (r *Repository) notify(ctx context.Context, changes ...entities.Change) error {
+	payloads := make([]string, 0, len(changes))
+
+	for _, change := range changes {
+		payload, err := json.Marshal(change)
+		if err != nil {
+			return fmt.Errorf("repository: changes: notify: Marshal: %w", err)
+		}
+
+		payloads = append(payloads, string(payload))
+	}
+
+	return r.Notify(ctx, ChangesChannel, payloads...)
+}
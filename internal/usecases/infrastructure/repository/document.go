@@ -28,15 +28,17 @@ func (r *Repository) CreateDocument(ctx context.Context, document entities.Docum
 		return fmt.Errorf("repository: document: CreateDocument: Insert: %w", err)
 	}
 
-	if _, err := r.Pool.Exec(ctx, sql, args...); err != nil {
-		if err := catchExpectedDocumentCreationError(err); err != nil {
-			return err
+	return r.WithinTx(ctx, func(ctx context.Context) error {
+		if _, err := r.Conn(ctx).Exec(ctx, sql, args...); err != nil {
+			return fmt.Errorf("repository: document: CreateDocument: Exec: %w", translateError(err, "documents"))
 		}
 
-		return fmt.Errorf("repository: document: CreateDocument: Exec: %w", err)
-	}
+		if err := r.notify(ctx, entities.Change{Entity: entities.ChangeDocument, Id: document.Id, PassengerId: document.PassengerId}); err != nil {
+			return fmt.Errorf("repository: document: CreateDocument: %w", err)
+		}
 
-	return nil
+		return nil
+	})
 }
 
 func (r *Repository) ReplaceDocument(ctx context.Context, document entities.Document) error {
@@ -54,20 +56,22 @@ func (r *Repository) ReplaceDocument(ctx context.Context, document entities.Docu
 		return fmt.Errorf("repository: document: ReplaceDocument: Update: %w", err)
 	}
 
-	tag, err := r.Pool.Exec(ctx, sql, args...)
-	if err != nil {
-		if err := catchExpectedDocumentCreationError(err); err != nil {
-			return err
+	return r.WithinTx(ctx, func(ctx context.Context) error {
+		tag, err := r.Conn(ctx).Exec(ctx, sql, args...)
+		if err != nil {
+			return fmt.Errorf("repository: document: ReplaceDocument: Exec: %w", translateError(err, "documents"))
 		}
 
-		return fmt.Errorf("repository: document: ReplaceDocument: Exec: %w", err)
-	}
+		if tag.RowsAffected() == 0 {
+			return fmt.Errorf("repository: document: ReplaceDocument: RowsAffected: %w", entities.ErrorNothingToChange)
+		}
 
-	if tag.RowsAffected() == 0 {
-		return fmt.Errorf("repository: document: ReplaceDocument: RowsAffected: %w", entities.ErrorNothingToChange)
-	}
+		if err := r.notify(ctx, entities.Change{Entity: entities.ChangeDocument, Id: document.Id, PassengerId: document.PassengerId}); err != nil {
+			return fmt.Errorf("repository: document: ReplaceDocument: %w", err)
+		}
 
-	return nil
+		return nil
+	})
 }
 
 func (r *Repository) DeleteDocument(ctx context.Context, id entities.Id) error {
@@ -80,16 +84,22 @@ func (r *Repository) DeleteDocument(ctx context.Context, id entities.Id) error {
 		return fmt.Errorf("repository: document: DeleteDocument: Delete: %w", err)
 	}
 
-	tag, err := r.Pool.Exec(ctx, sql, args...)
-	if err != nil {
-		return fmt.Errorf("repository: document: DeleteDocument: Exec: %w", err)
-	}
+	return r.WithinTx(ctx, func(ctx context.Context) error {
+		tag, err := r.Conn(ctx).Exec(ctx, sql, args...)
+		if err != nil {
+			return fmt.Errorf("repository: document: DeleteDocument: Exec: %w", translateError(err, "documents"))
+		}
 
-	if tag.RowsAffected() == 0 {
-		return fmt.Errorf("repository: document: DeleteDocument: RowsAffected: %w", entities.ErrorNothingToDelete)
-	}
+		if tag.RowsAffected() == 0 {
+			return fmt.Errorf("repository: document: DeleteDocument: RowsAffected: %w", entities.ErrorNothingToDelete)
+		}
 
-	return nil
+		if err := r.notify(ctx, entities.Change{Entity: entities.ChangeDocument, Id: id.Value}); err != nil {
+			return fmt.Errorf("repository: document: DeleteDocument: %w", err)
+		}
+
+		return nil
+	})
 }
 
 func (r *Repository) GetDocumentsByPassengerId(ctx context.Context, id entities.Id) ([]entities.Document, error) {
@@ -107,7 +117,7 @@ func (r *Repository) GetDocumentsByPassengerId(ctx context.Context, id entities.
 		return []entities.Document{}, fmt.Errorf("repository: document: GetDocumentsByPassengerId: Select: %w", err)
 	}
 
-	rows, err := r.Pool.Query(ctx, sql, args...)
+	rows, err := r.ReadConn(ctx).Query(ctx, sql, args...)
 	if err != nil {
 		return []entities.Document{}, fmt.Errorf("repository: document: GetDocumentsByPassengerId: Query: %w", err)
 	}
@@ -137,3 +147,48 @@ func (r *Repository) GetDocumentsByPassengerId(ctx context.Context, id entities.
 
 	return documents, nil
 }
+
+// GetDocumentsByPassengerIds is a batch read for loaders, the result is keyed by passenger id.
+func (r *Repository) GetDocumentsByPassengerIds(ctx context.Context, ids []string) (map[string][]entities.Document, error) {
+	sql, args, err := r.Builder.Select(
+		"document_id",
+		"type",
+		"number",
+		"passenger_id",
+	).
+		From("documents").
+		Where(squirrel.Eq{
+			"passenger_id": ids,
+		}).
+		ToSql()
+	if err != nil {
+		return map[string][]entities.Document{}, fmt.Errorf("repository: document: GetDocumentsByPassengerIds: Select: %w", err)
+	}
+
+	rows, err := r.ReadConn(ctx).Query(ctx, sql, args...)
+	if err != nil {
+		return map[string][]entities.Document{}, fmt.Errorf("repository: document: GetDocumentsByPassengerIds: Query: %w", err)
+	}
+
+	documentsByPassenger := map[string][]entities.Document{}
+	document := entities.Document{}
+
+	_, err = pgx.ForEachRow(
+		rows,
+		[]any{
+			&document.Id,
+			&document.Type,
+			&document.Number,
+			&document.PassengerId,
+		},
+		func() error {
+			documentsByPassenger[document.PassengerId] = append(documentsByPassenger[document.PassengerId], document)
+			return nil
+		},
+	)
+	if err != nil {
+		return map[string][]entities.Document{}, fmt.Errorf("repository: document: GetDocumentsByPassengerIds: ForEachRow: %w", err)
+	}
+
+	return documentsByPassenger, nil
+}
@@ -29,40 +29,43 @@ func (d *ticketDto) toEntity() entities.Ticket {
 	}
 }
 
+// passengerTicketWholeInfoDto is an element of the passengers json of GetWholeInfoAboutTicket.
 type passengerTicketWholeInfoDto struct {
-	Id         *string
-	FirstName  *string
-	LastName   *string
-	MiddleName *string
+	Id         string                       `json:"id"`
+	FirstName  string                       `json:"firstName"`
+	LastName   string                       `json:"lastName"`
+	MiddleName string                       `json:"middleName"`
+	Documents  []documentTicketWholeInfoDto `json:"documents"`
 }
 
-func (d *passengerTicketWholeInfoDto) toEntity() entities.Passenger {
-	if d.Id == nil {
-		return entities.Passenger{}
+func (d *passengerTicketWholeInfoDto) toEntity() entities.PassengerTicketWholeInfo {
+	passenger := entities.PassengerTicketWholeInfo{
+		Passenger: entities.Passenger{
+			Id:         d.Id,
+			FirstName:  d.FirstName,
+			LastName:   d.LastName,
+			MiddleName: d.MiddleName,
+		},
 	}
-	return entities.Passenger{
-		Id:         *d.Id,
-		FirstName:  *d.FirstName,
-		LastName:   *d.LastName,
-		MiddleName: *d.MiddleName,
+
+	for _, document := range d.Documents {
+		passenger.Documents = append(passenger.Documents, document.toEntity())
 	}
+
+	return passenger
 }
 
 type documentTicketWholeInfoDto struct {
-	Id     *string
-	Type   *string
-	Number *string
+	Id     string `json:"id"`
+	Type   string `json:"type"`
+	Number string `json:"number"`
 }
 
 func (d *documentTicketWholeInfoDto) toEntity() entities.DocumentTicketWholeInfo {
-	if d.Id == nil {
-		return entities.DocumentTicketWholeInfo{}
-	}
-
 	return entities.DocumentTicketWholeInfo{
-		Id:     *d.Id,
-		Type:   *d.Type,
-		Number: *d.Number,
+		Id:     d.Id,
+		Type:   d.Type,
+		Number: d.Number,
 	}
 }
 
@@ -85,3 +88,91 @@ func (d *reportRowByPassengerForPeriodDto) toEntity() entities.ReportRowByPassen
 		ServiceProvided: d.ServiceProvided,
 	}
 }
+
+type eventDto struct {
+	Seq           int64
+	Id            string
+	Type          string
+	AggregateType string
+	AggregateId   string
+	Payload       []byte
+	OccurredAt    pgtype.Timestamptz
+}
+
+func (d *eventDto) toEntity() entities.Event {
+	return entities.Event{
+		Id:            d.Id,
+		Seq:           d.Seq,
+		Type:          d.Type,
+		AggregateType: d.AggregateType,
+		AggregateId:   d.AggregateId,
+		Payload:       append([]byte{}, d.Payload...),
+		OccurredAt:    d.OccurredAt.Time.UTC().Format(time.RFC3339Nano),
+	}
+}
+
+type webhookSubscriptionDto struct {
+	Id         string
+	Url        string
+	Secret     string
+	EventTypes []string
+	Active     bool
+	CreatedAt  pgtype.Timestamptz
+}
+
+func (d *webhookSubscriptionDto) toEntity() entities.WebhookSubscription {
+	return entities.WebhookSubscription{
+		Id:         d.Id,
+		Url:        d.Url,
+		Secret:     d.Secret,
+		EventTypes: append([]string{}, d.EventTypes...),
+		Active:     d.Active,
+		CreatedAt:  d.CreatedAt.Time.Format(time.RFC3339),
+	}
+}
+
+type webhookDeliveryDto struct {
+	Id             string
+	SubscriptionId string
+	EventId        string
+	EventType      string
+	Payload        []byte
+	Status         string
+	Attempts       int
+	NextAttemptAt  pgtype.Timestamptz
+	LastStatusCode *int
+	LastError      *string
+	CreatedAt      pgtype.Timestamptz
+	DeliveredAt    pgtype.Timestamptz
+}
+
+func (d *webhookDeliveryDto) toEntity() entities.WebhookDelivery {
+	delivery := entities.WebhookDelivery{
+		Id:             d.Id,
+		SubscriptionId: d.SubscriptionId,
+		EventId:        d.EventId,
+		EventType:      d.EventType,
+		Payload:        append([]byte{}, d.Payload...),
+		Status:         d.Status,
+		Attempts:       d.Attempts,
+		CreatedAt:      d.CreatedAt.Time.Format(time.RFC3339),
+	}
+
+	if d.NextAttemptAt.Valid {
+		delivery.NextAttemptAt = d.NextAttemptAt.Time.Format(time.RFC3339)
+	}
+
+	if d.LastStatusCode != nil {
+		delivery.LastStatusCode = *d.LastStatusCode
+	}
+
+	if d.LastError != nil {
+		delivery.LastError = *d.LastError
+	}
+
+	if d.DeliveredAt.Valid {
+		delivery.DeliveredAt = d.DeliveredAt.Time.Format(time.RFC3339)
+	}
+
+	return delivery
+}
@@ -1,6 +1,7 @@
 package repository
 
 import (
+	"context"
 	"errors"
 	"fmt"
 
@@ -8,17 +9,117 @@ import (
 	"github.com/v1adhope/flights/internal/entities"
 )
 
-func catchExpectedDocumentCreationError(err error) error {
+const (
+	_pgCodeSerializationFailure = "40001"
+	_pgCodeDeadlockDetected     = "40P01"
+)
+
+type constraint struct {
+	// table owns the constraint
+	table string
+	// violated is returned when a row of the table violates the constraint
+	violated *entities.Error
+	// referenced is returned when a foreign key blocks changing the referenced row of another table
+	referenced *entities.Error
+}
+
+// constraints covers every constraint of db/migrations, keep it in sync with the schema.
+var constraints = map[string]constraint{
+	"pk_tickets_ticket_id": {
+		table:    "tickets",
+		violated: entities.ErrorHasAlreadyExists,
+	},
+	"pk_passengers_passenger_id": {
+		table:    "passengers",
+		violated: entities.ErrorHasAlreadyExists,
+	},
+	"pk_documents_document_id": {
+		table:    "documents",
+		violated: entities.ErrorHasAlreadyExists,
+	},
+	"uq_documents_type_number": {
+		table: "documents",
+		violated: entities.ErrorHasAlreadyExists.WithDetails(map[string]any{
+			"fields": []string{"type", "number"},
+		}),
+	},
+	"fk_document_passenger_passenger_id": {
+		table:      "documents",
+		violated:   entities.ErrorPassengerDoesNotExists,
+		referenced: entities.ErrorPassengerHasDocuments,
+	},
+	"pk_ticket_passenger_ticket_id_passenger_id": {
+		table:    "passenger_ticket",
+		violated: entities.ErrorHasAlreadyExists,
+	},
+	"fk_ticket_passenger_passenger_passenger_id": {
+		table:    "passenger_ticket",
+		violated: entities.ErrorPassengerDoesNotExists,
+	},
+	"fk_ticket_passenger_tickets_ticket_id": {
+		table:      "passenger_ticket",
+		violated:   entities.ErrorTicketDoesNotExists,
+		referenced: entities.ErrorsThereArePassengersOnTheFlight,
+	},
+	"pk_outbox_seq": {
+		table:    "outbox",
+		violated: entities.ErrorHasAlreadyExists,
+	},
+	"uq_outbox_event_id": {
+		table:    "outbox",
+		violated: entities.ErrorHasAlreadyExists,
+	},
+	"pk_webhook_subscriptions_subscription_id": {
+		table:    "webhook_subscriptions",
+		violated: entities.ErrorHasAlreadyExists,
+	},
+	"pk_webhook_deliveries_delivery_id": {
+		table:    "webhook_deliveries",
+		violated: entities.ErrorHasAlreadyExists,
+	},
+	"uq_webhook_deliveries_subscription_id_event_id": {
+		table:    "webhook_deliveries",
+		violated: entities.ErrorHasAlreadyExists,
+	},
+	"fk_webhook_deliveries_webhook_subscriptions_subscription_id": {
+		table:    "webhook_deliveries",
+		violated: entities.ErrorWebhookSubscriptionDoesNotExist,
+	},
+	"pk_idempotency_keys_idempotency_key": {
+		table:    "idempotency_keys",
+		violated: entities.ErrorHasAlreadyExists,
+	},
+}
+
+// translateError translates a constraint violation of a statement writing to the table into entities error,
+// other errors are returned as is.
+// Postgres reports foreign key violations with the referencing table either way, so the written table tells them apart.
+func translateError(err error, table string) error {
+	var pgErr *pgconn.PgError
+	if !errors.As(err, &pgErr) || pgErr.ConstraintName == "" {
+		return err
+	}
+
+	c, ok := constraints[pgErr.ConstraintName]
+	if !ok {
+		return err
+	}
+
+	if table != c.table && c.referenced != nil {
+		return fmt.Errorf("%s: %w", pgErr.ConstraintName, c.referenced)
+	}
+
+	return fmt.Errorf("%s: %w", pgErr.ConstraintName, c.violated)
+}
+
+// WithinTx reports transactions that kept failing on serialization or deadlocks as retryable.
+func (r *Repository) WithinTx(ctx context.Context, fn func(ctx context.Context) error) error {
+	err := r.Driver.WithinTx(ctx, fn)
+
 	var pgErr *pgconn.PgError
-	if errors.As(err, &pgErr) {
-		if pgErr.ConstraintName == "uq_documents_type_number" {
-			return fmt.Errorf("repository: document: catchExpectedErrorDocumentCreation: %w", entities.ErrorHasAlreadyExists)
-		}
-
-		if pgErr.ConstraintName == "fk_document_passenger_passenger_id" {
-			return fmt.Errorf("repository: document: catchExpectedErrorDocumentCreation: %w", entities.ErrorPassengerDoesNotExists)
-		}
+	if errors.As(err, &pgErr) && (pgErr.Code == _pgCodeSerializationFailure || pgErr.Code == _pgCodeDeadlockDetected) {
+		return fmt.Errorf("repository: errors: WithinTx: %w: %w", entities.ErrorTryAgain, err)
 	}
 
-	return nil
+	return err
 }
@@ -0,0 +1,36 @@
+package repository
+
+import (
+	"context"
+	"fmt"
+)
+
+func (r *Repository) Ping(ctx context.Context) error {
+	if err := r.Pool.Ping(ctx); err != nil {
+		return fmt.Errorf("repository: health: Ping: %w", err)
+	}
+
+	return nil
+}
+
+// GetMigrationVersion reads the version golang-migrate keeps in schema_migrations.
+func (r *Repository) GetMigrationVersion(ctx context.Context) (uint, bool, error) {
+	sql, args, err := r.Builder.Select(
+		"version",
+		"dirty",
+	).
+		From("schema_migrations").
+		Limit(1).
+		ToSql()
+	if err != nil {
+		return 0, false, fmt.Errorf("repository: health: GetMigrationVersion: Select: %w", err)
+	}
+
+	version, dirty := int64(0), false
+
+	if err := r.Pool.QueryRow(ctx, sql, args...).Scan(&version, &dirty); err != nil {
+		return 0, false, fmt.Errorf("repository: health: GetMigrationVersion: Scan: %w", err)
+	}
+
+	return uint(version), dirty, nil
+}
@@ -0,0 +1,177 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/Masterminds/squirrel"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgtype"
+	"github.com/v1adhope/flights/internal/entities"
+)
+
+func (r *Repository) GetIdempotentResponse(ctx context.Context, key string) (entities.IdempotentResponse, error) {
+	sql, args, err := r.Builder.Select(
+		"idempotency_key",
+		"request_hash",
+		"status_code",
+		"location",
+		"body",
+		"created_at",
+	).
+		From("idempotency_keys").
+		Where(squirrel.Eq{
+			"idempotency_key": key,
+		}).
+		ToSql()
+	if err != nil {
+		return entities.IdempotentResponse{}, fmt.Errorf("repository: idempotency: GetIdempotentResponse: Select: %w", err)
+	}
+
+	resp := entities.IdempotentResponse{}
+	createdAt := pgtype.Timestamptz{}
+
+	err = r.Conn(ctx).QueryRow(ctx, sql, args...).Scan(
+		&resp.Key,
+		&resp.RequestHash,
+		&resp.Status,
+		&resp.Location,
+		&resp.Body,
+		&createdAt,
+	)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return entities.IdempotentResponse{}, fmt.Errorf("repository: idempotency: GetIdempotentResponse: Scan: %w", entities.ErrorNothingFound)
+	}
+	if err != nil {
+		return entities.IdempotentResponse{}, fmt.Errorf("repository: idempotency: GetIdempotentResponse: Scan: %w", err)
+	}
+
+	resp.CreatedAt = createdAt.Time.Format(time.RFC3339)
+
+	return resp, nil
+}
+
+// CreateIdempotentResponse reserves the key, a second reservation fails with ErrorHasAlreadyExists.
+func (r *Repository) CreateIdempotentResponse(ctx context.Context, resp entities.IdempotentResponse) error {
+	sql, args, err := r.Builder.Insert("idempotency_keys").
+		Columns(
+			"idempotency_key",
+			"request_hash",
+			"status_code",
+			"location",
+			"body",
+			"created_at",
+		).
+		Values(
+			resp.Key,
+			resp.RequestHash,
+			resp.Status,
+			resp.Location,
+			resp.Body,
+			resp.CreatedAt,
+		).
+		ToSql()
+	if err != nil {
+		return fmt.Errorf("repository: idempotency: CreateIdempotentResponse: Insert: %w", err)
+	}
+
+	if _, err := r.Conn(ctx).Exec(ctx, sql, args...); err != nil {
+		return fmt.Errorf("repository: idempotency: CreateIdempotentResponse: Exec: %w", translateError(err, "idempotency_keys"))
+	}
+
+	return nil
+}
+
+func (r *Repository) ReplaceIdempotentResponse(ctx context.Context, resp entities.IdempotentResponse) error {
+	sql, args, err := r.Builder.Update("idempotency_keys").
+		SetMap(squirrel.Eq{
+			"status_code": resp.Status,
+			"location":    resp.Location,
+			"body":        resp.Body,
+		}).
+		Where(squirrel.Eq{
+			"idempotency_key": resp.Key,
+		}).
+		ToSql()
+	if err != nil {
+		return fmt.Errorf("repository: idempotency: ReplaceIdempotentResponse: Update: %w", err)
+	}
+
+	tag, err := r.Conn(ctx).Exec(ctx, sql, args...)
+	if err != nil {
+		return fmt.Errorf("repository: idempotency: ReplaceIdempotentResponse: Exec: %w", err)
+	}
+
+	if tag.RowsAffected() == 0 {
+		return fmt.Errorf("repository: idempotency: ReplaceIdempotentResponse: RowsAffected: %w", entities.ErrorNothingToChange)
+	}
+
+	return nil
+}
+
+// TakeOverIdempotentResponse restarts a request still in progress since before startedBefore,
+// only one of concurrent take-overs succeeds, the others fail with ErrorNothingToChange.
+func (r *Repository) TakeOverIdempotentResponse(ctx context.Context, resp entities.IdempotentResponse, startedBefore string) error {
+	sql, args, err := r.Builder.Update("idempotency_keys").
+		Set("created_at", resp.CreatedAt).
+		Where(squirrel.And{
+			squirrel.Eq{
+				"idempotency_key": resp.Key,
+				"status_code":     0,
+			},
+			squirrel.Lt{
+				"created_at": startedBefore,
+			},
+		}).
+		ToSql()
+	if err != nil {
+		return fmt.Errorf("repository: idempotency: TakeOverIdempotentResponse: Update: %w", err)
+	}
+
+	tag, err := r.Conn(ctx).Exec(ctx, sql, args...)
+	if err != nil {
+		return fmt.Errorf("repository: idempotency: TakeOverIdempotentResponse: Exec: %w", err)
+	}
+
+	if tag.RowsAffected() == 0 {
+		return fmt.Errorf("repository: idempotency: TakeOverIdempotentResponse: RowsAffected: %w", entities.ErrorNothingToChange)
+	}
+
+	return nil
+}
+
+func (r *Repository) DeleteIdempotentResponse(ctx context.Context, key string) error {
+	sql, args, err := r.Builder.Delete("idempotency_keys").
+		Where(squirrel.Eq{
+			"idempotency_key": key,
+		}).
+		ToSql()
+	if err != nil {
+		return fmt.Errorf("repository: idempotency: DeleteIdempotentResponse: Delete: %w", err)
+	}
+
+	if _, err := r.Conn(ctx).Exec(ctx, sql, args...); err != nil {
+		return fmt.Errorf("repository: idempotency: DeleteIdempotentResponse: Exec: %w", err)
+	}
+
+	return nil
+}
+
+func (r *Repository) DeleteIdempotentResponses(ctx context.Context, olderThan string) error {
+	sql, args, err := r.Builder.Delete("idempotency_keys").
+		Where(squirrel.Lt{
+			"created_at": olderThan,
+		}).
+		ToSql()
+	if err != nil {
+		return fmt.Errorf("repository: idempotency: DeleteIdempotentResponses: Delete: %w", err)
+	}
+
+	if _, err := r.Conn(ctx).Exec(ctx, sql, args...); err != nil {
+		return fmt.Errorf("repository: idempotency: DeleteIdempotentResponses: Exec: %w", err)
+	}
+
+	return nil
+}
@@ -0,0 +1,179 @@
+package repository
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/v1adhope/flights/internal/entities"
+)
+
+func (r *Repository) CopyTickets(ctx context.Context, tickets []entities.Ticket) error {
+	rows := make([][]any, 0, len(tickets))
+
+	for _, ticket := range tickets {
+		flyAt, err := time.Parse(time.RFC3339, ticket.FlyAt)
+		if err != nil {
+			return fmt.Errorf("repository: import: CopyTickets: Parse: %w", err)
+		}
+
+		arriveAt, err := time.Parse(time.RFC3339, ticket.ArriveAt)
+		if err != nil {
+			return fmt.Errorf("repository: import: CopyTickets: Parse: %w", err)
+		}
+
+		createdAt, err := time.Parse(time.RFC3339, ticket.CreatedAt)
+		if err != nil {
+			return fmt.Errorf("repository: import: CopyTickets: Parse: %w", err)
+		}
+
+		rows = append(rows, []any{
+			ticket.Id,
+			ticket.Provider,
+			ticket.FlyFrom,
+			ticket.FlyTo,
+			flyAt,
+			arriveAt,
+			createdAt,
+		})
+	}
+
+	return r.WithinTx(ctx, func(ctx context.Context) error {
+		_, err := r.Conn(ctx).CopyFrom(
+			ctx,
+			pgx.Identifier{"tickets"},
+			[]string{
+				"ticket_id",
+				"provider",
+				"fly_from",
+				"fly_to",
+				"fly_at",
+				"arrive_at",
+				"created_at",
+			},
+			pgx.CopyFromRows(rows),
+		)
+		if err != nil {
+			return fmt.Errorf("repository: import: CopyTickets: CopyFrom: %w", translateError(err, "tickets"))
+		}
+
+		changes := make([]entities.Change, 0, len(tickets))
+		for _, ticket := range tickets {
+			changes = append(changes, entities.Change{Entity: entities.ChangeTicket, Id: ticket.Id})
+		}
+
+		if err := r.notify(ctx, changes...); err != nil {
+			return fmt.Errorf("repository: import: CopyTickets: %w", err)
+		}
+
+		return nil
+	})
+}
+
+func (r *Repository) CopyPassengers(ctx context.Context, passengers []entities.Passenger) error {
+	return r.WithinTx(ctx, func(ctx context.Context) error {
+		_, err := r.Conn(ctx).CopyFrom(
+			ctx,
+			pgx.Identifier{"passengers"},
+			[]string{
+				"passenger_id",
+				"first_name",
+				"last_name",
+				"middle_name",
+			},
+			pgx.CopyFromSlice(len(passengers), func(i int) ([]any, error) {
+				return []any{
+					passengers[i].Id,
+					passengers[i].FirstName,
+					passengers[i].LastName,
+					passengers[i].MiddleName,
+				}, nil
+			}),
+		)
+		if err != nil {
+			return fmt.Errorf("repository: import: CopyPassengers: CopyFrom: %w", translateError(err, "passengers"))
+		}
+
+		changes := make([]entities.Change, 0, len(passengers))
+		for _, passenger := range passengers {
+			changes = append(changes, entities.Change{Entity: entities.ChangePassenger, Id: passenger.Id})
+		}
+
+		if err := r.notify(ctx, changes...); err != nil {
+			return fmt.Errorf("repository: import: CopyPassengers: %w", err)
+		}
+
+		return nil
+	})
+}
+
+func (r *Repository) CopyDocuments(ctx context.Context, documents []entities.Document) error {
+	return r.WithinTx(ctx, func(ctx context.Context) error {
+		_, err := r.Conn(ctx).CopyFrom(
+			ctx,
+			pgx.Identifier{"documents"},
+			[]string{
+				"document_id",
+				"type",
+				"number",
+				"passenger_id",
+			},
+			pgx.CopyFromSlice(len(documents), func(i int) ([]any, error) {
+				return []any{
+					documents[i].Id,
+					documents[i].Type,
+					documents[i].Number,
+					documents[i].PassengerId,
+				}, nil
+			}),
+		)
+		if err != nil {
+			return fmt.Errorf("repository: import: CopyDocuments: CopyFrom: %w", translateError(err, "documents"))
+		}
+
+		changes := make([]entities.Change, 0, len(documents))
+		for _, document := range documents {
+			changes = append(changes, entities.Change{Entity: entities.ChangeDocument, Id: document.Id, PassengerId: document.PassengerId})
+		}
+
+		if err := r.notify(ctx, changes...); err != nil {
+			return fmt.Errorf("repository: import: CopyDocuments: %w", err)
+		}
+
+		return nil
+	})
+}
+
+func (r *Repository) CopyBindings(ctx context.Context, bindings []entities.Binding) error {
+	return r.WithinTx(ctx, func(ctx context.Context) error {
+		_, err := r.Conn(ctx).CopyFrom(
+			ctx,
+			pgx.Identifier{"passenger_ticket"},
+			[]string{
+				"passenger_id",
+				"ticket_id",
+			},
+			pgx.CopyFromSlice(len(bindings), func(i int) ([]any, error) {
+				return []any{
+					bindings[i].PassengerId,
+					bindings[i].TicketId,
+				}, nil
+			}),
+		)
+		if err != nil {
+			return fmt.Errorf("repository: import: CopyBindings: CopyFrom: %w", translateError(err, "passenger_ticket"))
+		}
+
+		changes := make([]entities.Change, 0, len(bindings))
+		for _, binding := range bindings {
+			changes = append(changes, entities.Change{Entity: entities.ChangeBinding, PassengerId: binding.PassengerId, TicketId: binding.TicketId})
+		}
+
+		if err := r.notify(ctx, changes...); err != nil {
+			return fmt.Errorf("repository: import: CopyBindings: %w", err)
+		}
+
+		return nil
+	})
+}
@@ -0,0 +1,208 @@
+package repository
+
+import (
+	"cmp"
+	"context"
+	"fmt"
+	"slices"
+	"time"
+
+	"github.com/Masterminds/squirrel"
+	"github.com/jackc/pgx/v5"
+	"github.com/v1adhope/flights/internal/entities"
+)
+
+const (
+	// _outboxRelayLockKey is an arbitrary advisory lock key, one claim at a time keeps order per aggregate.
+	_outboxRelayLockKey = 7_146_201
+	// _outboxAggregateLockSpace is the first key of the two-key advisory locks of aggregates.
+	_outboxAggregateLockSpace = 7_146_202
+)
+
+// _lockAggregates takes the transaction advisory locks of the aggregates in one order, so writers can't deadlock.
+const _lockAggregates = `select pg_advisory_xact_lock($1, key) from (
+	select distinct hashtext(aggregate_type || ':' || aggregate_id) as key
+	from unnest($2::text[], $3::text[]) as aggregates(aggregate_type, aggregate_id)
+	order by key
+) keys`
+
+// AddEvents holds the aggregates of the events until the transaction ends. Seq is taken on insert,
+// so concurrent writers of an aggregate would otherwise commit out of seq order and a relay could skip an event.
+func (r *Repository) AddEvents(ctx context.Context, events ...entities.Event) error {
+	rows := make([][]any, 0, len(events))
+	aggregateTypes := make([]string, 0, len(events))
+	aggregateIds := make([]string, 0, len(events))
+
+	for _, event := range events {
+		occurredAt, err := time.Parse(time.RFC3339Nano, event.OccurredAt)
+		if err != nil {
+			return fmt.Errorf("repository: outbox: AddEvents: Parse: %w", err)
+		}
+
+		rows = append(rows, []any{
+			event.Id,
+			event.Type,
+			event.AggregateType,
+			event.AggregateId,
+			[]byte(event.Payload),
+			occurredAt,
+		})
+		aggregateTypes = append(aggregateTypes, event.AggregateType)
+		aggregateIds = append(aggregateIds, event.AggregateId)
+	}
+
+	return r.WithinTx(ctx, func(ctx context.Context) error {
+		if _, err := r.Conn(ctx).Exec(ctx, _lockAggregates, _outboxAggregateLockSpace, aggregateTypes, aggregateIds); err != nil {
+			return fmt.Errorf("repository: outbox: AddEvents: Exec: %w", err)
+		}
+
+		_, err := r.Conn(ctx).CopyFrom(
+			ctx,
+			pgx.Identifier{"outbox"},
+			[]string{
+				"event_id",
+				"type",
+				"aggregate_type",
+				"aggregate_id",
+				"payload",
+				"occurred_at",
+			},
+			pgx.CopyFromRows(rows),
+		)
+		if err != nil {
+			return fmt.Errorf("repository: outbox: AddEvents: CopyFrom: %w", translateError(err, "outbox"))
+		}
+
+		return nil
+	})
+}
+
+// _claimEvents claims the unpublished events of aggregates no relay holds, the first events of every aggregate come first,
+// so aggregates with many events can't fill the batch.
+const _claimEvents = `with claimable as (
+	select seq, row_number() over (partition by aggregate_type, aggregate_id order by seq) as position
+	from outbox
+	where published_at is null
+		and not exists (
+			select 1 from outbox claimed
+			where claimed.aggregate_type = outbox.aggregate_type
+				and claimed.aggregate_id = outbox.aggregate_id
+				and claimed.published_at is null
+				and claimed.claimed_until > now()
+		)
+	order by position, seq
+	limit $1
+)
+update outbox set claimed_until = now() + make_interval(secs => $2)
+from claimable
+where outbox.seq = claimable.seq
+returning outbox.seq, event_id, type, aggregate_type, aggregate_id, payload, occurred_at`
+
+// ClaimUnpublishedEvents holds up to limit unpublished events for lease and returns them in outbox order.
+// Claimed events are claimed again once the lease is over, so events not marked published in time are delivered again.
+func (r *Repository) ClaimUnpublishedEvents(ctx context.Context, limit uint64, lease time.Duration) ([]entities.Event, error) {
+	events := []entities.Event{}
+
+	err := r.WithinTx(ctx, func(ctx context.Context) error {
+		events = events[:0]
+
+		// Claims of concurrent relays would both see an aggregate as free
+		if _, err := r.Conn(ctx).Exec(ctx, "select pg_advisory_xact_lock($1)", _outboxRelayLockKey); err != nil {
+			return fmt.Errorf("repository: outbox: ClaimUnpublishedEvents: Exec: %w", err)
+		}
+
+		rows, err := r.Conn(ctx).Query(ctx, _claimEvents, limit, lease.Seconds())
+		if err != nil {
+			return fmt.Errorf("repository: outbox: ClaimUnpublishedEvents: Query: %w", err)
+		}
+
+		eventDto := eventDto{}
+
+		_, err = pgx.ForEachRow(
+			rows,
+			[]any{
+				&eventDto.Seq,
+				&eventDto.Id,
+				&eventDto.Type,
+				&eventDto.AggregateType,
+				&eventDto.AggregateId,
+				&eventDto.Payload,
+				&eventDto.OccurredAt,
+			},
+			func() error {
+				events = append(events, eventDto.toEntity())
+				return nil
+			},
+		)
+		if err != nil {
+			return fmt.Errorf("repository: outbox: ClaimUnpublishedEvents: ForEachRow: %w", err)
+		}
+
+		return nil
+	})
+	if err != nil {
+		return []entities.Event{}, err
+	}
+
+	slices.SortFunc(events, func(a, b entities.Event) int {
+		return cmp.Compare(a.Seq, b.Seq)
+	})
+
+	return events, nil
+}
+
+func (r *Repository) MarkEventsPublished(ctx context.Context, seqs []int64) error {
+	if len(seqs) == 0 {
+		return nil
+	}
+
+	sql, args, err := r.Builder.Update("outbox").
+		Set("published_at", squirrel.Expr("now()")).
+		Where(squirrel.Eq{
+			"seq": seqs,
+		}).
+		ToSql()
+	if err != nil {
+		return fmt.Errorf("repository: outbox: MarkEventsPublished: Update: %w", err)
+	}
+
+	if _, err := r.Conn(ctx).Exec(ctx, sql, args...); err != nil {
+		return fmt.Errorf("repository: outbox: MarkEventsPublished: Exec: %w", translateError(err, "outbox"))
+	}
+
+	return nil
+}
+
+// ReplayEvents makes already published events with seq >= fromSeq unpublished again, optionally for one aggregate only.
+func (r *Repository) ReplayEvents(ctx context.Context, fromSeq int64, aggregateId string) (int64, error) {
+	where := squirrel.And{
+		squirrel.GtOrEq{
+			"seq": fromSeq,
+		},
+		squirrel.NotEq{
+			"published_at": nil,
+		},
+	}
+
+	if aggregateId != "" {
+		where = append(where, squirrel.Eq{
+			"aggregate_id": aggregateId,
+		})
+	}
+
+	sql, args, err := r.Builder.Update("outbox").
+		Set("published_at", nil).
+		Set("claimed_until", nil).
+		Where(where).
+		ToSql()
+	if err != nil {
+		return 0, fmt.Errorf("repository: outbox: ReplayEvents: Update: %w", err)
+	}
+
+	tag, err := r.Conn(ctx).Exec(ctx, sql, args...)
+	if err != nil {
+		return 0, fmt.Errorf("repository: outbox: ReplayEvents: Exec: %w", err)
+	}
+
+	return tag.RowsAffected(), nil
+}
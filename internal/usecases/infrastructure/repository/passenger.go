@@ -6,7 +6,7 @@ import (
 	"fmt"
 
 	"github.com/Masterminds/squirrel"
-	"github.com/jackc/pgx/v5/pgconn"
+	"github.com/jackc/pgx/v5"
 	"github.com/v1adhope/flights/internal/entities"
 )
 
@@ -29,11 +29,17 @@ func (r *Repository) CreatePassenger(ctx context.Context, passenger entities.Pas
 		return fmt.Errorf("repository: passenger: CreatePassenger: Insert: %w", err)
 	}
 
-	if _, err := r.Pool.Exec(ctx, sql, args...); err != nil {
-		return fmt.Errorf("repository: passenger: CreatePassenger: Exec: %w", err)
-	}
+	return r.WithinTx(ctx, func(ctx context.Context) error {
+		if _, err := r.Conn(ctx).Exec(ctx, sql, args...); err != nil {
+			return fmt.Errorf("repository: passenger: CreatePassenger: Exec: %w", translateError(err, "passengers"))
+		}
+
+		if err := r.notify(ctx, entities.Change{Entity: entities.ChangePassenger, Id: passenger.Id}); err != nil {
+			return fmt.Errorf("repository: passenger: CreatePassenger: %w", err)
+		}
 
-	return nil
+		return nil
+	})
 }
 
 func (r *Repository) ReplacePassenger(ctx context.Context, passenger entities.Passenger) error {
@@ -51,16 +57,22 @@ func (r *Repository) ReplacePassenger(ctx context.Context, passenger entities.Pa
 		return fmt.Errorf("repository: passenger: ReplacePassenger: Update: %w", err)
 	}
 
-	tag, err := r.Pool.Exec(ctx, sql, args...)
-	if err != nil {
-		return fmt.Errorf("repository: passenger: ReplacePassenger: Exec: %w", err)
-	}
+	return r.WithinTx(ctx, func(ctx context.Context) error {
+		tag, err := r.Conn(ctx).Exec(ctx, sql, args...)
+		if err != nil {
+			return fmt.Errorf("repository: passenger: ReplacePassenger: Exec: %w", translateError(err, "passengers"))
+		}
 
-	if tag.RowsAffected() == 0 {
-		return fmt.Errorf("repository: passenger: ReplacePassenger: RowsAffected: %w", entities.ErrorNothingToChange)
-	}
+		if tag.RowsAffected() == 0 {
+			return fmt.Errorf("repository: passenger: ReplacePassenger: RowsAffected: %w", entities.ErrorNothingToChange)
+		}
 
-	return nil
+		if err := r.notify(ctx, entities.Change{Entity: entities.ChangePassenger, Id: passenger.Id}); err != nil {
+			return fmt.Errorf("repository: passenger: ReplacePassenger: %w", err)
+		}
+
+		return nil
+	})
 }
 
 func (r *Repository) DeletePassenger(ctx context.Context, id entities.Id) error {
@@ -73,16 +85,22 @@ func (r *Repository) DeletePassenger(ctx context.Context, id entities.Id) error
 		return fmt.Errorf("repository: passenger: DeletePassenger: Delete: %w", err)
 	}
 
-	tag, err := r.Pool.Exec(ctx, sql, args...)
-	if err != nil {
-		return fmt.Errorf("repository: passenger: DeletePassenger: Exec: %w", err)
-	}
+	return r.WithinTx(ctx, func(ctx context.Context) error {
+		tag, err := r.Conn(ctx).Exec(ctx, sql, args...)
+		if err != nil {
+			return fmt.Errorf("repository: passenger: DeletePassenger: Exec: %w", translateError(err, "passengers"))
+		}
 
-	if tag.RowsAffected() == 0 {
-		return fmt.Errorf("repository: passenger: DeletePassenger: RowsAffected: %w", entities.ErrorNothingToDelete)
-	}
+		if tag.RowsAffected() == 0 {
+			return fmt.Errorf("repository: passenger: DeletePassenger: RowsAffected: %w", entities.ErrorNothingToDelete)
+		}
+
+		if err := r.notify(ctx, entities.Change{Entity: entities.ChangePassenger, Id: id.Value}); err != nil {
+			return fmt.Errorf("repository: passenger: DeletePassenger: %w", err)
+		}
 
-	return nil
+		return nil
+	})
 }
 
 func (r *Repository) GetPassengers(ctx context.Context) ([]entities.Passenger, error) {
@@ -98,7 +116,7 @@ func (r *Repository) GetPassengers(ctx context.Context) ([]entities.Passenger, e
 		return []entities.Passenger{}, fmt.Errorf("repository: passenger: GetAllPassengers: Select: %w", err)
 	}
 
-	rows, err := r.Pool.Query(ctx, sql, args...)
+	rows, err := r.ReadConn(ctx).Query(ctx, sql, args...)
 	if err != nil {
 		return []entities.Passenger{}, fmt.Errorf("repository: passenger: GetAllPassengers: Query: %w", err)
 	}
@@ -121,26 +139,17 @@ func (r *Repository) BoundToTicket(ctx context.Context, id entities.Id, ticketId
 		return fmt.Errorf("repository: passenger: BoundToTicket: Insert: %w", err)
 	}
 
-	if _, err := r.Pool.Exec(ctx, sql, args...); err != nil {
-		var pgErr *pgconn.PgError
-		if errors.As(err, &pgErr) {
-			if pgErr.ConstraintName == "pk_ticket_passenger_ticket_id_passenger_id" {
-				return fmt.Errorf("repository: document: BoundToTicket: Exec: %w", entities.ErrorHasAlreadyExists)
-			}
-
-			if pgErr.ConstraintName == "fk_ticket_passenger_passenger_passenger_id" {
-				return fmt.Errorf("repository: document: BoundToTicket: Exec: %w", entities.ErrorPassengerDoesNotExists)
-			}
-
-			if pgErr.ConstraintName == "fk_ticket_passenger_tickets_ticket_id" {
-				return fmt.Errorf("repository: document: BoundToTicket: Exec: %w", entities.ErrorTicketDoesNotExists)
-			}
+	return r.WithinTx(ctx, func(ctx context.Context) error {
+		if _, err := r.Conn(ctx).Exec(ctx, sql, args...); err != nil {
+			return fmt.Errorf("repository: passenger: BoundToTicket: Exec: %w", translateError(err, "passenger_ticket"))
 		}
 
-		return fmt.Errorf("repository: passenger: BoundToTicket: Exec: %w", err)
-	}
+		if err := r.notify(ctx, entities.Change{Entity: entities.ChangeBinding, PassengerId: id.Value, TicketId: ticketId.Value}); err != nil {
+			return fmt.Errorf("repository: passenger: BoundToTicket: %w", err)
+		}
 
-	return nil
+		return nil
+	})
 }
 
 func (r *Repository) UnboundToTicket(ctx context.Context, id entities.Id, ticketId entities.Id) error {
@@ -154,16 +163,22 @@ func (r *Repository) UnboundToTicket(ctx context.Context, id entities.Id, ticket
 		return fmt.Errorf("repository: passenger: UnboundToTicket: Delete: %w", err)
 	}
 
-	tag, err := r.Pool.Exec(ctx, sql, args...)
-	if err != nil {
-		return fmt.Errorf("repository: passenger: UnboundToTicket: Exec: %w", err)
-	}
+	return r.WithinTx(ctx, func(ctx context.Context) error {
+		tag, err := r.Conn(ctx).Exec(ctx, sql, args...)
+		if err != nil {
+			return fmt.Errorf("repository: passenger: UnboundToTicket: Exec: %w", translateError(err, "passenger_ticket"))
+		}
 
-	if tag.RowsAffected() == 0 {
-		return fmt.Errorf("repository: passenger: UnboundToTicket: RowsAffected: %w", entities.ErrorNothingToDelete)
-	}
+		if tag.RowsAffected() == 0 {
+			return fmt.Errorf("repository: passenger: UnboundToTicket: RowsAffected: %w", entities.ErrorNothingToDelete)
+		}
+
+		if err := r.notify(ctx, entities.Change{Entity: entities.ChangeBinding, PassengerId: id.Value, TicketId: ticketId.Value}); err != nil {
+			return fmt.Errorf("repository: passenger: UnboundToTicket: %w", err)
+		}
 
-	return nil
+		return nil
+	})
 }
 
 func (r *Repository) GetPassengersByTicketId(ctx context.Context, id entities.Id) ([]entities.Passenger, error) {
@@ -183,10 +198,125 @@ func (r *Repository) GetPassengersByTicketId(ctx context.Context, id entities.Id
 		return []entities.Passenger{}, fmt.Errorf("repository: passenger: GetPassengersByTicketId: Select: %w", err)
 	}
 
-	rows, err := r.Pool.Query(ctx, sql, args...)
+	rows, err := r.ReadConn(ctx).Query(ctx, sql, args...)
 	if err != nil {
 		return []entities.Passenger{}, fmt.Errorf("repository: passenger: GetPassengersByTicketId: Query: %w", err)
 	}
 
 	return passengersRowReader(rows)
 }
+
+// GetPassengersByIds is a batch read for loaders, missing passengers are just absent from the result.
+func (r *Repository) GetPassengersByIds(ctx context.Context, ids []string) ([]entities.Passenger, error) {
+	sql, args, err := r.Builder.Select(
+		"passenger_id",
+		"first_name",
+		"last_name",
+		"middle_name",
+	).
+		From("passengers").
+		Where(squirrel.Eq{
+			"passenger_id": ids,
+		}).
+		ToSql()
+	if err != nil {
+		return []entities.Passenger{}, fmt.Errorf("repository: passenger: GetPassengersByIds: Select: %w", err)
+	}
+
+	rows, err := r.ReadConn(ctx).Query(ctx, sql, args...)
+	if err != nil {
+		return []entities.Passenger{}, fmt.Errorf("repository: passenger: GetPassengersByIds: Query: %w", err)
+	}
+
+	passengers, err := passengersRowReader(rows)
+	if err != nil && !errors.Is(err, entities.ErrorNothingFound) {
+		return []entities.Passenger{}, err
+	}
+
+	return passengers, nil
+}
+
+// GetPassengersByTicketIds is a batch read for loaders, the result is keyed by ticket id.
+func (r *Repository) GetPassengersByTicketIds(ctx context.Context, ids []string) (map[string][]entities.Passenger, error) {
+	sql, args, err := r.Builder.Select(
+		"passenger_ticket.ticket_id",
+		"passengers.passenger_id",
+		"passengers.first_name",
+		"passengers.last_name",
+		"passengers.middle_name",
+	).
+		From("passenger_ticket").
+		Join("passengers using(passenger_id)").
+		Where(squirrel.Eq{
+			"passenger_ticket.ticket_id": ids,
+		}).
+		ToSql()
+	if err != nil {
+		return map[string][]entities.Passenger{}, fmt.Errorf("repository: passenger: GetPassengersByTicketIds: Select: %w", err)
+	}
+
+	rows, err := r.ReadConn(ctx).Query(ctx, sql, args...)
+	if err != nil {
+		return map[string][]entities.Passenger{}, fmt.Errorf("repository: passenger: GetPassengersByTicketIds: Query: %w", err)
+	}
+
+	passengersByTicket := map[string][]entities.Passenger{}
+	ticketId := ""
+	passenger := entities.Passenger{}
+
+	_, err = pgx.ForEachRow(
+		rows,
+		[]any{
+			&ticketId,
+			&passenger.Id,
+			&passenger.FirstName,
+			&passenger.LastName,
+			&passenger.MiddleName,
+		},
+		func() error {
+			passengersByTicket[ticketId] = append(passengersByTicket[ticketId], passenger)
+			return nil
+		},
+	)
+	if err != nil {
+		return map[string][]entities.Passenger{}, fmt.Errorf("repository: passenger: GetPassengersByTicketIds: ForEachRow: %w", err)
+	}
+
+	return passengersByTicket, nil
+}
+
+// LockPassenger reads the passenger on the primary and locks it until the end of the transaction of ctx.
+func (r *Repository) LockPassenger(ctx context.Context, id entities.Id) (entities.Passenger, error) {
+	sql, args, err := r.Builder.Select(
+		"passenger_id",
+		"first_name",
+		"last_name",
+		"middle_name",
+	).
+		From("passengers").
+		Where(squirrel.Eq{
+			"passenger_id": id.Value,
+		}).
+		Suffix("for update").
+		ToSql()
+	if err != nil {
+		return entities.Passenger{}, fmt.Errorf("repository: passenger: LockPassenger: Select: %w", err)
+	}
+
+	passenger := entities.Passenger{}
+
+	err = r.Conn(ctx).QueryRow(ctx, sql, args...).Scan(
+		&passenger.Id,
+		&passenger.FirstName,
+		&passenger.LastName,
+		&passenger.MiddleName,
+	)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return entities.Passenger{}, fmt.Errorf("repository: passenger: LockPassenger: Scan: %w", entities.ErrorNothingFound)
+	}
+	if err != nil {
+		return entities.Passenger{}, fmt.Errorf("repository: passenger: LockPassenger: Scan: %w", err)
+	}
+
+	return passenger, nil
+}
@@ -17,7 +17,7 @@ func (r *Repository) GetRowsByPassengerIdForPeriod(ctx context.Context, id entit
 		).
 		ToSql()
 
-	rows, err := r.Pool.Query(ctx, sql, args...)
+	rows, err := r.ReadConn(ctx).Query(ctx, sql, args...)
 	if err != nil {
 		return []entities.ReportRowByPassengerForPeriod{}, fmt.Errorf("repository: report: GetRowsByPassengerIdForPeriod: Query: %w", err)
 	}
@@ -0,0 +1,265 @@
+package repository_test
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/v1adhope/flights/internal/entities"
+	"github.com/v1adhope/flights/internal/testhelpers"
+	"github.com/v1adhope/flights/internal/usecases"
+	"github.com/v1adhope/flights/internal/usecases/infrastructure/repository"
+	"github.com/v1adhope/flights/pkg/postgresql"
+)
+
+const _pgMigrationsSourceUrl = "file://../../../../db/migrations"
+
+func buildDriver(tb testing.TB, opts ...postgresql.Option) *postgresql.Driver {
+	ctx := context.Background()
+
+	pgC, err := testhelpers.BuildContainer(ctx, _pgMigrationsSourceUrl)
+	require.NoError(tb, err)
+	tb.Cleanup(func() {
+		pgC.Terminate(ctx)
+	})
+
+	require.NoError(tb, pgC.MigrateUp())
+
+	pd, err := postgresql.Build(ctx, append([]postgresql.Option{postgresql.WithConnStr(pgC.ConnStr)}, opts...)...)
+	require.NoError(tb, err)
+	tb.Cleanup(pd.Close)
+
+	return pd
+}
+
+func TestReposer(t *testing.T) {
+	ctx := context.Background()
+
+	pd := buildDriver(t)
+	repo := repository.New(pd)
+
+	testhelpers.RunReposerSuite(t, func(t *testing.T) usecases.Reposer {
+		_, err := pd.Pool.Exec(ctx, "truncate tickets, passengers, documents, passenger_ticket, outbox, webhook_subscriptions, webhook_deliveries, idempotency_keys")
+		require.NoError(t, err)
+
+		return repo
+	})
+}
+
+type changesHandler struct {
+	listening chan struct{}
+	payloads  chan string
+}
+
+func (h changesHandler) Listening(ctx context.Context) {
+	h.listening <- struct{}{}
+}
+
+func (h changesHandler) Notified(ctx context.Context, payload string) {
+	h.payloads <- payload
+}
+
+func (h changesHandler) Disconnected(err error) {}
+
+// TestChanges checks a write and its notification commit together, also outside WithinTx.
+func TestChanges(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	pd := buildDriver(t)
+	repo := repository.New(pd)
+
+	h := changesHandler{
+		listening: make(chan struct{}, 1),
+		payloads:  make(chan string, 16),
+	}
+
+	go pd.Listen(ctx, repository.ChangesChannel, h)
+	<-h.listening
+
+	expectChange := func(t *testing.T, want entities.Change) {
+		select {
+		case payload := <-h.payloads:
+			got := entities.Change{}
+			require.NoError(t, json.Unmarshal([]byte(payload), &got))
+			assert.Equal(t, want, got)
+		case <-time.After(5 * time.Second):
+			t.Fatalf("no change %v", want)
+		}
+	}
+
+	expectNone := func(t *testing.T) {
+		select {
+		case payload := <-h.payloads:
+			t.Fatalf("unexpected change %s", payload)
+		case <-time.After(200 * time.Millisecond):
+		}
+	}
+
+	passenger := entities.Passenger{
+		Id:         uuid.NewString(),
+		FirstName:  "Wendi",
+		LastName:   "Reyes",
+		MiddleName: "Mejia",
+	}
+
+	require.NoError(t, repo.CreatePassenger(ctx, passenger))
+	expectChange(t, entities.Change{Entity: entities.ChangePassenger, Id: passenger.Id})
+
+	require.Error(t, repo.CreatePassenger(ctx, passenger))
+	expectNone(t)
+
+	errRollback := errors.New("rollback")
+
+	err := repo.WithinTx(ctx, func(ctx context.Context) error {
+		if err := repo.DeletePassenger(ctx, entities.Id{Value: passenger.Id}); err != nil {
+			return err
+		}
+
+		return errRollback
+	})
+	require.ErrorIs(t, err, errRollback)
+	expectNone(t)
+}
+
+// _joinWholeInfo is the former query of GetWholeInfoAboutTicket, it returns a row per document of every passenger.
+// The baseline decodes its rows without assembling them, so the former method took more on top.
+const _joinWholeInfo = `select
+	tickets.provider, tickets.fly_from, tickets.fly_to, tickets.fly_at, tickets.arrive_at, tickets.created_at,
+	passengers.passenger_id, passengers.first_name, passengers.last_name, passengers.middle_name,
+	documents.document_id, documents.type, documents.number
+from tickets
+left join passenger_ticket using(ticket_id)
+left join passengers using(passenger_id)
+left join documents using(passenger_id)
+where ticket_id = $1`
+
+const (
+	_benchOtherTickets = 10000
+	_benchDocuments    = 3
+)
+
+// rowCounter counts the rows Postgres sent for the queries of the pool.
+type rowCounter struct {
+	rows atomic.Int64
+}
+
+func (c *rowCounter) TraceQueryStart(ctx context.Context, _ *pgx.Conn, _ pgx.TraceQueryStartData) context.Context {
+	return ctx
+}
+
+func (c *rowCounter) TraceQueryEnd(_ context.Context, _ *pgx.Conn, data pgx.TraceQueryEndData) {
+	c.rows.Add(data.CommandTag.RowsAffected())
+}
+
+// BenchmarkGetWholeInfoAboutTicket compares GetWholeInfoAboutTicket with the former join read raw as a baseline,
+// rows/op is the number of rows sent by Postgres.
+func BenchmarkGetWholeInfoAboutTicket(b *testing.B) {
+	ctx := context.Background()
+
+	counter := &rowCounter{}
+	pd := buildDriver(b, postgresql.WithTracer(counter))
+	repo := repository.New(pd)
+
+	// Tickets of other passengers, so the queries run against tables of realistic size
+	seedTickets(b, repo, _benchOtherTickets, 1, _benchDocuments)
+
+	for _, passengers := range []int{10, 100, 1000} {
+		ticketId := seedTickets(b, repo, 1, passengers, _benchDocuments)[0]
+
+		b.Run(fmt.Sprintf("join/passengers=%d", passengers), func(b *testing.B) {
+			counter.rows.Store(0)
+
+			for range b.N {
+				r, err := pd.Pool.Query(ctx, _joinWholeInfo, ticketId)
+				require.NoError(b, err)
+
+				for r.Next() {
+					_, err := r.Values()
+					require.NoError(b, err)
+				}
+				require.NoError(b, r.Err())
+			}
+
+			b.ReportMetric(float64(counter.rows.Load())/float64(b.N), "rows/op")
+		})
+
+		b.Run(fmt.Sprintf("json_agg/passengers=%d", passengers), func(b *testing.B) {
+			counter.rows.Store(0)
+
+			for range b.N {
+				info, err := repo.GetWholeInfoAboutTicket(ctx, entities.Id{Value: ticketId})
+				require.NoError(b, err)
+				require.Len(b, info.Passengers, passengers)
+			}
+
+			b.ReportMetric(float64(counter.rows.Load())/float64(b.N), "rows/op")
+		})
+	}
+}
+
+// seedTickets imports tickets with passengers of their own, every passenger has documents.
+func seedTickets(b *testing.B, repo *repository.Repository, tickets, passengersPerTicket, documentsPerPassenger int) []string {
+	ctx := context.Background()
+	createdAt := time.Now().Format(time.RFC3339)
+
+	ticketIds := make([]string, 0, tickets)
+	ticketRows := make([]entities.Ticket, 0, tickets)
+	passengerRows := make([]entities.Passenger, 0, tickets*passengersPerTicket)
+	documentRows := make([]entities.Document, 0, tickets*passengersPerTicket*documentsPerPassenger)
+	bindingRows := make([]entities.Binding, 0, tickets*passengersPerTicket)
+
+	for range tickets {
+		ticketId := uuid.NewString()
+
+		ticketIds = append(ticketIds, ticketId)
+		ticketRows = append(ticketRows, entities.Ticket{
+			Id:        ticketId,
+			Provider:  "Emirates",
+			FlyFrom:   "Moscow",
+			FlyTo:     "Hanoi",
+			FlyAt:     "3022-01-02T15:04:05+03:00",
+			ArriveAt:  "3022-01-03T18:04:40+07:00",
+			CreatedAt: createdAt,
+		})
+
+		for range passengersPerTicket {
+			passengerId := uuid.NewString()
+
+			passengerRows = append(passengerRows, entities.Passenger{
+				Id:         passengerId,
+				FirstName:  "Wendi",
+				LastName:   passengerId[:8],
+				MiddleName: "Mejia",
+			})
+			bindingRows = append(bindingRows, entities.Binding{
+				PassengerId: passengerId,
+				TicketId:    ticketId,
+			})
+
+			for d := range documentsPerPassenger {
+				documentRows = append(documentRows, entities.Document{
+					Id:          uuid.NewString(),
+					Type:        fmt.Sprintf("Document %d", d),
+					Number:      passengerId,
+					PassengerId: passengerId,
+				})
+			}
+		}
+	}
+
+	require.NoError(b, repo.CopyTickets(ctx, ticketRows))
+	require.NoError(b, repo.CopyPassengers(ctx, passengerRows))
+	require.NoError(b, repo.CopyDocuments(ctx, documentRows))
+	require.NoError(b, repo.CopyBindings(ctx, bindingRows))
+
+	return ticketIds
+}
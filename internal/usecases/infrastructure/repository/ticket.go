@@ -7,7 +7,6 @@ import (
 
 	"github.com/Masterminds/squirrel"
 	"github.com/jackc/pgx/v5"
-	"github.com/jackc/pgx/v5/pgconn"
 	"github.com/v1adhope/flights/internal/entities"
 )
 
@@ -36,11 +35,17 @@ func (r *Repository) CreateTicket(ctx context.Context, ticket entities.Ticket) e
 		return fmt.Errorf("repository: ticket: CreateTicket: Insert: %w", err)
 	}
 
-	if _, err := r.Pool.Exec(ctx, sql, args...); err != nil {
-		return fmt.Errorf("repository: ticket: CreateTicket: Exec: %w", err)
-	}
+	return r.WithinTx(ctx, func(ctx context.Context) error {
+		if _, err := r.Conn(ctx).Exec(ctx, sql, args...); err != nil {
+			return fmt.Errorf("repository: ticket: CreateTicket: Exec: %w", translateError(err, "tickets"))
+		}
+
+		if err := r.notify(ctx, entities.Change{Entity: entities.ChangeTicket, Id: ticket.Id}); err != nil {
+			return fmt.Errorf("repository: ticket: CreateTicket: %w", err)
+		}
 
-	return nil
+		return nil
+	})
 }
 
 func (r *Repository) ReplaceTicket(ctx context.Context, ticket entities.Ticket) error {
@@ -60,16 +65,22 @@ func (r *Repository) ReplaceTicket(ctx context.Context, ticket entities.Ticket)
 		return fmt.Errorf("repository: ticket: ReplaceTicket: Update: %w", err)
 	}
 
-	tag, err := r.Pool.Exec(ctx, sql, args...)
-	if err != nil {
-		return fmt.Errorf("repository: ticket: ReplaceTicket: Exec: %w", err)
-	}
+	return r.WithinTx(ctx, func(ctx context.Context) error {
+		tag, err := r.Conn(ctx).Exec(ctx, sql, args...)
+		if err != nil {
+			return fmt.Errorf("repository: ticket: ReplaceTicket: Exec: %w", translateError(err, "tickets"))
+		}
 
-	if tag.RowsAffected() == 0 {
-		return fmt.Errorf("repository: ticket: ReplaceTicket: RowsAffected: %w", entities.ErrorNothingToChange)
-	}
+		if tag.RowsAffected() == 0 {
+			return fmt.Errorf("repository: ticket: ReplaceTicket: RowsAffected: %w", entities.ErrorNothingToChange)
+		}
+
+		if err := r.notify(ctx, entities.Change{Entity: entities.ChangeTicket, Id: ticket.Id}); err != nil {
+			return fmt.Errorf("repository: ticket: ReplaceTicket: %w", err)
+		}
 
-	return nil
+		return nil
+	})
 }
 
 func (r *Repository) DeleteTicket(ctx context.Context, id entities.Id) error {
@@ -82,23 +93,22 @@ func (r *Repository) DeleteTicket(ctx context.Context, id entities.Id) error {
 		return fmt.Errorf("repository: ticket: DeleteTicket: Delete: %w", err)
 	}
 
-	tag, err := r.Pool.Exec(ctx, sql, args...)
-	if err != nil {
-		var pgErr *pgconn.PgError
-		if errors.As(err, &pgErr) {
-			if pgErr.ConstraintName == "fk_ticket_passenger_tickets_ticket_id" {
-				return fmt.Errorf("repository: document: BoundToTicket: Exec: %w", entities.ErrorsThereArePassengersOnTheFlight)
-			}
+	return r.WithinTx(ctx, func(ctx context.Context) error {
+		tag, err := r.Conn(ctx).Exec(ctx, sql, args...)
+		if err != nil {
+			return fmt.Errorf("repository: ticket: DeleteTicket: Exec: %w", translateError(err, "tickets"))
 		}
 
-		return fmt.Errorf("repository: ticket: DeleteTicket: Exec: %w", err)
-	}
+		if tag.RowsAffected() == 0 {
+			return fmt.Errorf("repository: ticket: DeleteTicket: RowsAffected: %w", entities.ErrorNothingToDelete)
+		}
 
-	if tag.RowsAffected() == 0 {
-		return fmt.Errorf("repository: ticket: DeleteTicket: RowsAffected: %w", entities.ErrorNothingToDelete)
-	}
+		if err := r.notify(ctx, entities.Change{Entity: entities.ChangeTicket, Id: id.Value}); err != nil {
+			return fmt.Errorf("repository: ticket: DeleteTicket: %w", err)
+		}
 
-	return nil
+		return nil
+	})
 }
 
 func (r *Repository) GetTickets(ctx context.Context) ([]entities.Ticket, error) {
@@ -117,7 +127,7 @@ func (r *Repository) GetTickets(ctx context.Context) ([]entities.Ticket, error)
 		return []entities.Ticket{}, fmt.Errorf("repository: ticket: GetAllTickets: Select: %w", err)
 	}
 
-	rows, err := r.Pool.Query(ctx, sql, args...)
+	rows, err := r.ReadConn(ctx).Query(ctx, sql, args...)
 	if err != nil {
 		return []entities.Ticket{}, fmt.Errorf("repository: ticket: GetAllTickets: Query: %w", err)
 	}
@@ -150,104 +160,281 @@ func (r *Repository) GetTickets(ctx context.Context) ([]entities.Ticket, error)
 	return tickets, nil
 }
 
+// GetTicketsPage pages tickets by id, ids are UUIDv6 so pages follow creation order.
+func (r *Repository) GetTicketsPage(ctx context.Context, page entities.Page) ([]entities.Ticket, error) {
+	builder := r.Builder.Select(
+		"ticket_id",
+		"provider",
+		"fly_from",
+		"fly_to",
+		"fly_at",
+		"arrive_at",
+		"created_at",
+	).
+		From("tickets").
+		OrderBy("ticket_id").
+		Limit(page.Limit)
+
+	if page.After != "" {
+		builder = builder.Where(squirrel.Gt{
+			"ticket_id": page.After,
+		})
+	}
+
+	sql, args, err := builder.ToSql()
+	if err != nil {
+		return []entities.Ticket{}, fmt.Errorf("repository: ticket: GetTicketsPage: Select: %w", err)
+	}
+
+	rows, err := r.ReadConn(ctx).Query(ctx, sql, args...)
+	if err != nil {
+		return []entities.Ticket{}, fmt.Errorf("repository: ticket: GetTicketsPage: Query: %w", err)
+	}
+
+	tickets := []entities.Ticket{}
+	ticket := ticketDto{}
+
+	_, err = pgx.ForEachRow(
+		rows,
+		[]any{
+			&ticket.Id,
+			&ticket.Provider,
+			&ticket.FlyFrom,
+			&ticket.FlyTo,
+			&ticket.FlyAt,
+			&ticket.ArriveAt,
+			&ticket.CreatedAt,
+		}, func() error {
+			tickets = append(tickets, ticket.toEntity())
+			return nil
+		})
+	if err != nil {
+		return []entities.Ticket{}, fmt.Errorf("repository: ticket: GetTicketsPage: ForEachRow: %w", err)
+	}
+
+	return tickets, nil
+}
+
+// _ticketPassengers aggregates the passengers of the ticket with their documents into one json array,
+// so the ticket is a single row however many passengers and documents it has.
+// Text is ordered by bytes with collate "C" whatever the database collation is, the memory storage orders it alike.
+const _ticketPassengers = `lateral (
+	select json_agg(json_build_object(
+		'id', passengers.passenger_id,
+		'firstName', passengers.first_name,
+		'lastName', passengers.last_name,
+		'middleName', passengers.middle_name,
+		'documents', passenger_documents.documents
+	) order by passengers.last_name collate "C", passengers.first_name collate "C", passengers.passenger_id) as passengers
+	from passenger_ticket
+	join passengers using(passenger_id)
+	left join lateral (
+		select json_agg(json_build_object(
+			'id', documents.document_id,
+			'type', documents.type,
+			'number', documents.number
+		) order by documents.type collate "C", documents.number collate "C") as documents
+		from documents
+		where documents.passenger_id = passengers.passenger_id
+	) passenger_documents on true
+	where passenger_ticket.ticket_id = tickets.ticket_id
+) ticket_passengers on true`
+
+// GetWholeInfoAboutTicket returns passengers ordered by last name and their documents ordered by type.
 func (r *Repository) GetWholeInfoAboutTicket(ctx context.Context, id entities.Id) (entities.TicketWholeInfo, error) {
 	sql, args, err := r.Builder.Select(
+		"tickets.ticket_id",
 		"tickets.provider",
 		"tickets.fly_from",
 		"tickets.fly_to",
 		"tickets.fly_at",
 		"tickets.arrive_at",
 		"tickets.created_at",
-		"passengers.passenger_id",
-		"passengers.first_name",
-		"passengers.last_name",
-		"passengers.middle_name",
-		"documents.document_id",
-		"documents.type",
-		"documents.number",
+		"ticket_passengers.passengers",
 	).
 		From("tickets").
-		LeftJoin("passenger_ticket using(ticket_id)").
-		LeftJoin("passengers using(passenger_id)").
-		LeftJoin("documents using(passenger_id)").
+		LeftJoin(_ticketPassengers).
 		Where(squirrel.Eq{
-			"ticket_id": id.Value,
+			"tickets.ticket_id": id.Value,
 		}).
 		ToSql()
 	if err != nil {
 		return entities.TicketWholeInfo{}, fmt.Errorf("repository: ticket: GetWholeInfoAboutTicket: Select: %w", err)
 	}
 
-	rows, err := r.Pool.Query(ctx, sql, args...)
+	ticketDto := ticketDto{}
+	passengersDto := []passengerTicketWholeInfoDto{}
+
+	err = r.ReadConn(ctx).QueryRow(ctx, sql, args...).Scan(
+		&ticketDto.Id,
+		&ticketDto.Provider,
+		&ticketDto.FlyFrom,
+		&ticketDto.FlyTo,
+		&ticketDto.FlyAt,
+		&ticketDto.ArriveAt,
+		&ticketDto.CreatedAt,
+		&passengersDto,
+	)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return entities.TicketWholeInfo{}, fmt.Errorf("repository: ticket: GetWholeInfoAboutTicket: Scan: %w", entities.ErrorNothingFound)
+	}
 	if err != nil {
-		return entities.TicketWholeInfo{}, fmt.Errorf("repository: ticket: GetWholeInfoAboutTicket: Query: %w", err)
+		return entities.TicketWholeInfo{}, fmt.Errorf("repository: ticket: GetWholeInfoAboutTicket: Scan: %w", err)
 	}
 
-	ticketDto := ticketDto{}
-	passengerDto := passengerTicketWholeInfoDto{}
-	documentDto := documentTicketWholeInfoDto{}
-	documentsByPassenger := map[entities.Passenger][]entities.DocumentTicketWholeInfo{}
+	ticket := entities.TicketWholeInfo{
+		Ticket:     ticketDto.toEntity(),
+		Passengers: []entities.PassengerTicketWholeInfo{},
+	}
+
+	for _, passengerDto := range passengersDto {
+		ticket.Passengers = append(ticket.Passengers, passengerDto.toEntity())
+	}
+
+	return ticket, nil
+}
+
+// GetTicketsByIds is a batch read for loaders, missing tickets are just absent from the result.
+func (r *Repository) GetTicketsByIds(ctx context.Context, ids []string) ([]entities.Ticket, error) {
+	sql, args, err := r.Builder.Select(
+		"ticket_id",
+		"provider",
+		"fly_from",
+		"fly_to",
+		"fly_at",
+		"arrive_at",
+		"created_at",
+	).
+		From("tickets").
+		Where(squirrel.Eq{
+			"ticket_id": ids,
+		}).
+		ToSql()
+	if err != nil {
+		return []entities.Ticket{}, fmt.Errorf("repository: ticket: GetTicketsByIds: Select: %w", err)
+	}
 
-	tag, err := pgx.ForEachRow(
+	rows, err := r.ReadConn(ctx).Query(ctx, sql, args...)
+	if err != nil {
+		return []entities.Ticket{}, fmt.Errorf("repository: ticket: GetTicketsByIds: Query: %w", err)
+	}
+
+	tickets := []entities.Ticket{}
+	ticket := ticketDto{}
+
+	_, err = pgx.ForEachRow(
 		rows,
 		[]any{
-			&ticketDto.Provider,
-			&ticketDto.FlyFrom,
-			&ticketDto.FlyTo,
-			&ticketDto.FlyAt,
-			&ticketDto.ArriveAt,
-			&ticketDto.CreatedAt,
-			&passengerDto.Id,
-			&passengerDto.FirstName,
-			&passengerDto.LastName,
-			&passengerDto.MiddleName,
-			&documentDto.Id,
-			&documentDto.Type,
-			&documentDto.Number,
-		},
-		func() error {
-			if passengerDto.Id == nil {
-				return nil
-			}
-
-			passenger := passengerDto.toEntity()
-
-			if documentDto.Id == nil {
-				documentsByPassenger[passenger] = nil
-				return nil
-			}
-
-			if _, ok := documentsByPassenger[passenger]; !ok {
-				documentsByPassenger[passenger] = []entities.DocumentTicketWholeInfo{}
-			}
-
-			documentsByPassenger[passenger] = append(
-				documentsByPassenger[passenger],
-				documentDto.toEntity(),
-			)
-
+			&ticket.Id,
+			&ticket.Provider,
+			&ticket.FlyFrom,
+			&ticket.FlyTo,
+			&ticket.FlyAt,
+			&ticket.ArriveAt,
+			&ticket.CreatedAt,
+		}, func() error {
+			tickets = append(tickets, ticket.toEntity())
 			return nil
-		},
-	)
+		})
 	if err != nil {
-		return entities.TicketWholeInfo{}, fmt.Errorf("repository: ticket: GetWholeInfoAboutTicket: ForEachRow: %w", err)
+		return []entities.Ticket{}, fmt.Errorf("repository: ticket: GetTicketsByIds: ForEachRow: %w", err)
 	}
 
-	if tag.RowsAffected() == 0 {
-		return entities.TicketWholeInfo{}, fmt.Errorf("repository: ticket: GetWholeInfoAboutTicket: RowsAffected: %w", entities.ErrorNothingFound)
+	return tickets, nil
+}
+
+// GetTicketsByPassengerIds is a batch read for loaders, the result is keyed by passenger id.
+func (r *Repository) GetTicketsByPassengerIds(ctx context.Context, ids []string) (map[string][]entities.Ticket, error) {
+	sql, args, err := r.Builder.Select(
+		"passenger_ticket.passenger_id",
+		"tickets.ticket_id",
+		"tickets.provider",
+		"tickets.fly_from",
+		"tickets.fly_to",
+		"tickets.fly_at",
+		"tickets.arrive_at",
+		"tickets.created_at",
+	).
+		From("passenger_ticket").
+		Join("tickets using(ticket_id)").
+		Where(squirrel.Eq{
+			"passenger_ticket.passenger_id": ids,
+		}).
+		OrderBy("tickets.fly_at").
+		ToSql()
+	if err != nil {
+		return map[string][]entities.Ticket{}, fmt.Errorf("repository: ticket: GetTicketsByPassengerIds: Select: %w", err)
 	}
 
-	ticket := entities.TicketWholeInfo{
-		Ticket:     ticketDto.toEntity(),
-		Passengers: []entities.PassengerTicketWholeInfo{},
+	rows, err := r.ReadConn(ctx).Query(ctx, sql, args...)
+	if err != nil {
+		return map[string][]entities.Ticket{}, fmt.Errorf("repository: ticket: GetTicketsByPassengerIds: Query: %w", err)
 	}
 
-	for passenger, document := range documentsByPassenger {
-		ticket.Passengers = append(ticket.Passengers, entities.PassengerTicketWholeInfo{
-			Passenger: passenger,
-			Documents: document,
+	ticketsByPassenger := map[string][]entities.Ticket{}
+	passengerId := ""
+	ticket := ticketDto{}
+
+	_, err = pgx.ForEachRow(
+		rows,
+		[]any{
+			&passengerId,
+			&ticket.Id,
+			&ticket.Provider,
+			&ticket.FlyFrom,
+			&ticket.FlyTo,
+			&ticket.FlyAt,
+			&ticket.ArriveAt,
+			&ticket.CreatedAt,
+		}, func() error {
+			ticketsByPassenger[passengerId] = append(ticketsByPassenger[passengerId], ticket.toEntity())
+			return nil
 		})
+	if err != nil {
+		return map[string][]entities.Ticket{}, fmt.Errorf("repository: ticket: GetTicketsByPassengerIds: ForEachRow: %w", err)
 	}
 
-	return ticket, nil
+	return ticketsByPassenger, nil
+}
+
+// LockTicket reads the ticket on the primary and locks it until the end of the transaction of ctx.
+func (r *Repository) LockTicket(ctx context.Context, id entities.Id) (entities.Ticket, error) {
+	sql, args, err := r.Builder.Select(
+		"ticket_id",
+		"provider",
+		"fly_from",
+		"fly_to",
+		"fly_at",
+		"arrive_at",
+		"created_at",
+	).
+		From("tickets").
+		Where(squirrel.Eq{
+			"ticket_id": id.Value,
+		}).
+		Suffix("for update").
+		ToSql()
+	if err != nil {
+		return entities.Ticket{}, fmt.Errorf("repository: ticket: LockTicket: Select: %w", err)
+	}
+
+	ticket := ticketDto{}
+
+	err = r.Conn(ctx).QueryRow(ctx, sql, args...).Scan(
+		&ticket.Id,
+		&ticket.Provider,
+		&ticket.FlyFrom,
+		&ticket.FlyTo,
+		&ticket.FlyAt,
+		&ticket.ArriveAt,
+		&ticket.CreatedAt,
+	)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return entities.Ticket{}, fmt.Errorf("repository: ticket: LockTicket: Scan: %w", entities.ErrorNothingFound)
+	}
+	if err != nil {
+		return entities.Ticket{}, fmt.Errorf("repository: ticket: LockTicket: Scan: %w", err)
+	}
+
+	return ticket.toEntity(), nil
 }
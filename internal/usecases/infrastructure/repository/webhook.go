@@ -0,0 +1,436 @@
+package repository
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/Masterminds/squirrel"
+	"github.com/jackc/pgx/v5"
+	"github.com/v1adhope/flights/internal/entities"
+	"github.com/v1adhope/flights/pkg/postgresql"
+)
+
+var webhookDeliveryColumns = []string{
+	"delivery_id",
+	"subscription_id",
+	"event_id",
+	"event_type",
+	"payload",
+	"status",
+	"attempts",
+	"next_attempt_at",
+	"last_status_code",
+	"last_error",
+	"created_at",
+	"delivered_at",
+}
+
+func (r *Repository) CreateWebhookSubscription(ctx context.Context, subscription entities.WebhookSubscription) error {
+	sql, args, err := r.Builder.Insert("webhook_subscriptions").
+		Columns(
+			"subscription_id",
+			"url",
+			"secret",
+			"event_types",
+			"active",
+			"created_at",
+		).
+		Values(
+			subscription.Id,
+			subscription.Url,
+			subscription.Secret,
+			subscription.EventTypes,
+			subscription.Active,
+			subscription.CreatedAt,
+		).
+		ToSql()
+	if err != nil {
+		return fmt.Errorf("repository: webhook: CreateWebhookSubscription: Insert: %w", err)
+	}
+
+	if _, err := r.Conn(ctx).Exec(ctx, sql, args...); err != nil {
+		return fmt.Errorf("repository: webhook: CreateWebhookSubscription: Exec: %w", translateError(err, "webhook_subscriptions"))
+	}
+
+	return nil
+}
+
+func (r *Repository) ReplaceWebhookSubscription(ctx context.Context, subscription entities.WebhookSubscription) error {
+	set := squirrel.Eq{
+		"url":         subscription.Url,
+		"event_types": subscription.EventTypes,
+		"active":      subscription.Active,
+	}
+
+	if subscription.Secret != "" {
+		set["secret"] = subscription.Secret
+	}
+
+	sql, args, err := r.Builder.Update("webhook_subscriptions").
+		SetMap(set).
+		Where(squirrel.Eq{
+			"subscription_id": subscription.Id,
+		}).
+		ToSql()
+	if err != nil {
+		return fmt.Errorf("repository: webhook: ReplaceWebhookSubscription: Update: %w", err)
+	}
+
+	tag, err := r.Conn(ctx).Exec(ctx, sql, args...)
+	if err != nil {
+		return fmt.Errorf("repository: webhook: ReplaceWebhookSubscription: Exec: %w", translateError(err, "webhook_subscriptions"))
+	}
+
+	if tag.RowsAffected() == 0 {
+		return fmt.Errorf("repository: webhook: ReplaceWebhookSubscription: RowsAffected: %w", entities.ErrorNothingToChange)
+	}
+
+	return nil
+}
+
+func (r *Repository) DeleteWebhookSubscription(ctx context.Context, id entities.Id) error {
+	sql, args, err := r.Builder.Delete("webhook_subscriptions").
+		Where(squirrel.Eq{
+			"subscription_id": id.Value,
+		}).
+		ToSql()
+	if err != nil {
+		return fmt.Errorf("repository: webhook: DeleteWebhookSubscription: Delete: %w", err)
+	}
+
+	tag, err := r.Conn(ctx).Exec(ctx, sql, args...)
+	if err != nil {
+		return fmt.Errorf("repository: webhook: DeleteWebhookSubscription: Exec: %w", translateError(err, "webhook_subscriptions"))
+	}
+
+	if tag.RowsAffected() == 0 {
+		return fmt.Errorf("repository: webhook: DeleteWebhookSubscription: RowsAffected: %w", entities.ErrorNothingToDelete)
+	}
+
+	return nil
+}
+
+func (r *Repository) GetWebhookSubscriptions(ctx context.Context) ([]entities.WebhookSubscription, error) {
+	sql, args, err := r.selectWebhookSubscriptions().
+		OrderBy("created_at").
+		ToSql()
+	if err != nil {
+		return []entities.WebhookSubscription{}, fmt.Errorf("repository: webhook: GetWebhookSubscriptions: Select: %w", err)
+	}
+
+	subscriptions, err := r.queryWebhookSubscriptions(ctx, sql, args...)
+	if err != nil {
+		return []entities.WebhookSubscription{}, fmt.Errorf("repository: webhook: GetWebhookSubscriptions: %w", err)
+	}
+
+	if len(subscriptions) == 0 {
+		return []entities.WebhookSubscription{}, fmt.Errorf("repository: webhook: GetWebhookSubscriptions: len: %w", entities.ErrorNothingFound)
+	}
+
+	return subscriptions, nil
+}
+
+func (r *Repository) GetActiveWebhookSubscriptions(ctx context.Context) ([]entities.WebhookSubscription, error) {
+	sql, args, err := r.selectWebhookSubscriptions().
+		Where(squirrel.Eq{
+			"active": true,
+		}).
+		ToSql()
+	if err != nil {
+		return []entities.WebhookSubscription{}, fmt.Errorf("repository: webhook: GetActiveWebhookSubscriptions: Select: %w", err)
+	}
+
+	subscriptions, err := r.queryWebhookSubscriptions(ctx, sql, args...)
+	if err != nil {
+		return []entities.WebhookSubscription{}, fmt.Errorf("repository: webhook: GetActiveWebhookSubscriptions: %w", err)
+	}
+
+	return subscriptions, nil
+}
+
+func (r *Repository) GetWebhookSubscription(ctx context.Context, id entities.Id) (entities.WebhookSubscription, error) {
+	sql, args, err := r.selectWebhookSubscriptions().
+		Where(squirrel.Eq{
+			"subscription_id": id.Value,
+		}).
+		ToSql()
+	if err != nil {
+		return entities.WebhookSubscription{}, fmt.Errorf("repository: webhook: GetWebhookSubscription: Select: %w", err)
+	}
+
+	subscriptions, err := r.queryWebhookSubscriptions(ctx, sql, args...)
+	if err != nil {
+		return entities.WebhookSubscription{}, fmt.Errorf("repository: webhook: GetWebhookSubscription: %w", err)
+	}
+
+	if len(subscriptions) == 0 {
+		return entities.WebhookSubscription{}, fmt.Errorf("repository: webhook: GetWebhookSubscription: len: %w", entities.ErrorNothingFound)
+	}
+
+	return subscriptions[0], nil
+}
+
+func (r *Repository) selectWebhookSubscriptions() squirrel.SelectBuilder {
+	return r.Builder.Select(
+		"subscription_id",
+		"url",
+		"secret",
+		"event_types",
+		"active",
+		"created_at",
+	).
+		From("webhook_subscriptions")
+}
+
+func (r *Repository) queryWebhookSubscriptions(ctx context.Context, sql string, args ...any) ([]entities.WebhookSubscription, error) {
+	rows, err := r.ReadConn(ctx).Query(ctx, sql, args...)
+	if err != nil {
+		return nil, fmt.Errorf("Query: %w", err)
+	}
+
+	subscriptions := []entities.WebhookSubscription{}
+	subscriptionDto := webhookSubscriptionDto{}
+
+	_, err = pgx.ForEachRow(
+		rows,
+		[]any{
+			&subscriptionDto.Id,
+			&subscriptionDto.Url,
+			&subscriptionDto.Secret,
+			&subscriptionDto.EventTypes,
+			&subscriptionDto.Active,
+			&subscriptionDto.CreatedAt,
+		},
+		func() error {
+			subscriptions = append(subscriptions, subscriptionDto.toEntity())
+			return nil
+		},
+	)
+	if err != nil {
+		return nil, fmt.Errorf("ForEachRow: %w", err)
+	}
+
+	return subscriptions, nil
+}
+
+// AddWebhookDeliveries skips deliveries of an event already enqueued for the subscription, the relay may publish an event twice.
+func (r *Repository) AddWebhookDeliveries(ctx context.Context, deliveries ...entities.WebhookDelivery) error {
+	if len(deliveries) == 0 {
+		return nil
+	}
+
+	builder := r.Builder.Insert("webhook_deliveries").
+		Columns(
+			"delivery_id",
+			"subscription_id",
+			"event_id",
+			"event_type",
+			"payload",
+			"status",
+			"attempts",
+			"next_attempt_at",
+			"created_at",
+		)
+
+	for _, delivery := range deliveries {
+		builder = builder.Values(
+			delivery.Id,
+			delivery.SubscriptionId,
+			delivery.EventId,
+			delivery.EventType,
+			[]byte(delivery.Payload),
+			delivery.Status,
+			delivery.Attempts,
+			delivery.NextAttemptAt,
+			delivery.CreatedAt,
+		)
+	}
+
+	sql, args, err := builder.
+		Suffix("on conflict (subscription_id, event_id) do nothing").
+		ToSql()
+	if err != nil {
+		return fmt.Errorf("repository: webhook: AddWebhookDeliveries: Insert: %w", err)
+	}
+
+	if _, err := r.Conn(ctx).Exec(ctx, sql, args...); err != nil {
+		return fmt.Errorf("repository: webhook: AddWebhookDeliveries: Exec: %w", translateError(err, "webhook_deliveries"))
+	}
+
+	return nil
+}
+
+// ClaimDueWebhookDeliveries postpones due pending deliveries of active subscriptions by lease and returns them,
+// concurrent workers never claim the same delivery until the lease is over.
+func (r *Repository) ClaimDueWebhookDeliveries(ctx context.Context, limit uint64, lease time.Duration) ([]entities.WebhookDelivery, error) {
+	// Nested builder keeps ? placeholders, the outer one numbers them all.
+	due := r.Builder.PlaceholderFormat(squirrel.Question).Select("delivery_id").
+		From("webhook_deliveries").
+		Where(squirrel.And{
+			squirrel.Eq{
+				"status": entities.WebhookDeliveryPending,
+			},
+			squirrel.Expr("next_attempt_at <= now()"),
+			squirrel.Expr("exists (select 1 from webhook_subscriptions s where s.subscription_id = webhook_deliveries.subscription_id and s.active)"),
+		}).
+		OrderBy("next_attempt_at").
+		Limit(limit).
+		Suffix("for update skip locked")
+
+	sql, args, err := r.Builder.Update("webhook_deliveries").
+		Set("next_attempt_at", squirrel.Expr("now() + make_interval(secs => ?)", lease.Seconds())).
+		Where(squirrel.Expr("delivery_id in (?)", due)).
+		Suffix("returning " + strings.Join(webhookDeliveryColumns, ", ")).
+		ToSql()
+	if err != nil {
+		return []entities.WebhookDelivery{}, fmt.Errorf("repository: webhook: ClaimDueWebhookDeliveries: Update: %w", err)
+	}
+
+	deliveries, err := r.queryWebhookDeliveries(ctx, r.Conn(ctx), sql, args...)
+	if err != nil {
+		return []entities.WebhookDelivery{}, fmt.Errorf("repository: webhook: ClaimDueWebhookDeliveries: %w", err)
+	}
+
+	return deliveries, nil
+}
+
+func (r *Repository) UpdateWebhookDelivery(ctx context.Context, delivery entities.WebhookDelivery) error {
+	set := squirrel.Eq{
+		"status":           delivery.Status,
+		"attempts":         delivery.Attempts,
+		"next_attempt_at":  nullableString(delivery.NextAttemptAt),
+		"last_status_code": nullableInt(delivery.LastStatusCode),
+		"last_error":       nullableString(delivery.LastError),
+		"delivered_at":     nullableString(delivery.DeliveredAt),
+	}
+
+	sql, args, err := r.Builder.Update("webhook_deliveries").
+		SetMap(set).
+		Where(squirrel.Eq{
+			"delivery_id": delivery.Id,
+		}).
+		ToSql()
+	if err != nil {
+		return fmt.Errorf("repository: webhook: UpdateWebhookDelivery: Update: %w", err)
+	}
+
+	tag, err := r.Conn(ctx).Exec(ctx, sql, args...)
+	if err != nil {
+		return fmt.Errorf("repository: webhook: UpdateWebhookDelivery: Exec: %w", translateError(err, "webhook_deliveries"))
+	}
+
+	if tag.RowsAffected() == 0 {
+		return fmt.Errorf("repository: webhook: UpdateWebhookDelivery: RowsAffected: %w", entities.ErrorNothingToChange)
+	}
+
+	return nil
+}
+
+func (r *Repository) GetWebhookDeliveries(ctx context.Context, subscriptionId entities.Id) ([]entities.WebhookDelivery, error) {
+	sql, args, err := r.Builder.Select(webhookDeliveryColumns...).
+		From("webhook_deliveries").
+		Where(squirrel.Eq{
+			"subscription_id": subscriptionId.Value,
+		}).
+		OrderBy("created_at desc").
+		ToSql()
+	if err != nil {
+		return []entities.WebhookDelivery{}, fmt.Errorf("repository: webhook: GetWebhookDeliveries: Select: %w", err)
+	}
+
+	deliveries, err := r.queryWebhookDeliveries(ctx, r.ReadConn(ctx), sql, args...)
+	if err != nil {
+		return []entities.WebhookDelivery{}, fmt.Errorf("repository: webhook: GetWebhookDeliveries: %w", err)
+	}
+
+	if len(deliveries) == 0 {
+		return []entities.WebhookDelivery{}, fmt.Errorf("repository: webhook: GetWebhookDeliveries: len: %w", entities.ErrorNothingFound)
+	}
+
+	return deliveries, nil
+}
+
+func (r *Repository) RedeliverWebhookDelivery(ctx context.Context, subscriptionId, deliveryId entities.Id) error {
+	sql, args, err := r.Builder.Update("webhook_deliveries").
+		SetMap(squirrel.Eq{
+			"status":          entities.WebhookDeliveryPending,
+			"attempts":        0,
+			"next_attempt_at": squirrel.Expr("now()"),
+			"delivered_at":    nil,
+		}).
+		Where(squirrel.Eq{
+			"delivery_id":     deliveryId.Value,
+			"subscription_id": subscriptionId.Value,
+		}).
+		ToSql()
+	if err != nil {
+		return fmt.Errorf("repository: webhook: RedeliverWebhookDelivery: Update: %w", err)
+	}
+
+	tag, err := r.Conn(ctx).Exec(ctx, sql, args...)
+	if err != nil {
+		return fmt.Errorf("repository: webhook: RedeliverWebhookDelivery: Exec: %w", translateError(err, "webhook_deliveries"))
+	}
+
+	if tag.RowsAffected() == 0 {
+		return fmt.Errorf("repository: webhook: RedeliverWebhookDelivery: RowsAffected: %w", entities.ErrorNothingToChange)
+	}
+
+	return nil
+}
+
+// queryWebhookDeliveries reads deliveries returned by q, claims must pass the primary.
+func (r *Repository) queryWebhookDeliveries(ctx context.Context, q postgresql.Querier, sql string, args ...any) ([]entities.WebhookDelivery, error) {
+	rows, err := q.Query(ctx, sql, args...)
+	if err != nil {
+		return nil, fmt.Errorf("Query: %w", err)
+	}
+
+	deliveries := []entities.WebhookDelivery{}
+	deliveryDto := webhookDeliveryDto{}
+
+	_, err = pgx.ForEachRow(
+		rows,
+		[]any{
+			&deliveryDto.Id,
+			&deliveryDto.SubscriptionId,
+			&deliveryDto.EventId,
+			&deliveryDto.EventType,
+			&deliveryDto.Payload,
+			&deliveryDto.Status,
+			&deliveryDto.Attempts,
+			&deliveryDto.NextAttemptAt,
+			&deliveryDto.LastStatusCode,
+			&deliveryDto.LastError,
+			&deliveryDto.CreatedAt,
+			&deliveryDto.DeliveredAt,
+		},
+		func() error {
+			deliveries = append(deliveries, deliveryDto.toEntity())
+			return nil
+		},
+	)
+	if err != nil {
+		return nil, fmt.Errorf("ForEachRow: %w", err)
+	}
+
+	return deliveries, nil
+}
+
+func nullableString(s string) any {
+	if s == "" {
+		return nil
+	}
+
+	return s
+}
+
+func nullableInt(i int) any {
+	if i == 0 {
+		return nil
+	}
+
+	return i
+}
@@ -0,0 +1,76 @@
+package webhook
+
+import (
+	"bytes"
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"io"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/v1adhope/flights/internal/entities"
+)
+
+const (
+	HeaderSignature = "X-Flights-Signature"
+	HeaderEvent     = "X-Flights-Event"
+	HeaderEventId   = "X-Flights-Event-Id"
+	HeaderDelivery  = "X-Flights-Delivery"
+)
+
+// Sender posts delivery payloads signed as
+// X-Flights-Signature: t=<unix seconds>,v1=<hex HMAC-SHA256 of "<t>.<body>" with the subscription secret>.
+// Receivers should reject stale timestamps to prevent replays.
+type Sender struct {
+	client *http.Client
+}
+
+func NewSender() *Sender {
+	return &Sender{
+		client: &http.Client{},
+	}
+}
+
+func (s *Sender) Send(ctx context.Context, subscription entities.WebhookSubscription, delivery entities.WebhookDelivery) (int, error) {
+	body := []byte(delivery.Payload)
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, subscription.Url, bytes.NewReader(body))
+	if err != nil {
+		return 0, fmt.Errorf("webhook: sender: Send: NewRequestWithContext: %w", err)
+	}
+
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set(HeaderSignature, Sign(subscription.Secret, time.Now(), body))
+	req.Header.Set(HeaderEvent, delivery.EventType)
+	req.Header.Set(HeaderEventId, delivery.EventId)
+	req.Header.Set(HeaderDelivery, delivery.Id)
+
+	resp, err := s.client.Do(req)
+	if err != nil {
+		return 0, fmt.Errorf("webhook: sender: Send: Do: %w", err)
+	}
+	defer resp.Body.Close()
+
+	io.Copy(io.Discard, io.LimitReader(resp.Body, 64<<10))
+
+	if resp.StatusCode < 200 || resp.StatusCode > 299 {
+		return resp.StatusCode, fmt.Errorf("webhook: sender: Send: unexpected status %d", resp.StatusCode)
+	}
+
+	return resp.StatusCode, nil
+}
+
+func Sign(secret string, t time.Time, body []byte) string {
+	timestamp := strconv.FormatInt(t.Unix(), 10)
+
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write([]byte(timestamp))
+	mac.Write([]byte("."))
+	mac.Write(body)
+
+	return fmt.Sprintf("t=%s,v1=%s", timestamp, hex.EncodeToString(mac.Sum(nil)))
+}
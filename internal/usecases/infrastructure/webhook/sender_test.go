@@ -0,0 +1,107 @@
+package webhook_test
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"regexp"
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/v1adhope/flights/internal/entities"
+	"github.com/v1adhope/flights/internal/usecases/infrastructure/webhook"
+)
+
+var signatureFormat = regexp.MustCompile(`^t=(\d+),v1=([0-9a-f]{64})$`)
+
+type received struct {
+	header http.Header
+	body   []byte
+}
+
+func receiver(t *testing.T, status int) (*httptest.Server, chan received) {
+	requests := make(chan received, 1)
+
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, err := io.ReadAll(r.Body)
+		require.NoError(t, err)
+
+		requests <- received{r.Header.Clone(), body}
+
+		w.WriteHeader(status)
+	}))
+	t.Cleanup(srv.Close)
+
+	return srv, requests
+}
+
+func delivery() entities.WebhookDelivery {
+	return entities.WebhookDelivery{
+		Id:        "1efc3c2d-4b4e-6a3e-8f4a-0242ac120002",
+		EventId:   "1efc3c2d-4b4e-6a3e-8f4a-0242ac120003",
+		EventType: entities.EventTicketCreated,
+		Payload:   json.RawMessage(`{"id":"1efc3c2d-4b4e-6a3e-8f4a-0242ac120004"}`),
+	}
+}
+
+func TestSend(t *testing.T) {
+	srv, requests := receiver(t, http.StatusNoContent)
+
+	subscription := entities.WebhookSubscription{Url: srv.URL, Secret: "secret"}
+	d := delivery()
+
+	before := time.Now().Unix()
+
+	status, err := webhook.NewSender().Send(context.Background(), subscription, d)
+	require.NoError(t, err)
+	assert.Equal(t, http.StatusNoContent, status)
+
+	r := <-requests
+	assert.JSONEq(t, string(d.Payload), string(r.body))
+	assert.Equal(t, "application/json", r.header.Get("Content-Type"))
+	assert.Equal(t, d.EventType, r.header.Get(webhook.HeaderEvent))
+	assert.Equal(t, d.EventId, r.header.Get(webhook.HeaderEventId))
+	assert.Equal(t, d.Id, r.header.Get(webhook.HeaderDelivery))
+
+	// The receiver recomputes the signature from the timestamp and the raw body
+	signature := r.header.Get(webhook.HeaderSignature)
+	match := signatureFormat.FindStringSubmatch(signature)
+	require.NotNil(t, match, signature)
+
+	timestamp, err := strconv.ParseInt(match[1], 10, 64)
+	require.NoError(t, err)
+	assert.InDelta(t, before, timestamp, 1)
+	assert.Equal(t, webhook.Sign("secret", time.Unix(timestamp, 0), r.body), signature)
+	assert.NotEqual(t, webhook.Sign("other", time.Unix(timestamp, 0), r.body), signature)
+}
+
+func TestSendUnexpectedStatus(t *testing.T) {
+	srv, requests := receiver(t, http.StatusBadGateway)
+
+	status, err := webhook.NewSender().Send(context.Background(), entities.WebhookSubscription{Url: srv.URL}, delivery())
+	assert.ErrorContains(t, err, "unexpected status 502")
+	assert.Equal(t, http.StatusBadGateway, status)
+	<-requests
+}
+
+func TestSendUnreachable(t *testing.T) {
+	srv, _ := receiver(t, http.StatusOK)
+	srv.Close()
+
+	status, err := webhook.NewSender().Send(context.Background(), entities.WebhookSubscription{Url: srv.URL}, delivery())
+	assert.Error(t, err)
+	assert.Zero(t, status)
+}
+
+func TestSign(t *testing.T) {
+	// HMAC-SHA256("secret", "1700000000.{}")
+	assert.Equal(t,
+		"t=1700000000,v1=b8569b78799ff9e3cbff0fc2d63a33a2b57f3282abd07c37ae5e8e7d79a5f163",
+		webhook.Sign("secret", time.Unix(1700000000, 0), []byte("{}")),
+	)
+}
@@ -2,24 +2,39 @@ package usecases
 
 import (
 	"context"
+	"time"
 
 	"github.com/v1adhope/flights/internal/entities"
 )
 
 type Reposer interface {
+	Transactor
 	Ticket
 	Passenger
 	Document
 	Report
+	Import
+	Outbox
+	Webhook
+	Idempotency
+	Health
 }
 
 type (
+	Transactor interface {
+		WithinTx(ctx context.Context, fn func(ctx context.Context) error) error
+	}
+
 	Ticket interface {
 		CreateTicket(ctx context.Context, ticket entities.Ticket) error
 		ReplaceTicket(ctx context.Context, ticket entities.Ticket) error
 		DeleteTicket(ctx context.Context, id entities.Id) error
 		GetTickets(ctx context.Context) ([]entities.Ticket, error)
+		GetTicketsPage(ctx context.Context, page entities.Page) ([]entities.Ticket, error)
 		GetWholeInfoAboutTicket(ctx context.Context, id entities.Id) (entities.TicketWholeInfo, error)
+		GetTicketsByIds(ctx context.Context, ids []string) ([]entities.Ticket, error)
+		GetTicketsByPassengerIds(ctx context.Context, ids []string) (map[string][]entities.Ticket, error)
+		LockTicket(ctx context.Context, id entities.Id) (entities.Ticket, error)
 	}
 
 	Passenger interface {
@@ -31,6 +46,9 @@ type (
 		GetPassengersByTicketId(ctx context.Context, id entities.Id) ([]entities.Passenger, error)
 
 		GetPassengers(ctx context.Context) ([]entities.Passenger, error)
+		GetPassengersByIds(ctx context.Context, ids []string) ([]entities.Passenger, error)
+		GetPassengersByTicketIds(ctx context.Context, ids []string) (map[string][]entities.Passenger, error)
+		LockPassenger(ctx context.Context, id entities.Id) (entities.Passenger, error)
 	}
 
 	Document interface {
@@ -38,9 +56,73 @@ type (
 		ReplaceDocument(ctx context.Context, document entities.Document) error
 		DeleteDocument(ctx context.Context, id entities.Id) error
 		GetDocumentsByPassengerId(ctx context.Context, id entities.Id) ([]entities.Document, error)
+		GetDocumentsByPassengerIds(ctx context.Context, ids []string) (map[string][]entities.Document, error)
 	}
 
 	Report interface {
 		GetRowsByPassengerIdForPeriod(ctx context.Context, id entities.Id, filter entities.PeriodFilter) ([]entities.ReportRowByPassengerForPeriod, error)
 	}
+
+	Import interface {
+		CopyTickets(ctx context.Context, tickets []entities.Ticket) error
+		CopyPassengers(ctx context.Context, passengers []entities.Passenger) error
+		CopyDocuments(ctx context.Context, documents []entities.Document) error
+		CopyBindings(ctx context.Context, bindings []entities.Binding) error
+	}
+
+	Outbox interface {
+		AddEvents(ctx context.Context, events ...entities.Event) error
+		ClaimUnpublishedEvents(ctx context.Context, limit uint64, lease time.Duration) ([]entities.Event, error)
+		MarkEventsPublished(ctx context.Context, seqs []int64) error
+		ReplayEvents(ctx context.Context, fromSeq int64, aggregateId string) (int64, error)
+	}
+
+	Webhook interface {
+		CreateWebhookSubscription(ctx context.Context, subscription entities.WebhookSubscription) error
+		ReplaceWebhookSubscription(ctx context.Context, subscription entities.WebhookSubscription) error
+		DeleteWebhookSubscription(ctx context.Context, id entities.Id) error
+		GetWebhookSubscriptions(ctx context.Context) ([]entities.WebhookSubscription, error)
+		GetActiveWebhookSubscriptions(ctx context.Context) ([]entities.WebhookSubscription, error)
+		GetWebhookSubscription(ctx context.Context, id entities.Id) (entities.WebhookSubscription, error)
+		AddWebhookDeliveries(ctx context.Context, deliveries ...entities.WebhookDelivery) error
+		ClaimDueWebhookDeliveries(ctx context.Context, limit uint64, lease time.Duration) ([]entities.WebhookDelivery, error)
+		UpdateWebhookDelivery(ctx context.Context, delivery entities.WebhookDelivery) error
+		GetWebhookDeliveries(ctx context.Context, subscriptionId entities.Id) ([]entities.WebhookDelivery, error)
+		RedeliverWebhookDelivery(ctx context.Context, subscriptionId, deliveryId entities.Id) error
+	}
+
+	Idempotency interface {
+		GetIdempotentResponse(ctx context.Context, key string) (entities.IdempotentResponse, error)
+		CreateIdempotentResponse(ctx context.Context, resp entities.IdempotentResponse) error
+		ReplaceIdempotentResponse(ctx context.Context, resp entities.IdempotentResponse) error
+		TakeOverIdempotentResponse(ctx context.Context, resp entities.IdempotentResponse, startedBefore string) error
+		DeleteIdempotentResponse(ctx context.Context, key string) error
+		DeleteIdempotentResponses(ctx context.Context, olderThan string) error
+	}
+
+	Health interface {
+		Ping(ctx context.Context) error
+		GetMigrationVersion(ctx context.Context) (uint, bool, error)
+	}
 )
+
+type Publisher interface {
+	Publish(ctx context.Context, event entities.Event) error
+}
+
+type WebhookSender interface {
+	Send(ctx context.Context, subscription entities.WebhookSubscription, delivery entities.WebhookDelivery) (int, error)
+}
+
+type Logger interface {
+	Info(format string, msg ...any)
+	Error(err error, format string, msg ...any)
+}
+
+// Observer watches usecase calls, metrics and tracing implement it.
+type Observer interface {
+	// StartCall is called when the usecase name starts, the returned func when it returns with its error.
+	StartCall(ctx context.Context, name string) (context.Context, func(err error))
+	// Count adds n to the business counter name, see the Count* constants.
+	Count(ctx context.Context, name string, n int)
+}
@@ -0,0 +1,38 @@
+package usecases
+
+import "context"
+
+// Business counters reported to observers.
+const (
+	CountTicketsCreated      = "tickets_created"
+	CountPassengersBound     = "passengers_bound"
+	CountReportRowsGenerated = "report_rows_generated"
+)
+
+// observe reports the call of the usecase name to the observers, done gets the error the usecase returns.
+func (u *Usecases) observe(ctx context.Context, name string) (context.Context, func(err *error)) {
+	if len(u.observers) == 0 {
+		return ctx, func(*error) {}
+	}
+
+	ends := make([]func(err error), 0, len(u.observers))
+
+	for _, o := range u.observers {
+		var end func(err error)
+
+		ctx, end = o.StartCall(ctx, name)
+		ends = append(ends, end)
+	}
+
+	return ctx, func(err *error) {
+		for i := len(ends) - 1; i >= 0; i-- {
+			ends[i](*err)
+		}
+	}
+}
+
+func (u *Usecases) count(ctx context.Context, name string, n int) {
+	for _, o := range u.observers {
+		o.Count(ctx, name, n)
+	}
+}
@@ -8,7 +8,10 @@ import (
 	"github.com/v1adhope/flights/internal/entities"
 )
 
-func (u *Usecases) CreatePassenger(ctx context.Context, passenger entities.Passenger) (entities.Id, error) {
+func (u *Usecases) CreatePassenger(ctx context.Context, passenger entities.Passenger) (_ entities.Id, err error) {
+	ctx, done := u.observe(ctx, "CreatePassenger")
+	defer done(&err)
+
 	id, err := uuid.NewV6()
 	if err != nil {
 		return entities.Id{}, fmt.Errorf("usecases: passenger: CreatePassenger: NewV6: %w", err)
@@ -16,46 +19,156 @@ func (u *Usecases) CreatePassenger(ctx context.Context, passenger entities.Passe
 
 	passenger.Id = id.String()
 
-	if err := u.repos.CreatePassenger(ctx, passenger); err != nil {
+	err = u.changeWithEvent(
+		ctx,
+		func(ctx context.Context) error {
+			return u.repos.CreatePassenger(ctx, passenger)
+		},
+		entities.EventPassengerCreated, entities.AggregatePassenger, passenger.Id, passenger,
+	)
+	if err != nil {
 		return entities.Id{}, err
 	}
 
 	return entities.Id{passenger.Id}, nil
 }
 
-func (u *Usecases) ReplacePassenger(ctx context.Context, passenger entities.Passenger) error {
-	if err := u.repos.ReplacePassenger(ctx, passenger); err != nil {
+// RegisterPassenger creates the passenger with its documents and binds it to the tickets, all or nothing.
+func (u *Usecases) RegisterPassenger(ctx context.Context, passenger entities.Passenger, documents []entities.Document, ticketIds []entities.Id) (_ entities.Id, err error) {
+	ctx, done := u.observe(ctx, "RegisterPassenger")
+	defer done(&err)
+
+	id := entities.Id{}
+
+	err = u.WithinTx(ctx, func(ctx context.Context) error {
+		id, err = u.CreatePassenger(ctx, passenger)
+		if err != nil {
+			return err
+		}
+
+		for _, document := range documents {
+			document.PassengerId = id.Value
+
+			if _, err := u.CreateDocument(ctx, document); err != nil {
+				return err
+			}
+		}
+
+		for _, ticketId := range ticketIds {
+			if err := u.BoundToTicket(ctx, id, ticketId); err != nil {
+				return err
+			}
+		}
+
+		return nil
+	})
+	if err != nil {
+		return entities.Id{}, err
+	}
+
+	return id, nil
+}
+
+func (u *Usecases) ReplacePassenger(ctx context.Context, passenger entities.Passenger) (err error) {
+	ctx, done := u.observe(ctx, "ReplacePassenger")
+	defer done(&err)
+
+	err = u.changeWithEvent(
+		ctx,
+		func(ctx context.Context) error {
+			return u.repos.ReplacePassenger(ctx, passenger)
+		},
+		entities.EventPassengerReplaced, entities.AggregatePassenger, passenger.Id, passenger,
+	)
+	if err != nil {
 		return err
 	}
 
 	return nil
 }
 
-func (u *Usecases) DeletePassenger(ctx context.Context, id entities.Id) error {
-	if err := u.repos.DeletePassenger(ctx, id); err != nil {
+func (u *Usecases) DeletePassenger(ctx context.Context, id entities.Id) (err error) {
+	ctx, done := u.observe(ctx, "DeletePassenger")
+	defer done(&err)
+
+	err = u.changeWithEvent(
+		ctx,
+		func(ctx context.Context) error {
+			return u.repos.DeletePassenger(ctx, id)
+		},
+		entities.EventPassengerDeleted, entities.AggregatePassenger, id.Value, id,
+	)
+	if err != nil {
 		return err
 	}
 
 	return nil
 }
 
-func (u *Usecases) BoundToTicket(ctx context.Context, id entities.Id, ticketId entities.Id) error {
-	if err := u.repos.BoundToTicket(ctx, id, ticketId); err != nil {
+// ReplacePassengerIf replaces the passenger if precondition accepts its current state, e.g. an If-Match check.
+func (u *Usecases) ReplacePassengerIf(ctx context.Context, passenger entities.Passenger, precondition func(current entities.Passenger) error) (err error) {
+	ctx, done := u.observe(ctx, "ReplacePassengerIf")
+	defer done(&err)
+
+	return withPrecondition(ctx, u.repos, entities.Id{Value: passenger.Id}, u.repos.LockPassenger, precondition, func(ctx context.Context) error {
+		return u.ReplacePassenger(ctx, passenger)
+	})
+}
+
+// DeletePassengerIf deletes the passenger if precondition accepts its current state, e.g. an If-Match check.
+func (u *Usecases) DeletePassengerIf(ctx context.Context, id entities.Id, precondition func(current entities.Passenger) error) (err error) {
+	ctx, done := u.observe(ctx, "DeletePassengerIf")
+	defer done(&err)
+
+	return withPrecondition(ctx, u.repos, id, u.repos.LockPassenger, precondition, func(ctx context.Context) error {
+		return u.DeletePassenger(ctx, id)
+	})
+}
+
+func (u *Usecases) BoundToTicket(ctx context.Context, id entities.Id, ticketId entities.Id) (err error) {
+	ctx, done := u.observe(ctx, "BoundToTicket")
+	defer done(&err)
+
+	err = u.changeWithEvent(
+		ctx,
+		func(ctx context.Context) error {
+			return u.repos.BoundToTicket(ctx, id, ticketId)
+		},
+		entities.EventPassengerBound, entities.AggregatePassenger, id.Value,
+		entities.BindingEventPayload{PassengerId: id.Value, TicketId: ticketId.Value},
+	)
+	if err != nil {
 		return err
 	}
 
+	u.count(ctx, CountPassengersBound, 1)
+
 	return nil
 }
 
-func (u *Usecases) UnboundToTicket(ctx context.Context, id entities.Id, ticketId entities.Id) error {
-	if err := u.repos.UnboundToTicket(ctx, id, ticketId); err != nil {
+func (u *Usecases) UnboundToTicket(ctx context.Context, id entities.Id, ticketId entities.Id) (err error) {
+	ctx, done := u.observe(ctx, "UnboundToTicket")
+	defer done(&err)
+
+	err = u.changeWithEvent(
+		ctx,
+		func(ctx context.Context) error {
+			return u.repos.UnboundToTicket(ctx, id, ticketId)
+		},
+		entities.EventPassengerUnbound, entities.AggregatePassenger, id.Value,
+		entities.BindingEventPayload{PassengerId: id.Value, TicketId: ticketId.Value},
+	)
+	if err != nil {
 		return err
 	}
 
 	return nil
 }
 
-func (u *Usecases) GetPassengersByTicketId(ctx context.Context, id entities.Id) ([]entities.Passenger, error) {
+func (u *Usecases) GetPassengersByTicketId(ctx context.Context, id entities.Id) (_ []entities.Passenger, err error) {
+	ctx, done := u.observe(ctx, "GetPassengersByTicketId")
+	defer done(&err)
+
 	passengers, err := u.repos.GetPassengersByTicketId(ctx, id)
 	if err != nil {
 		return []entities.Passenger{}, err
@@ -64,7 +177,10 @@ func (u *Usecases) GetPassengersByTicketId(ctx context.Context, id entities.Id)
 	return passengers, nil
 }
 
-func (u *Usecases) GetPassengers(ctx context.Context) ([]entities.Passenger, error) {
+func (u *Usecases) GetPassengers(ctx context.Context) (_ []entities.Passenger, err error) {
+	ctx, done := u.observe(ctx, "GetPassengers")
+	defer done(&err)
+
 	passengers, err := u.repos.GetPassengers(ctx)
 	if err != nil {
 		return []entities.Passenger{}, err
@@ -72,3 +188,27 @@ func (u *Usecases) GetPassengers(ctx context.Context) ([]entities.Passenger, err
 
 	return passengers, nil
 }
+
+func (u *Usecases) GetPassengersByIds(ctx context.Context, ids []string) (_ []entities.Passenger, err error) {
+	ctx, done := u.observe(ctx, "GetPassengersByIds")
+	defer done(&err)
+
+	passengers, err := u.repos.GetPassengersByIds(ctx, ids)
+	if err != nil {
+		return []entities.Passenger{}, err
+	}
+
+	return passengers, nil
+}
+
+func (u *Usecases) GetPassengersByTicketIds(ctx context.Context, ids []string) (_ map[string][]entities.Passenger, err error) {
+	ctx, done := u.observe(ctx, "GetPassengersByTicketIds")
+	defer done(&err)
+
+	passengers, err := u.repos.GetPassengersByTicketIds(ctx, ids)
+	if err != nil {
+		return map[string][]entities.Passenger{}, err
+	}
+
+	return passengers, nil
+}
@@ -0,0 +1,36 @@
+package usecases
+
+import (
+	"context"
+
+	"github.com/v1adhope/flights/internal/entities"
+)
+
+// withPrecondition writes only if precondition accepts the current value read by lock,
+// the value stays locked until the write commits so no concurrent write comes in between.
+// A nil precondition writes unconditionally.
+func withPrecondition[T any](
+	ctx context.Context,
+	repos Reposer,
+	id entities.Id,
+	lock func(ctx context.Context, id entities.Id) (T, error),
+	precondition func(current T) error,
+	write func(ctx context.Context) error,
+) error {
+	if precondition == nil {
+		return write(ctx)
+	}
+
+	return repos.WithinTx(ctx, func(ctx context.Context) error {
+		current, err := lock(ctx, id)
+		if err != nil {
+			return err
+		}
+
+		if err := precondition(current); err != nil {
+			return err
+		}
+
+		return write(ctx)
+	})
+}
@@ -0,0 +1,85 @@
+package usecases_test
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/v1adhope/flights/internal/entities"
+	"github.com/v1adhope/flights/internal/usecases"
+	"github.com/v1adhope/flights/internal/usecases/infrastructure/memory"
+)
+
+var errStale = errors.New("stale")
+
+// unchanged accepts only the state the client has read.
+func unchanged(read entities.Passenger) func(current entities.Passenger) error {
+	return func(current entities.Passenger) error {
+		if current != read {
+			return errStale
+		}
+
+		return nil
+	}
+}
+
+func TestReplaceIfLostUpdate(t *testing.T) {
+	ctx := context.Background()
+	uc := usecases.New(memory.New())
+
+	id, err := uc.CreatePassenger(ctx, entities.Passenger{FirstName: "Wendi", LastName: "Reyes", MiddleName: "Mejia"})
+	require.NoError(t, err)
+
+	passengers, err := uc.GetPassengersByIds(ctx, []string{id.Value})
+	require.NoError(t, err)
+	require.Len(t, passengers, 1)
+	read := passengers[0]
+
+	// Clients replacing the same state they have read, only the first write wins
+	const clients = 5
+
+	var wg sync.WaitGroup
+	errs := make([]error, clients)
+
+	for i := range clients {
+		wg.Add(1)
+
+		go func() {
+			defer wg.Done()
+
+			replaced := read
+			replaced.MiddleName = string(rune('A' + i))
+
+			errs[i] = uc.ReplacePassengerIf(ctx, replaced, unchanged(read))
+		}()
+	}
+
+	wg.Wait()
+
+	succeeded := 0
+
+	for _, err := range errs {
+		if err == nil {
+			succeeded++
+			continue
+		}
+
+		assert.ErrorIs(t, err, errStale)
+	}
+
+	assert.Equal(t, 1, succeeded)
+
+	// A rejected delete leaves the passenger
+	assert.ErrorIs(t, uc.DeletePassengerIf(ctx, id, unchanged(read)), errStale)
+
+	passengers, err = uc.GetPassengersByIds(ctx, []string{id.Value})
+	require.NoError(t, err)
+	require.Len(t, passengers, 1)
+	assert.NotEqual(t, read, passengers[0])
+
+	require.NoError(t, uc.DeletePassengerIf(ctx, id, unchanged(passengers[0])))
+	assert.ErrorIs(t, uc.DeletePassengerIf(ctx, id, unchanged(passengers[0])), entities.ErrorNothingFound)
+}
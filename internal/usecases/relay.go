@@ -0,0 +1,92 @@
+package usecases
+
+import (
+	"context"
+	"time"
+
+	"github.com/v1adhope/flights/internal/entities"
+)
+
+// Relay publishes outbox events to the sink with at-least-once delivery.
+// Events of one aggregate are published in outbox order: after a failed publish the rest of the aggregate waits for the lease to end.
+type Relay struct {
+	repos     Reposer
+	sink      Publisher
+	log       Logger
+	interval  time.Duration
+	batchSize uint64
+	lease     time.Duration
+}
+
+// NewRelay builds a relay claiming batchSize events for lease, it must be longer than publishing a batch takes.
+func NewRelay(r Reposer, sink Publisher, log Logger, interval time.Duration, batchSize uint64, lease time.Duration) *Relay {
+	return &Relay{
+		repos:     r,
+		sink:      sink,
+		log:       log,
+		interval:  interval,
+		batchSize: batchSize,
+		lease:     lease,
+	}
+}
+
+// Run relays events until ctx is done.
+func (r *Relay) Run(ctx context.Context) {
+	ticker := time.NewTicker(r.interval)
+	defer ticker.Stop()
+
+	for {
+		n, err := r.RelayBatch(ctx)
+		if err != nil {
+			r.log.Error(err, "%s", "outbox relay")
+		}
+
+		if n == int(r.batchSize) && ctx.Err() == nil {
+			continue
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// RelayBatch publishes one batch of unpublished events and returns how many of them were published.
+// Events are claimed and the claim is committed before publishing, so no transaction waits on the sink.
+func (r *Relay) RelayBatch(ctx context.Context) (int, error) {
+	events, err := r.repos.ClaimUnpublishedEvents(ctx, r.batchSize, r.lease)
+	if err != nil {
+		return 0, err
+	}
+
+	published := make([]int64, 0, len(events))
+	blocked := map[string]struct{}{}
+
+	for _, event := range events {
+		key := aggregateKey(event)
+
+		if _, ok := blocked[key]; ok {
+			continue
+		}
+
+		if err := r.sink.Publish(ctx, event); err != nil {
+			r.log.Error(err, "outbox relay: publish event %d of %s", event.Seq, key)
+			blocked[key] = struct{}{}
+			continue
+		}
+
+		published = append(published, event.Seq)
+	}
+
+	if err := r.repos.MarkEventsPublished(ctx, published); err != nil {
+		return 0, err
+	}
+
+	return len(published), nil
+}
+
+func aggregateKey(event entities.Event) string {
+	return event.AggregateType + ":" + event.AggregateId
+}
@@ -0,0 +1,227 @@
+package usecases_test
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"io"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/v1adhope/flights/internal/entities"
+	"github.com/v1adhope/flights/internal/usecases"
+	"github.com/v1adhope/flights/internal/usecases/infrastructure/memory"
+	"github.com/v1adhope/flights/internal/usecases/infrastructure/publisher"
+	"github.com/v1adhope/flights/pkg/logger"
+)
+
+const _relayLease = 20 * time.Millisecond
+
+var errSink = errors.New("sink is down")
+
+func newLogger() *logger.Log {
+	return logger.New(
+		logger.WithLevel("error"),
+		logger.WithOutput(io.Discard),
+	)
+}
+
+func addEvents(t *testing.T, repo usecases.Reposer, aggregateIds ...string) []entities.Event {
+	events := make([]entities.Event, 0, len(aggregateIds))
+
+	for _, aggregateId := range aggregateIds {
+		id := uuid.NewString()
+
+		events = append(events, entities.Event{
+			Id:            id,
+			Type:          entities.EventTicketCreated,
+			AggregateType: entities.AggregateTicket,
+			AggregateId:   aggregateId,
+			Payload:       json.RawMessage(`{"id":"` + id + `"}`),
+			OccurredAt:    time.Now().UTC().Format(time.RFC3339Nano),
+		})
+	}
+
+	require.NoError(t, repo.AddEvents(context.Background(), events...))
+
+	return events
+}
+
+// failing makes the sink fail for the events of aggregates while they are in the map.
+func failing(sink *publisher.Memory, aggregates map[string]bool) {
+	sink.Subscribe(func(ctx context.Context, event entities.Event) error {
+		if aggregates[event.AggregateId] {
+			return errSink
+		}
+
+		return nil
+	})
+}
+
+func publishedIds(sink *publisher.Memory, aggregateId string) []string {
+	ids := []string{}
+
+	for _, event := range sink.Events() {
+		if aggregateId == "" || event.AggregateId == aggregateId {
+			ids = append(ids, event.Id)
+		}
+	}
+
+	return ids
+}
+
+func eventIds(events []entities.Event) []string {
+	ids := make([]string, 0, len(events))
+
+	for _, event := range events {
+		ids = append(ids, event.Id)
+	}
+
+	return ids
+}
+
+func TestRelayFailingAggregate(t *testing.T) {
+	ctx := context.Background()
+	repo := memory.New()
+	sink := publisher.NewMemory()
+
+	a, b := uuid.NewString(), uuid.NewString()
+	events := addEvents(t, repo, a, b, a, b)
+
+	down := map[string]bool{a: true}
+	failing(sink, down)
+
+	relay := usecases.NewRelay(repo, sink, newLogger(), time.Second, 10, _relayLease)
+
+	n, err := relay.RelayBatch(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, 2, n)
+	assert.Empty(t, publishedIds(sink, a))
+	assert.Equal(t, eventIds([]entities.Event{events[1], events[3]}), publishedIds(sink, b))
+
+	// The failed aggregate waits for the lease, then goes on in order
+	n, err = relay.RelayBatch(ctx)
+	require.NoError(t, err)
+	assert.Zero(t, n)
+
+	delete(down, a)
+	time.Sleep(_relayLease)
+
+	n, err = relay.RelayBatch(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, 2, n)
+	assert.Equal(t, eventIds([]entities.Event{events[0], events[2]}), publishedIds(sink, a))
+}
+
+func TestRelayOrderPerAggregate(t *testing.T) {
+	ctx := context.Background()
+	repo := memory.New()
+	sink := publisher.NewMemory()
+
+	a, b, c := uuid.NewString(), uuid.NewString(), uuid.NewString()
+	events := addEvents(t, repo, a, b, a, c, a, b, c, a)
+
+	// Batches smaller than the outbox split aggregates, their events still go out in outbox order
+	relay := usecases.NewRelay(repo, sink, newLogger(), time.Second, 3, _relayLease)
+
+	for range 4 {
+		_, err := relay.RelayBatch(ctx)
+		require.NoError(t, err)
+	}
+
+	for _, aggregateId := range []string{a, b, c} {
+		want := []string{}
+
+		for _, event := range events {
+			if event.AggregateId == aggregateId {
+				want = append(want, event.Id)
+			}
+		}
+
+		assert.Equal(t, want, publishedIds(sink, aggregateId), aggregateId)
+	}
+}
+
+// TestRelayStarvation checks an aggregate with a full batch of failing events doesn't hold back others.
+func TestRelayStarvation(t *testing.T) {
+	ctx := context.Background()
+	repo := memory.New()
+	sink := publisher.NewMemory()
+
+	a, b := uuid.NewString(), uuid.NewString()
+	addEvents(t, repo, a, a, a, a, a)
+	events := addEvents(t, repo, b)
+
+	failing(sink, map[string]bool{a: true})
+
+	relay := usecases.NewRelay(repo, sink, newLogger(), time.Second, 5, _relayLease)
+
+	n, err := relay.RelayBatch(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, 1, n)
+	assert.Equal(t, eventIds(events), publishedIds(sink, ""))
+}
+
+// unmarkable loses the outcome of a publish, like a relay crashing before marking the events.
+type unmarkable struct {
+	usecases.Reposer
+}
+
+func (r unmarkable) MarkEventsPublished(ctx context.Context, seqs []int64) error {
+	return errors.New("relay crashed")
+}
+
+func TestRelayRedeliversUnmarked(t *testing.T) {
+	ctx := context.Background()
+	repo := memory.New()
+	sink := publisher.NewMemory()
+
+	events := addEvents(t, repo, uuid.NewString())
+
+	_, err := usecases.NewRelay(unmarkable{repo}, sink, newLogger(), time.Second, 10, _relayLease).RelayBatch(ctx)
+	require.Error(t, err)
+	assert.Equal(t, eventIds(events), publishedIds(sink, ""))
+
+	relay := usecases.NewRelay(repo, sink, newLogger(), time.Second, 10, _relayLease)
+
+	// The claim holds the events until the lease is over
+	n, err := relay.RelayBatch(ctx)
+	require.NoError(t, err)
+	assert.Zero(t, n)
+
+	time.Sleep(_relayLease)
+
+	n, err = relay.RelayBatch(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, 1, n)
+	assert.Equal(t, eventIds(append(events, events...)), publishedIds(sink, ""))
+}
+
+func TestRelayReplay(t *testing.T) {
+	ctx := context.Background()
+	repo := memory.New()
+	sink := publisher.NewMemory()
+	uc := usecases.New(repo)
+
+	a, b := uuid.NewString(), uuid.NewString()
+	events := addEvents(t, repo, a, b)
+
+	relay := usecases.NewRelay(repo, sink, newLogger(), time.Second, 10, _relayLease)
+
+	n, err := relay.RelayBatch(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, 2, n)
+
+	replayed, err := uc.ReplayEvents(ctx, 0, a)
+	require.NoError(t, err)
+	assert.Equal(t, int64(1), replayed)
+
+	n, err = relay.RelayBatch(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, 1, n)
+	assert.Equal(t, eventIds([]entities.Event{events[0], events[0]}), publishedIds(sink, a))
+	assert.Equal(t, eventIds(events[1:]), publishedIds(sink, b))
+}
@@ -6,11 +6,16 @@ import (
 	"github.com/v1adhope/flights/internal/entities"
 )
 
-func (u *Usecases) GetRowsByPassengerIdForPeriod(ctx context.Context, id entities.Id, filter entities.PeriodFilter) ([]entities.ReportRowByPassengerForPeriod, error) {
+func (u *Usecases) GetRowsByPassengerIdForPeriod(ctx context.Context, id entities.Id, filter entities.PeriodFilter) (_ []entities.ReportRowByPassengerForPeriod, err error) {
+	ctx, done := u.observe(ctx, "GetRowsByPassengerIdForPeriod")
+	defer done(&err)
+
 	rows, err := u.repos.GetRowsByPassengerIdForPeriod(ctx, id, filter)
 	if err != nil {
 		return []entities.ReportRowByPassengerForPeriod{}, err
 	}
 
+	u.count(ctx, CountReportRowsGenerated, len(rows))
+
 	return rows, nil
 }
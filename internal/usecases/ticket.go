@@ -9,7 +9,10 @@ import (
 	"github.com/v1adhope/flights/internal/entities"
 )
 
-func (u *Usecases) CreateTicket(ctx context.Context, ticket entities.Ticket) (entities.Id, error) {
+func (u *Usecases) CreateTicket(ctx context.Context, ticket entities.Ticket) (_ entities.Id, err error) {
+	ctx, done := u.observe(ctx, "CreateTicket")
+	defer done(&err)
+
 	id, err := uuid.NewV6()
 	if err != nil {
 		return entities.Id{}, fmt.Errorf("usecases: ticket: CreateTicket: NewV6: %w", err)
@@ -19,30 +22,82 @@ func (u *Usecases) CreateTicket(ctx context.Context, ticket entities.Ticket) (en
 
 	ticket.CreatedAt = time.Now().UTC().Format(time.RFC3339)
 
-	if err := u.repos.CreateTicket(ctx, ticket); err != nil {
+	err = u.changeWithEvent(
+		ctx,
+		func(ctx context.Context) error {
+			return u.repos.CreateTicket(ctx, ticket)
+		},
+		entities.EventTicketCreated, entities.AggregateTicket, ticket.Id, ticket,
+	)
+	if err != nil {
 		return entities.Id{}, err
 	}
 
+	u.count(ctx, CountTicketsCreated, 1)
+
 	return entities.Id{ticket.Id}, nil
 }
 
-func (u *Usecases) ReplaceTicket(ctx context.Context, ticket entities.Ticket) error {
-	if err := u.repos.ReplaceTicket(ctx, ticket); err != nil {
+func (u *Usecases) ReplaceTicket(ctx context.Context, ticket entities.Ticket) (err error) {
+	ctx, done := u.observe(ctx, "ReplaceTicket")
+	defer done(&err)
+
+	err = u.changeWithEvent(
+		ctx,
+		func(ctx context.Context) error {
+			return u.repos.ReplaceTicket(ctx, ticket)
+		},
+		entities.EventTicketReplaced, entities.AggregateTicket, ticket.Id, ticket,
+	)
+	if err != nil {
 		return err
 	}
 
 	return nil
 }
 
-func (u *Usecases) DeleteTicket(ctx context.Context, id entities.Id) error {
-	if err := u.repos.DeleteTicket(ctx, id); err != nil {
+func (u *Usecases) DeleteTicket(ctx context.Context, id entities.Id) (err error) {
+	ctx, done := u.observe(ctx, "DeleteTicket")
+	defer done(&err)
+
+	err = u.changeWithEvent(
+		ctx,
+		func(ctx context.Context) error {
+			return u.repos.DeleteTicket(ctx, id)
+		},
+		entities.EventTicketDeleted, entities.AggregateTicket, id.Value, id,
+	)
+	if err != nil {
 		return err
 	}
 
 	return nil
 }
 
-func (u *Usecases) GetTickets(ctx context.Context) ([]entities.Ticket, error) {
+// ReplaceTicketIf replaces the ticket if precondition accepts its current state, e.g. an If-Match check.
+func (u *Usecases) ReplaceTicketIf(ctx context.Context, ticket entities.Ticket, precondition func(current entities.Ticket) error) (err error) {
+	ctx, done := u.observe(ctx, "ReplaceTicketIf")
+	defer done(&err)
+
+	return withPrecondition(ctx, u.repos, entities.Id{Value: ticket.Id}, u.repos.LockTicket, precondition, func(ctx context.Context) error {
+		return u.ReplaceTicket(ctx, ticket)
+	})
+}
+
+// DeleteTicketIf deletes the ticket if precondition accepts its current state, e.g. an If-Match check.
+func (u *Usecases) DeleteTicketIf(ctx context.Context, id entities.Id, precondition func(current entities.Ticket) error) (err error) {
+	ctx, done := u.observe(ctx, "DeleteTicketIf")
+	defer done(&err)
+
+	return withPrecondition(ctx, u.repos, id, u.repos.LockTicket, precondition, func(ctx context.Context) error {
+		return u.DeleteTicket(ctx, id)
+	})
+}
+
+func (u *Usecases) GetTickets(ctx context.Context) (_ []entities.Ticket, err error) {
+	ctx, done := u.observe(ctx, "GetTickets")
+	defer done(&err)
+
 	tickets, err := u.repos.GetTickets(ctx)
 	if err != nil {
 		return []entities.Ticket{}, err
@@ -51,7 +106,22 @@ func (u *Usecases) GetTickets(ctx context.Context) ([]entities.Ticket, error) {
 	return tickets, nil
 }
 
-func (u *Usecases) GetWholeInfoAboutTicket(ctx context.Context, id entities.Id) (entities.TicketWholeInfo, error) {
+func (u *Usecases) GetTicketsPage(ctx context.Context, page entities.Page) (_ []entities.Ticket, err error) {
+	ctx, done := u.observe(ctx, "GetTicketsPage")
+	defer done(&err)
+
+	tickets, err := u.repos.GetTicketsPage(ctx, page)
+	if err != nil {
+		return []entities.Ticket{}, err
+	}
+
+	return tickets, nil
+}
+
+func (u *Usecases) GetWholeInfoAboutTicket(ctx context.Context, id entities.Id) (_ entities.TicketWholeInfo, err error) {
+	ctx, done := u.observe(ctx, "GetWholeInfoAboutTicket")
+	defer done(&err)
+
 	tickets, err := u.repos.GetWholeInfoAboutTicket(ctx, id)
 	if err != nil {
 		return entities.TicketWholeInfo{}, err
@@ -59,3 +129,27 @@ func (u *Usecases) GetWholeInfoAboutTicket(ctx context.Context, id entities.Id)
 
 	return tickets, nil
 }
+
+func (u *Usecases) GetTicketsByIds(ctx context.Context, ids []string) (_ []entities.Ticket, err error) {
+	ctx, done := u.observe(ctx, "GetTicketsByIds")
+	defer done(&err)
+
+	tickets, err := u.repos.GetTicketsByIds(ctx, ids)
+	if err != nil {
+		return []entities.Ticket{}, err
+	}
+
+	return tickets, nil
+}
+
+func (u *Usecases) GetTicketsByPassengerIds(ctx context.Context, ids []string) (_ map[string][]entities.Ticket, err error) {
+	ctx, done := u.observe(ctx, "GetTicketsByPassengerIds")
+	defer done(&err)
+
+	tickets, err := u.repos.GetTicketsByPassengerIds(ctx, ids)
+	if err != nil {
+		return map[string][]entities.Ticket{}, err
+	}
+
+	return tickets, nil
+}
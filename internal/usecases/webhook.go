@@ -0,0 +1,309 @@
+package usecases
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/v1adhope/flights/internal/entities"
+)
+
+func (u *Usecases) CreateWebhookSubscription(ctx context.Context, subscription entities.WebhookSubscription) (_ entities.WebhookSubscription, err error) {
+	ctx, done := u.observe(ctx, "CreateWebhookSubscription")
+	defer done(&err)
+
+	id, err := uuid.NewV6()
+	if err != nil {
+		return entities.WebhookSubscription{}, fmt.Errorf("usecases: webhook: CreateWebhookSubscription: NewV6: %w", err)
+	}
+
+	subscription.Id = id.String()
+	subscription.CreatedAt = time.Now().UTC().Format(time.RFC3339)
+
+	if subscription.Secret == "" {
+		secret := make([]byte, 32)
+
+		if _, err := rand.Read(secret); err != nil {
+			return entities.WebhookSubscription{}, fmt.Errorf("usecases: webhook: CreateWebhookSubscription: Read: %w", err)
+		}
+
+		subscription.Secret = hex.EncodeToString(secret)
+	}
+
+	if err := u.repos.CreateWebhookSubscription(ctx, subscription); err != nil {
+		return entities.WebhookSubscription{}, err
+	}
+
+	return subscription, nil
+}
+
+func (u *Usecases) ReplaceWebhookSubscription(ctx context.Context, subscription entities.WebhookSubscription) (err error) {
+	ctx, done := u.observe(ctx, "ReplaceWebhookSubscription")
+	defer done(&err)
+
+	if err := u.repos.ReplaceWebhookSubscription(ctx, subscription); err != nil {
+		return err
+	}
+
+	return nil
+}
+
+func (u *Usecases) DeleteWebhookSubscription(ctx context.Context, id entities.Id) (err error) {
+	ctx, done := u.observe(ctx, "DeleteWebhookSubscription")
+	defer done(&err)
+
+	if err := u.repos.DeleteWebhookSubscription(ctx, id); err != nil {
+		return err
+	}
+
+	return nil
+}
+
+// GetWebhookSubscriptions never returns secrets, they are shown once on creation.
+func (u *Usecases) GetWebhookSubscriptions(ctx context.Context) (_ []entities.WebhookSubscription, err error) {
+	ctx, done := u.observe(ctx, "GetWebhookSubscriptions")
+	defer done(&err)
+
+	subscriptions, err := u.repos.GetWebhookSubscriptions(ctx)
+	if err != nil {
+		return []entities.WebhookSubscription{}, err
+	}
+
+	for i := range subscriptions {
+		subscriptions[i].Secret = ""
+	}
+
+	return subscriptions, nil
+}
+
+func (u *Usecases) GetWebhookSubscription(ctx context.Context, id entities.Id) (_ entities.WebhookSubscription, err error) {
+	ctx, done := u.observe(ctx, "GetWebhookSubscription")
+	defer done(&err)
+
+	subscription, err := u.repos.GetWebhookSubscription(ctx, id)
+	if err != nil {
+		return entities.WebhookSubscription{}, err
+	}
+
+	subscription.Secret = ""
+
+	return subscription, nil
+}
+
+func (u *Usecases) GetWebhookDeliveries(ctx context.Context, subscriptionId entities.Id) (_ []entities.WebhookDelivery, err error) {
+	ctx, done := u.observe(ctx, "GetWebhookDeliveries")
+	defer done(&err)
+
+	deliveries, err := u.repos.GetWebhookDeliveries(ctx, subscriptionId)
+	if err != nil {
+		return []entities.WebhookDelivery{}, err
+	}
+
+	return deliveries, nil
+}
+
+func (u *Usecases) RedeliverWebhookDelivery(ctx context.Context, subscriptionId, deliveryId entities.Id) (err error) {
+	ctx, done := u.observe(ctx, "RedeliverWebhookDelivery")
+	defer done(&err)
+
+	if err := u.repos.RedeliverWebhookDelivery(ctx, subscriptionId, deliveryId); err != nil {
+		return err
+	}
+
+	return nil
+}
+
+// enqueueWebhooks adds a delivery of every event to each active subscription of its type,
+// with the events in one transaction, so webhooks don't depend on the outbox sink.
+func enqueueWebhooks(ctx context.Context, repos Reposer, events ...entities.Event) error {
+	subscriptions, err := repos.GetActiveWebhookSubscriptions(ctx)
+	if err != nil {
+		return err
+	}
+
+	now := time.Now().UTC().Format(time.RFC3339Nano)
+	deliveries := []entities.WebhookDelivery{}
+
+	for _, event := range events {
+		payload, err := json.Marshal(event)
+		if err != nil {
+			return fmt.Errorf("usecases: webhook: enqueueWebhooks: Marshal: %w", err)
+		}
+
+		for _, subscription := range subscriptions {
+			if !subscription.Matches(event.Type) {
+				continue
+			}
+
+			id, err := uuid.NewV6()
+			if err != nil {
+				return fmt.Errorf("usecases: webhook: enqueueWebhooks: NewV6: %w", err)
+			}
+
+			deliveries = append(deliveries, entities.WebhookDelivery{
+				Id:             id.String(),
+				SubscriptionId: subscription.Id,
+				EventId:        event.Id,
+				EventType:      event.Type,
+				Payload:        payload,
+				Status:         entities.WebhookDeliveryPending,
+				NextAttemptAt:  now,
+				CreatedAt:      now,
+			})
+		}
+	}
+
+	return repos.AddWebhookDeliveries(ctx, deliveries...)
+}
+
+// WebhookDispatcher sends due deliveries in the background, so request handlers never wait for partners.
+type WebhookDispatcher struct {
+	repos       Reposer
+	sender      WebhookSender
+	log         Logger
+	interval    time.Duration
+	batchSize   uint64
+	maxAttempts int
+	backoff     time.Duration
+	maxBackoff  time.Duration
+	sendTimeout time.Duration
+}
+
+func NewWebhookDispatcher(r Reposer, sender WebhookSender, log Logger, interval time.Duration, batchSize uint64, maxAttempts int, backoff, maxBackoff, sendTimeout time.Duration) *WebhookDispatcher {
+	return &WebhookDispatcher{
+		repos:       r,
+		sender:      sender,
+		log:         log,
+		interval:    interval,
+		batchSize:   batchSize,
+		maxAttempts: maxAttempts,
+		backoff:     backoff,
+		maxBackoff:  maxBackoff,
+		sendTimeout: sendTimeout,
+	}
+}
+
+// Run sends due deliveries until ctx is done.
+func (d *WebhookDispatcher) Run(ctx context.Context) {
+	ticker := time.NewTicker(d.interval)
+	defer ticker.Stop()
+
+	for {
+		n, err := d.DeliverBatch(ctx)
+		if err != nil {
+			d.log.Error(err, "%s", "webhook dispatcher")
+		}
+
+		if n == int(d.batchSize) && ctx.Err() == nil {
+			continue
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// DeliverBatch sends up to batchSize due deliveries and records the outcome.
+// A failed delivery is retried with exponential backoff up to maxBackoff and becomes dead after maxAttempts.
+// Every delivery is claimed just before it is sent, so its lease doesn't depend on the size of the batch.
+func (d *WebhookDispatcher) DeliverBatch(ctx context.Context) (int, error) {
+	subscriptions := map[string]entities.WebhookSubscription{}
+	sent := 0
+
+	for range d.batchSize {
+		deliveries, err := d.repos.ClaimDueWebhookDeliveries(ctx, 1, d.lease())
+		if err != nil {
+			return sent, err
+		}
+
+		if len(deliveries) == 0 {
+			break
+		}
+
+		delivery := deliveries[0]
+
+		subscription, ok := subscriptions[delivery.SubscriptionId]
+		if !ok {
+			subscription, err = d.repos.GetWebhookSubscription(ctx, entities.Id{Value: delivery.SubscriptionId})
+			if err != nil && !errors.Is(err, entities.ErrorNothingFound) {
+				return sent, err
+			}
+
+			subscriptions[delivery.SubscriptionId] = subscription
+		}
+
+		// Deleted or deactivated since the claim, claims skip deliveries of inactive subscriptions
+		if !subscription.Active {
+			continue
+		}
+
+		sendCtx, cancel := context.WithTimeout(ctx, d.sendTimeout)
+		statusCode, err := d.sender.Send(sendCtx, subscription, delivery)
+		cancel()
+
+		if err := d.repos.UpdateWebhookDelivery(ctx, d.outcome(delivery, statusCode, err)); err != nil {
+			return sent, err
+		}
+
+		sent++
+	}
+
+	return sent, nil
+}
+
+func (d *WebhookDispatcher) outcome(delivery entities.WebhookDelivery, statusCode int, err error) entities.WebhookDelivery {
+	now := time.Now().UTC()
+
+	delivery.Attempts++
+	delivery.LastStatusCode = statusCode
+	delivery.LastError = ""
+
+	if err == nil {
+		delivery.Status = entities.WebhookDeliveryDelivered
+		delivery.NextAttemptAt = ""
+		delivery.DeliveredAt = now.Format(time.RFC3339Nano)
+
+		return delivery
+	}
+
+	delivery.LastError = err.Error()
+
+	if delivery.Attempts >= d.maxAttempts {
+		delivery.Status = entities.WebhookDeliveryDead
+		delivery.NextAttemptAt = ""
+
+		return delivery
+	}
+
+	delivery.NextAttemptAt = now.Add(d.retryDelay(delivery.Attempts)).Format(time.RFC3339Nano)
+
+	return delivery
+}
+
+// retryDelay doubles backoff for every failed attempt but the first, it stops doubling at maxBackoff so it can't overflow.
+func (d *WebhookDispatcher) retryDelay(attempts int) time.Duration {
+	delay := d.backoff
+
+	for range attempts - 1 {
+		if delay >= d.maxBackoff/2 {
+			return d.maxBackoff
+		}
+
+		delay *= 2
+	}
+
+	return min(delay, d.maxBackoff)
+}
+
+// lease covers sending one delivery and recording its outcome before another worker may claim it again.
+func (d *WebhookDispatcher) lease() time.Duration {
+	return 2 * d.sendTimeout
+}
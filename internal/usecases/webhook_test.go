@@ -0,0 +1,254 @@
+package usecases_test
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/v1adhope/flights/internal/entities"
+	"github.com/v1adhope/flights/internal/usecases"
+	"github.com/v1adhope/flights/internal/usecases/infrastructure/memory"
+	"github.com/v1adhope/flights/internal/usecases/infrastructure/webhook"
+)
+
+const (
+	_webhookBackoff     = 50 * time.Millisecond
+	_webhookMaxAttempts = 3
+)
+
+// partner answers with status until it is changed and counts the requests.
+type partner struct {
+	srv      *httptest.Server
+	status   atomic.Int32
+	requests atomic.Int32
+}
+
+func newPartner(t *testing.T, status int) *partner {
+	p := &partner{}
+	p.status.Store(int32(status))
+
+	p.srv = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		p.requests.Add(1)
+		w.WriteHeader(int(p.status.Load()))
+	}))
+	t.Cleanup(p.srv.Close)
+
+	return p
+}
+
+func newDispatcher(repo usecases.Reposer) *usecases.WebhookDispatcher {
+	return usecases.NewWebhookDispatcher(repo, webhook.NewSender(), newLogger(), time.Second, 10, _webhookMaxAttempts, _webhookBackoff, time.Hour, time.Second)
+}
+
+func subscribe(t *testing.T, uc *usecases.Usecases, p *partner) entities.WebhookSubscription {
+	subscription, err := uc.CreateWebhookSubscription(context.Background(), entities.WebhookSubscription{
+		Url:        p.srv.URL,
+		EventTypes: []string{entities.EventPassengerCreated},
+		Active:     true,
+	})
+	require.NoError(t, err)
+
+	return subscription
+}
+
+func lastDelivery(t *testing.T, uc *usecases.Usecases, subscription entities.WebhookSubscription) entities.WebhookDelivery {
+	deliveries, err := uc.GetWebhookDeliveries(context.Background(), entities.Id{Value: subscription.Id})
+	require.NoError(t, err)
+	require.Len(t, deliveries, 1)
+
+	return deliveries[0]
+}
+
+func TestWebhookDeliveryLifecycle(t *testing.T) {
+	ctx := context.Background()
+	repo := memory.New()
+	uc := usecases.New(repo)
+	dispatcher := newDispatcher(repo)
+
+	p := newPartner(t, http.StatusInternalServerError)
+	subscription := subscribe(t, uc, p)
+
+	// The delivery is enqueued with the event, no relay or sink is involved
+	_, err := uc.CreatePassenger(ctx, entities.Passenger{FirstName: "Wendi", LastName: "Reyes", MiddleName: "Mejia"})
+	require.NoError(t, err)
+
+	delivery := lastDelivery(t, uc, subscription)
+	assert.Equal(t, entities.WebhookDeliveryPending, delivery.Status)
+	assert.Equal(t, entities.EventPassengerCreated, delivery.EventType)
+	assert.Zero(t, delivery.Attempts)
+
+	// Every failed attempt doubles the backoff until the delivery is dead
+	for attempt := 1; attempt <= _webhookMaxAttempts; attempt++ {
+		n, err := dispatcher.DeliverBatch(ctx)
+		require.NoError(t, err)
+		require.Equal(t, 1, n)
+		sent := time.Now()
+
+		delivery = lastDelivery(t, uc, subscription)
+		assert.Equal(t, attempt, delivery.Attempts)
+		assert.Equal(t, http.StatusInternalServerError, delivery.LastStatusCode)
+		assert.Contains(t, delivery.LastError, "unexpected status 500")
+
+		if attempt == _webhookMaxAttempts {
+			break
+		}
+
+		assert.Equal(t, entities.WebhookDeliveryPending, delivery.Status)
+
+		// Timestamps are reported in seconds, the doubling shows in when the delivery is due again
+		backoff := _webhookBackoff << (attempt - 1)
+
+		next, err := time.Parse(time.RFC3339Nano, delivery.NextAttemptAt)
+		require.NoError(t, err)
+		assert.WithinDuration(t, sent.Add(backoff), next, time.Second)
+
+		time.Sleep(time.Until(sent.Add(backoff * 3 / 4)))
+
+		n, err = dispatcher.DeliverBatch(ctx)
+		require.NoError(t, err)
+		assert.Zero(t, n, "due before the backoff of attempt %d is over", attempt)
+
+		time.Sleep(time.Until(sent.Add(backoff + _webhookBackoff/5)))
+	}
+
+	assert.Equal(t, entities.WebhookDeliveryDead, delivery.Status)
+	assert.Empty(t, delivery.NextAttemptAt)
+	assert.EqualValues(t, _webhookMaxAttempts, p.requests.Load())
+
+	n, err := dispatcher.DeliverBatch(ctx)
+	require.NoError(t, err)
+	assert.Zero(t, n)
+
+	// A redelivered dead delivery starts over
+	p.status.Store(http.StatusOK)
+	require.NoError(t, uc.RedeliverWebhookDelivery(ctx, entities.Id{Value: subscription.Id}, entities.Id{Value: delivery.Id}))
+
+	n, err = dispatcher.DeliverBatch(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, 1, n)
+
+	delivery = lastDelivery(t, uc, subscription)
+	assert.Equal(t, entities.WebhookDeliveryDelivered, delivery.Status)
+	assert.Equal(t, 1, delivery.Attempts)
+	assert.Equal(t, http.StatusOK, delivery.LastStatusCode)
+	assert.Empty(t, delivery.LastError)
+	assert.Empty(t, delivery.NextAttemptAt)
+	assert.NotEmpty(t, delivery.DeliveredAt)
+	assert.EqualValues(t, _webhookMaxAttempts+1, p.requests.Load())
+}
+
+func TestWebhookInactiveSubscription(t *testing.T) {
+	ctx := context.Background()
+	repo := memory.New()
+	uc := usecases.New(repo)
+
+	p := newPartner(t, http.StatusOK)
+	subscription := subscribe(t, uc, p)
+
+	_, err := uc.CreatePassenger(ctx, entities.Passenger{FirstName: "Wendi", LastName: "Reyes", MiddleName: "Mejia"})
+	require.NoError(t, err)
+
+	subscription.Secret = ""
+	subscription.Active = false
+	require.NoError(t, uc.ReplaceWebhookSubscription(ctx, subscription))
+
+	n, err := newDispatcher(repo).DeliverBatch(ctx)
+	require.NoError(t, err)
+	assert.Zero(t, n)
+	assert.Zero(t, p.requests.Load())
+	assert.Equal(t, entities.WebhookDeliveryPending, lastDelivery(t, uc, subscription).Status)
+
+	// Inactive subscriptions get no new deliveries
+	_, err = uc.CreatePassenger(ctx, entities.Passenger{FirstName: "Iris", LastName: "Reyes", MiddleName: "Mejia"})
+	require.NoError(t, err)
+	lastDelivery(t, uc, subscription)
+}
+
+func TestWebhookBatch(t *testing.T) {
+	ctx := context.Background()
+	repo := memory.New()
+	uc := usecases.New(repo)
+
+	p := newPartner(t, http.StatusOK)
+	subscription := subscribe(t, uc, p)
+
+	for range 3 {
+		_, err := uc.CreatePassenger(ctx, entities.Passenger{FirstName: "Wendi", LastName: "Reyes", MiddleName: "Mejia"})
+		require.NoError(t, err)
+	}
+
+	// Deliveries are claimed one by one up to the batch size
+	dispatcher := usecases.NewWebhookDispatcher(repo, webhook.NewSender(), newLogger(), time.Second, 2, _webhookMaxAttempts, _webhookBackoff, time.Hour, time.Second)
+
+	n, err := dispatcher.DeliverBatch(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, 2, n)
+
+	n, err = dispatcher.DeliverBatch(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, 1, n)
+
+	deliveries, err := uc.GetWebhookDeliveries(ctx, entities.Id{Value: subscription.Id})
+	require.NoError(t, err)
+	require.Len(t, deliveries, 3)
+
+	for _, delivery := range deliveries {
+		assert.Equal(t, entities.WebhookDeliveryDelivered, delivery.Status)
+	}
+
+	assert.EqualValues(t, 3, p.requests.Load())
+}
+
+func TestWebhookMaxBackoff(t *testing.T) {
+	ctx := context.Background()
+	repo := memory.New()
+	uc := usecases.New(repo)
+
+	p := newPartner(t, http.StatusInternalServerError)
+	subscription := subscribe(t, uc, p)
+
+	_, err := uc.CreatePassenger(ctx, entities.Passenger{FirstName: "Wendi", LastName: "Reyes", MiddleName: "Mejia"})
+	require.NoError(t, err)
+
+	const maxBackoff = time.Hour
+
+	dispatcher := usecases.NewWebhookDispatcher(repo, webhook.NewSender(), newLogger(), time.Second, 10, 10000, 30*time.Second, maxBackoff, time.Second)
+
+	tcs := []struct {
+		attempts int
+		want     time.Duration
+	}{
+		{attempts: 1, want: 30 * time.Second},
+		{attempts: 7, want: 32 * time.Minute},
+		{attempts: 8, want: maxBackoff},
+		{attempts: 64, want: maxBackoff},
+		{attempts: 65, want: maxBackoff},
+		{attempts: 9999, want: maxBackoff},
+	}
+
+	for _, tc := range tcs {
+		// Due again after attempts-1 failures
+		delivery := lastDelivery(t, uc, subscription)
+		delivery.Attempts = tc.attempts - 1
+		delivery.NextAttemptAt = time.Now().Add(-time.Second).Format(time.RFC3339Nano)
+		require.NoError(t, repo.UpdateWebhookDelivery(ctx, delivery))
+
+		n, err := dispatcher.DeliverBatch(ctx)
+		require.NoError(t, err)
+		require.Equal(t, 1, n)
+		sent := time.Now()
+
+		delivery = lastDelivery(t, uc, subscription)
+		assert.Equal(t, tc.attempts, delivery.Attempts)
+		assert.Equal(t, entities.WebhookDeliveryPending, delivery.Status)
+
+		next, err := time.Parse(time.RFC3339Nano, delivery.NextAttemptAt)
+		require.NoError(t, err)
+		assert.WithinDuration(t, sent.Add(tc.want), next, time.Second, "attempt %d", tc.attempts)
+	}
+}
@@ -0,0 +1,62 @@
+package flightsclient
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/url"
+)
+
+type documentCreateReq struct {
+	Type   string `json:"type"`
+	Number string `json:"number"`
+}
+
+type documentReplaceReq struct {
+	Type        string `json:"type"`
+	Number      string `json:"number"`
+	PassengerId string `json:"passengerId"`
+}
+
+// CreateDocument adds the document to document.PassengerId and returns its id.
+func (c *Client) CreateDocument(ctx context.Context, document Document) (string, error) {
+	created := id{}
+
+	_, err := c.do(ctx, request{
+		method: http.MethodPost,
+		path:   fmt.Sprintf("/passengers/%s/documents", url.PathEscape(document.PassengerId)),
+		body: documentCreateReq{
+			Type:   document.Type,
+			Number: document.Number,
+		},
+	}, &created)
+	if err != nil {
+		return "", err
+	}
+
+	return created.Value, nil
+}
+
+// ReplaceDocument may move the document to another passenger.
+func (c *Client) ReplaceDocument(ctx context.Context, document Document) error {
+	_, err := c.do(ctx, request{
+		method: http.MethodPut,
+		path:   "/documents/" + url.PathEscape(document.Id),
+		body: documentReplaceReq{
+			Type:        document.Type,
+			Number:      document.Number,
+			PassengerId: document.PassengerId,
+		},
+	}, nil)
+
+	return err
+}
+
+func (c *Client) DeleteDocument(ctx context.Context, documentId string) error {
+	_, err := c.do(ctx, request{
+		method: http.MethodDelete,
+		path:   "/documents/" + url.PathEscape(documentId),
+	}, nil)
+
+	return err
+}
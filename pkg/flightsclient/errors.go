@@ -0,0 +1,103 @@
+package flightsclient
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+)
+
+// Error is a failed response, problem details of the API are decoded into it.
+type Error struct {
+	Status   int
+	Type     string
+	Title    string
+	Detail   string
+	Instance string
+	// Code is the code of the domain error, compare errors with errors.Is and the sentinels below
+	Code    string
+	Details map[string]any
+	// Fields lists the rejected fields of validation problems
+	Fields []FieldError
+	// Retryable errors are transient, the client has retried them already
+	Retryable bool
+}
+
+type FieldError struct {
+	Field   string `json:"field"`
+	Rule    string `json:"rule"`
+	Message string `json:"message"`
+	Value   any    `json:"value,omitempty"`
+}
+
+func (e *Error) Error() string {
+	if e.Status == 0 {
+		return "flightsclient: " + e.Code
+	}
+
+	msg := fmt.Sprintf("flightsclient: %d %s", e.Status, e.Title)
+
+	if e.Detail != "" {
+		msg += ": " + e.Detail
+	}
+
+	return msg
+}
+
+// Is matches errors with the same code, so errors.Is(err, flightsclient.ErrorNothingFound) works.
+func (e *Error) Is(target error) bool {
+	t, ok := target.(*Error)
+
+	return ok && t.Code != "" && t.Code == e.Code
+}
+
+type problem struct {
+	Type     string         `json:"type"`
+	Title    string         `json:"title"`
+	Status   int            `json:"status"`
+	Detail   string         `json:"detail"`
+	Instance string         `json:"instance"`
+	Code     string         `json:"code"`
+	Details  map[string]any `json:"details"`
+	Errors   []FieldError   `json:"errors"`
+}
+
+func newError(resp *http.Response, body []byte) *Error {
+	e := &Error{
+		Status:    resp.StatusCode,
+		Title:     http.StatusText(resp.StatusCode),
+		Retryable: resp.StatusCode == http.StatusTooManyRequests || resp.StatusCode >= http.StatusInternalServerError || resp.Header.Get("Retry-After") != "",
+	}
+
+	p := problem{}
+	if err := json.Unmarshal(body, &p); err != nil {
+		return e
+	}
+
+	e.Type = p.Type
+	e.Detail = p.Detail
+	e.Instance = p.Instance
+	e.Code = p.Code
+	e.Details = p.Details
+	e.Fields = p.Errors
+
+	if p.Title != "" {
+		e.Title = p.Title
+	}
+
+	return e
+}
+
+// Sentinels mirror the domain errors of the API, their codes are stable.
+var (
+	ErrorNothingToChange                = &Error{Code: "nothing_to_change"}
+	ErrorNothingToDelete                = &Error{Code: "nothing_to_delete"}
+	ErrorNothingFound                   = &Error{Code: "nothing_found"}
+	ErrorHasAlreadyExists               = &Error{Code: "already_exists"}
+	ErrorPassengerDoesNotExists         = &Error{Code: "passenger_does_not_exist"}
+	ErrorTicketDoesNotExists            = &Error{Code: "ticket_does_not_exist"}
+	ErrorsThereArePassengersOnTheFlight = &Error{Code: "passengers_on_the_flight"}
+	ErrorPassengerHasDocuments          = &Error{Code: "passenger_has_documents"}
+	ErrorIdempotencyKeyReused           = &Error{Code: "idempotency_key_reused"}
+	ErrorIdempotentRequestInProgress    = &Error{Code: "idempotent_request_in_progress"}
+	ErrorTryAgain                       = &Error{Code: "try_again"}
+)
@@ -0,0 +1,221 @@
+// Package flightsclient is a client of the Flights API v2.
+//
+// Failed requests are retried with exponential backoff on network errors, 429 and 5xx responses.
+// POST requests carry a generated Idempotency-Key, so retrying them never creates a resource twice.
+package flightsclient
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"math/rand/v2"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/propagation"
+)
+
+const _basePath = "/v2"
+
+type Client struct {
+	baseURL        *url.URL
+	httpClient     *http.Client
+	maxRetries     int
+	minBackoff     time.Duration
+	maxBackoff     time.Duration
+	userAgent      string
+	acceptLanguage string
+}
+
+// New builds a client of the API served at baseURL, e.g. "http://localhost:8080".
+func New(baseURL string, opts ...Option) (*Client, error) {
+	cfg := config(opts...)
+
+	u, err := url.Parse(baseURL)
+	if err != nil {
+		return nil, fmt.Errorf("flightsclient: flightsclient: New: Parse: %w", err)
+	}
+
+	if u.Scheme == "" || u.Host == "" {
+		return nil, fmt.Errorf("flightsclient: flightsclient: New: base url must be absolute, got %q", baseURL)
+	}
+
+	u.Path = strings.TrimSuffix(u.Path, "/")
+
+	return &Client{
+		baseURL:        u,
+		httpClient:     cfg.HTTPClient,
+		maxRetries:     cfg.MaxRetries,
+		minBackoff:     cfg.MinBackoff,
+		maxBackoff:     cfg.MaxBackoff,
+		userAgent:      cfg.UserAgent,
+		acceptLanguage: cfg.AcceptLanguage,
+	}, nil
+}
+
+type request struct {
+	method  string
+	path    string
+	query   url.Values
+	body    any
+	ifMatch string
+}
+
+// do sends the request until it succeeds or retries run out and decodes the response body into out.
+func (c *Client) do(ctx context.Context, req request, out any) (http.Header, error) {
+	var body []byte
+
+	if req.body != nil {
+		var err error
+
+		body, err = json.Marshal(req.body)
+		if err != nil {
+			return nil, fmt.Errorf("flightsclient: flightsclient: do: Marshal: %w", err)
+		}
+	}
+
+	idempotencyKey := ""
+	if req.method == http.MethodPost {
+		idempotencyKey = uuid.NewString()
+	}
+
+	for attempt := 0; ; attempt++ {
+		header, retryAfter, err := c.send(ctx, req, body, idempotencyKey, out)
+		if err == nil {
+			return header, nil
+		}
+
+		if attempt >= c.maxRetries || !retryable(ctx, err) {
+			return header, err
+		}
+
+		if err := sleep(ctx, c.backoff(attempt, retryAfter)); err != nil {
+			return header, fmt.Errorf("flightsclient: flightsclient: do: %w", err)
+		}
+	}
+}
+
+func (c *Client) send(ctx context.Context, req request, body []byte, idempotencyKey string, out any) (http.Header, time.Duration, error) {
+	u := *c.baseURL
+	u.Path += _basePath + req.path
+	u.RawQuery = req.query.Encode()
+
+	httpReq, err := http.NewRequestWithContext(ctx, req.method, u.String(), bytes.NewReader(body))
+	if err != nil {
+		return nil, 0, fmt.Errorf("flightsclient: flightsclient: send: NewRequestWithContext: %w", err)
+	}
+
+	httpReq.Header.Set("Accept", "application/json, application/problem+json")
+	httpReq.Header.Set("User-Agent", c.userAgent)
+
+	if body != nil {
+		httpReq.Header.Set("Content-Type", "application/json")
+	}
+
+	if c.acceptLanguage != "" {
+		httpReq.Header.Set("Accept-Language", c.acceptLanguage)
+	}
+
+	if idempotencyKey != "" {
+		httpReq.Header.Set("Idempotency-Key", idempotencyKey)
+	}
+
+	if req.ifMatch != "" {
+		httpReq.Header.Set("If-Match", req.ifMatch)
+	}
+
+	// Continues the trace of ctx on the server when the caller set up OpenTelemetry
+	otel.GetTextMapPropagator().Inject(ctx, propagation.HeaderCarrier(httpReq.Header))
+
+	resp, err := c.httpClient.Do(httpReq)
+	if err != nil {
+		return nil, 0, fmt.Errorf("flightsclient: flightsclient: send: Do: %w", err)
+	}
+	defer resp.Body.Close()
+
+	data, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return resp.Header, 0, fmt.Errorf("flightsclient: flightsclient: send: ReadAll: %w", err)
+	}
+
+	if resp.StatusCode >= http.StatusBadRequest {
+		return resp.Header, retryAfter(resp.Header), newError(resp, data)
+	}
+
+	if out != nil && len(data) > 0 {
+		if err := json.Unmarshal(data, out); err != nil {
+			return resp.Header, 0, fmt.Errorf("flightsclient: flightsclient: send: Unmarshal: %w", err)
+		}
+	}
+
+	return resp.Header, 0, nil
+}
+
+// retryable reports whether the request may succeed the next time, the errors of the context are final.
+func retryable(ctx context.Context, err error) bool {
+	if ctx.Err() != nil {
+		return false
+	}
+
+	apiErr := &Error{}
+	if !errors.As(err, &apiErr) {
+		return true
+	}
+
+	return apiErr.Retryable
+}
+
+// backoff is exponential with full jitter, a Retry-After of the server takes precedence.
+func (c *Client) backoff(attempt int, retryAfter time.Duration) time.Duration {
+	if retryAfter > 0 {
+		return retryAfter
+	}
+
+	d := c.minBackoff << attempt
+	if d <= 0 || d > c.maxBackoff {
+		d = c.maxBackoff
+	}
+
+	if d <= 0 {
+		return 0
+	}
+
+	return rand.N(d) + 1
+}
+
+func retryAfter(header http.Header) time.Duration {
+	value := header.Get("Retry-After")
+	if value == "" {
+		return 0
+	}
+
+	if seconds, err := strconv.Atoi(value); err == nil {
+		return time.Duration(seconds) * time.Second
+	}
+
+	if at, err := http.ParseTime(value); err == nil {
+		return time.Until(at)
+	}
+
+	return 0
+}
+
+func sleep(ctx context.Context, d time.Duration) error {
+	timer := time.NewTimer(d)
+	defer timer.Stop()
+
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-timer.C:
+		return nil
+	}
+}
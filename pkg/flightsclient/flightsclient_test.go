@@ -0,0 +1,350 @@
+package flightsclient_test
+
+import (
+	"context"
+	"errors"
+	"log"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/suite"
+	v2 "github.com/v1adhope/flights/internal/controllers/http/v2"
+	"github.com/v1adhope/flights/internal/usecases"
+	"github.com/v1adhope/flights/internal/usecases/infrastructure/memory"
+	"github.com/v1adhope/flights/pkg/flightsclient"
+	"github.com/v1adhope/flights/pkg/logger"
+)
+
+const (
+	_loggerLevel = "debug"
+)
+
+type Suite struct {
+	suite.Suite
+	ctx    context.Context
+	router *gin.Engine
+	server *httptest.Server
+	client *flightsclient.Client
+}
+
+func (s *Suite) SetupSuite() {
+	s.ctx = context.Background()
+
+	gin.SetMode(gin.DebugMode)
+	router := gin.New()
+	router.Use(gin.Recovery())
+	v2.Register(&v2.Router{
+		Handler:  router,
+		Usecases: usecases.New(memory.New()),
+		Log: logger.New(
+			logger.WithLevel(_loggerLevel),
+		),
+	})
+
+	s.router = router
+	s.server = httptest.NewServer(router)
+	s.client = s.newClient(s.server.URL)
+}
+
+func (s *Suite) TearDownSuite() {
+	s.server.Close()
+}
+
+func TestSuite(t *testing.T) {
+	suite.Run(t, new(Suite))
+}
+
+func (s *Suite) newClient(baseURL string) *flightsclient.Client {
+	client, err := flightsclient.New(
+		baseURL,
+		flightsclient.WithMaxRetries(3),
+		flightsclient.WithBackoff(time.Millisecond, 10*time.Millisecond),
+	)
+	if err != nil {
+		log.Fatalf("flightsclient: flightsclient_test: newClient: New: %v", err)
+	}
+
+	return client
+}
+
+func newTicket() flightsclient.Ticket {
+	flyAt := time.Now().Add(24 * time.Hour).Truncate(time.Second)
+
+	return flightsclient.Ticket{
+		Provider: "Emirates",
+		FlyFrom:  "Moscow",
+		FlyTo:    "Hanoi",
+		FlyAt:    flyAt,
+		ArriveAt: flyAt.Add(9 * time.Hour),
+	}
+}
+
+func (s *Suite) Test1aTicketLifecycle() {
+	t := s.T()
+
+	ticket := newTicket()
+
+	ticketId, err := s.client.CreateTicket(s.ctx, ticket)
+	assert.NoError(t, err)
+	assert.NotEmpty(t, ticketId)
+
+	got, err := s.client.GetTicket(s.ctx, ticketId)
+	assert.NoError(t, err)
+	assert.Equal(t, ticketId, got.Id)
+	assert.Equal(t, ticket.Provider, got.Provider)
+	assert.True(t, ticket.FlyAt.Equal(got.FlyAt))
+	assert.NotEmpty(t, got.ETag)
+
+	stale := got
+	got.Provider = "Aeroflot"
+	assert.NoError(t, s.client.ReplaceTicket(s.ctx, got))
+
+	stale.Provider = "Qatar Airways"
+	err = s.client.ReplaceTicket(s.ctx, stale)
+	apiErr := &flightsclient.Error{}
+	assert.ErrorAs(t, err, &apiErr)
+	assert.Equal(t, http.StatusPreconditionFailed, apiErr.Status)
+
+	assert.NoError(t, s.client.DeleteTicket(s.ctx, ticketId, ""))
+
+	_, err = s.client.GetTicket(s.ctx, ticketId)
+	assert.ErrorIs(t, err, flightsclient.ErrorNothingFound)
+	assert.ErrorAs(t, err, &apiErr)
+	assert.Equal(t, http.StatusNotFound, apiErr.Status)
+}
+
+func (s *Suite) Test1bTicketsIterator() {
+	t := s.T()
+
+	created := map[string]bool{}
+
+	for range 5 {
+		ticketId, err := s.client.CreateTicket(s.ctx, newTicket())
+		assert.NoError(t, err)
+
+		created[ticketId] = true
+	}
+
+	page, err := s.client.GetTicketsPage(s.ctx, "", 2)
+	assert.NoError(t, err)
+	assert.Len(t, page.Tickets, 2)
+	assert.Equal(t, page.Tickets[1].Id, page.Next)
+
+	seen := map[string]bool{}
+	prev := ""
+
+	for ticket, err := range s.client.Tickets(s.ctx, 2) {
+		assert.NoError(t, err)
+		assert.False(t, seen[ticket.Id], "ticket %s is seen twice", ticket.Id)
+		assert.Greater(t, ticket.Id, prev)
+
+		seen[ticket.Id] = true
+		prev = ticket.Id
+	}
+
+	for ticketId := range created {
+		assert.True(t, seen[ticketId], "ticket %s is not seen", ticketId)
+	}
+}
+
+func (s *Suite) Test1cPassengersAndDocuments() {
+	t := s.T()
+
+	ticket := newTicket()
+
+	ticketId, err := s.client.CreateTicket(s.ctx, ticket)
+	assert.NoError(t, err)
+
+	passengerId, err := s.client.CreatePassenger(s.ctx, flightsclient.Passenger{
+		FirstName:  "Riley",
+		LastName:   "Scott",
+		MiddleName: "Reed",
+	})
+	assert.NoError(t, err)
+
+	documentId, err := s.client.CreateDocument(s.ctx, flightsclient.Document{
+		Type:        flightsclient.DocumentPassport,
+		Number:      "5555444441",
+		PassengerId: passengerId,
+	})
+	assert.NoError(t, err)
+
+	_, err = s.client.CreateDocument(s.ctx, flightsclient.Document{
+		Type:        flightsclient.DocumentPassport,
+		Number:      "5555444441",
+		PassengerId: passengerId,
+	})
+	assert.ErrorIs(t, err, flightsclient.ErrorHasAlreadyExists)
+
+	assert.NoError(t, s.client.BindPassenger(s.ctx, ticketId, passengerId))
+	assert.NoError(t, s.client.BindPassenger(s.ctx, ticketId, passengerId))
+
+	passengers, err := s.client.GetTicketPassengers(s.ctx, ticketId)
+	assert.NoError(t, err)
+	assert.Len(t, passengers, 1)
+
+	tickets, err := s.client.GetPassengerTickets(s.ctx, passengerId)
+	assert.NoError(t, err)
+	assert.Len(t, tickets, 1)
+
+	documents, err := s.client.GetPassengerDocuments(s.ctx, passengerId)
+	assert.NoError(t, err)
+	assert.Len(t, documents, 1)
+	assert.Equal(t, documentId, documents[0].Id)
+
+	wholeInfo, err := s.client.GetTicketWholeInfo(s.ctx, ticketId)
+	assert.NoError(t, err)
+	assert.Len(t, wholeInfo.Passengers, 1)
+	assert.Len(t, wholeInfo.Passengers[0].Documents, 1)
+
+	_, err = s.client.GetPassengerReport(s.ctx, passengerId, time.Now().Add(-time.Hour), ticket.ArriveAt.Add(time.Hour))
+	assert.NoError(t, err)
+
+	err = s.client.DeleteTicket(s.ctx, ticketId, "")
+	assert.ErrorIs(t, err, flightsclient.ErrorsThereArePassengersOnTheFlight)
+
+	err = s.client.BindPassenger(s.ctx, ticketId, "1efa5f3d-2b9c-6d0e-8f1a-0242ac120002")
+	assert.ErrorIs(t, err, flightsclient.ErrorPassengerDoesNotExists)
+
+	assert.NoError(t, s.client.UnbindPassenger(s.ctx, ticketId, passengerId))
+	assert.NoError(t, s.client.DeleteTicket(s.ctx, ticketId, ""))
+
+	err = s.client.DeletePassenger(s.ctx, passengerId, "")
+	assert.ErrorIs(t, err, flightsclient.ErrorPassengerHasDocuments)
+
+	assert.NoError(t, s.client.ReplaceDocument(s.ctx, flightsclient.Document{
+		Id:          documentId,
+		Type:        flightsclient.DocumentIdCard,
+		Number:      "5555444442",
+		PassengerId: passengerId,
+	}))
+	assert.NoError(t, s.client.DeleteDocument(s.ctx, documentId))
+
+	passenger, err := s.client.GetPassenger(s.ctx, passengerId)
+	assert.NoError(t, err)
+	assert.NoError(t, s.client.DeletePassenger(s.ctx, passengerId, passenger.ETag))
+}
+
+func (s *Suite) Test1dValidationError() {
+	t := s.T()
+
+	ticket := newTicket()
+	ticket.FlyAt = time.Now().Add(-time.Hour)
+
+	_, err := s.client.CreateTicket(s.ctx, ticket)
+
+	apiErr := &flightsclient.Error{}
+	assert.ErrorAs(t, err, &apiErr)
+	assert.Equal(t, http.StatusUnprocessableEntity, apiErr.Status)
+	assert.Equal(t, v2.ProblemValidation, apiErr.Type)
+	assert.NotEmpty(t, apiErr.Fields)
+	assert.False(t, apiErr.Retryable)
+}
+
+// flakyHandler fails the first requests before or after they reach the router.
+type flakyHandler struct {
+	router http.Handler
+	// failBefore requests are rejected with 503 without reaching the router
+	failBefore int
+	// failAfter requests are handled, then their responses are replaced with 502
+	failAfter int
+
+	mu              sync.Mutex
+	requests        int
+	idempotencyKeys []string
+}
+
+func (h *flakyHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	h.mu.Lock()
+	h.requests++
+	n := h.requests
+	h.idempotencyKeys = append(h.idempotencyKeys, r.Header.Get("Idempotency-Key"))
+	h.mu.Unlock()
+
+	if n <= h.failBefore {
+		w.WriteHeader(http.StatusServiceUnavailable)
+		return
+	}
+
+	if n <= h.failBefore+h.failAfter {
+		h.router.ServeHTTP(httptest.NewRecorder(), r)
+		w.WriteHeader(http.StatusBadGateway)
+		return
+	}
+
+	h.router.ServeHTTP(w, r)
+}
+
+func (s *Suite) Test2aRetriesWithIdempotencyKey() {
+	t := s.T()
+
+	flaky := &flakyHandler{
+		router:     s.router,
+		failBefore: 1,
+		failAfter:  1,
+	}
+
+	server := httptest.NewServer(flaky)
+	defer server.Close()
+
+	client := s.newClient(server.URL)
+
+	passengerId, err := client.CreatePassenger(s.ctx, flightsclient.Passenger{
+		FirstName:  "Avery",
+		LastName:   "Quinn",
+		MiddleName: "Lane",
+	})
+	assert.NoError(t, err)
+	assert.Equal(t, 3, flaky.requests)
+
+	for _, key := range flaky.idempotencyKeys {
+		assert.NotEmpty(t, key)
+		assert.Equal(t, flaky.idempotencyKeys[0], key)
+	}
+
+	passengers, err := s.client.GetPassengers(s.ctx)
+	assert.NoError(t, err)
+
+	created := 0
+	for _, passenger := range passengers {
+		if passenger.FirstName == "Avery" && passenger.LastName == "Quinn" {
+			created++
+			assert.Equal(t, passengerId, passenger.Id)
+		}
+	}
+
+	assert.Equal(t, 1, created)
+}
+
+func (s *Suite) Test2bRetriesRunOut() {
+	t := s.T()
+
+	flaky := &flakyHandler{
+		router:     s.router,
+		failBefore: 10,
+	}
+
+	server := httptest.NewServer(flaky)
+	defer server.Close()
+
+	client := s.newClient(server.URL)
+
+	_, err := client.GetTicketsPage(s.ctx, "", 0)
+
+	apiErr := &flightsclient.Error{}
+	assert.ErrorAs(t, err, &apiErr)
+	assert.Equal(t, http.StatusServiceUnavailable, apiErr.Status)
+	assert.True(t, apiErr.Retryable)
+	assert.Equal(t, 4, flaky.requests)
+
+	ctx, cancel := context.WithCancel(s.ctx)
+	cancel()
+
+	_, err = client.GetTicketsPage(ctx, "", 0)
+	assert.True(t, errors.Is(err, context.Canceled))
+}
@@ -0,0 +1,71 @@
+package flightsclient
+
+import (
+	"net/http"
+	"time"
+)
+
+type Option func(*Config)
+
+type Config struct {
+	HTTPClient     *http.Client
+	MaxRetries     int
+	MinBackoff     time.Duration
+	MaxBackoff     time.Duration
+	UserAgent      string
+	AcceptLanguage string
+}
+
+func WithHTTPClient(c *http.Client) Option {
+	return func(cfg *Config) {
+		cfg.HTTPClient = c
+	}
+}
+
+// WithMaxRetries sets how many times a failed request is retried, 0 disables retries.
+func WithMaxRetries(n int) Option {
+	return func(cfg *Config) {
+		cfg.MaxRetries = n
+	}
+}
+
+// WithBackoff sets the bounds of the exponential backoff between retries.
+func WithBackoff(min, max time.Duration) Option {
+	return func(cfg *Config) {
+		cfg.MinBackoff = min
+		cfg.MaxBackoff = max
+	}
+}
+
+func WithUserAgent(ua string) Option {
+	return func(cfg *Config) {
+		cfg.UserAgent = ua
+	}
+}
+
+// WithAcceptLanguage sets the language of error details, e.g. "ru".
+func WithAcceptLanguage(lang string) Option {
+	return func(cfg *Config) {
+		cfg.AcceptLanguage = lang
+	}
+}
+
+func config(opts ...Option) Config {
+	cfg := Config{
+		HTTPClient: &http.Client{Timeout: 30 * time.Second},
+		MaxRetries: 3,
+		MinBackoff: 100 * time.Millisecond,
+		MaxBackoff: 5 * time.Second,
+		UserAgent:  "flightsclient",
+	}
+
+	for _, opt := range opts {
+		opt(&cfg)
+	}
+
+	if cfg.MaxBackoff < cfg.MinBackoff {
+		cfg.MaxBackoff = cfg.MinBackoff
+	}
+
+	return cfg
+}
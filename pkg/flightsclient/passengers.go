@@ -0,0 +1,141 @@
+package flightsclient
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/url"
+	"time"
+)
+
+type passengerReq struct {
+	FirstName  string `json:"firstName"`
+	LastName   string `json:"lastName"`
+	MiddleName string `json:"middleName"`
+}
+
+func newPassengerReq(p Passenger) passengerReq {
+	return passengerReq{
+		FirstName:  p.FirstName,
+		LastName:   p.LastName,
+		MiddleName: p.MiddleName,
+	}
+}
+
+// CreatePassenger returns the id of the created passenger.
+func (c *Client) CreatePassenger(ctx context.Context, passenger Passenger) (string, error) {
+	created := id{}
+
+	_, err := c.do(ctx, request{
+		method: http.MethodPost,
+		path:   "/passengers",
+		body:   newPassengerReq(passenger),
+	}, &created)
+	if err != nil {
+		return "", err
+	}
+
+	return created.Value, nil
+}
+
+func (c *Client) GetPassenger(ctx context.Context, passengerId string) (Passenger, error) {
+	passenger := Passenger{}
+
+	header, err := c.do(ctx, request{
+		method: http.MethodGet,
+		path:   "/passengers/" + url.PathEscape(passengerId),
+	}, &passenger)
+	if err != nil {
+		return Passenger{}, err
+	}
+
+	passenger.ETag = header.Get("ETag")
+
+	return passenger, nil
+}
+
+// GetPassengers lists all passengers, the API serves it in debug mode only.
+func (c *Client) GetPassengers(ctx context.Context) ([]Passenger, error) {
+	passengers := []Passenger{}
+
+	_, err := c.do(ctx, request{
+		method: http.MethodGet,
+		path:   "/passengers",
+	}, &passengers)
+	if err != nil {
+		return []Passenger{}, err
+	}
+
+	return passengers, nil
+}
+
+// ReplacePassenger fails with a 412 Error if the passenger has been changed since passenger.ETag was read.
+func (c *Client) ReplacePassenger(ctx context.Context, passenger Passenger) error {
+	_, err := c.do(ctx, request{
+		method:  http.MethodPut,
+		path:    "/passengers/" + url.PathEscape(passenger.Id),
+		body:    newPassengerReq(passenger),
+		ifMatch: passenger.ETag,
+	}, nil)
+
+	return err
+}
+
+// DeletePassenger deletes the passenger unconditionally if etag is empty.
+func (c *Client) DeletePassenger(ctx context.Context, passengerId, etag string) error {
+	_, err := c.do(ctx, request{
+		method:  http.MethodDelete,
+		path:    "/passengers/" + url.PathEscape(passengerId),
+		ifMatch: etag,
+	}, nil)
+
+	return err
+}
+
+func (c *Client) GetPassengerTickets(ctx context.Context, passengerId string) ([]Ticket, error) {
+	tickets := []Ticket{}
+
+	_, err := c.do(ctx, request{
+		method: http.MethodGet,
+		path:   fmt.Sprintf("/passengers/%s/tickets", url.PathEscape(passengerId)),
+	}, &tickets)
+	if err != nil {
+		return []Ticket{}, err
+	}
+
+	return tickets, nil
+}
+
+func (c *Client) GetPassengerDocuments(ctx context.Context, passengerId string) ([]Document, error) {
+	documents := []Document{}
+
+	_, err := c.do(ctx, request{
+		method: http.MethodGet,
+		path:   fmt.Sprintf("/passengers/%s/documents", url.PathEscape(passengerId)),
+	}, &documents)
+	if err != nil {
+		return []Document{}, err
+	}
+
+	return documents, nil
+}
+
+// GetPassengerReport returns the flights of the passenger for the period.
+func (c *Client) GetPassengerReport(ctx context.Context, passengerId string, from, to time.Time) ([]ReportRow, error) {
+	query := url.Values{}
+	query.Set("from", from.Format(time.RFC3339))
+	query.Set("to", to.Format(time.RFC3339))
+
+	rows := []ReportRow{}
+
+	_, err := c.do(ctx, request{
+		method: http.MethodGet,
+		path:   fmt.Sprintf("/passengers/%s/report", url.PathEscape(passengerId)),
+		query:  query,
+	}, &rows)
+	if err != nil {
+		return []ReportRow{}, err
+	}
+
+	return rows, nil
+}
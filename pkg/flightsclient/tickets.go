@@ -0,0 +1,214 @@
+package flightsclient
+
+import (
+	"context"
+	"fmt"
+	"iter"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+)
+
+type ticketReq struct {
+	Provider string `json:"provider"`
+	FlyFrom  string `json:"flyFrom"`
+	FlyTo    string `json:"flyTo"`
+	FlyAt    string `json:"flyAt"`
+	ArriveAt string `json:"arriveAt"`
+}
+
+func newTicketReq(t Ticket) ticketReq {
+	return ticketReq{
+		Provider: t.Provider,
+		FlyFrom:  t.FlyFrom,
+		FlyTo:    t.FlyTo,
+		FlyAt:    t.FlyAt.Format(time.RFC3339),
+		ArriveAt: t.ArriveAt.Format(time.RFC3339),
+	}
+}
+
+// CreateTicket returns the id of the created ticket.
+func (c *Client) CreateTicket(ctx context.Context, ticket Ticket) (string, error) {
+	created := id{}
+
+	_, err := c.do(ctx, request{
+		method: http.MethodPost,
+		path:   "/tickets",
+		body:   newTicketReq(ticket),
+	}, &created)
+	if err != nil {
+		return "", err
+	}
+
+	return created.Value, nil
+}
+
+func (c *Client) GetTicket(ctx context.Context, ticketId string) (Ticket, error) {
+	ticket := Ticket{}
+
+	header, err := c.do(ctx, request{
+		method: http.MethodGet,
+		path:   "/tickets/" + url.PathEscape(ticketId),
+	}, &ticket)
+	if err != nil {
+		return Ticket{}, err
+	}
+
+	ticket.ETag = header.Get("ETag")
+
+	return ticket, nil
+}
+
+// ReplaceTicket fails with a 412 Error if the ticket has been changed since ticket.ETag was read.
+func (c *Client) ReplaceTicket(ctx context.Context, ticket Ticket) error {
+	_, err := c.do(ctx, request{
+		method:  http.MethodPut,
+		path:    "/tickets/" + url.PathEscape(ticket.Id),
+		body:    newTicketReq(ticket),
+		ifMatch: ticket.ETag,
+	}, nil)
+
+	return err
+}
+
+// DeleteTicket deletes the ticket unconditionally if etag is empty.
+func (c *Client) DeleteTicket(ctx context.Context, ticketId, etag string) error {
+	_, err := c.do(ctx, request{
+		method:  http.MethodDelete,
+		path:    "/tickets/" + url.PathEscape(ticketId),
+		ifMatch: etag,
+	}, nil)
+
+	return err
+}
+
+// GetTicketsPage returns up to limit tickets created after the ticket with the after id,
+// the server chooses the limit if it is 0.
+func (c *Client) GetTicketsPage(ctx context.Context, after string, limit int) (TicketsPage, error) {
+	query := url.Values{}
+
+	if after != "" {
+		query.Set("after", after)
+	}
+
+	if limit > 0 {
+		query.Set("limit", strconv.Itoa(limit))
+	}
+
+	tickets := []Ticket{}
+
+	header, err := c.do(ctx, request{
+		method: http.MethodGet,
+		path:   "/tickets",
+		query:  query,
+	}, &tickets)
+	if err != nil {
+		return TicketsPage{}, err
+	}
+
+	return TicketsPage{
+		Tickets: tickets,
+		Next:    nextAfter(header),
+	}, nil
+}
+
+// Tickets iterates over all tickets page by page, the iteration stops at the first error.
+func (c *Client) Tickets(ctx context.Context, pageSize int) iter.Seq2[Ticket, error] {
+	return func(yield func(Ticket, error) bool) {
+		after := ""
+
+		for {
+			page, err := c.GetTicketsPage(ctx, after, pageSize)
+			if err != nil {
+				yield(Ticket{}, err)
+				return
+			}
+
+			for _, ticket := range page.Tickets {
+				if !yield(ticket, nil) {
+					return
+				}
+			}
+
+			if page.Next == "" {
+				return
+			}
+
+			after = page.Next
+		}
+	}
+}
+
+func (c *Client) GetTicketWholeInfo(ctx context.Context, ticketId string) (TicketWholeInfo, error) {
+	wholeInfo := TicketWholeInfo{}
+
+	_, err := c.do(ctx, request{
+		method: http.MethodGet,
+		path:   fmt.Sprintf("/tickets/%s/whole-info", url.PathEscape(ticketId)),
+	}, &wholeInfo)
+	if err != nil {
+		return TicketWholeInfo{}, err
+	}
+
+	if wholeInfo.Id == "" {
+		wholeInfo.Id = ticketId
+	}
+
+	return wholeInfo, nil
+}
+
+func (c *Client) GetTicketPassengers(ctx context.Context, ticketId string) ([]Passenger, error) {
+	passengers := []Passenger{}
+
+	_, err := c.do(ctx, request{
+		method: http.MethodGet,
+		path:   fmt.Sprintf("/tickets/%s/passengers", url.PathEscape(ticketId)),
+	}, &passengers)
+	if err != nil {
+		return []Passenger{}, err
+	}
+
+	return passengers, nil
+}
+
+// BindPassenger is idempotent, binding a bound passenger succeeds.
+func (c *Client) BindPassenger(ctx context.Context, ticketId, passengerId string) error {
+	_, err := c.do(ctx, request{
+		method: http.MethodPut,
+		path:   fmt.Sprintf("/tickets/%s/passengers/%s", url.PathEscape(ticketId), url.PathEscape(passengerId)),
+	}, nil)
+
+	return err
+}
+
+func (c *Client) UnbindPassenger(ctx context.Context, ticketId, passengerId string) error {
+	_, err := c.do(ctx, request{
+		method: http.MethodDelete,
+		path:   fmt.Sprintf("/tickets/%s/passengers/%s", url.PathEscape(ticketId), url.PathEscape(passengerId)),
+	}, nil)
+
+	return err
+}
+
+// nextAfter finds the after of the Link header with rel="next".
+func nextAfter(header http.Header) string {
+	for _, link := range header.Values("Link") {
+		for _, part := range strings.Split(link, ",") {
+			target, params, ok := strings.Cut(strings.TrimSpace(part), ";")
+			if !ok || !strings.Contains(params, `rel="next"`) {
+				continue
+			}
+
+			u, err := url.Parse(strings.Trim(strings.TrimSpace(target), "<>"))
+			if err != nil {
+				continue
+			}
+
+			return u.Query().Get("after")
+		}
+	}
+
+	return ""
+}
@@ -0,0 +1,67 @@
+package flightsclient
+
+import "time"
+
+type Ticket struct {
+	Id        string    `json:"id,omitempty"`
+	Provider  string    `json:"provider"`
+	FlyFrom   string    `json:"flyFrom"`
+	FlyTo     string    `json:"flyTo"`
+	FlyAt     time.Time `json:"flyAt"`
+	ArriveAt  time.Time `json:"arriveAt"`
+	CreatedAt time.Time `json:"createdAt"`
+	// ETag is set by GetTicket, ReplaceTicket sends it as If-Match
+	ETag string `json:"-"`
+}
+
+type TicketWholeInfo struct {
+	Ticket
+	Passengers []PassengerWholeInfo `json:"passengers"`
+}
+
+type Passenger struct {
+	Id         string `json:"id,omitempty"`
+	FirstName  string `json:"firstName"`
+	LastName   string `json:"lastName"`
+	MiddleName string `json:"middleName"`
+	// ETag is set by GetPassenger, ReplacePassenger sends it as If-Match
+	ETag string `json:"-"`
+}
+
+type PassengerWholeInfo struct {
+	Passenger
+	Documents []Document `json:"documents"`
+}
+
+// Document types accepted by the API.
+const (
+	DocumentPassport              = "Passport"
+	DocumentIdCard                = "Id card"
+	DocumentInternationalPassport = "International passport"
+)
+
+type Document struct {
+	Id          string `json:"id,omitempty"`
+	Type        string `json:"type"`
+	Number      string `json:"number"`
+	PassengerId string `json:"passengerId,omitempty"`
+}
+
+type ReportRow struct {
+	DateOfIssue     time.Time `json:"dateOfIssue"`
+	FlyAt           time.Time `json:"flyAt"`
+	TicketId        string    `json:"ticketID"`
+	FlyFrom         string    `json:"flyFrom"`
+	FlyTo           string    `json:"flyTo"`
+	ServiceProvided bool      `json:"serviceProvided"`
+}
+
+type TicketsPage struct {
+	Tickets []Ticket
+	// Next is the after of the next page, empty on the last page
+	Next string
+}
+
+type id struct {
+	Value string `json:"id"`
+}
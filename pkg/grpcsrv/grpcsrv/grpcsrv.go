@@ -0,0 +1,76 @@
+package grpcsrv
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net"
+	"time"
+
+	"google.golang.org/grpc"
+)
+
+type Server struct {
+	*grpc.Server
+	ln              net.Listener
+	socket          string
+	shutdownTimeout time.Duration
+}
+
+func New(opts ...Option) *Server {
+	cfg := config(opts...)
+
+	return &Server{
+		Server: grpc.NewServer(
+			grpc.ChainUnaryInterceptor(cfg.UnaryInterceptors...),
+			grpc.ConnectionTimeout(cfg.ConnectionTimeout),
+		),
+		socket:          cfg.Socket,
+		shutdownTimeout: cfg.ShutdownTimeout,
+	}
+}
+
+// Listen binds the socket, the server can be run by an httpsrv.Group alongside the HTTP ones.
+func (s *Server) Listen() error {
+	ln, err := net.Listen("tcp", s.socket)
+	if err != nil {
+		return fmt.Errorf("grpcsrv: grpcsrv: Listen: %w", err)
+	}
+
+	s.ln = ln
+
+	return nil
+}
+
+// Serve blocks until the server is shut down, it requires Listen.
+func (s *Server) Serve() error {
+	if err := s.Server.Serve(s.ln); err != nil && !errors.Is(err, grpc.ErrServerStopped) {
+		return fmt.Errorf("grpcsrv: grpcsrv: Serve: %w", err)
+	}
+
+	return nil
+}
+
+// Shutdown waits for in-flight RPCs up to the shutdown timeout or the deadline of ctx and then closes the rest.
+func (s *Server) Shutdown(ctx context.Context) error {
+	ctx, cancel := context.WithTimeout(ctx, s.shutdownTimeout)
+	defer cancel()
+
+	stopped := make(chan struct{})
+
+	go func() {
+		s.GracefulStop()
+		close(stopped)
+	}()
+
+	// Serve closes the listener, unless it never started
+	defer s.ln.Close()
+
+	select {
+	case <-stopped:
+		return nil
+	case <-ctx.Done():
+		s.Server.Stop()
+		return ctx.Err()
+	}
+}
@@ -0,0 +1,54 @@
+package grpcsrv
+
+import (
+	"time"
+
+	"google.golang.org/grpc"
+)
+
+type Option func(*Config)
+
+type Config struct {
+	Socket            string
+	ShutdownTimeout   time.Duration
+	ConnectionTimeout time.Duration
+	UnaryInterceptors []grpc.UnaryServerInterceptor
+}
+
+func WithSocket(socket string) Option {
+	return func(cfg *Config) {
+		cfg.Socket = socket
+	}
+}
+
+func WithShutdownTimeout(st time.Duration) Option {
+	return func(cfg *Config) {
+		cfg.ShutdownTimeout = st
+	}
+}
+
+func WithConnectionTimeout(ct time.Duration) Option {
+	return func(cfg *Config) {
+		cfg.ConnectionTimeout = ct
+	}
+}
+
+func WithUnaryInterceptors(interceptors ...grpc.UnaryServerInterceptor) Option {
+	return func(cfg *Config) {
+		cfg.UnaryInterceptors = append(cfg.UnaryInterceptors, interceptors...)
+	}
+}
+
+func config(opts ...Option) Config {
+	cfg := Config{
+		Socket:            ":9090",
+		ShutdownTimeout:   0,
+		ConnectionTimeout: 10 * time.Second,
+	}
+
+	for _, opt := range opts {
+		opt(&cfg)
+	}
+
+	return cfg
+}
@@ -0,0 +1,126 @@
+package httpsrv
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"sync"
+	"time"
+)
+
+// Runner is a server of a Group.
+type Runner interface {
+	// Listen binds the socket, it is called for every runner before any of them serves
+	Listen() error
+	// Serve blocks until Shutdown, it returns nil when the runner was shut down
+	Serve() error
+	Shutdown(ctx context.Context) error
+}
+
+// Group runs several servers, e.g. the public API and the admin one, with one lifecycle.
+type Group struct {
+	runners         []Runner
+	shutdownTimeout time.Duration
+	drainDelay      time.Duration
+	onShutdown      []func()
+}
+
+// NewGroup accepts WithShutdownTimeout, WithDrainDelay and WithOnShutdown, other options configure servers and are ignored.
+func NewGroup(opts ...Option) *Group {
+	cfg := config(opts...)
+
+	return &Group{
+		shutdownTimeout: cfg.ShutdownTimeout,
+		drainDelay:      cfg.DrainDelay,
+		onShutdown:      cfg.OnShutdown,
+	}
+}
+
+func (g *Group) Add(runners ...Runner) *Group {
+	g.runners = append(g.runners, runners...)
+
+	return g
+}
+
+// Run serves until ctx is done or one of the runners fails, then shuts all of them down.
+// The shutdown hooks run first and the runners keep serving for the drain delay,
+// so load balancers notice the failing readiness before the sockets close.
+func (g *Group) Run(ctx context.Context) error {
+	for i, r := range g.runners {
+		if err := r.Listen(); err != nil {
+			g.shutdown(g.runners[:i])
+			return fmt.Errorf("httpsrv: group: Run: %w", err)
+		}
+	}
+
+	served := make(chan error, len(g.runners))
+	for _, r := range g.runners {
+		go func() {
+			served <- r.Serve()
+		}()
+	}
+
+	var errs []error
+	running := len(g.runners)
+
+	select {
+	case <-ctx.Done():
+		log.Print("shutdown server ...")
+
+		for _, fn := range g.onShutdown {
+			fn()
+		}
+
+		time.Sleep(g.drainDelay)
+	case err := <-served:
+		// One runner stopped on its own, the rest must not outlive it
+		running--
+		errs = append(errs, err)
+	}
+
+	errs = append(errs, g.shutdown(g.runners))
+
+	for range running {
+		errs = append(errs, <-served)
+	}
+
+	log.Print("server exiting")
+
+	return errors.Join(errs...)
+}
+
+// shutdown stops runners concurrently, they share the shutdown timeout.
+func (g *Group) shutdown(runners []Runner) error {
+	ctx, cancel := context.WithTimeout(context.Background(), g.shutdownTimeout)
+	defer cancel()
+
+	var (
+		wg   sync.WaitGroup
+		mu   sync.Mutex
+		errs []error
+	)
+
+	for _, r := range runners {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+
+			err := r.Shutdown(ctx)
+			if errors.Is(err, context.DeadlineExceeded) {
+				log.Printf("timeout of %s seconds", g.shutdownTimeout.String())
+				return
+			}
+
+			if err != nil {
+				mu.Lock()
+				errs = append(errs, fmt.Errorf("httpsrv: group: shutdown: %w", err))
+				mu.Unlock()
+			}
+		}()
+	}
+
+	wg.Wait()
+
+	return errors.Join(errs...)
+}
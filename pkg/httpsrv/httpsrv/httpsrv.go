@@ -2,60 +2,156 @@ package httpsrv
 
 import (
 	"context"
-	"log"
+	"crypto/tls"
+	"errors"
+	"fmt"
+	"io/fs"
+	"net"
 	"net/http"
 	"os"
 	"os/signal"
+	"strings"
 	"syscall"
 	"time"
+
+	"golang.org/x/net/http2"
+	"golang.org/x/net/http2/h2c"
 )
 
+const _unixPrefix = "unix:"
+
 type Server struct {
 	*http.Server
-	shutdownTimeout time.Duration
+	ln                net.Listener
+	certFile          string
+	keyFile           string
+	certCheckInterval time.Duration
+	shutdownTimeout   time.Duration
+	drainDelay        time.Duration
+	onShutdown        []func()
 }
 
 func New(h http.Handler, opts ...Option) *Server {
 	cfg := config(opts...)
 
+	srv := &http.Server{
+		Handler:           h,
+		Addr:              cfg.Socket,
+		WriteTimeout:      cfg.WriteTimeout,
+		ReadTimeout:       cfg.ReadTimeout,
+		ReadHeaderTimeout: cfg.ReadHeaderTimeout,
+		IdleTimeout:       cfg.IdleTimeout,
+		MaxHeaderBytes:    cfg.MaxHeaderBytes,
+	}
+
+	switch {
+	case !cfg.Http2:
+		// A non-nil empty map turns off HTTP/2 over TLS
+		srv.TLSNextProto = map[string]func(*http.Server, *tls.Conn, http.Handler){}
+	case cfg.CertFile == "":
+		srv.Handler = h2c.NewHandler(h, &http2.Server{IdleTimeout: cfg.IdleTimeout})
+	}
+
 	return &Server{
-		Server: &http.Server{
-			Handler:      h,
-			Addr:         cfg.Socket,
-			WriteTimeout: cfg.WriteTimeout,
-			ReadTimeout:  cfg.ReadTimeout,
-		},
+		Server:            srv,
+		certFile:          cfg.CertFile,
+		keyFile:           cfg.KeyFile,
+		certCheckInterval: cfg.CertCheckInterval,
+		shutdownTimeout:   cfg.ShutdownTimeout,
+		drainDelay:        cfg.DrainDelay,
+		onShutdown:        cfg.OnShutdown,
 	}
 }
 
-func (s *Server) Run() {
-	go func() {
-		if err := s.ListenAndServe(); err != http.ErrServerClosed && err != nil {
-			log.Fatalf("httpsrv: httpsrv: listenAndServe: %s", err)
+// Run serves until SIGINT or SIGTERM and shuts the server down gracefully, use a Group to run several servers.
+func (s *Server) Run() error {
+	ctx, stop := signal.NotifyContext(context.Background(), syscall.SIGINT, syscall.SIGTERM)
+	defer stop()
+
+	g := &Group{
+		runners:         []Runner{s},
+		shutdownTimeout: s.shutdownTimeout,
+		drainDelay:      s.drainDelay,
+		onShutdown:      s.onShutdown,
+	}
+
+	return g.Run(ctx)
+}
+
+// Listen binds the socket and loads the certificate, so a misconfigured server fails before any server of a group serves.
+func (s *Server) Listen() error {
+	network, addr := "tcp", s.Addr
+	if path, ok := strings.CutPrefix(s.Addr, _unixPrefix); ok {
+		network, addr = "unix", path
+
+		if err := removeStaleSocket(path); err != nil {
+			return fmt.Errorf("httpsrv: httpsrv: Listen: removeStaleSocket: %w", err)
+		}
+	}
+
+	if s.certFile != "" {
+		certs, err := newCertReloader(s.certFile, s.keyFile, s.certCheckInterval)
+		if err != nil {
+			return fmt.Errorf("httpsrv: httpsrv: Listen: %w", err)
 		}
-	}()
 
-	s.gracefulShutdown()
+		s.TLSConfig = &tls.Config{
+			MinVersion:     tls.VersionTLS12,
+			GetCertificate: certs.GetCertificate,
+		}
+	}
+
+	ln, err := net.Listen(network, addr)
+	if err != nil {
+		return fmt.Errorf("httpsrv: httpsrv: Listen: %w", err)
+	}
+
+	s.ln = ln
+
+	return nil
 }
 
-func (s *Server) gracefulShutdown() {
-	quit := make(chan os.Signal, 1)
-	signal.Notify(quit, syscall.SIGINT, syscall.SIGTERM)
-	<-quit
+// Serve blocks until the server is shut down, it requires Listen.
+func (s *Server) Serve() error {
+	var err error
+	if s.TLSConfig != nil {
+		err = s.Server.ServeTLS(s.ln, "", "")
+	} else {
+		err = s.Server.Serve(s.ln)
+	}
 
-	log.Print("shutdown server ...")
+	if err != nil && !errors.Is(err, http.ErrServerClosed) {
+		return fmt.Errorf("httpsrv: httpsrv: Serve: %w", err)
+	}
 
-	ctx, cancel := context.WithTimeout(context.Background(), s.shutdownTimeout)
-	defer cancel()
+	return nil
+}
+
+// Shutdown stops the server gracefully, it also closes the socket of a server that listens but doesn't serve yet.
+func (s *Server) Shutdown(ctx context.Context) error {
+	err := s.Server.Shutdown(ctx)
+
+	if s.ln != nil {
+		// Serve has closed it already unless it never started
+		s.ln.Close()
+	}
 
-	if err := s.Shutdown(ctx); err != nil {
-		log.Fatalf("httpsrv: httpsrv: gracefulShutdown: shutdown: %s", err)
+	return err
+}
+
+// removeStaleSocket removes a socket file left by a previous process, other files are kept so Listen fails on them.
+func removeStaleSocket(path string) error {
+	fi, err := os.Stat(path)
+	if errors.Is(err, fs.ErrNotExist) {
+		return nil
+	}
+	if err != nil {
+		return err
 	}
 
-	select {
-	case <-ctx.Done():
-		log.Printf("timeout of %s seconds", s.shutdownTimeout.String())
+	if fi.Mode()&fs.ModeSocket == 0 {
+		return nil
 	}
 
-	log.Print("server exiting")
+	return os.Remove(path)
 }
@@ -0,0 +1,196 @@
+package httpsrv_test
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/tls"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
+	"io"
+	"math/big"
+	"net"
+	"net/http"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/v1adhope/flights/pkg/httpsrv/httpsrv"
+	"golang.org/x/net/http2"
+)
+
+var okHandler = http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+	io.WriteString(w, r.Proto)
+})
+
+func unixSocket(t *testing.T) string {
+	// Socket paths are limited to about 100 bytes, t.TempDir can be longer
+	dir, err := os.MkdirTemp("", "httpsrv")
+	require.NoError(t, err)
+	t.Cleanup(func() { os.RemoveAll(dir) })
+
+	return filepath.Join(dir, "srv.sock")
+}
+
+func unixDialer(path string) func(ctx context.Context, _, _ string) (net.Conn, error) {
+	return func(ctx context.Context, _, _ string) (net.Conn, error) {
+		return (&net.Dialer{}).DialContext(ctx, "unix", path)
+	}
+}
+
+func run(t *testing.T, g *httpsrv.Group, sockets ...string) (stop func() error) {
+	ctx, cancel := context.WithCancel(context.Background())
+
+	done := make(chan error, 1)
+	go func() {
+		done <- g.Run(ctx)
+	}()
+
+	for _, socket := range sockets {
+		require.Eventually(t, func() bool {
+			conn, err := net.Dial("unix", socket)
+			if err == nil {
+				conn.Close()
+			}
+
+			return err == nil
+		}, time.Second, 10*time.Millisecond)
+	}
+
+	return func() error {
+		cancel()
+		return <-done
+	}
+}
+
+func get(t *testing.T, client *http.Client, url string) string {
+	resp, err := client.Get(url)
+	require.NoError(t, err)
+	defer resp.Body.Close()
+
+	body, err := io.ReadAll(resp.Body)
+	require.NoError(t, err)
+
+	return string(body)
+}
+
+func TestGroup(t *testing.T) {
+	api, admin := unixSocket(t), unixSocket(t)
+
+	drained := false
+	g := httpsrv.NewGroup(
+		httpsrv.WithShutdownTimeout(time.Second),
+		httpsrv.WithOnShutdown(func() { drained = true }),
+	).Add(
+		httpsrv.New(okHandler, httpsrv.WithSocket("unix:"+api)),
+		httpsrv.New(okHandler, httpsrv.WithSocket("unix:"+admin), httpsrv.WithHttp2(false)),
+	)
+
+	stop := run(t, g, api, admin)
+
+	for _, socket := range []string{api, admin} {
+		client := &http.Client{Transport: &http.Transport{DialContext: unixDialer(socket)}}
+		assert.Equal(t, "HTTP/1.1", get(t, client, "http://srv/"))
+	}
+
+	h2c := &http.Client{Transport: &http2.Transport{
+		AllowHTTP: true,
+		DialTLSContext: func(ctx context.Context, _, _ string, _ *tls.Config) (net.Conn, error) {
+			return unixDialer(api)(ctx, "", "")
+		},
+	}}
+	assert.Equal(t, "HTTP/2.0", get(t, h2c, "http://srv/"))
+
+	require.NoError(t, stop())
+	assert.True(t, drained)
+	assert.NoFileExists(t, api)
+}
+
+func TestGroupListenError(t *testing.T) {
+	ln, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+	defer ln.Close()
+
+	api := unixSocket(t)
+
+	err = httpsrv.NewGroup().Add(
+		httpsrv.New(okHandler, httpsrv.WithSocket("unix:"+api)),
+		httpsrv.New(okHandler, httpsrv.WithSocket(ln.Addr().String())),
+	).Run(context.Background())
+
+	assert.ErrorContains(t, err, "address already in use")
+	assert.NoFileExists(t, api, "bound sockets are closed")
+}
+
+func writeCert(t *testing.T, certFile, keyFile string, serial int64, modTime time.Time) {
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.NoError(t, err)
+
+	tmpl := &x509.Certificate{
+		SerialNumber: big.NewInt(serial),
+		Subject:      pkix.Name{CommonName: "srv"},
+		NotBefore:    time.Now().Add(-time.Hour),
+		NotAfter:     time.Now().Add(time.Hour),
+		DNSNames:     []string{"srv"},
+	}
+
+	der, err := x509.CreateCertificate(rand.Reader, tmpl, tmpl, &key.PublicKey, key)
+	require.NoError(t, err)
+
+	keyDer, err := x509.MarshalECPrivateKey(key)
+	require.NoError(t, err)
+
+	require.NoError(t, os.WriteFile(certFile, pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: der}), 0o600))
+	require.NoError(t, os.WriteFile(keyFile, pem.EncodeToMemory(&pem.Block{Type: "EC PRIVATE KEY", Bytes: keyDer}), 0o600))
+
+	for _, file := range []string{certFile, keyFile} {
+		require.NoError(t, os.Chtimes(file, modTime, modTime))
+	}
+}
+
+func TestTLSReload(t *testing.T) {
+	dir := t.TempDir()
+	certFile, keyFile := filepath.Join(dir, "cert.pem"), filepath.Join(dir, "key.pem")
+	writeCert(t, certFile, keyFile, 1, time.Now().Add(-time.Minute))
+
+	api := unixSocket(t)
+
+	stop := run(t, httpsrv.NewGroup().Add(httpsrv.New(
+		okHandler,
+		httpsrv.WithSocket("unix:"+api),
+		httpsrv.WithTLS(certFile, keyFile),
+		httpsrv.WithCertCheckInterval(0),
+	)), api)
+	defer stop()
+
+	serial := func() int64 {
+		transport := &http.Transport{
+			DialContext:       unixDialer(api),
+			TLSClientConfig:   &tls.Config{InsecureSkipVerify: true},
+			ForceAttemptHTTP2: true,
+		}
+		defer transport.CloseIdleConnections()
+
+		resp, err := (&http.Client{Transport: transport}).Get("https://srv/")
+		require.NoError(t, err)
+		defer resp.Body.Close()
+
+		assert.Equal(t, 2, resp.ProtoMajor)
+
+		return resp.TLS.PeerCertificates[0].SerialNumber.Int64()
+	}
+
+	assert.Equal(t, int64(1), serial())
+
+	writeCert(t, certFile, keyFile, 2, time.Now())
+	assert.Equal(t, int64(2), serial())
+
+	os.WriteFile(certFile, []byte("broken"), 0o600)
+	os.Chtimes(certFile, time.Now().Add(time.Minute), time.Now().Add(time.Minute))
+	assert.Equal(t, int64(2), serial(), "a broken renewal keeps the previous certificate")
+}
@@ -5,12 +5,23 @@ import "time"
 type Option func(*Config)
 
 type Config struct {
-	Socket          string
-	ShutdownTimeout time.Duration
-	WriteTimeout    time.Duration
-	ReadTimeout     time.Duration
+	// Socket is "host:port" or "unix:/path/to/socket"
+	Socket            string
+	ShutdownTimeout   time.Duration
+	WriteTimeout      time.Duration
+	ReadTimeout       time.Duration
+	ReadHeaderTimeout time.Duration
+	IdleTimeout       time.Duration
+	MaxHeaderBytes    int
+	DrainDelay        time.Duration
+	OnShutdown        []func()
+	CertFile          string
+	KeyFile           string
+	CertCheckInterval time.Duration
+	Http2             bool
 }
 
+// WithSocket accepts "host:port" or "unix:/path/to/socket".
 func WithSocket(socket string) Option {
 	return func(cfg *Config) {
 		cfg.Socket = socket
@@ -35,12 +46,72 @@ func WithReadTimeout(rt time.Duration) Option {
 	}
 }
 
+func WithReadHeaderTimeout(rht time.Duration) Option {
+	return func(cfg *Config) {
+		cfg.ReadHeaderTimeout = rht
+	}
+}
+
+func WithIdleTimeout(it time.Duration) Option {
+	return func(cfg *Config) {
+		cfg.IdleTimeout = it
+	}
+}
+
+func WithMaxHeaderBytes(n int) Option {
+	return func(cfg *Config) {
+		cfg.MaxHeaderBytes = n
+	}
+}
+
+// WithDrainDelay keeps the server serving for dd after a shutdown signal, before it stops accepting connections.
+func WithDrainDelay(dd time.Duration) Option {
+	return func(cfg *Config) {
+		cfg.DrainDelay = dd
+	}
+}
+
+// WithOnShutdown adds fn to run as soon as a shutdown signal is received, before the server stops.
+func WithOnShutdown(fn func()) Option {
+	return func(cfg *Config) {
+		cfg.OnShutdown = append(cfg.OnShutdown, fn)
+	}
+}
+
+// WithTLS serves HTTPS, the certificate is loaded again when one of the files changes.
+func WithTLS(certFile, keyFile string) Option {
+	return func(cfg *Config) {
+		cfg.CertFile = certFile
+		cfg.KeyFile = keyFile
+	}
+}
+
+// WithCertCheckInterval sets how often handshakes check the certificate files for changes.
+func WithCertCheckInterval(ci time.Duration) Option {
+	return func(cfg *Config) {
+		cfg.CertCheckInterval = ci
+	}
+}
+
+// WithHttp2 serves HTTP/2 over TLS and as h2c without it.
+func WithHttp2(enabled bool) Option {
+	return func(cfg *Config) {
+		cfg.Http2 = enabled
+	}
+}
+
 func config(opts ...Option) Config {
 	cfg := Config{
-		Socket:          ":8080",
-		ShutdownTimeout: 0,
-		WriteTimeout:    10 * time.Second,
-		ReadTimeout:     10 * time.Second,
+		Socket:            ":8080",
+		ShutdownTimeout:   0,
+		DrainDelay:        0,
+		WriteTimeout:      10 * time.Second,
+		ReadTimeout:       10 * time.Second,
+		ReadHeaderTimeout: 5 * time.Second,
+		IdleTimeout:       60 * time.Second,
+		MaxHeaderBytes:    1 << 20,
+		CertCheckInterval: 10 * time.Second,
+		Http2:             true,
 	}
 
 	for _, opt := range opts {
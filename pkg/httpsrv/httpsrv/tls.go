@@ -0,0 +1,91 @@
+package httpsrv
+
+import (
+	"crypto/tls"
+	"fmt"
+	"log"
+	"os"
+	"sync"
+	"time"
+)
+
+// certReloader loads the certificate again when its files change, so renewed certificates are served without a restart.
+type certReloader struct {
+	certFile      string
+	keyFile       string
+	checkInterval time.Duration
+
+	mu      sync.Mutex
+	cert    *tls.Certificate
+	modTime time.Time
+	checked time.Time
+}
+
+func newCertReloader(certFile, keyFile string, checkInterval time.Duration) (*certReloader, error) {
+	r := &certReloader{
+		certFile:      certFile,
+		keyFile:       keyFile,
+		checkInterval: checkInterval,
+	}
+
+	if err := r.reload(); err != nil {
+		return nil, fmt.Errorf("httpsrv: tls: newCertReloader: %w", err)
+	}
+
+	return r, nil
+}
+
+func (r *certReloader) GetCertificate(*tls.ClientHelloInfo) (*tls.Certificate, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if time.Since(r.checked) >= r.checkInterval {
+		// A broken renewal keeps the previous certificate
+		if err := r.reload(); err != nil {
+			log.Printf("httpsrv: tls: GetCertificate: %s", err)
+		}
+	}
+
+	return r.cert, nil
+}
+
+// reload is called with mu held or before r is shared.
+func (r *certReloader) reload() error {
+	r.checked = time.Now()
+
+	modTime, err := latestModTime(r.certFile, r.keyFile)
+	if err != nil {
+		return fmt.Errorf("reload: Stat: %w", err)
+	}
+
+	if r.cert != nil && !modTime.After(r.modTime) {
+		return nil
+	}
+
+	cert, err := tls.LoadX509KeyPair(r.certFile, r.keyFile)
+	if err != nil {
+		return fmt.Errorf("reload: LoadX509KeyPair: %w", err)
+	}
+
+	r.cert = &cert
+	r.modTime = modTime
+
+	return nil
+}
+
+func latestModTime(files ...string) (time.Time, error) {
+	var latest time.Time
+
+	for _, file := range files {
+		fi, err := os.Stat(file)
+		if err != nil {
+			return time.Time{}, err
+		}
+
+		if fi.ModTime().After(latest) {
+			latest = fi.ModTime()
+		}
+	}
+
+	return latest, nil
+}
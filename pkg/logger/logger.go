@@ -1,38 +1,77 @@
 package logger
 
 import (
+	"context"
 	"fmt"
 	"log/slog"
-	"os"
 )
 
 type Log struct {
 	*slog.Logger
+	level *slog.LevelVar
 }
 
 func New(opts ...Option) *Log {
 	cfg := config(opts...)
 
-	log := slog.New(slog.NewJSONHandler(os.Stdout, &cfg))
+	level := &slog.LevelVar{}
+	level.Set(cfg.Level.Level())
+	cfg.Level = level
+
+	var handler slog.Handler = slog.NewJSONHandler(cfg.Output, &cfg.HandlerOptions)
+	if cfg.Format == "text" {
+		handler = slog.NewTextHandler(cfg.Output, &cfg.HandlerOptions)
+	}
+
+	log := slog.New(&contextHandler{handler})
 
 	slog.SetDefault(log)
 
-	return &Log{log}
+	return &Log{log, level}
+}
+
+// SetLevel changes the level of l at runtime, it accepts the levels of WithLevel.
+func (l *Log) SetLevel(lvl string) error {
+	level, ok := parseLevel(lvl)
+	if !ok {
+		return fmt.Errorf("logger: logger: SetLevel: unknown level %q", lvl)
+	}
+
+	l.level.Set(level)
+
+	return nil
 }
 
 func (l *Log) Info(format string, msg ...any) {
-	l.Logger.Info(fmt.Sprintf(format, msg...))
+	l.InfoCtx(context.Background(), format, msg...)
 }
 
 func (l *Log) Debug(err error, format string, msg ...any) {
-	l.Logger.Debug(
+	l.DebugCtx(context.Background(), err, format, msg...)
+}
+
+func (l *Log) Error(err error, format string, msg ...any) {
+	l.ErrorCtx(context.Background(), err, format, msg...)
+}
+
+// InfoCtx logs with the attributes of ctx, see ContextWith.
+func (l *Log) InfoCtx(ctx context.Context, format string, msg ...any) {
+	l.Logger.InfoContext(ctx, fmt.Sprintf(format, msg...))
+}
+
+// DebugCtx logs with the attributes of ctx, see ContextWith.
+func (l *Log) DebugCtx(ctx context.Context, err error, format string, msg ...any) {
+	l.Logger.DebugContext(
+		ctx,
 		fmt.Sprintf(format, msg...),
 		handleErr(err),
 	)
 }
 
-func (l *Log) Error(err error, format string, msg ...any) {
-	l.Logger.Error(
+// ErrorCtx logs with the attributes of ctx, see ContextWith.
+func (l *Log) ErrorCtx(ctx context.Context, err error, format string, msg ...any) {
+	l.Logger.ErrorContext(
+		ctx,
 		fmt.Sprintf(format, msg...),
 		handleErr(err),
 	)
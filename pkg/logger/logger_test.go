@@ -0,0 +1,94 @@
+package logger_test
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"log/slog"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/v1adhope/flights/pkg/logger"
+	"go.opentelemetry.io/otel/trace"
+)
+
+func TestContextAttrs(t *testing.T) {
+	buf := &bytes.Buffer{}
+
+	log := logger.New(
+		logger.WithLevel("debug"),
+		logger.WithOutput(buf),
+	)
+
+	traceId, _ := trace.TraceIDFromHex("4bf92f3577b34da6a3ce929d0e0e4736")
+	spanId, _ := trace.SpanIDFromHex("00f067aa0ba902b7")
+
+	ctx := logger.ContextWith(context.Background(), slog.String("request_id", "abc"))
+	ctx = trace.ContextWithSpanContext(ctx, trace.NewSpanContext(trace.SpanContextConfig{
+		TraceID: traceId,
+		SpanID:  spanId,
+	}))
+
+	log.DebugCtx(ctx, errors.New("boom"), "%s", "StatusUnprocessableEntity")
+
+	line := map[string]any{}
+	assert.NoError(t, json.Unmarshal(buf.Bytes(), &line))
+
+	assert.Equal(t, "StatusUnprocessableEntity", line["msg"])
+	assert.Equal(t, "boom", line["err"])
+	assert.Equal(t, "abc", line["request_id"])
+	assert.Equal(t, "4bf92f3577b34da6a3ce929d0e0e4736", line["trace_id"])
+	assert.Equal(t, "00f067aa0ba902b7", line["span_id"])
+}
+
+func TestContextWithDoesNotShareAttrs(t *testing.T) {
+	buf := &bytes.Buffer{}
+
+	log := logger.New(
+		logger.WithOutput(buf),
+		logger.WithFormat("text"),
+	)
+
+	parent := logger.ContextWith(context.Background(), slog.String("a", "1"))
+	_ = logger.ContextWith(parent, slog.String("b", "2"))
+
+	log.InfoCtx(parent, "%s", "parent")
+
+	assert.Contains(t, buf.String(), "a=1")
+	assert.NotContains(t, buf.String(), "b=2")
+}
+
+func TestLevel(t *testing.T) {
+	buf := &bytes.Buffer{}
+
+	log := logger.New(
+		logger.WithLevel("warn"),
+		logger.WithOutput(buf),
+	)
+
+	log.Info("%s", "skipped")
+	log.Error(nil, "%s", "logged")
+
+	assert.NotContains(t, buf.String(), "skipped")
+	assert.Contains(t, buf.String(), "logged")
+}
+
+func TestSetLevel(t *testing.T) {
+	buf := &bytes.Buffer{}
+
+	log := logger.New(
+		logger.WithLevel("warn"),
+		logger.WithOutput(buf),
+	)
+
+	log.Info("%s", "skipped")
+
+	assert.NoError(t, log.SetLevel("info"))
+	log.Info("%s", "logged")
+
+	assert.Error(t, log.SetLevel("verbose"))
+
+	assert.NotContains(t, buf.String(), "skipped")
+	assert.Contains(t, buf.String(), "logged")
+}
@@ -1,23 +1,64 @@
 package logger
 
-import "log/slog"
+import (
+	"io"
+	"log/slog"
+	"os"
+)
 
-type Option func(*slog.HandlerOptions)
+type Option func(*Config)
 
+type Config struct {
+	slog.HandlerOptions
+	// Format is "json" or "text"
+	Format string
+	Output io.Writer
+}
+
+// WithLevel accepts one of "debug", "info", "warn", "error".
 func WithLevel(lvl string) Option {
-	return func(opts *slog.HandlerOptions) {
-		switch lvl {
-		case "debug":
-			opts.Level = slog.LevelDebug
-		case "error":
-			opts.Level = slog.LevelError
+	return func(cfg *Config) {
+		if level, ok := parseLevel(lvl); ok {
+			cfg.Level = level
 		}
 	}
 }
 
-func config(opts ...Option) slog.HandlerOptions {
-	cfg := slog.HandlerOptions{
-		Level: slog.LevelInfo,
+func parseLevel(lvl string) (slog.Level, bool) {
+	switch lvl {
+	case "debug":
+		return slog.LevelDebug, true
+	case "info":
+		return slog.LevelInfo, true
+	case "warn":
+		return slog.LevelWarn, true
+	case "error":
+		return slog.LevelError, true
+	}
+
+	return 0, false
+}
+
+// WithFormat accepts "json" or "text".
+func WithFormat(format string) Option {
+	return func(cfg *Config) {
+		cfg.Format = format
+	}
+}
+
+func WithOutput(w io.Writer) Option {
+	return func(cfg *Config) {
+		cfg.Output = w
+	}
+}
+
+func config(opts ...Option) Config {
+	cfg := Config{
+		HandlerOptions: slog.HandlerOptions{
+			Level: slog.LevelInfo,
+		},
+		Format: "json",
+		Output: os.Stdout,
 	}
 
 	for _, opt := range opts {
@@ -0,0 +1,121 @@
+// Package lru is a size-bounded least recently used cache with expiring entries, safe for concurrent use.
+package lru
+
+import (
+	"container/list"
+	"sync"
+	"time"
+)
+
+type Cache[K comparable, V any] struct {
+	mu       sync.Mutex
+	capacity int
+	ttl      time.Duration
+	onEvict  func(key K, value V)
+	now      func() time.Time
+	order    *list.List
+	entries  map[K]*list.Element
+}
+
+type entry[K comparable, V any] struct {
+	key       K
+	value     V
+	expiresAt time.Time
+}
+
+func New[K comparable, V any](opts ...Option[K, V]) *Cache[K, V] {
+	cfg := config(opts...)
+
+	return &Cache[K, V]{
+		capacity: cfg.Capacity,
+		ttl:      cfg.Ttl,
+		onEvict:  cfg.OnEvict,
+		now:      cfg.Now,
+		order:    list.New(),
+		entries:  map[K]*list.Element{},
+	}
+}
+
+// Get returns the value of key and marks it as recently used, expired entries are dropped.
+func (c *Cache[K, V]) Get(key K) (V, bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	el, ok := c.entries[key]
+	if !ok {
+		var zero V
+		return zero, false
+	}
+
+	e := el.Value.(*entry[K, V])
+	if !e.expiresAt.IsZero() && !c.now().Before(e.expiresAt) {
+		c.remove(el)
+
+		var zero V
+		return zero, false
+	}
+
+	c.order.MoveToFront(el)
+
+	return e.value, true
+}
+
+// Set adds or replaces the value of key, it expires after the ttl of the cache.
+func (c *Cache[K, V]) Set(key K, value V) {
+	c.SetWithTtl(key, value, c.ttl)
+}
+
+// SetWithTtl is Set with the ttl of the entry, 0 keeps it until it is evicted.
+func (c *Cache[K, V]) SetWithTtl(key K, value V, ttl time.Duration) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	var expiresAt time.Time
+	if ttl > 0 {
+		expiresAt = c.now().Add(ttl)
+	}
+
+	if el, ok := c.entries[key]; ok {
+		c.remove(el)
+	}
+
+	c.entries[key] = c.order.PushFront(&entry[K, V]{key, value, expiresAt})
+
+	for c.order.Len() > c.capacity {
+		c.remove(c.order.Back())
+	}
+}
+
+func (c *Cache[K, V]) Delete(key K) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if el, ok := c.entries[key]; ok {
+		c.remove(el)
+	}
+}
+
+// Purge removes every entry.
+func (c *Cache[K, V]) Purge() {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	for c.order.Len() > 0 {
+		c.remove(c.order.Back())
+	}
+}
+
+// Len counts expired entries too until they are touched.
+func (c *Cache[K, V]) Len() int {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	return c.order.Len()
+}
+
+func (c *Cache[K, V]) remove(el *list.Element) {
+	e := c.order.Remove(el).(*entry[K, V])
+	delete(c.entries, e.key)
+
+	c.onEvict(e.key, e.value)
+}
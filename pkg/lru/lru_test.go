@@ -0,0 +1,60 @@
+package lru_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/v1adhope/flights/pkg/lru"
+)
+
+func TestCapacity(t *testing.T) {
+	var evicted []string
+
+	c := lru.New(
+		lru.WithCapacity[string, int](2),
+		lru.WithOnEvict(func(key string, _ int) { evicted = append(evicted, key) }),
+	)
+
+	c.Set("a", 1)
+	c.Set("b", 2)
+	c.Get("a")
+	c.Set("c", 3)
+
+	_, ok := c.Get("b")
+	assert.False(t, ok, "the least recently used entry is evicted")
+
+	v, ok := c.Get("a")
+	assert.True(t, ok)
+	assert.Equal(t, 1, v)
+
+	c.Delete("c")
+
+	assert.Equal(t, []string{"b", "c"}, evicted)
+	assert.Equal(t, 1, c.Len())
+
+	c.Purge()
+
+	assert.Equal(t, []string{"b", "c", "a"}, evicted)
+	assert.Equal(t, 0, c.Len())
+}
+
+func TestTtl(t *testing.T) {
+	now := time.Now()
+
+	c := lru.New(
+		lru.WithTtl[string, int](time.Minute),
+		lru.WithNow[string, int](func() time.Time { return now }),
+	)
+
+	c.Set("a", 1)
+	c.SetWithTtl("b", 2, 0)
+
+	now = now.Add(time.Minute)
+
+	_, ok := c.Get("a")
+	assert.False(t, ok)
+
+	_, ok = c.Get("b")
+	assert.True(t, ok, "entries without ttl don't expire")
+}
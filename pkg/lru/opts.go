@@ -0,0 +1,55 @@
+package lru
+
+import "time"
+
+type Option[K comparable, V any] func(*Config[K, V])
+
+type Config[K comparable, V any] struct {
+	Capacity int
+	Ttl      time.Duration
+	// OnEvict is called with the mutex of the cache held for entries dropped by capacity, expiry or Delete
+	OnEvict func(key K, value V)
+	Now     func() time.Time
+}
+
+// WithCapacity bounds the number of entries, the least recently used one is evicted first.
+func WithCapacity[K comparable, V any](n int) Option[K, V] {
+	return func(cfg *Config[K, V]) {
+		cfg.Capacity = n
+	}
+}
+
+// WithTtl expires entries d after they are set, 0 keeps them until they are evicted.
+func WithTtl[K comparable, V any](d time.Duration) Option[K, V] {
+	return func(cfg *Config[K, V]) {
+		cfg.Ttl = d
+	}
+}
+
+func WithOnEvict[K comparable, V any](fn func(key K, value V)) Option[K, V] {
+	return func(cfg *Config[K, V]) {
+		cfg.OnEvict = fn
+	}
+}
+
+// WithNow replaces the clock, tests use it to expire entries.
+func WithNow[K comparable, V any](now func() time.Time) Option[K, V] {
+	return func(cfg *Config[K, V]) {
+		cfg.Now = now
+	}
+}
+
+func config[K comparable, V any](opts ...Option[K, V]) Config[K, V] {
+	cfg := Config[K, V]{
+		Capacity: 1024,
+		Ttl:      0,
+		OnEvict:  func(K, V) {},
+		Now:      time.Now,
+	}
+
+	for _, opt := range opts {
+		opt(&cfg)
+	}
+
+	return cfg
+}
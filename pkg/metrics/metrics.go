@@ -0,0 +1,150 @@
+// Package metrics collects Prometheus metrics of HTTP requests, usecase calls, caches, business counters and pgx pools.
+package metrics
+
+import (
+	"net/http"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/jackc/pgx/v5/pgxpool"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/collectors"
+	"github.com/prometheus/client_golang/prometheus/promhttp"
+)
+
+type Metrics struct {
+	registry        *prometheus.Registry
+	namespace       string
+	httpRequests    *prometheus.CounterVec
+	httpDuration    *prometheus.HistogramVec
+	usecaseDuration *prometheus.HistogramVec
+	usecaseErrors   *prometheus.CounterVec
+	cacheRequests   *prometheus.CounterVec
+
+	countersMu sync.Mutex
+	counters   map[string]prometheus.Counter
+}
+
+// New builds metrics on their own registry with the Go and process collectors.
+func New(opts ...Option) *Metrics {
+	cfg := config(opts...)
+
+	m := &Metrics{
+		registry:  prometheus.NewRegistry(),
+		namespace: cfg.Namespace,
+		httpRequests: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Namespace: cfg.Namespace,
+			Subsystem: "http",
+			Name:      "requests_total",
+			Help:      "HTTP requests by method, route template and status.",
+		}, []string{"method", "route", "status"}),
+		httpDuration: prometheus.NewHistogramVec(prometheus.HistogramOpts{
+			Namespace: cfg.Namespace,
+			Subsystem: "http",
+			Name:      "request_duration_seconds",
+			Help:      "Latency of HTTP requests by method, route template and status.",
+			Buckets:   cfg.Buckets,
+		}, []string{"method", "route", "status"}),
+		usecaseDuration: prometheus.NewHistogramVec(prometheus.HistogramOpts{
+			Namespace: cfg.Namespace,
+			Subsystem: "usecase",
+			Name:      "call_duration_seconds",
+			Help:      "Duration of usecase calls.",
+			Buckets:   cfg.Buckets,
+		}, []string{"usecase"}),
+		usecaseErrors: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Namespace: cfg.Namespace,
+			Subsystem: "usecase",
+			Name:      "errors_total",
+			Help:      "Failed usecase calls by error category.",
+		}, []string{"usecase", "category"}),
+		cacheRequests: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Namespace: cfg.Namespace,
+			Subsystem: "cache",
+			Name:      "requests_total",
+			Help:      "Cache lookups by cache and result, hit or miss.",
+		}, []string{"cache", "result"}),
+		counters: map[string]prometheus.Counter{},
+	}
+
+	m.registry.MustRegister(
+		collectors.NewGoCollector(),
+		collectors.NewProcessCollector(collectors.ProcessCollectorOpts{}),
+		m.httpRequests,
+		m.httpDuration,
+		m.usecaseDuration,
+		m.usecaseErrors,
+		m.cacheRequests,
+	)
+
+	return m
+}
+
+// Handler serves the metrics in the Prometheus exposition format.
+func (m *Metrics) Handler() http.Handler {
+	return promhttp.HandlerFor(m.registry, promhttp.HandlerOpts{})
+}
+
+// Register adds collectors to the registry of the metrics.
+func (m *Metrics) Register(cs ...prometheus.Collector) error {
+	for _, c := range cs {
+		if err := m.registry.Register(c); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// ObserveRequest records an HTTP request, route is the template of the route, not the raw path.
+func (m *Metrics) ObserveRequest(method, route string, status int, d time.Duration) {
+	code := strconv.Itoa(status)
+
+	m.httpRequests.WithLabelValues(method, route, code).Inc()
+	m.httpDuration.WithLabelValues(method, route, code).Observe(d.Seconds())
+}
+
+// ObserveCall records a usecase call, an empty category means it succeeded.
+func (m *Metrics) ObserveCall(usecase string, d time.Duration, category string) {
+	m.usecaseDuration.WithLabelValues(usecase).Observe(d.Seconds())
+
+	if category != "" {
+		m.usecaseErrors.WithLabelValues(usecase, category).Inc()
+	}
+}
+
+// ObserveCache records a lookup of cache.
+func (m *Metrics) ObserveCache(cache string, hit bool) {
+	result := "miss"
+	if hit {
+		result = "hit"
+	}
+
+	m.cacheRequests.WithLabelValues(cache, result).Inc()
+}
+
+// Add adds n to the business counter name, it is exported as {namespace}_{name}_total.
+func (m *Metrics) Add(name string, n int) {
+	m.countersMu.Lock()
+	defer m.countersMu.Unlock()
+
+	counter, ok := m.counters[name]
+	if !ok {
+		counter = prometheus.NewCounter(prometheus.CounterOpts{
+			Namespace: m.namespace,
+			Name:      name + "_total",
+			Help:      "Business counter " + name + ".",
+		})
+
+		m.registry.MustRegister(counter)
+		m.counters[name] = counter
+	}
+
+	counter.Add(float64(n))
+}
+
+// RegisterPgxPool exports the stats of pool.
+func (m *Metrics) RegisterPgxPool(pool *pgxpool.Pool) error {
+	return m.Register(NewPgxPoolCollector(m.namespace, pool))
+}
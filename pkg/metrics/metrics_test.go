@@ -0,0 +1,80 @@
+package metrics_test
+
+import (
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/v1adhope/flights/pkg/metrics"
+)
+
+func scrape(t *testing.T, m *metrics.Metrics) string {
+	w := httptest.NewRecorder()
+
+	m.Handler().ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/metrics", nil))
+
+	assert.Equal(t, http.StatusOK, w.Code)
+
+	body, err := io.ReadAll(w.Body)
+	assert.NoError(t, err)
+
+	return string(body)
+}
+
+func TestMetrics(t *testing.T) {
+	m := metrics.New(
+		metrics.WithNamespace("test"),
+	)
+
+	m.ObserveRequest(http.MethodGet, "/v1/tickets/:id", http.StatusOK, 10*time.Millisecond)
+	m.ObserveCall("CreateTicket", time.Millisecond, "")
+	m.ObserveCall("DeleteTicket", time.Millisecond, "not_found")
+	m.Add("tickets_created", 2)
+	m.Add("tickets_created", 1)
+	m.ObserveCache("ticket_whole_info", true)
+	m.ObserveCache("ticket_whole_info", false)
+	m.ObserveCache("ticket_whole_info", true)
+
+	body := scrape(t, m)
+
+	tcs := []struct {
+		key    string
+		series string
+	}{
+		{
+			key:    "Requests",
+			series: `test_http_requests_total{method="GET",route="/v1/tickets/:id",status="200"} 1`,
+		},
+		{
+			key:    "Request duration",
+			series: `test_http_request_duration_seconds_count{method="GET",route="/v1/tickets/:id",status="200"} 1`,
+		},
+		{
+			key:    "Usecase duration",
+			series: `test_usecase_call_duration_seconds_count{usecase="CreateTicket"} 1`,
+		},
+		{
+			key:    "Usecase errors",
+			series: `test_usecase_errors_total{category="not_found",usecase="DeleteTicket"} 1`,
+		},
+		{
+			key:    "Cache hits",
+			series: `test_cache_requests_total{cache="ticket_whole_info",result="hit"} 2`,
+		},
+		{
+			key:    "Cache misses",
+			series: `test_cache_requests_total{cache="ticket_whole_info",result="miss"} 1`,
+		},
+		{
+			key:    "Business counter",
+			series: `test_tickets_created_total 3`,
+		},
+	}
+
+	for _, tc := range tcs {
+		assert.Contains(t, body, tc.series, tc.key)
+	}
+}
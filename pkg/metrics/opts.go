@@ -0,0 +1,36 @@
+package metrics
+
+import "github.com/prometheus/client_golang/prometheus"
+
+type Option func(*Config)
+
+type Config struct {
+	Namespace string
+	Buckets   []float64
+}
+
+func WithNamespace(namespace string) Option {
+	return func(cfg *Config) {
+		cfg.Namespace = namespace
+	}
+}
+
+// WithBuckets sets the buckets of the duration histograms in seconds.
+func WithBuckets(buckets []float64) Option {
+	return func(cfg *Config) {
+		cfg.Buckets = buckets
+	}
+}
+
+func config(opts ...Option) Config {
+	cfg := Config{
+		Namespace: "flights",
+		Buckets:   prometheus.DefBuckets,
+	}
+
+	for _, opt := range opts {
+		opt(&cfg)
+	}
+
+	return cfg
+}
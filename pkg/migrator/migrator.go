@@ -0,0 +1,492 @@
+// Package migrator applies golang-migrate migrations of a fs.FS to Postgres.
+//
+// On top of golang-migrate it keeps one migrator at a time with an advisory lock,
+// validates checksums of applied migrations and refuses to drop tables that have rows.
+package migrator
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io/fs"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/golang-migrate/migrate/v4"
+	_ "github.com/golang-migrate/migrate/v4/database/postgres"
+	"github.com/golang-migrate/migrate/v4/source/iofs"
+	"github.com/jackc/pgx/v5"
+)
+
+const _checksumsTable = "schema_migrations_checksums"
+
+var (
+	ErrChecksumMismatch = errors.New("applied migration has been modified")
+	ErrDestructive      = errors.New("migration drops tables that have rows")
+	ErrDirty            = errors.New("database is dirty, fix it by hand and force the version")
+)
+
+type Migrator struct {
+	fsys             fs.FS
+	connStr          string
+	allowDestructive bool
+	lockKey          int64
+	migrations       []migration
+}
+
+type migration struct {
+	Version uint
+	Name    string
+	Up      string
+	Down    string
+}
+
+// checksum covers both files, a changed down migration would no longer revert what was applied.
+func (m *migration) checksum() string {
+	h := sha256.New()
+	h.Write([]byte(m.Up))
+	h.Write([]byte{0})
+	h.Write([]byte(m.Down))
+
+	return hex.EncodeToString(h.Sum(nil))
+}
+
+// Status of a migration, Modified is set for applied migrations whose file has changed since.
+type Status struct {
+	Version  uint
+	Name     string
+	Applied  bool
+	Modified bool
+}
+
+// New reads migrations named like 000001_init.up.sql at the root of fsys.
+func New(fsys fs.FS, connStr string, opts ...Option) (*Migrator, error) {
+	cfg := config(opts...)
+
+	migrations, err := readMigrations(fsys)
+	if err != nil {
+		return nil, fmt.Errorf("migrator: migrator: New: %w", err)
+	}
+
+	return &Migrator{
+		fsys:             fsys,
+		connStr:          connStr,
+		allowDestructive: cfg.AllowDestructive,
+		lockKey:          cfg.LockKey,
+		migrations:       migrations,
+	}, nil
+}
+
+// Latest returns the version of the last migration of fsys, the version a migrated database is at.
+func Latest(fsys fs.FS) (uint, error) {
+	migrations, err := readMigrations(fsys)
+	if err != nil {
+		return 0, fmt.Errorf("migrator: migrator: Latest: %w", err)
+	}
+
+	if len(migrations) == 0 {
+		return 0, nil
+	}
+
+	return migrations[len(migrations)-1].Version, nil
+}
+
+var _migrationFileRe = regexp.MustCompile(`^(\d+)_(.+)\.(up|down)\.sql$`)
+
+func readMigrations(fsys fs.FS) ([]migration, error) {
+	entries, err := fs.ReadDir(fsys, ".")
+	if err != nil {
+		return nil, fmt.Errorf("readMigrations: ReadDir: %w", err)
+	}
+
+	byVersion := map[uint]*migration{}
+
+	for _, entry := range entries {
+		match := _migrationFileRe.FindStringSubmatch(entry.Name())
+		if match == nil {
+			continue
+		}
+
+		version, err := strconv.ParseUint(match[1], 10, 64)
+		if err != nil {
+			return nil, fmt.Errorf("readMigrations: ParseUint: %w", err)
+		}
+
+		data, err := fs.ReadFile(fsys, entry.Name())
+		if err != nil {
+			return nil, fmt.Errorf("readMigrations: ReadFile: %w", err)
+		}
+
+		m, ok := byVersion[uint(version)]
+		if !ok {
+			m = &migration{Version: uint(version), Name: match[2]}
+			byVersion[uint(version)] = m
+		}
+
+		if match[3] == "up" {
+			m.Up = string(data)
+		} else {
+			m.Down = string(data)
+		}
+	}
+
+	migrations := make([]migration, 0, len(byVersion))
+
+	for _, m := range byVersion {
+		migrations = append(migrations, *m)
+	}
+
+	sort.Slice(migrations, func(i, j int) bool {
+		return migrations[i].Version < migrations[j].Version
+	})
+
+	return migrations, nil
+}
+
+// session is a locked connection and golang-migrate for the time of one command.
+type session struct {
+	conn *pgx.Conn
+	mg   *migrate.Migrate
+}
+
+func (m *Migrator) withSession(ctx context.Context, fn func(s *session) error) error {
+	conn, err := pgx.Connect(ctx, m.connStr)
+	if err != nil {
+		return fmt.Errorf("Connect: %w", err)
+	}
+	defer conn.Close(context.Background())
+
+	if _, err := conn.Exec(ctx, "select pg_advisory_lock($1)", m.lockKey); err != nil {
+		return fmt.Errorf("pg_advisory_lock: %w", err)
+	}
+	defer conn.Exec(context.Background(), "select pg_advisory_unlock($1)", m.lockKey)
+
+	_, err = conn.Exec(ctx, `create table if not exists `+_checksumsTable+` (
+  version bigint,
+  name varchar(255) not null,
+  checksum varchar(64) not null,
+  applied_at timestamp with time zone not null default now(),
+
+  constraint pk_schema_migrations_checksums_version primary key(version)
+)`)
+	if err != nil {
+		return fmt.Errorf("create %s: %w", _checksumsTable, err)
+	}
+
+	src, err := iofs.New(m.fsys, ".")
+	if err != nil {
+		return fmt.Errorf("iofs: New: %w", err)
+	}
+
+	mg, err := migrate.NewWithSourceInstance("iofs", src, m.connStr)
+	if err != nil {
+		return fmt.Errorf("NewWithSourceInstance: %w", err)
+	}
+	defer mg.Close()
+
+	return fn(&session{conn, mg})
+}
+
+func (s *session) version() (uint, error) {
+	version, dirty, err := s.mg.Version()
+	if errors.Is(err, migrate.ErrNilVersion) {
+		return 0, nil
+	}
+	if err != nil {
+		return 0, fmt.Errorf("Version: %w", err)
+	}
+
+	if dirty {
+		return version, fmt.Errorf("version %d: %w", version, ErrDirty)
+	}
+
+	return version, nil
+}
+
+func (s *session) checksums(ctx context.Context) (map[uint]string, error) {
+	rows, err := s.conn.Query(ctx, "select version, checksum from "+_checksumsTable)
+	if err != nil {
+		return nil, fmt.Errorf("checksums: Query: %w", err)
+	}
+
+	checksums := map[uint]string{}
+	version, checksum := int64(0), ""
+
+	_, err = pgx.ForEachRow(rows, []any{&version, &checksum}, func() error {
+		checksums[uint(version)] = checksum
+		return nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("checksums: ForEachRow: %w", err)
+	}
+
+	return checksums, nil
+}
+
+// record saves checksums of applied migrations, migrations applied before checksums existed are adopted as they are.
+func (s *session) record(ctx context.Context, migrations []migration, version uint) error {
+	for _, m := range migrations {
+		if m.Version > version {
+			break
+		}
+
+		_, err := s.conn.Exec(
+			ctx,
+			"insert into "+_checksumsTable+" (version, name, checksum) values ($1, $2, $3) on conflict (version) do nothing",
+			int64(m.Version), m.Name, m.checksum(),
+		)
+		if err != nil {
+			return fmt.Errorf("record: Exec: %w", err)
+		}
+	}
+
+	return nil
+}
+
+func (m *Migrator) validate(ctx context.Context, s *session, version uint) error {
+	checksums, err := s.checksums(ctx)
+	if err != nil {
+		return err
+	}
+
+	for _, mig := range m.migrations {
+		if mig.Version > version {
+			break
+		}
+
+		if checksum, ok := checksums[mig.Version]; ok && checksum != mig.checksum() {
+			return fmt.Errorf("%d_%s: %w", mig.Version, mig.Name, ErrChecksumMismatch)
+		}
+	}
+
+	return s.record(ctx, m.migrations, version)
+}
+
+// Up applies all pending migrations.
+func (m *Migrator) Up(ctx context.Context) error {
+	err := m.withSession(ctx, func(s *session) error {
+		version, err := s.version()
+		if err != nil {
+			return err
+		}
+
+		if err := m.validate(ctx, s, version); err != nil {
+			return err
+		}
+
+		for _, mig := range m.migrations {
+			if mig.Version <= version {
+				continue
+			}
+
+			if err := m.checkDestructive(ctx, s.conn, mig, mig.Up); err != nil {
+				return err
+			}
+		}
+
+		if err := s.mg.Up(); err != nil && !errors.Is(err, migrate.ErrNoChange) {
+			return fmt.Errorf("Up: %w", err)
+		}
+
+		version, err = s.version()
+		if err != nil {
+			return err
+		}
+
+		return s.record(ctx, m.migrations, version)
+	})
+	if err != nil {
+		return fmt.Errorf("migrator: migrator: Up: %w", err)
+	}
+
+	return nil
+}
+
+// Down reverts the last steps migrations.
+func (m *Migrator) Down(ctx context.Context, steps int) error {
+	err := m.withSession(ctx, func(s *session) error {
+		version, err := s.version()
+		if err != nil {
+			return err
+		}
+
+		if err := m.validate(ctx, s, version); err != nil {
+			return err
+		}
+
+		reverted := 0
+
+		for i := len(m.migrations) - 1; i >= 0 && reverted < steps; i-- {
+			mig := m.migrations[i]
+			if mig.Version > version {
+				continue
+			}
+
+			if err := m.checkDestructive(ctx, s.conn, mig, mig.Down); err != nil {
+				return err
+			}
+
+			reverted++
+		}
+
+		if reverted == 0 {
+			return nil
+		}
+
+		if err := s.mg.Steps(-reverted); err != nil {
+			return fmt.Errorf("Steps: %w", err)
+		}
+
+		version, err = s.version()
+		if err != nil {
+			return err
+		}
+
+		if _, err := s.conn.Exec(ctx, "delete from "+_checksumsTable+" where version > $1", int64(version)); err != nil {
+			return fmt.Errorf("delete %s: %w", _checksumsTable, err)
+		}
+
+		return nil
+	})
+	if err != nil {
+		return fmt.Errorf("migrator: migrator: Down: %w", err)
+	}
+
+	return nil
+}
+
+// Force sets the version without running migrations and clears the dirty flag,
+// the schema must have been brought to that version by hand.
+// Checksums of later versions are forgotten and earlier ones are adopted as they are.
+func (m *Migrator) Force(ctx context.Context, version uint) error {
+	err := m.withSession(ctx, func(s *session) error {
+		if err := s.mg.Force(int(version)); err != nil {
+			return fmt.Errorf("Force: %w", err)
+		}
+
+		if _, err := s.conn.Exec(ctx, "delete from "+_checksumsTable+" where version > $1", int64(version)); err != nil {
+			return fmt.Errorf("delete %s: %w", _checksumsTable, err)
+		}
+
+		return s.record(ctx, m.migrations, version)
+	})
+	if err != nil {
+		return fmt.Errorf("migrator: migrator: Force: %w", err)
+	}
+
+	return nil
+}
+
+// Version returns the version of the last applied migration, 0 if there is none.
+func (m *Migrator) Version(ctx context.Context) (uint, error) {
+	version := uint(0)
+
+	err := m.withSession(ctx, func(s *session) error {
+		var err error
+
+		version, err = s.version()
+
+		return err
+	})
+	if err != nil {
+		return version, fmt.Errorf("migrator: migrator: Version: %w", err)
+	}
+
+	return version, nil
+}
+
+func (m *Migrator) Status(ctx context.Context) ([]Status, error) {
+	statuses := make([]Status, 0, len(m.migrations))
+
+	err := m.withSession(ctx, func(s *session) error {
+		version, err := s.version()
+		if err != nil && !errors.Is(err, ErrDirty) {
+			return err
+		}
+
+		checksums, err := s.checksums(ctx)
+		if err != nil {
+			return err
+		}
+
+		for _, mig := range m.migrations {
+			checksum, ok := checksums[mig.Version]
+
+			statuses = append(statuses, Status{
+				Version:  mig.Version,
+				Name:     mig.Name,
+				Applied:  mig.Version <= version,
+				Modified: ok && checksum != mig.checksum(),
+			})
+		}
+
+		return nil
+	})
+	if err != nil {
+		return []Status{}, fmt.Errorf("migrator: migrator: Status: %w", err)
+	}
+
+	return statuses, nil
+}
+
+var (
+	_dropTableRe    = regexp.MustCompile(`(?is)\bdrop\s+table\s+(?:if\s+exists\s+)?([^;]+?)\s*(?:\b(?:cascade|restrict)\s*)?(?:;|$)`)
+	_sqlCommentLine = regexp.MustCompile(`(?m)--.*$`)
+)
+
+// droppedTables lists the tables dropped by the statements of sql.
+func droppedTables(sql string) []string {
+	tables := []string{}
+
+	for _, match := range _dropTableRe.FindAllStringSubmatch(_sqlCommentLine.ReplaceAllString(sql, ""), -1) {
+		for _, table := range strings.Split(match[1], ",") {
+			if table = strings.TrimSpace(table); table != "" {
+				tables = append(tables, table)
+			}
+		}
+	}
+
+	return tables
+}
+
+// checkDestructive refuses sql that drops tables with rows.
+func (m *Migrator) checkDestructive(ctx context.Context, conn *pgx.Conn, mig migration, sql string) error {
+	if m.allowDestructive {
+		return nil
+	}
+
+	nonEmpty := []string{}
+
+	for _, table := range droppedTables(sql) {
+		exists := false
+
+		if err := conn.QueryRow(ctx, "select to_regclass($1) is not null", table).Scan(&exists); err != nil {
+			return fmt.Errorf("checkDestructive: to_regclass: %w", err)
+		}
+
+		if !exists {
+			continue
+		}
+
+		hasRows := false
+		ident := pgx.Identifier(strings.Split(strings.ReplaceAll(table, `"`, ""), ".")).Sanitize()
+
+		if err := conn.QueryRow(ctx, "select exists (select 1 from "+ident+")").Scan(&hasRows); err != nil {
+			return fmt.Errorf("checkDestructive: exists: %w", err)
+		}
+
+		if hasRows {
+			nonEmpty = append(nonEmpty, table)
+		}
+	}
+
+	if len(nonEmpty) > 0 {
+		return fmt.Errorf("%d_%s: %s: %w", mig.Version, mig.Name, strings.Join(nonEmpty, ", "), ErrDestructive)
+	}
+
+	return nil
+}
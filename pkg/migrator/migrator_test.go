@@ -0,0 +1,95 @@
+package migrator
+
+import (
+	"testing"
+	"testing/fstest"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/v1adhope/flights/db"
+)
+
+func TestDroppedTables(t *testing.T) {
+	tcs := []struct {
+		key    string
+		sql    string
+		tables []string
+	}{
+		{
+			key:    "Create",
+			sql:    "create table if not exists tickets (ticket_id uuid);",
+			tables: []string{},
+		},
+		{
+			key:    "Drop",
+			sql:    "drop table tickets;",
+			tables: []string{"tickets"},
+		},
+		{
+			key:    "Drop if exists cascade",
+			sql:    "DROP TABLE IF EXISTS passengers CASCADE;\ndrop table if exists documents",
+			tables: []string{"passengers", "documents"},
+		},
+		{
+			key:    "Drop several",
+			sql:    "drop table webhook_deliveries, public.webhook_subscriptions;",
+			tables: []string{"webhook_deliveries", "public.webhook_subscriptions"},
+		},
+		{
+			key:    "Drop index",
+			sql:    "drop index if exists idxs_ticket_created_at_arrive_at;",
+			tables: []string{},
+		},
+		{
+			key:    "Comment",
+			sql:    "-- drop table tickets;\nselect 1;",
+			tables: []string{},
+		},
+	}
+
+	for _, tc := range tcs {
+		assert.Equal(t, tc.tables, droppedTables(tc.sql), tc.key)
+	}
+}
+
+func TestReadMigrations(t *testing.T) {
+	migrations, err := readMigrations(fstest.MapFS{
+		"000002_outbox.up.sql":   {Data: []byte("create table outbox ();")},
+		"000002_outbox.down.sql": {Data: []byte("drop table outbox;")},
+		"000001_init.up.sql":     {Data: []byte("create table tickets ();")},
+		"000001_init.down.sql":   {Data: []byte("drop table tickets;")},
+		"readme.md":              {Data: []byte("not a migration")},
+	})
+	assert.NoError(t, err)
+
+	assert.Len(t, migrations, 2)
+	assert.Equal(t, uint(1), migrations[0].Version)
+	assert.Equal(t, "init", migrations[0].Name)
+	assert.Equal(t, "drop table outbox;", migrations[1].Down)
+}
+
+func TestChecksum(t *testing.T) {
+	m := migration{Up: "create table outbox ();", Down: "drop table outbox;"}
+
+	changedDown := m
+	changedDown.Down = "drop table outbox cascade;"
+
+	moved := migration{Up: m.Up + m.Down, Down: ""}
+
+	assert.Equal(t, m.checksum(), m.checksum())
+	assert.NotEqual(t, m.checksum(), changedDown.checksum())
+	assert.NotEqual(t, m.checksum(), moved.checksum())
+}
+
+// TestEmbeddedMigrations keeps upgrades non-destructive, an up migration must not drop tables.
+func TestEmbeddedMigrations(t *testing.T) {
+	migrations, err := readMigrations(db.Migrations)
+	assert.NoError(t, err)
+	assert.NotEmpty(t, migrations)
+
+	for i, m := range migrations {
+		assert.Equal(t, uint(i+1), m.Version, "versions must be sequential")
+		assert.NotEmpty(t, m.Up, m.Name)
+		assert.NotEmpty(t, m.Down, m.Name)
+		assert.Empty(t, droppedTables(m.Up), "%d_%s drops tables", m.Version, m.Name)
+	}
+}
@@ -0,0 +1,35 @@
+package migrator
+
+type Option func(*Config)
+
+type Config struct {
+	AllowDestructive bool
+	LockKey          int64
+}
+
+// WithAllowDestructive lets migrations drop tables that have rows.
+func WithAllowDestructive(allow bool) Option {
+	return func(cfg *Config) {
+		cfg.AllowDestructive = allow
+	}
+}
+
+// WithLockKey sets the key of the advisory lock that keeps concurrent migrators out.
+func WithLockKey(key int64) Option {
+	return func(cfg *Config) {
+		cfg.LockKey = key
+	}
+}
+
+func config(opts ...Option) Config {
+	cfg := Config{
+		AllowDestructive: false,
+		LockKey:          7_357_001,
+	}
+
+	for _, opt := range opts {
+		opt(&cfg)
+	}
+
+	return cfg
+}
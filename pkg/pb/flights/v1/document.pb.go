@@ -0,0 +1,568 @@
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// versions:
+// 	protoc-gen-go v1.36.6
+// 	protoc        v5.29.3
+// source: flights/v1/document.proto
+
+package flightsv1
+
+import (
+	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
+	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	reflect "reflect"
+	sync "sync"
+	unsafe "unsafe"
+)
+
+const (
+	// Verify that this generated code is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(20 - protoimpl.MinVersion)
+	// Verify that runtime/protoimpl is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
+)
+
+// Type is one of Passport, Id card, International passport.
+type Document struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	Type          string                 `protobuf:"bytes,2,opt,name=type,proto3" json:"type,omitempty"`
+	Number        string                 `protobuf:"bytes,3,opt,name=number,proto3" json:"number,omitempty"`
+	PassengerId   string                 `protobuf:"bytes,4,opt,name=passenger_id,json=passengerId,proto3" json:"passenger_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Document) Reset() {
+	*x = Document{}
+	mi := &file_flights_v1_document_proto_msgTypes[0]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Document) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Document) ProtoMessage() {}
+
+func (x *Document) ProtoReflect() protoreflect.Message {
+	mi := &file_flights_v1_document_proto_msgTypes[0]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Document.ProtoReflect.Descriptor instead.
+func (*Document) Descriptor() ([]byte, []int) {
+	return file_flights_v1_document_proto_rawDescGZIP(), []int{0}
+}
+
+func (x *Document) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *Document) GetType() string {
+	if x != nil {
+		return x.Type
+	}
+	return ""
+}
+
+func (x *Document) GetNumber() string {
+	if x != nil {
+		return x.Number
+	}
+	return ""
+}
+
+func (x *Document) GetPassengerId() string {
+	if x != nil {
+		return x.PassengerId
+	}
+	return ""
+}
+
+type CreateDocumentRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Type          string                 `protobuf:"bytes,1,opt,name=type,proto3" json:"type,omitempty"`
+	Number        string                 `protobuf:"bytes,2,opt,name=number,proto3" json:"number,omitempty"`
+	PassengerId   string                 `protobuf:"bytes,3,opt,name=passenger_id,json=passengerId,proto3" json:"passenger_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CreateDocumentRequest) Reset() {
+	*x = CreateDocumentRequest{}
+	mi := &file_flights_v1_document_proto_msgTypes[1]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CreateDocumentRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CreateDocumentRequest) ProtoMessage() {}
+
+func (x *CreateDocumentRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_flights_v1_document_proto_msgTypes[1]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CreateDocumentRequest.ProtoReflect.Descriptor instead.
+func (*CreateDocumentRequest) Descriptor() ([]byte, []int) {
+	return file_flights_v1_document_proto_rawDescGZIP(), []int{1}
+}
+
+func (x *CreateDocumentRequest) GetType() string {
+	if x != nil {
+		return x.Type
+	}
+	return ""
+}
+
+func (x *CreateDocumentRequest) GetNumber() string {
+	if x != nil {
+		return x.Number
+	}
+	return ""
+}
+
+func (x *CreateDocumentRequest) GetPassengerId() string {
+	if x != nil {
+		return x.PassengerId
+	}
+	return ""
+}
+
+type CreateDocumentResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CreateDocumentResponse) Reset() {
+	*x = CreateDocumentResponse{}
+	mi := &file_flights_v1_document_proto_msgTypes[2]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CreateDocumentResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CreateDocumentResponse) ProtoMessage() {}
+
+func (x *CreateDocumentResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_flights_v1_document_proto_msgTypes[2]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CreateDocumentResponse.ProtoReflect.Descriptor instead.
+func (*CreateDocumentResponse) Descriptor() ([]byte, []int) {
+	return file_flights_v1_document_proto_rawDescGZIP(), []int{2}
+}
+
+func (x *CreateDocumentResponse) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+type ReplaceDocumentRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	Type          string                 `protobuf:"bytes,2,opt,name=type,proto3" json:"type,omitempty"`
+	Number        string                 `protobuf:"bytes,3,opt,name=number,proto3" json:"number,omitempty"`
+	PassengerId   string                 `protobuf:"bytes,4,opt,name=passenger_id,json=passengerId,proto3" json:"passenger_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ReplaceDocumentRequest) Reset() {
+	*x = ReplaceDocumentRequest{}
+	mi := &file_flights_v1_document_proto_msgTypes[3]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ReplaceDocumentRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ReplaceDocumentRequest) ProtoMessage() {}
+
+func (x *ReplaceDocumentRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_flights_v1_document_proto_msgTypes[3]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ReplaceDocumentRequest.ProtoReflect.Descriptor instead.
+func (*ReplaceDocumentRequest) Descriptor() ([]byte, []int) {
+	return file_flights_v1_document_proto_rawDescGZIP(), []int{3}
+}
+
+func (x *ReplaceDocumentRequest) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *ReplaceDocumentRequest) GetType() string {
+	if x != nil {
+		return x.Type
+	}
+	return ""
+}
+
+func (x *ReplaceDocumentRequest) GetNumber() string {
+	if x != nil {
+		return x.Number
+	}
+	return ""
+}
+
+func (x *ReplaceDocumentRequest) GetPassengerId() string {
+	if x != nil {
+		return x.PassengerId
+	}
+	return ""
+}
+
+type ReplaceDocumentResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ReplaceDocumentResponse) Reset() {
+	*x = ReplaceDocumentResponse{}
+	mi := &file_flights_v1_document_proto_msgTypes[4]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ReplaceDocumentResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ReplaceDocumentResponse) ProtoMessage() {}
+
+func (x *ReplaceDocumentResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_flights_v1_document_proto_msgTypes[4]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ReplaceDocumentResponse.ProtoReflect.Descriptor instead.
+func (*ReplaceDocumentResponse) Descriptor() ([]byte, []int) {
+	return file_flights_v1_document_proto_rawDescGZIP(), []int{4}
+}
+
+type DeleteDocumentRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *DeleteDocumentRequest) Reset() {
+	*x = DeleteDocumentRequest{}
+	mi := &file_flights_v1_document_proto_msgTypes[5]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DeleteDocumentRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DeleteDocumentRequest) ProtoMessage() {}
+
+func (x *DeleteDocumentRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_flights_v1_document_proto_msgTypes[5]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DeleteDocumentRequest.ProtoReflect.Descriptor instead.
+func (*DeleteDocumentRequest) Descriptor() ([]byte, []int) {
+	return file_flights_v1_document_proto_rawDescGZIP(), []int{5}
+}
+
+func (x *DeleteDocumentRequest) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+type DeleteDocumentResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *DeleteDocumentResponse) Reset() {
+	*x = DeleteDocumentResponse{}
+	mi := &file_flights_v1_document_proto_msgTypes[6]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DeleteDocumentResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DeleteDocumentResponse) ProtoMessage() {}
+
+func (x *DeleteDocumentResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_flights_v1_document_proto_msgTypes[6]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DeleteDocumentResponse.ProtoReflect.Descriptor instead.
+func (*DeleteDocumentResponse) Descriptor() ([]byte, []int) {
+	return file_flights_v1_document_proto_rawDescGZIP(), []int{6}
+}
+
+type ListDocumentsByPassengerRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	PassengerId   string                 `protobuf:"bytes,1,opt,name=passenger_id,json=passengerId,proto3" json:"passenger_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListDocumentsByPassengerRequest) Reset() {
+	*x = ListDocumentsByPassengerRequest{}
+	mi := &file_flights_v1_document_proto_msgTypes[7]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListDocumentsByPassengerRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListDocumentsByPassengerRequest) ProtoMessage() {}
+
+func (x *ListDocumentsByPassengerRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_flights_v1_document_proto_msgTypes[7]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListDocumentsByPassengerRequest.ProtoReflect.Descriptor instead.
+func (*ListDocumentsByPassengerRequest) Descriptor() ([]byte, []int) {
+	return file_flights_v1_document_proto_rawDescGZIP(), []int{7}
+}
+
+func (x *ListDocumentsByPassengerRequest) GetPassengerId() string {
+	if x != nil {
+		return x.PassengerId
+	}
+	return ""
+}
+
+type ListDocumentsByPassengerResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Documents     []*Document            `protobuf:"bytes,1,rep,name=documents,proto3" json:"documents,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListDocumentsByPassengerResponse) Reset() {
+	*x = ListDocumentsByPassengerResponse{}
+	mi := &file_flights_v1_document_proto_msgTypes[8]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListDocumentsByPassengerResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListDocumentsByPassengerResponse) ProtoMessage() {}
+
+func (x *ListDocumentsByPassengerResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_flights_v1_document_proto_msgTypes[8]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListDocumentsByPassengerResponse.ProtoReflect.Descriptor instead.
+func (*ListDocumentsByPassengerResponse) Descriptor() ([]byte, []int) {
+	return file_flights_v1_document_proto_rawDescGZIP(), []int{8}
+}
+
+func (x *ListDocumentsByPassengerResponse) GetDocuments() []*Document {
+	if x != nil {
+		return x.Documents
+	}
+	return nil
+}
+
+var File_flights_v1_document_proto protoreflect.FileDescriptor
+
+const file_flights_v1_document_proto_rawDesc = "" +
+	"\n" +
+	"\x19flights/v1/document.proto\x12\n" +
+	"flights.v1\"i\n" +
+	"\bDocument\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\x12\x12\n" +
+	"\x04type\x18\x02 \x01(\tR\x04type\x12\x16\n" +
+	"\x06number\x18\x03 \x01(\tR\x06number\x12!\n" +
+	"\fpassenger_id\x18\x04 \x01(\tR\vpassengerId\"f\n" +
+	"\x15CreateDocumentRequest\x12\x12\n" +
+	"\x04type\x18\x01 \x01(\tR\x04type\x12\x16\n" +
+	"\x06number\x18\x02 \x01(\tR\x06number\x12!\n" +
+	"\fpassenger_id\x18\x03 \x01(\tR\vpassengerId\"(\n" +
+	"\x16CreateDocumentResponse\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\"w\n" +
+	"\x16ReplaceDocumentRequest\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\x12\x12\n" +
+	"\x04type\x18\x02 \x01(\tR\x04type\x12\x16\n" +
+	"\x06number\x18\x03 \x01(\tR\x06number\x12!\n" +
+	"\fpassenger_id\x18\x04 \x01(\tR\vpassengerId\"\x19\n" +
+	"\x17ReplaceDocumentResponse\"'\n" +
+	"\x15DeleteDocumentRequest\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\"\x18\n" +
+	"\x16DeleteDocumentResponse\"D\n" +
+	"\x1fListDocumentsByPassengerRequest\x12!\n" +
+	"\fpassenger_id\x18\x01 \x01(\tR\vpassengerId\"V\n" +
+	" ListDocumentsByPassengerResponse\x122\n" +
+	"\tdocuments\x18\x01 \x03(\v2\x14.flights.v1.DocumentR\tdocuments2\x96\x03\n" +
+	"\x0fDocumentService\x12W\n" +
+	"\x0eCreateDocument\x12!.flights.v1.CreateDocumentRequest\x1a\".flights.v1.CreateDocumentResponse\x12Z\n" +
+	"\x0fReplaceDocument\x12\".flights.v1.ReplaceDocumentRequest\x1a#.flights.v1.ReplaceDocumentResponse\x12W\n" +
+	"\x0eDeleteDocument\x12!.flights.v1.DeleteDocumentRequest\x1a\".flights.v1.DeleteDocumentResponse\x12u\n" +
+	"\x18ListDocumentsByPassenger\x12+.flights.v1.ListDocumentsByPassengerRequest\x1a,.flights.v1.ListDocumentsByPassengerResponseB9Z7github.com/v1adhope/flights/pkg/pb/flights/v1;flightsv1b\x06proto3"
+
+var (
+	file_flights_v1_document_proto_rawDescOnce sync.Once
+	file_flights_v1_document_proto_rawDescData []byte
+)
+
+func file_flights_v1_document_proto_rawDescGZIP() []byte {
+	file_flights_v1_document_proto_rawDescOnce.Do(func() {
+		file_flights_v1_document_proto_rawDescData = protoimpl.X.CompressGZIP(unsafe.Slice(unsafe.StringData(file_flights_v1_document_proto_rawDesc), len(file_flights_v1_document_proto_rawDesc)))
+	})
+	return file_flights_v1_document_proto_rawDescData
+}
+
+var file_flights_v1_document_proto_msgTypes = make([]protoimpl.MessageInfo, 9)
+var file_flights_v1_document_proto_goTypes = []any{
+	(*Document)(nil),                         // 0: flights.v1.Document
+	(*CreateDocumentRequest)(nil),            // 1: flights.v1.CreateDocumentRequest
+	(*CreateDocumentResponse)(nil),           // 2: flights.v1.CreateDocumentResponse
+	(*ReplaceDocumentRequest)(nil),           // 3: flights.v1.ReplaceDocumentRequest
+	(*ReplaceDocumentResponse)(nil),          // 4: flights.v1.ReplaceDocumentResponse
+	(*DeleteDocumentRequest)(nil),            // 5: flights.v1.DeleteDocumentRequest
+	(*DeleteDocumentResponse)(nil),           // 6: flights.v1.DeleteDocumentResponse
+	(*ListDocumentsByPassengerRequest)(nil),  // 7: flights.v1.ListDocumentsByPassengerRequest
+	(*ListDocumentsByPassengerResponse)(nil), // 8: flights.v1.ListDocumentsByPassengerResponse
+}
+var file_flights_v1_document_proto_depIdxs = []int32{
+	0, // 0: flights.v1.ListDocumentsByPassengerResponse.documents:type_name -> flights.v1.Document
+	1, // 1: flights.v1.DocumentService.CreateDocument:input_type -> flights.v1.CreateDocumentRequest
+	3, // 2: flights.v1.DocumentService.ReplaceDocument:input_type -> flights.v1.ReplaceDocumentRequest
+	5, // 3: flights.v1.DocumentService.DeleteDocument:input_type -> flights.v1.DeleteDocumentRequest
+	7, // 4: flights.v1.DocumentService.ListDocumentsByPassenger:input_type -> flights.v1.ListDocumentsByPassengerRequest
+	2, // 5: flights.v1.DocumentService.CreateDocument:output_type -> flights.v1.CreateDocumentResponse
+	4, // 6: flights.v1.DocumentService.ReplaceDocument:output_type -> flights.v1.ReplaceDocumentResponse
+	6, // 7: flights.v1.DocumentService.DeleteDocument:output_type -> flights.v1.DeleteDocumentResponse
+	8, // 8: flights.v1.DocumentService.ListDocumentsByPassenger:output_type -> flights.v1.ListDocumentsByPassengerResponse
+	5, // [5:9] is the sub-list for method output_type
+	1, // [1:5] is the sub-list for method input_type
+	1, // [1:1] is the sub-list for extension type_name
+	1, // [1:1] is the sub-list for extension extendee
+	0, // [0:1] is the sub-list for field type_name
+}
+
+func init() { file_flights_v1_document_proto_init() }
+func file_flights_v1_document_proto_init() {
+	if File_flights_v1_document_proto != nil {
+		return
+	}
+	type x struct{}
+	out := protoimpl.TypeBuilder{
+		File: protoimpl.DescBuilder{
+			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
+			RawDescriptor: unsafe.Slice(unsafe.StringData(file_flights_v1_document_proto_rawDesc), len(file_flights_v1_document_proto_rawDesc)),
+			NumEnums:      0,
+			NumMessages:   9,
+			NumExtensions: 0,
+			NumServices:   1,
+		},
+		GoTypes:           file_flights_v1_document_proto_goTypes,
+		DependencyIndexes: file_flights_v1_document_proto_depIdxs,
+		MessageInfos:      file_flights_v1_document_proto_msgTypes,
+	}.Build()
+	File_flights_v1_document_proto = out.File
+	file_flights_v1_document_proto_goTypes = nil
+	file_flights_v1_document_proto_depIdxs = nil
+}
@@ -0,0 +1,920 @@
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// versions:
+// 	protoc-gen-go v1.36.6
+// 	protoc        v5.29.3
+// source: flights/v1/passenger.proto
+
+package flightsv1
+
+import (
+	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
+	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	reflect "reflect"
+	sync "sync"
+	unsafe "unsafe"
+)
+
+const (
+	// Verify that this generated code is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(20 - protoimpl.MinVersion)
+	// Verify that runtime/protoimpl is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
+)
+
+type Passenger struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	FirstName     string                 `protobuf:"bytes,2,opt,name=first_name,json=firstName,proto3" json:"first_name,omitempty"`
+	LastName      string                 `protobuf:"bytes,3,opt,name=last_name,json=lastName,proto3" json:"last_name,omitempty"`
+	MiddleName    string                 `protobuf:"bytes,4,opt,name=middle_name,json=middleName,proto3" json:"middle_name,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Passenger) Reset() {
+	*x = Passenger{}
+	mi := &file_flights_v1_passenger_proto_msgTypes[0]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Passenger) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Passenger) ProtoMessage() {}
+
+func (x *Passenger) ProtoReflect() protoreflect.Message {
+	mi := &file_flights_v1_passenger_proto_msgTypes[0]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Passenger.ProtoReflect.Descriptor instead.
+func (*Passenger) Descriptor() ([]byte, []int) {
+	return file_flights_v1_passenger_proto_rawDescGZIP(), []int{0}
+}
+
+func (x *Passenger) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *Passenger) GetFirstName() string {
+	if x != nil {
+		return x.FirstName
+	}
+	return ""
+}
+
+func (x *Passenger) GetLastName() string {
+	if x != nil {
+		return x.LastName
+	}
+	return ""
+}
+
+func (x *Passenger) GetMiddleName() string {
+	if x != nil {
+		return x.MiddleName
+	}
+	return ""
+}
+
+type PassengerWholeInfo struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Passenger     *Passenger             `protobuf:"bytes,1,opt,name=passenger,proto3" json:"passenger,omitempty"`
+	Documents     []*Document            `protobuf:"bytes,2,rep,name=documents,proto3" json:"documents,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *PassengerWholeInfo) Reset() {
+	*x = PassengerWholeInfo{}
+	mi := &file_flights_v1_passenger_proto_msgTypes[1]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *PassengerWholeInfo) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*PassengerWholeInfo) ProtoMessage() {}
+
+func (x *PassengerWholeInfo) ProtoReflect() protoreflect.Message {
+	mi := &file_flights_v1_passenger_proto_msgTypes[1]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use PassengerWholeInfo.ProtoReflect.Descriptor instead.
+func (*PassengerWholeInfo) Descriptor() ([]byte, []int) {
+	return file_flights_v1_passenger_proto_rawDescGZIP(), []int{1}
+}
+
+func (x *PassengerWholeInfo) GetPassenger() *Passenger {
+	if x != nil {
+		return x.Passenger
+	}
+	return nil
+}
+
+func (x *PassengerWholeInfo) GetDocuments() []*Document {
+	if x != nil {
+		return x.Documents
+	}
+	return nil
+}
+
+type CreatePassengerRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	FirstName     string                 `protobuf:"bytes,1,opt,name=first_name,json=firstName,proto3" json:"first_name,omitempty"`
+	LastName      string                 `protobuf:"bytes,2,opt,name=last_name,json=lastName,proto3" json:"last_name,omitempty"`
+	MiddleName    string                 `protobuf:"bytes,3,opt,name=middle_name,json=middleName,proto3" json:"middle_name,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CreatePassengerRequest) Reset() {
+	*x = CreatePassengerRequest{}
+	mi := &file_flights_v1_passenger_proto_msgTypes[2]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CreatePassengerRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CreatePassengerRequest) ProtoMessage() {}
+
+func (x *CreatePassengerRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_flights_v1_passenger_proto_msgTypes[2]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CreatePassengerRequest.ProtoReflect.Descriptor instead.
+func (*CreatePassengerRequest) Descriptor() ([]byte, []int) {
+	return file_flights_v1_passenger_proto_rawDescGZIP(), []int{2}
+}
+
+func (x *CreatePassengerRequest) GetFirstName() string {
+	if x != nil {
+		return x.FirstName
+	}
+	return ""
+}
+
+func (x *CreatePassengerRequest) GetLastName() string {
+	if x != nil {
+		return x.LastName
+	}
+	return ""
+}
+
+func (x *CreatePassengerRequest) GetMiddleName() string {
+	if x != nil {
+		return x.MiddleName
+	}
+	return ""
+}
+
+type CreatePassengerResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CreatePassengerResponse) Reset() {
+	*x = CreatePassengerResponse{}
+	mi := &file_flights_v1_passenger_proto_msgTypes[3]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CreatePassengerResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CreatePassengerResponse) ProtoMessage() {}
+
+func (x *CreatePassengerResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_flights_v1_passenger_proto_msgTypes[3]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CreatePassengerResponse.ProtoReflect.Descriptor instead.
+func (*CreatePassengerResponse) Descriptor() ([]byte, []int) {
+	return file_flights_v1_passenger_proto_rawDescGZIP(), []int{3}
+}
+
+func (x *CreatePassengerResponse) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+type ReplacePassengerRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	FirstName     string                 `protobuf:"bytes,2,opt,name=first_name,json=firstName,proto3" json:"first_name,omitempty"`
+	LastName      string                 `protobuf:"bytes,3,opt,name=last_name,json=lastName,proto3" json:"last_name,omitempty"`
+	MiddleName    string                 `protobuf:"bytes,4,opt,name=middle_name,json=middleName,proto3" json:"middle_name,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ReplacePassengerRequest) Reset() {
+	*x = ReplacePassengerRequest{}
+	mi := &file_flights_v1_passenger_proto_msgTypes[4]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ReplacePassengerRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ReplacePassengerRequest) ProtoMessage() {}
+
+func (x *ReplacePassengerRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_flights_v1_passenger_proto_msgTypes[4]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ReplacePassengerRequest.ProtoReflect.Descriptor instead.
+func (*ReplacePassengerRequest) Descriptor() ([]byte, []int) {
+	return file_flights_v1_passenger_proto_rawDescGZIP(), []int{4}
+}
+
+func (x *ReplacePassengerRequest) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *ReplacePassengerRequest) GetFirstName() string {
+	if x != nil {
+		return x.FirstName
+	}
+	return ""
+}
+
+func (x *ReplacePassengerRequest) GetLastName() string {
+	if x != nil {
+		return x.LastName
+	}
+	return ""
+}
+
+func (x *ReplacePassengerRequest) GetMiddleName() string {
+	if x != nil {
+		return x.MiddleName
+	}
+	return ""
+}
+
+type ReplacePassengerResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ReplacePassengerResponse) Reset() {
+	*x = ReplacePassengerResponse{}
+	mi := &file_flights_v1_passenger_proto_msgTypes[5]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ReplacePassengerResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ReplacePassengerResponse) ProtoMessage() {}
+
+func (x *ReplacePassengerResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_flights_v1_passenger_proto_msgTypes[5]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ReplacePassengerResponse.ProtoReflect.Descriptor instead.
+func (*ReplacePassengerResponse) Descriptor() ([]byte, []int) {
+	return file_flights_v1_passenger_proto_rawDescGZIP(), []int{5}
+}
+
+type DeletePassengerRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *DeletePassengerRequest) Reset() {
+	*x = DeletePassengerRequest{}
+	mi := &file_flights_v1_passenger_proto_msgTypes[6]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DeletePassengerRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DeletePassengerRequest) ProtoMessage() {}
+
+func (x *DeletePassengerRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_flights_v1_passenger_proto_msgTypes[6]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DeletePassengerRequest.ProtoReflect.Descriptor instead.
+func (*DeletePassengerRequest) Descriptor() ([]byte, []int) {
+	return file_flights_v1_passenger_proto_rawDescGZIP(), []int{6}
+}
+
+func (x *DeletePassengerRequest) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+type DeletePassengerResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *DeletePassengerResponse) Reset() {
+	*x = DeletePassengerResponse{}
+	mi := &file_flights_v1_passenger_proto_msgTypes[7]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DeletePassengerResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DeletePassengerResponse) ProtoMessage() {}
+
+func (x *DeletePassengerResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_flights_v1_passenger_proto_msgTypes[7]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DeletePassengerResponse.ProtoReflect.Descriptor instead.
+func (*DeletePassengerResponse) Descriptor() ([]byte, []int) {
+	return file_flights_v1_passenger_proto_rawDescGZIP(), []int{7}
+}
+
+type BindToTicketRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	TicketId      string                 `protobuf:"bytes,2,opt,name=ticket_id,json=ticketId,proto3" json:"ticket_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *BindToTicketRequest) Reset() {
+	*x = BindToTicketRequest{}
+	mi := &file_flights_v1_passenger_proto_msgTypes[8]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *BindToTicketRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*BindToTicketRequest) ProtoMessage() {}
+
+func (x *BindToTicketRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_flights_v1_passenger_proto_msgTypes[8]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use BindToTicketRequest.ProtoReflect.Descriptor instead.
+func (*BindToTicketRequest) Descriptor() ([]byte, []int) {
+	return file_flights_v1_passenger_proto_rawDescGZIP(), []int{8}
+}
+
+func (x *BindToTicketRequest) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *BindToTicketRequest) GetTicketId() string {
+	if x != nil {
+		return x.TicketId
+	}
+	return ""
+}
+
+type BindToTicketResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *BindToTicketResponse) Reset() {
+	*x = BindToTicketResponse{}
+	mi := &file_flights_v1_passenger_proto_msgTypes[9]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *BindToTicketResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*BindToTicketResponse) ProtoMessage() {}
+
+func (x *BindToTicketResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_flights_v1_passenger_proto_msgTypes[9]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use BindToTicketResponse.ProtoReflect.Descriptor instead.
+func (*BindToTicketResponse) Descriptor() ([]byte, []int) {
+	return file_flights_v1_passenger_proto_rawDescGZIP(), []int{9}
+}
+
+type UnbindFromTicketRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	TicketId      string                 `protobuf:"bytes,2,opt,name=ticket_id,json=ticketId,proto3" json:"ticket_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *UnbindFromTicketRequest) Reset() {
+	*x = UnbindFromTicketRequest{}
+	mi := &file_flights_v1_passenger_proto_msgTypes[10]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *UnbindFromTicketRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*UnbindFromTicketRequest) ProtoMessage() {}
+
+func (x *UnbindFromTicketRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_flights_v1_passenger_proto_msgTypes[10]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use UnbindFromTicketRequest.ProtoReflect.Descriptor instead.
+func (*UnbindFromTicketRequest) Descriptor() ([]byte, []int) {
+	return file_flights_v1_passenger_proto_rawDescGZIP(), []int{10}
+}
+
+func (x *UnbindFromTicketRequest) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *UnbindFromTicketRequest) GetTicketId() string {
+	if x != nil {
+		return x.TicketId
+	}
+	return ""
+}
+
+type UnbindFromTicketResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *UnbindFromTicketResponse) Reset() {
+	*x = UnbindFromTicketResponse{}
+	mi := &file_flights_v1_passenger_proto_msgTypes[11]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *UnbindFromTicketResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*UnbindFromTicketResponse) ProtoMessage() {}
+
+func (x *UnbindFromTicketResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_flights_v1_passenger_proto_msgTypes[11]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use UnbindFromTicketResponse.ProtoReflect.Descriptor instead.
+func (*UnbindFromTicketResponse) Descriptor() ([]byte, []int) {
+	return file_flights_v1_passenger_proto_rawDescGZIP(), []int{11}
+}
+
+type ListPassengersRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListPassengersRequest) Reset() {
+	*x = ListPassengersRequest{}
+	mi := &file_flights_v1_passenger_proto_msgTypes[12]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListPassengersRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListPassengersRequest) ProtoMessage() {}
+
+func (x *ListPassengersRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_flights_v1_passenger_proto_msgTypes[12]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListPassengersRequest.ProtoReflect.Descriptor instead.
+func (*ListPassengersRequest) Descriptor() ([]byte, []int) {
+	return file_flights_v1_passenger_proto_rawDescGZIP(), []int{12}
+}
+
+type ListPassengersResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Passengers    []*Passenger           `protobuf:"bytes,1,rep,name=passengers,proto3" json:"passengers,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListPassengersResponse) Reset() {
+	*x = ListPassengersResponse{}
+	mi := &file_flights_v1_passenger_proto_msgTypes[13]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListPassengersResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListPassengersResponse) ProtoMessage() {}
+
+func (x *ListPassengersResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_flights_v1_passenger_proto_msgTypes[13]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListPassengersResponse.ProtoReflect.Descriptor instead.
+func (*ListPassengersResponse) Descriptor() ([]byte, []int) {
+	return file_flights_v1_passenger_proto_rawDescGZIP(), []int{13}
+}
+
+func (x *ListPassengersResponse) GetPassengers() []*Passenger {
+	if x != nil {
+		return x.Passengers
+	}
+	return nil
+}
+
+type ListPassengersByTicketRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	TicketId      string                 `protobuf:"bytes,1,opt,name=ticket_id,json=ticketId,proto3" json:"ticket_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListPassengersByTicketRequest) Reset() {
+	*x = ListPassengersByTicketRequest{}
+	mi := &file_flights_v1_passenger_proto_msgTypes[14]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListPassengersByTicketRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListPassengersByTicketRequest) ProtoMessage() {}
+
+func (x *ListPassengersByTicketRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_flights_v1_passenger_proto_msgTypes[14]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListPassengersByTicketRequest.ProtoReflect.Descriptor instead.
+func (*ListPassengersByTicketRequest) Descriptor() ([]byte, []int) {
+	return file_flights_v1_passenger_proto_rawDescGZIP(), []int{14}
+}
+
+func (x *ListPassengersByTicketRequest) GetTicketId() string {
+	if x != nil {
+		return x.TicketId
+	}
+	return ""
+}
+
+type ListPassengersByTicketResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Passengers    []*Passenger           `protobuf:"bytes,1,rep,name=passengers,proto3" json:"passengers,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListPassengersByTicketResponse) Reset() {
+	*x = ListPassengersByTicketResponse{}
+	mi := &file_flights_v1_passenger_proto_msgTypes[15]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListPassengersByTicketResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListPassengersByTicketResponse) ProtoMessage() {}
+
+func (x *ListPassengersByTicketResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_flights_v1_passenger_proto_msgTypes[15]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListPassengersByTicketResponse.ProtoReflect.Descriptor instead.
+func (*ListPassengersByTicketResponse) Descriptor() ([]byte, []int) {
+	return file_flights_v1_passenger_proto_rawDescGZIP(), []int{15}
+}
+
+func (x *ListPassengersByTicketResponse) GetPassengers() []*Passenger {
+	if x != nil {
+		return x.Passengers
+	}
+	return nil
+}
+
+var File_flights_v1_passenger_proto protoreflect.FileDescriptor
+
+const file_flights_v1_passenger_proto_rawDesc = "" +
+	"\n" +
+	"\x1aflights/v1/passenger.proto\x12\n" +
+	"flights.v1\x1a\x19flights/v1/document.proto\"x\n" +
+	"\tPassenger\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\x12\x1d\n" +
+	"\n" +
+	"first_name\x18\x02 \x01(\tR\tfirstName\x12\x1b\n" +
+	"\tlast_name\x18\x03 \x01(\tR\blastName\x12\x1f\n" +
+	"\vmiddle_name\x18\x04 \x01(\tR\n" +
+	"middleName\"}\n" +
+	"\x12PassengerWholeInfo\x123\n" +
+	"\tpassenger\x18\x01 \x01(\v2\x15.flights.v1.PassengerR\tpassenger\x122\n" +
+	"\tdocuments\x18\x02 \x03(\v2\x14.flights.v1.DocumentR\tdocuments\"u\n" +
+	"\x16CreatePassengerRequest\x12\x1d\n" +
+	"\n" +
+	"first_name\x18\x01 \x01(\tR\tfirstName\x12\x1b\n" +
+	"\tlast_name\x18\x02 \x01(\tR\blastName\x12\x1f\n" +
+	"\vmiddle_name\x18\x03 \x01(\tR\n" +
+	"middleName\")\n" +
+	"\x17CreatePassengerResponse\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\"\x86\x01\n" +
+	"\x17ReplacePassengerRequest\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\x12\x1d\n" +
+	"\n" +
+	"first_name\x18\x02 \x01(\tR\tfirstName\x12\x1b\n" +
+	"\tlast_name\x18\x03 \x01(\tR\blastName\x12\x1f\n" +
+	"\vmiddle_name\x18\x04 \x01(\tR\n" +
+	"middleName\"\x1a\n" +
+	"\x18ReplacePassengerResponse\"(\n" +
+	"\x16DeletePassengerRequest\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\"\x19\n" +
+	"\x17DeletePassengerResponse\"B\n" +
+	"\x13BindToTicketRequest\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\x12\x1b\n" +
+	"\tticket_id\x18\x02 \x01(\tR\bticketId\"\x16\n" +
+	"\x14BindToTicketResponse\"F\n" +
+	"\x17UnbindFromTicketRequest\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\x12\x1b\n" +
+	"\tticket_id\x18\x02 \x01(\tR\bticketId\"\x1a\n" +
+	"\x18UnbindFromTicketResponse\"\x17\n" +
+	"\x15ListPassengersRequest\"O\n" +
+	"\x16ListPassengersResponse\x125\n" +
+	"\n" +
+	"passengers\x18\x01 \x03(\v2\x15.flights.v1.PassengerR\n" +
+	"passengers\"<\n" +
+	"\x1dListPassengersByTicketRequest\x12\x1b\n" +
+	"\tticket_id\x18\x01 \x01(\tR\bticketId\"W\n" +
+	"\x1eListPassengersByTicketResponse\x125\n" +
+	"\n" +
+	"passengers\x18\x01 \x03(\v2\x15.flights.v1.PassengerR\n" +
+	"passengers2\xa5\x05\n" +
+	"\x10PassengerService\x12Z\n" +
+	"\x0fCreatePassenger\x12\".flights.v1.CreatePassengerRequest\x1a#.flights.v1.CreatePassengerResponse\x12]\n" +
+	"\x10ReplacePassenger\x12#.flights.v1.ReplacePassengerRequest\x1a$.flights.v1.ReplacePassengerResponse\x12Z\n" +
+	"\x0fDeletePassenger\x12\".flights.v1.DeletePassengerRequest\x1a#.flights.v1.DeletePassengerResponse\x12Q\n" +
+	"\fBindToTicket\x12\x1f.flights.v1.BindToTicketRequest\x1a .flights.v1.BindToTicketResponse\x12]\n" +
+	"\x10UnbindFromTicket\x12#.flights.v1.UnbindFromTicketRequest\x1a$.flights.v1.UnbindFromTicketResponse\x12W\n" +
+	"\x0eListPassengers\x12!.flights.v1.ListPassengersRequest\x1a\".flights.v1.ListPassengersResponse\x12o\n" +
+	"\x16ListPassengersByTicket\x12).flights.v1.ListPassengersByTicketRequest\x1a*.flights.v1.ListPassengersByTicketResponseB9Z7github.com/v1adhope/flights/pkg/pb/flights/v1;flightsv1b\x06proto3"
+
+var (
+	file_flights_v1_passenger_proto_rawDescOnce sync.Once
+	file_flights_v1_passenger_proto_rawDescData []byte
+)
+
+func file_flights_v1_passenger_proto_rawDescGZIP() []byte {
+	file_flights_v1_passenger_proto_rawDescOnce.Do(func() {
+		file_flights_v1_passenger_proto_rawDescData = protoimpl.X.CompressGZIP(unsafe.Slice(unsafe.StringData(file_flights_v1_passenger_proto_rawDesc), len(file_flights_v1_passenger_proto_rawDesc)))
+	})
+	return file_flights_v1_passenger_proto_rawDescData
+}
+
+var file_flights_v1_passenger_proto_msgTypes = make([]protoimpl.MessageInfo, 16)
+var file_flights_v1_passenger_proto_goTypes = []any{
+	(*Passenger)(nil),                      // 0: flights.v1.Passenger
+	(*PassengerWholeInfo)(nil),             // 1: flights.v1.PassengerWholeInfo
+	(*CreatePassengerRequest)(nil),         // 2: flights.v1.CreatePassengerRequest
+	(*CreatePassengerResponse)(nil),        // 3: flights.v1.CreatePassengerResponse
+	(*ReplacePassengerRequest)(nil),        // 4: flights.v1.ReplacePassengerRequest
+	(*ReplacePassengerResponse)(nil),       // 5: flights.v1.ReplacePassengerResponse
+	(*DeletePassengerRequest)(nil),         // 6: flights.v1.DeletePassengerRequest
+	(*DeletePassengerResponse)(nil),        // 7: flights.v1.DeletePassengerResponse
+	(*BindToTicketRequest)(nil),            // 8: flights.v1.BindToTicketRequest
+	(*BindToTicketResponse)(nil),           // 9: flights.v1.BindToTicketResponse
+	(*UnbindFromTicketRequest)(nil),        // 10: flights.v1.UnbindFromTicketRequest
+	(*UnbindFromTicketResponse)(nil),       // 11: flights.v1.UnbindFromTicketResponse
+	(*ListPassengersRequest)(nil),          // 12: flights.v1.ListPassengersRequest
+	(*ListPassengersResponse)(nil),         // 13: flights.v1.ListPassengersResponse
+	(*ListPassengersByTicketRequest)(nil),  // 14: flights.v1.ListPassengersByTicketRequest
+	(*ListPassengersByTicketResponse)(nil), // 15: flights.v1.ListPassengersByTicketResponse
+	(*Document)(nil),                       // 16: flights.v1.Document
+}
+var file_flights_v1_passenger_proto_depIdxs = []int32{
+	0,  // 0: flights.v1.PassengerWholeInfo.passenger:type_name -> flights.v1.Passenger
+	16, // 1: flights.v1.PassengerWholeInfo.documents:type_name -> flights.v1.Document
+	0,  // 2: flights.v1.ListPassengersResponse.passengers:type_name -> flights.v1.Passenger
+	0,  // 3: flights.v1.ListPassengersByTicketResponse.passengers:type_name -> flights.v1.Passenger
+	2,  // 4: flights.v1.PassengerService.CreatePassenger:input_type -> flights.v1.CreatePassengerRequest
+	4,  // 5: flights.v1.PassengerService.ReplacePassenger:input_type -> flights.v1.ReplacePassengerRequest
+	6,  // 6: flights.v1.PassengerService.DeletePassenger:input_type -> flights.v1.DeletePassengerRequest
+	8,  // 7: flights.v1.PassengerService.BindToTicket:input_type -> flights.v1.BindToTicketRequest
+	10, // 8: flights.v1.PassengerService.UnbindFromTicket:input_type -> flights.v1.UnbindFromTicketRequest
+	12, // 9: flights.v1.PassengerService.ListPassengers:input_type -> flights.v1.ListPassengersRequest
+	14, // 10: flights.v1.PassengerService.ListPassengersByTicket:input_type -> flights.v1.ListPassengersByTicketRequest
+	3,  // 11: flights.v1.PassengerService.CreatePassenger:output_type -> flights.v1.CreatePassengerResponse
+	5,  // 12: flights.v1.PassengerService.ReplacePassenger:output_type -> flights.v1.ReplacePassengerResponse
+	7,  // 13: flights.v1.PassengerService.DeletePassenger:output_type -> flights.v1.DeletePassengerResponse
+	9,  // 14: flights.v1.PassengerService.BindToTicket:output_type -> flights.v1.BindToTicketResponse
+	11, // 15: flights.v1.PassengerService.UnbindFromTicket:output_type -> flights.v1.UnbindFromTicketResponse
+	13, // 16: flights.v1.PassengerService.ListPassengers:output_type -> flights.v1.ListPassengersResponse
+	15, // 17: flights.v1.PassengerService.ListPassengersByTicket:output_type -> flights.v1.ListPassengersByTicketResponse
+	11, // [11:18] is the sub-list for method output_type
+	4,  // [4:11] is the sub-list for method input_type
+	4,  // [4:4] is the sub-list for extension type_name
+	4,  // [4:4] is the sub-list for extension extendee
+	0,  // [0:4] is the sub-list for field type_name
+}
+
+func init() { file_flights_v1_passenger_proto_init() }
+func file_flights_v1_passenger_proto_init() {
+	if File_flights_v1_passenger_proto != nil {
+		return
+	}
+	file_flights_v1_document_proto_init()
+	type x struct{}
+	out := protoimpl.TypeBuilder{
+		File: protoimpl.DescBuilder{
+			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
+			RawDescriptor: unsafe.Slice(unsafe.StringData(file_flights_v1_passenger_proto_rawDesc), len(file_flights_v1_passenger_proto_rawDesc)),
+			NumEnums:      0,
+			NumMessages:   16,
+			NumExtensions: 0,
+			NumServices:   1,
+		},
+		GoTypes:           file_flights_v1_passenger_proto_goTypes,
+		DependencyIndexes: file_flights_v1_passenger_proto_depIdxs,
+		MessageInfos:      file_flights_v1_passenger_proto_msgTypes,
+	}.Build()
+	File_flights_v1_passenger_proto = out.File
+	file_flights_v1_passenger_proto_goTypes = nil
+	file_flights_v1_passenger_proto_depIdxs = nil
+}
@@ -0,0 +1,349 @@
+// Code generated by protoc-gen-go-grpc. DO NOT EDIT.
+// versions:
+// - protoc-gen-go-grpc v1.5.1
+// - protoc             v5.29.3
+// source: flights/v1/passenger.proto
+
+package flightsv1
+
+import (
+	context "context"
+	grpc "google.golang.org/grpc"
+	codes "google.golang.org/grpc/codes"
+	status "google.golang.org/grpc/status"
+)
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the grpc package it is being compiled against.
+// Requires gRPC-Go v1.64.0 or later.
+const _ = grpc.SupportPackageIsVersion9
+
+const (
+	PassengerService_CreatePassenger_FullMethodName        = "/flights.v1.PassengerService/CreatePassenger"
+	PassengerService_ReplacePassenger_FullMethodName       = "/flights.v1.PassengerService/ReplacePassenger"
+	PassengerService_DeletePassenger_FullMethodName        = "/flights.v1.PassengerService/DeletePassenger"
+	PassengerService_BindToTicket_FullMethodName           = "/flights.v1.PassengerService/BindToTicket"
+	PassengerService_UnbindFromTicket_FullMethodName       = "/flights.v1.PassengerService/UnbindFromTicket"
+	PassengerService_ListPassengers_FullMethodName         = "/flights.v1.PassengerService/ListPassengers"
+	PassengerService_ListPassengersByTicket_FullMethodName = "/flights.v1.PassengerService/ListPassengersByTicket"
+)
+
+// PassengerServiceClient is the client API for PassengerService service.
+//
+// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://pkg.go.dev/google.golang.org/grpc/?tab=doc#ClientConn.NewStream.
+type PassengerServiceClient interface {
+	CreatePassenger(ctx context.Context, in *CreatePassengerRequest, opts ...grpc.CallOption) (*CreatePassengerResponse, error)
+	ReplacePassenger(ctx context.Context, in *ReplacePassengerRequest, opts ...grpc.CallOption) (*ReplacePassengerResponse, error)
+	DeletePassenger(ctx context.Context, in *DeletePassengerRequest, opts ...grpc.CallOption) (*DeletePassengerResponse, error)
+	BindToTicket(ctx context.Context, in *BindToTicketRequest, opts ...grpc.CallOption) (*BindToTicketResponse, error)
+	UnbindFromTicket(ctx context.Context, in *UnbindFromTicketRequest, opts ...grpc.CallOption) (*UnbindFromTicketResponse, error)
+	ListPassengers(ctx context.Context, in *ListPassengersRequest, opts ...grpc.CallOption) (*ListPassengersResponse, error)
+	ListPassengersByTicket(ctx context.Context, in *ListPassengersByTicketRequest, opts ...grpc.CallOption) (*ListPassengersByTicketResponse, error)
+}
+
+type passengerServiceClient struct {
+	cc grpc.ClientConnInterface
+}
+
+func NewPassengerServiceClient(cc grpc.ClientConnInterface) PassengerServiceClient {
+	return &passengerServiceClient{cc}
+}
+
+func (c *passengerServiceClient) CreatePassenger(ctx context.Context, in *CreatePassengerRequest, opts ...grpc.CallOption) (*CreatePassengerResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(CreatePassengerResponse)
+	err := c.cc.Invoke(ctx, PassengerService_CreatePassenger_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *passengerServiceClient) ReplacePassenger(ctx context.Context, in *ReplacePassengerRequest, opts ...grpc.CallOption) (*ReplacePassengerResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ReplacePassengerResponse)
+	err := c.cc.Invoke(ctx, PassengerService_ReplacePassenger_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *passengerServiceClient) DeletePassenger(ctx context.Context, in *DeletePassengerRequest, opts ...grpc.CallOption) (*DeletePassengerResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(DeletePassengerResponse)
+	err := c.cc.Invoke(ctx, PassengerService_DeletePassenger_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *passengerServiceClient) BindToTicket(ctx context.Context, in *BindToTicketRequest, opts ...grpc.CallOption) (*BindToTicketResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(BindToTicketResponse)
+	err := c.cc.Invoke(ctx, PassengerService_BindToTicket_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *passengerServiceClient) UnbindFromTicket(ctx context.Context, in *UnbindFromTicketRequest, opts ...grpc.CallOption) (*UnbindFromTicketResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(UnbindFromTicketResponse)
+	err := c.cc.Invoke(ctx, PassengerService_UnbindFromTicket_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *passengerServiceClient) ListPassengers(ctx context.Context, in *ListPassengersRequest, opts ...grpc.CallOption) (*ListPassengersResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ListPassengersResponse)
+	err := c.cc.Invoke(ctx, PassengerService_ListPassengers_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *passengerServiceClient) ListPassengersByTicket(ctx context.Context, in *ListPassengersByTicketRequest, opts ...grpc.CallOption) (*ListPassengersByTicketResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ListPassengersByTicketResponse)
+	err := c.cc.Invoke(ctx, PassengerService_ListPassengersByTicket_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// PassengerServiceServer is the server API for PassengerService service.
+// All implementations must embed UnimplementedPassengerServiceServer
+// for forward compatibility.
+type PassengerServiceServer interface {
+	CreatePassenger(context.Context, *CreatePassengerRequest) (*CreatePassengerResponse, error)
+	ReplacePassenger(context.Context, *ReplacePassengerRequest) (*ReplacePassengerResponse, error)
+	DeletePassenger(context.Context, *DeletePassengerRequest) (*DeletePassengerResponse, error)
+	BindToTicket(context.Context, *BindToTicketRequest) (*BindToTicketResponse, error)
+	UnbindFromTicket(context.Context, *UnbindFromTicketRequest) (*UnbindFromTicketResponse, error)
+	ListPassengers(context.Context, *ListPassengersRequest) (*ListPassengersResponse, error)
+	ListPassengersByTicket(context.Context, *ListPassengersByTicketRequest) (*ListPassengersByTicketResponse, error)
+	mustEmbedUnimplementedPassengerServiceServer()
+}
+
+// UnimplementedPassengerServiceServer must be embedded to have
+// forward compatible implementations.
+//
+// NOTE: this should be embedded by value instead of pointer to avoid a nil
+// pointer dereference when methods are called.
+type UnimplementedPassengerServiceServer struct{}
+
+func (UnimplementedPassengerServiceServer) CreatePassenger(context.Context, *CreatePassengerRequest) (*CreatePassengerResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method CreatePassenger not implemented")
+}
+func (UnimplementedPassengerServiceServer) ReplacePassenger(context.Context, *ReplacePassengerRequest) (*ReplacePassengerResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ReplacePassenger not implemented")
+}
+func (UnimplementedPassengerServiceServer) DeletePassenger(context.Context, *DeletePassengerRequest) (*DeletePassengerResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method DeletePassenger not implemented")
+}
+func (UnimplementedPassengerServiceServer) BindToTicket(context.Context, *BindToTicketRequest) (*BindToTicketResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method BindToTicket not implemented")
+}
+func (UnimplementedPassengerServiceServer) UnbindFromTicket(context.Context, *UnbindFromTicketRequest) (*UnbindFromTicketResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method UnbindFromTicket not implemented")
+}
+func (UnimplementedPassengerServiceServer) ListPassengers(context.Context, *ListPassengersRequest) (*ListPassengersResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListPassengers not implemented")
+}
+func (UnimplementedPassengerServiceServer) ListPassengersByTicket(context.Context, *ListPassengersByTicketRequest) (*ListPassengersByTicketResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListPassengersByTicket not implemented")
+}
+func (UnimplementedPassengerServiceServer) mustEmbedUnimplementedPassengerServiceServer() {}
+func (UnimplementedPassengerServiceServer) testEmbeddedByValue()                          {}
+
+// UnsafePassengerServiceServer may be embedded to opt out of forward compatibility for this service.
+// Use of this interface is not recommended, as added methods to PassengerServiceServer will
+// result in compilation errors.
+type UnsafePassengerServiceServer interface {
+	mustEmbedUnimplementedPassengerServiceServer()
+}
+
+func RegisterPassengerServiceServer(s grpc.ServiceRegistrar, srv PassengerServiceServer) {
+	// If the following call pancis, it indicates UnimplementedPassengerServiceServer was
+	// embedded by pointer and is nil.  This will cause panics if an
+	// unimplemented method is ever invoked, so we test this at initialization
+	// time to prevent it from happening at runtime later due to I/O.
+	if t, ok := srv.(interface{ testEmbeddedByValue() }); ok {
+		t.testEmbeddedByValue()
+	}
+	s.RegisterService(&PassengerService_ServiceDesc, srv)
+}
+
+func _PassengerService_CreatePassenger_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CreatePassengerRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(PassengerServiceServer).CreatePassenger(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: PassengerService_CreatePassenger_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(PassengerServiceServer).CreatePassenger(ctx, req.(*CreatePassengerRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _PassengerService_ReplacePassenger_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ReplacePassengerRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(PassengerServiceServer).ReplacePassenger(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: PassengerService_ReplacePassenger_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(PassengerServiceServer).ReplacePassenger(ctx, req.(*ReplacePassengerRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _PassengerService_DeletePassenger_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(DeletePassengerRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(PassengerServiceServer).DeletePassenger(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: PassengerService_DeletePassenger_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(PassengerServiceServer).DeletePassenger(ctx, req.(*DeletePassengerRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _PassengerService_BindToTicket_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(BindToTicketRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(PassengerServiceServer).BindToTicket(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: PassengerService_BindToTicket_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(PassengerServiceServer).BindToTicket(ctx, req.(*BindToTicketRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _PassengerService_UnbindFromTicket_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(UnbindFromTicketRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(PassengerServiceServer).UnbindFromTicket(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: PassengerService_UnbindFromTicket_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(PassengerServiceServer).UnbindFromTicket(ctx, req.(*UnbindFromTicketRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _PassengerService_ListPassengers_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListPassengersRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(PassengerServiceServer).ListPassengers(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: PassengerService_ListPassengers_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(PassengerServiceServer).ListPassengers(ctx, req.(*ListPassengersRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _PassengerService_ListPassengersByTicket_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListPassengersByTicketRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(PassengerServiceServer).ListPassengersByTicket(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: PassengerService_ListPassengersByTicket_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(PassengerServiceServer).ListPassengersByTicket(ctx, req.(*ListPassengersByTicketRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+// PassengerService_ServiceDesc is the grpc.ServiceDesc for PassengerService service.
+// It's only intended for direct use with grpc.RegisterService,
+// and not to be introspected or modified (even as a copy)
+var PassengerService_ServiceDesc = grpc.ServiceDesc{
+	ServiceName: "flights.v1.PassengerService",
+	HandlerType: (*PassengerServiceServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "CreatePassenger",
+			Handler:    _PassengerService_CreatePassenger_Handler,
+		},
+		{
+			MethodName: "ReplacePassenger",
+			Handler:    _PassengerService_ReplacePassenger_Handler,
+		},
+		{
+			MethodName: "DeletePassenger",
+			Handler:    _PassengerService_DeletePassenger_Handler,
+		},
+		{
+			MethodName: "BindToTicket",
+			Handler:    _PassengerService_BindToTicket_Handler,
+		},
+		{
+			MethodName: "UnbindFromTicket",
+			Handler:    _PassengerService_UnbindFromTicket_Handler,
+		},
+		{
+			MethodName: "ListPassengers",
+			Handler:    _PassengerService_ListPassengers_Handler,
+		},
+		{
+			MethodName: "ListPassengersByTicket",
+			Handler:    _PassengerService_ListPassengersByTicket_Handler,
+		},
+	},
+	Streams:  []grpc.StreamDesc{},
+	Metadata: "flights/v1/passenger.proto",
+}
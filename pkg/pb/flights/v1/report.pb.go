@@ -0,0 +1,286 @@
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// versions:
+// 	protoc-gen-go v1.36.6
+// 	protoc        v5.29.3
+// source: flights/v1/report.proto
+
+package flightsv1
+
+import (
+	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
+	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	reflect "reflect"
+	sync "sync"
+	unsafe "unsafe"
+)
+
+const (
+	// Verify that this generated code is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(20 - protoimpl.MinVersion)
+	// Verify that runtime/protoimpl is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
+)
+
+type ReportRowByPassengerForPeriod struct {
+	state           protoimpl.MessageState `protogen:"open.v1"`
+	DateOfIssue     string                 `protobuf:"bytes,1,opt,name=date_of_issue,json=dateOfIssue,proto3" json:"date_of_issue,omitempty"`
+	FlyAt           string                 `protobuf:"bytes,2,opt,name=fly_at,json=flyAt,proto3" json:"fly_at,omitempty"`
+	TicketId        string                 `protobuf:"bytes,3,opt,name=ticket_id,json=ticketId,proto3" json:"ticket_id,omitempty"`
+	FlyFrom         string                 `protobuf:"bytes,4,opt,name=fly_from,json=flyFrom,proto3" json:"fly_from,omitempty"`
+	FlyTo           string                 `protobuf:"bytes,5,opt,name=fly_to,json=flyTo,proto3" json:"fly_to,omitempty"`
+	ServiceProvided bool                   `protobuf:"varint,6,opt,name=service_provided,json=serviceProvided,proto3" json:"service_provided,omitempty"`
+	unknownFields   protoimpl.UnknownFields
+	sizeCache       protoimpl.SizeCache
+}
+
+func (x *ReportRowByPassengerForPeriod) Reset() {
+	*x = ReportRowByPassengerForPeriod{}
+	mi := &file_flights_v1_report_proto_msgTypes[0]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ReportRowByPassengerForPeriod) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ReportRowByPassengerForPeriod) ProtoMessage() {}
+
+func (x *ReportRowByPassengerForPeriod) ProtoReflect() protoreflect.Message {
+	mi := &file_flights_v1_report_proto_msgTypes[0]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ReportRowByPassengerForPeriod.ProtoReflect.Descriptor instead.
+func (*ReportRowByPassengerForPeriod) Descriptor() ([]byte, []int) {
+	return file_flights_v1_report_proto_rawDescGZIP(), []int{0}
+}
+
+func (x *ReportRowByPassengerForPeriod) GetDateOfIssue() string {
+	if x != nil {
+		return x.DateOfIssue
+	}
+	return ""
+}
+
+func (x *ReportRowByPassengerForPeriod) GetFlyAt() string {
+	if x != nil {
+		return x.FlyAt
+	}
+	return ""
+}
+
+func (x *ReportRowByPassengerForPeriod) GetTicketId() string {
+	if x != nil {
+		return x.TicketId
+	}
+	return ""
+}
+
+func (x *ReportRowByPassengerForPeriod) GetFlyFrom() string {
+	if x != nil {
+		return x.FlyFrom
+	}
+	return ""
+}
+
+func (x *ReportRowByPassengerForPeriod) GetFlyTo() string {
+	if x != nil {
+		return x.FlyTo
+	}
+	return ""
+}
+
+func (x *ReportRowByPassengerForPeriod) GetServiceProvided() bool {
+	if x != nil {
+		return x.ServiceProvided
+	}
+	return false
+}
+
+type GetReportByPassengerForPeriodRequest struct {
+	state       protoimpl.MessageState `protogen:"open.v1"`
+	PassengerId string                 `protobuf:"bytes,1,opt,name=passenger_id,json=passengerId,proto3" json:"passenger_id,omitempty"`
+	// RFC 3339 period bounds.
+	From          string `protobuf:"bytes,2,opt,name=from,proto3" json:"from,omitempty"`
+	To            string `protobuf:"bytes,3,opt,name=to,proto3" json:"to,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetReportByPassengerForPeriodRequest) Reset() {
+	*x = GetReportByPassengerForPeriodRequest{}
+	mi := &file_flights_v1_report_proto_msgTypes[1]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetReportByPassengerForPeriodRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetReportByPassengerForPeriodRequest) ProtoMessage() {}
+
+func (x *GetReportByPassengerForPeriodRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_flights_v1_report_proto_msgTypes[1]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetReportByPassengerForPeriodRequest.ProtoReflect.Descriptor instead.
+func (*GetReportByPassengerForPeriodRequest) Descriptor() ([]byte, []int) {
+	return file_flights_v1_report_proto_rawDescGZIP(), []int{1}
+}
+
+func (x *GetReportByPassengerForPeriodRequest) GetPassengerId() string {
+	if x != nil {
+		return x.PassengerId
+	}
+	return ""
+}
+
+func (x *GetReportByPassengerForPeriodRequest) GetFrom() string {
+	if x != nil {
+		return x.From
+	}
+	return ""
+}
+
+func (x *GetReportByPassengerForPeriodRequest) GetTo() string {
+	if x != nil {
+		return x.To
+	}
+	return ""
+}
+
+type GetReportByPassengerForPeriodResponse struct {
+	state         protoimpl.MessageState           `protogen:"open.v1"`
+	Rows          []*ReportRowByPassengerForPeriod `protobuf:"bytes,1,rep,name=rows,proto3" json:"rows,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetReportByPassengerForPeriodResponse) Reset() {
+	*x = GetReportByPassengerForPeriodResponse{}
+	mi := &file_flights_v1_report_proto_msgTypes[2]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetReportByPassengerForPeriodResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetReportByPassengerForPeriodResponse) ProtoMessage() {}
+
+func (x *GetReportByPassengerForPeriodResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_flights_v1_report_proto_msgTypes[2]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetReportByPassengerForPeriodResponse.ProtoReflect.Descriptor instead.
+func (*GetReportByPassengerForPeriodResponse) Descriptor() ([]byte, []int) {
+	return file_flights_v1_report_proto_rawDescGZIP(), []int{2}
+}
+
+func (x *GetReportByPassengerForPeriodResponse) GetRows() []*ReportRowByPassengerForPeriod {
+	if x != nil {
+		return x.Rows
+	}
+	return nil
+}
+
+var File_flights_v1_report_proto protoreflect.FileDescriptor
+
+const file_flights_v1_report_proto_rawDesc = "" +
+	"\n" +
+	"\x17flights/v1/report.proto\x12\n" +
+	"flights.v1\"\xd4\x01\n" +
+	"\x1dReportRowByPassengerForPeriod\x12\"\n" +
+	"\rdate_of_issue\x18\x01 \x01(\tR\vdateOfIssue\x12\x15\n" +
+	"\x06fly_at\x18\x02 \x01(\tR\x05flyAt\x12\x1b\n" +
+	"\tticket_id\x18\x03 \x01(\tR\bticketId\x12\x19\n" +
+	"\bfly_from\x18\x04 \x01(\tR\aflyFrom\x12\x15\n" +
+	"\x06fly_to\x18\x05 \x01(\tR\x05flyTo\x12)\n" +
+	"\x10service_provided\x18\x06 \x01(\bR\x0fserviceProvided\"m\n" +
+	"$GetReportByPassengerForPeriodRequest\x12!\n" +
+	"\fpassenger_id\x18\x01 \x01(\tR\vpassengerId\x12\x12\n" +
+	"\x04from\x18\x02 \x01(\tR\x04from\x12\x0e\n" +
+	"\x02to\x18\x03 \x01(\tR\x02to\"f\n" +
+	"%GetReportByPassengerForPeriodResponse\x12=\n" +
+	"\x04rows\x18\x01 \x03(\v2).flights.v1.ReportRowByPassengerForPeriodR\x04rows2\x96\x01\n" +
+	"\rReportService\x12\x84\x01\n" +
+	"\x1dGetReportByPassengerForPeriod\x120.flights.v1.GetReportByPassengerForPeriodRequest\x1a1.flights.v1.GetReportByPassengerForPeriodResponseB9Z7github.com/v1adhope/flights/pkg/pb/flights/v1;flightsv1b\x06proto3"
+
+var (
+	file_flights_v1_report_proto_rawDescOnce sync.Once
+	file_flights_v1_report_proto_rawDescData []byte
+)
+
+func file_flights_v1_report_proto_rawDescGZIP() []byte {
+	file_flights_v1_report_proto_rawDescOnce.Do(func() {
+		file_flights_v1_report_proto_rawDescData = protoimpl.X.CompressGZIP(unsafe.Slice(unsafe.StringData(file_flights_v1_report_proto_rawDesc), len(file_flights_v1_report_proto_rawDesc)))
+	})
+	return file_flights_v1_report_proto_rawDescData
+}
+
+var file_flights_v1_report_proto_msgTypes = make([]protoimpl.MessageInfo, 3)
+var file_flights_v1_report_proto_goTypes = []any{
+	(*ReportRowByPassengerForPeriod)(nil),         // 0: flights.v1.ReportRowByPassengerForPeriod
+	(*GetReportByPassengerForPeriodRequest)(nil),  // 1: flights.v1.GetReportByPassengerForPeriodRequest
+	(*GetReportByPassengerForPeriodResponse)(nil), // 2: flights.v1.GetReportByPassengerForPeriodResponse
+}
+var file_flights_v1_report_proto_depIdxs = []int32{
+	0, // 0: flights.v1.GetReportByPassengerForPeriodResponse.rows:type_name -> flights.v1.ReportRowByPassengerForPeriod
+	1, // 1: flights.v1.ReportService.GetReportByPassengerForPeriod:input_type -> flights.v1.GetReportByPassengerForPeriodRequest
+	2, // 2: flights.v1.ReportService.GetReportByPassengerForPeriod:output_type -> flights.v1.GetReportByPassengerForPeriodResponse
+	2, // [2:3] is the sub-list for method output_type
+	1, // [1:2] is the sub-list for method input_type
+	1, // [1:1] is the sub-list for extension type_name
+	1, // [1:1] is the sub-list for extension extendee
+	0, // [0:1] is the sub-list for field type_name
+}
+
+func init() { file_flights_v1_report_proto_init() }
+func file_flights_v1_report_proto_init() {
+	if File_flights_v1_report_proto != nil {
+		return
+	}
+	type x struct{}
+	out := protoimpl.TypeBuilder{
+		File: protoimpl.DescBuilder{
+			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
+			RawDescriptor: unsafe.Slice(unsafe.StringData(file_flights_v1_report_proto_rawDesc), len(file_flights_v1_report_proto_rawDesc)),
+			NumEnums:      0,
+			NumMessages:   3,
+			NumExtensions: 0,
+			NumServices:   1,
+		},
+		GoTypes:           file_flights_v1_report_proto_goTypes,
+		DependencyIndexes: file_flights_v1_report_proto_depIdxs,
+		MessageInfos:      file_flights_v1_report_proto_msgTypes,
+	}.Build()
+	File_flights_v1_report_proto = out.File
+	file_flights_v1_report_proto_goTypes = nil
+	file_flights_v1_report_proto_depIdxs = nil
+}
@@ -0,0 +1,783 @@
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// versions:
+// 	protoc-gen-go v1.36.6
+// 	protoc        v5.29.3
+// source: flights/v1/ticket.proto
+
+package flightsv1
+
+import (
+	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
+	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	reflect "reflect"
+	sync "sync"
+	unsafe "unsafe"
+)
+
+const (
+	// Verify that this generated code is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(20 - protoimpl.MinVersion)
+	// Verify that runtime/protoimpl is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
+)
+
+// Times are RFC 3339 strings, the same values the HTTP API accepts and returns.
+type Ticket struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	Provider      string                 `protobuf:"bytes,2,opt,name=provider,proto3" json:"provider,omitempty"`
+	FlyFrom       string                 `protobuf:"bytes,3,opt,name=fly_from,json=flyFrom,proto3" json:"fly_from,omitempty"`
+	FlyTo         string                 `protobuf:"bytes,4,opt,name=fly_to,json=flyTo,proto3" json:"fly_to,omitempty"`
+	FlyAt         string                 `protobuf:"bytes,5,opt,name=fly_at,json=flyAt,proto3" json:"fly_at,omitempty"`
+	ArriveAt      string                 `protobuf:"bytes,6,opt,name=arrive_at,json=arriveAt,proto3" json:"arrive_at,omitempty"`
+	CreatedAt     string                 `protobuf:"bytes,7,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Ticket) Reset() {
+	*x = Ticket{}
+	mi := &file_flights_v1_ticket_proto_msgTypes[0]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Ticket) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Ticket) ProtoMessage() {}
+
+func (x *Ticket) ProtoReflect() protoreflect.Message {
+	mi := &file_flights_v1_ticket_proto_msgTypes[0]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Ticket.ProtoReflect.Descriptor instead.
+func (*Ticket) Descriptor() ([]byte, []int) {
+	return file_flights_v1_ticket_proto_rawDescGZIP(), []int{0}
+}
+
+func (x *Ticket) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *Ticket) GetProvider() string {
+	if x != nil {
+		return x.Provider
+	}
+	return ""
+}
+
+func (x *Ticket) GetFlyFrom() string {
+	if x != nil {
+		return x.FlyFrom
+	}
+	return ""
+}
+
+func (x *Ticket) GetFlyTo() string {
+	if x != nil {
+		return x.FlyTo
+	}
+	return ""
+}
+
+func (x *Ticket) GetFlyAt() string {
+	if x != nil {
+		return x.FlyAt
+	}
+	return ""
+}
+
+func (x *Ticket) GetArriveAt() string {
+	if x != nil {
+		return x.ArriveAt
+	}
+	return ""
+}
+
+func (x *Ticket) GetCreatedAt() string {
+	if x != nil {
+		return x.CreatedAt
+	}
+	return ""
+}
+
+type TicketWholeInfo struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Ticket        *Ticket                `protobuf:"bytes,1,opt,name=ticket,proto3" json:"ticket,omitempty"`
+	Passengers    []*PassengerWholeInfo  `protobuf:"bytes,2,rep,name=passengers,proto3" json:"passengers,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *TicketWholeInfo) Reset() {
+	*x = TicketWholeInfo{}
+	mi := &file_flights_v1_ticket_proto_msgTypes[1]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *TicketWholeInfo) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*TicketWholeInfo) ProtoMessage() {}
+
+func (x *TicketWholeInfo) ProtoReflect() protoreflect.Message {
+	mi := &file_flights_v1_ticket_proto_msgTypes[1]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use TicketWholeInfo.ProtoReflect.Descriptor instead.
+func (*TicketWholeInfo) Descriptor() ([]byte, []int) {
+	return file_flights_v1_ticket_proto_rawDescGZIP(), []int{1}
+}
+
+func (x *TicketWholeInfo) GetTicket() *Ticket {
+	if x != nil {
+		return x.Ticket
+	}
+	return nil
+}
+
+func (x *TicketWholeInfo) GetPassengers() []*PassengerWholeInfo {
+	if x != nil {
+		return x.Passengers
+	}
+	return nil
+}
+
+type CreateTicketRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Provider      string                 `protobuf:"bytes,1,opt,name=provider,proto3" json:"provider,omitempty"`
+	FlyFrom       string                 `protobuf:"bytes,2,opt,name=fly_from,json=flyFrom,proto3" json:"fly_from,omitempty"`
+	FlyTo         string                 `protobuf:"bytes,3,opt,name=fly_to,json=flyTo,proto3" json:"fly_to,omitempty"`
+	FlyAt         string                 `protobuf:"bytes,4,opt,name=fly_at,json=flyAt,proto3" json:"fly_at,omitempty"`
+	ArriveAt      string                 `protobuf:"bytes,5,opt,name=arrive_at,json=arriveAt,proto3" json:"arrive_at,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CreateTicketRequest) Reset() {
+	*x = CreateTicketRequest{}
+	mi := &file_flights_v1_ticket_proto_msgTypes[2]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CreateTicketRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CreateTicketRequest) ProtoMessage() {}
+
+func (x *CreateTicketRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_flights_v1_ticket_proto_msgTypes[2]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CreateTicketRequest.ProtoReflect.Descriptor instead.
+func (*CreateTicketRequest) Descriptor() ([]byte, []int) {
+	return file_flights_v1_ticket_proto_rawDescGZIP(), []int{2}
+}
+
+func (x *CreateTicketRequest) GetProvider() string {
+	if x != nil {
+		return x.Provider
+	}
+	return ""
+}
+
+func (x *CreateTicketRequest) GetFlyFrom() string {
+	if x != nil {
+		return x.FlyFrom
+	}
+	return ""
+}
+
+func (x *CreateTicketRequest) GetFlyTo() string {
+	if x != nil {
+		return x.FlyTo
+	}
+	return ""
+}
+
+func (x *CreateTicketRequest) GetFlyAt() string {
+	if x != nil {
+		return x.FlyAt
+	}
+	return ""
+}
+
+func (x *CreateTicketRequest) GetArriveAt() string {
+	if x != nil {
+		return x.ArriveAt
+	}
+	return ""
+}
+
+type CreateTicketResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CreateTicketResponse) Reset() {
+	*x = CreateTicketResponse{}
+	mi := &file_flights_v1_ticket_proto_msgTypes[3]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CreateTicketResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CreateTicketResponse) ProtoMessage() {}
+
+func (x *CreateTicketResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_flights_v1_ticket_proto_msgTypes[3]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CreateTicketResponse.ProtoReflect.Descriptor instead.
+func (*CreateTicketResponse) Descriptor() ([]byte, []int) {
+	return file_flights_v1_ticket_proto_rawDescGZIP(), []int{3}
+}
+
+func (x *CreateTicketResponse) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+type ReplaceTicketRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	Provider      string                 `protobuf:"bytes,2,opt,name=provider,proto3" json:"provider,omitempty"`
+	FlyFrom       string                 `protobuf:"bytes,3,opt,name=fly_from,json=flyFrom,proto3" json:"fly_from,omitempty"`
+	FlyTo         string                 `protobuf:"bytes,4,opt,name=fly_to,json=flyTo,proto3" json:"fly_to,omitempty"`
+	FlyAt         string                 `protobuf:"bytes,5,opt,name=fly_at,json=flyAt,proto3" json:"fly_at,omitempty"`
+	ArriveAt      string                 `protobuf:"bytes,6,opt,name=arrive_at,json=arriveAt,proto3" json:"arrive_at,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ReplaceTicketRequest) Reset() {
+	*x = ReplaceTicketRequest{}
+	mi := &file_flights_v1_ticket_proto_msgTypes[4]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ReplaceTicketRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ReplaceTicketRequest) ProtoMessage() {}
+
+func (x *ReplaceTicketRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_flights_v1_ticket_proto_msgTypes[4]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ReplaceTicketRequest.ProtoReflect.Descriptor instead.
+func (*ReplaceTicketRequest) Descriptor() ([]byte, []int) {
+	return file_flights_v1_ticket_proto_rawDescGZIP(), []int{4}
+}
+
+func (x *ReplaceTicketRequest) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *ReplaceTicketRequest) GetProvider() string {
+	if x != nil {
+		return x.Provider
+	}
+	return ""
+}
+
+func (x *ReplaceTicketRequest) GetFlyFrom() string {
+	if x != nil {
+		return x.FlyFrom
+	}
+	return ""
+}
+
+func (x *ReplaceTicketRequest) GetFlyTo() string {
+	if x != nil {
+		return x.FlyTo
+	}
+	return ""
+}
+
+func (x *ReplaceTicketRequest) GetFlyAt() string {
+	if x != nil {
+		return x.FlyAt
+	}
+	return ""
+}
+
+func (x *ReplaceTicketRequest) GetArriveAt() string {
+	if x != nil {
+		return x.ArriveAt
+	}
+	return ""
+}
+
+type ReplaceTicketResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ReplaceTicketResponse) Reset() {
+	*x = ReplaceTicketResponse{}
+	mi := &file_flights_v1_ticket_proto_msgTypes[5]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ReplaceTicketResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ReplaceTicketResponse) ProtoMessage() {}
+
+func (x *ReplaceTicketResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_flights_v1_ticket_proto_msgTypes[5]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ReplaceTicketResponse.ProtoReflect.Descriptor instead.
+func (*ReplaceTicketResponse) Descriptor() ([]byte, []int) {
+	return file_flights_v1_ticket_proto_rawDescGZIP(), []int{5}
+}
+
+type DeleteTicketRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *DeleteTicketRequest) Reset() {
+	*x = DeleteTicketRequest{}
+	mi := &file_flights_v1_ticket_proto_msgTypes[6]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DeleteTicketRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DeleteTicketRequest) ProtoMessage() {}
+
+func (x *DeleteTicketRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_flights_v1_ticket_proto_msgTypes[6]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DeleteTicketRequest.ProtoReflect.Descriptor instead.
+func (*DeleteTicketRequest) Descriptor() ([]byte, []int) {
+	return file_flights_v1_ticket_proto_rawDescGZIP(), []int{6}
+}
+
+func (x *DeleteTicketRequest) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+type DeleteTicketResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *DeleteTicketResponse) Reset() {
+	*x = DeleteTicketResponse{}
+	mi := &file_flights_v1_ticket_proto_msgTypes[7]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DeleteTicketResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DeleteTicketResponse) ProtoMessage() {}
+
+func (x *DeleteTicketResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_flights_v1_ticket_proto_msgTypes[7]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DeleteTicketResponse.ProtoReflect.Descriptor instead.
+func (*DeleteTicketResponse) Descriptor() ([]byte, []int) {
+	return file_flights_v1_ticket_proto_rawDescGZIP(), []int{7}
+}
+
+type ListTicketsRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListTicketsRequest) Reset() {
+	*x = ListTicketsRequest{}
+	mi := &file_flights_v1_ticket_proto_msgTypes[8]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListTicketsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListTicketsRequest) ProtoMessage() {}
+
+func (x *ListTicketsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_flights_v1_ticket_proto_msgTypes[8]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListTicketsRequest.ProtoReflect.Descriptor instead.
+func (*ListTicketsRequest) Descriptor() ([]byte, []int) {
+	return file_flights_v1_ticket_proto_rawDescGZIP(), []int{8}
+}
+
+type ListTicketsResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Tickets       []*Ticket              `protobuf:"bytes,1,rep,name=tickets,proto3" json:"tickets,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListTicketsResponse) Reset() {
+	*x = ListTicketsResponse{}
+	mi := &file_flights_v1_ticket_proto_msgTypes[9]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListTicketsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListTicketsResponse) ProtoMessage() {}
+
+func (x *ListTicketsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_flights_v1_ticket_proto_msgTypes[9]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListTicketsResponse.ProtoReflect.Descriptor instead.
+func (*ListTicketsResponse) Descriptor() ([]byte, []int) {
+	return file_flights_v1_ticket_proto_rawDescGZIP(), []int{9}
+}
+
+func (x *ListTicketsResponse) GetTickets() []*Ticket {
+	if x != nil {
+		return x.Tickets
+	}
+	return nil
+}
+
+type GetTicketWholeInfoRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetTicketWholeInfoRequest) Reset() {
+	*x = GetTicketWholeInfoRequest{}
+	mi := &file_flights_v1_ticket_proto_msgTypes[10]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetTicketWholeInfoRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetTicketWholeInfoRequest) ProtoMessage() {}
+
+func (x *GetTicketWholeInfoRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_flights_v1_ticket_proto_msgTypes[10]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetTicketWholeInfoRequest.ProtoReflect.Descriptor instead.
+func (*GetTicketWholeInfoRequest) Descriptor() ([]byte, []int) {
+	return file_flights_v1_ticket_proto_rawDescGZIP(), []int{10}
+}
+
+func (x *GetTicketWholeInfoRequest) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+type GetTicketWholeInfoResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Info          *TicketWholeInfo       `protobuf:"bytes,1,opt,name=info,proto3" json:"info,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetTicketWholeInfoResponse) Reset() {
+	*x = GetTicketWholeInfoResponse{}
+	mi := &file_flights_v1_ticket_proto_msgTypes[11]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetTicketWholeInfoResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetTicketWholeInfoResponse) ProtoMessage() {}
+
+func (x *GetTicketWholeInfoResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_flights_v1_ticket_proto_msgTypes[11]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetTicketWholeInfoResponse.ProtoReflect.Descriptor instead.
+func (*GetTicketWholeInfoResponse) Descriptor() ([]byte, []int) {
+	return file_flights_v1_ticket_proto_rawDescGZIP(), []int{11}
+}
+
+func (x *GetTicketWholeInfoResponse) GetInfo() *TicketWholeInfo {
+	if x != nil {
+		return x.Info
+	}
+	return nil
+}
+
+var File_flights_v1_ticket_proto protoreflect.FileDescriptor
+
+const file_flights_v1_ticket_proto_rawDesc = "" +
+	"\n" +
+	"\x17flights/v1/ticket.proto\x12\n" +
+	"flights.v1\x1a\x1aflights/v1/passenger.proto\"\xb9\x01\n" +
+	"\x06Ticket\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\x12\x1a\n" +
+	"\bprovider\x18\x02 \x01(\tR\bprovider\x12\x19\n" +
+	"\bfly_from\x18\x03 \x01(\tR\aflyFrom\x12\x15\n" +
+	"\x06fly_to\x18\x04 \x01(\tR\x05flyTo\x12\x15\n" +
+	"\x06fly_at\x18\x05 \x01(\tR\x05flyAt\x12\x1b\n" +
+	"\tarrive_at\x18\x06 \x01(\tR\barriveAt\x12\x1d\n" +
+	"\n" +
+	"created_at\x18\a \x01(\tR\tcreatedAt\"}\n" +
+	"\x0fTicketWholeInfo\x12*\n" +
+	"\x06ticket\x18\x01 \x01(\v2\x12.flights.v1.TicketR\x06ticket\x12>\n" +
+	"\n" +
+	"passengers\x18\x02 \x03(\v2\x1e.flights.v1.PassengerWholeInfoR\n" +
+	"passengers\"\x97\x01\n" +
+	"\x13CreateTicketRequest\x12\x1a\n" +
+	"\bprovider\x18\x01 \x01(\tR\bprovider\x12\x19\n" +
+	"\bfly_from\x18\x02 \x01(\tR\aflyFrom\x12\x15\n" +
+	"\x06fly_to\x18\x03 \x01(\tR\x05flyTo\x12\x15\n" +
+	"\x06fly_at\x18\x04 \x01(\tR\x05flyAt\x12\x1b\n" +
+	"\tarrive_at\x18\x05 \x01(\tR\barriveAt\"&\n" +
+	"\x14CreateTicketResponse\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\"\xa8\x01\n" +
+	"\x14ReplaceTicketRequest\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\x12\x1a\n" +
+	"\bprovider\x18\x02 \x01(\tR\bprovider\x12\x19\n" +
+	"\bfly_from\x18\x03 \x01(\tR\aflyFrom\x12\x15\n" +
+	"\x06fly_to\x18\x04 \x01(\tR\x05flyTo\x12\x15\n" +
+	"\x06fly_at\x18\x05 \x01(\tR\x05flyAt\x12\x1b\n" +
+	"\tarrive_at\x18\x06 \x01(\tR\barriveAt\"\x17\n" +
+	"\x15ReplaceTicketResponse\"%\n" +
+	"\x13DeleteTicketRequest\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\"\x16\n" +
+	"\x14DeleteTicketResponse\"\x14\n" +
+	"\x12ListTicketsRequest\"C\n" +
+	"\x13ListTicketsResponse\x12,\n" +
+	"\atickets\x18\x01 \x03(\v2\x12.flights.v1.TicketR\atickets\"+\n" +
+	"\x19GetTicketWholeInfoRequest\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\"M\n" +
+	"\x1aGetTicketWholeInfoResponse\x12/\n" +
+	"\x04info\x18\x01 \x01(\v2\x1b.flights.v1.TicketWholeInfoR\x04info2\xc0\x03\n" +
+	"\rTicketService\x12Q\n" +
+	"\fCreateTicket\x12\x1f.flights.v1.CreateTicketRequest\x1a .flights.v1.CreateTicketResponse\x12T\n" +
+	"\rReplaceTicket\x12 .flights.v1.ReplaceTicketRequest\x1a!.flights.v1.ReplaceTicketResponse\x12Q\n" +
+	"\fDeleteTicket\x12\x1f.flights.v1.DeleteTicketRequest\x1a .flights.v1.DeleteTicketResponse\x12N\n" +
+	"\vListTickets\x12\x1e.flights.v1.ListTicketsRequest\x1a\x1f.flights.v1.ListTicketsResponse\x12c\n" +
+	"\x12GetTicketWholeInfo\x12%.flights.v1.GetTicketWholeInfoRequest\x1a&.flights.v1.GetTicketWholeInfoResponseB9Z7github.com/v1adhope/flights/pkg/pb/flights/v1;flightsv1b\x06proto3"
+
+var (
+	file_flights_v1_ticket_proto_rawDescOnce sync.Once
+	file_flights_v1_ticket_proto_rawDescData []byte
+)
+
+func file_flights_v1_ticket_proto_rawDescGZIP() []byte {
+	file_flights_v1_ticket_proto_rawDescOnce.Do(func() {
+		file_flights_v1_ticket_proto_rawDescData = protoimpl.X.CompressGZIP(unsafe.Slice(unsafe.StringData(file_flights_v1_ticket_proto_rawDesc), len(file_flights_v1_ticket_proto_rawDesc)))
+	})
+	return file_flights_v1_ticket_proto_rawDescData
+}
+
+var file_flights_v1_ticket_proto_msgTypes = make([]protoimpl.MessageInfo, 12)
+var file_flights_v1_ticket_proto_goTypes = []any{
+	(*Ticket)(nil),                     // 0: flights.v1.Ticket
+	(*TicketWholeInfo)(nil),            // 1: flights.v1.TicketWholeInfo
+	(*CreateTicketRequest)(nil),        // 2: flights.v1.CreateTicketRequest
+	(*CreateTicketResponse)(nil),       // 3: flights.v1.CreateTicketResponse
+	(*ReplaceTicketRequest)(nil),       // 4: flights.v1.ReplaceTicketRequest
+	(*ReplaceTicketResponse)(nil),      // 5: flights.v1.ReplaceTicketResponse
+	(*DeleteTicketRequest)(nil),        // 6: flights.v1.DeleteTicketRequest
+	(*DeleteTicketResponse)(nil),       // 7: flights.v1.DeleteTicketResponse
+	(*ListTicketsRequest)(nil),         // 8: flights.v1.ListTicketsRequest
+	(*ListTicketsResponse)(nil),        // 9: flights.v1.ListTicketsResponse
+	(*GetTicketWholeInfoRequest)(nil),  // 10: flights.v1.GetTicketWholeInfoRequest
+	(*GetTicketWholeInfoResponse)(nil), // 11: flights.v1.GetTicketWholeInfoResponse
+	(*PassengerWholeInfo)(nil),         // 12: flights.v1.PassengerWholeInfo
+}
+var file_flights_v1_ticket_proto_depIdxs = []int32{
+	0,  // 0: flights.v1.TicketWholeInfo.ticket:type_name -> flights.v1.Ticket
+	12, // 1: flights.v1.TicketWholeInfo.passengers:type_name -> flights.v1.PassengerWholeInfo
+	0,  // 2: flights.v1.ListTicketsResponse.tickets:type_name -> flights.v1.Ticket
+	1,  // 3: flights.v1.GetTicketWholeInfoResponse.info:type_name -> flights.v1.TicketWholeInfo
+	2,  // 4: flights.v1.TicketService.CreateTicket:input_type -> flights.v1.CreateTicketRequest
+	4,  // 5: flights.v1.TicketService.ReplaceTicket:input_type -> flights.v1.ReplaceTicketRequest
+	6,  // 6: flights.v1.TicketService.DeleteTicket:input_type -> flights.v1.DeleteTicketRequest
+	8,  // 7: flights.v1.TicketService.ListTickets:input_type -> flights.v1.ListTicketsRequest
+	10, // 8: flights.v1.TicketService.GetTicketWholeInfo:input_type -> flights.v1.GetTicketWholeInfoRequest
+	3,  // 9: flights.v1.TicketService.CreateTicket:output_type -> flights.v1.CreateTicketResponse
+	5,  // 10: flights.v1.TicketService.ReplaceTicket:output_type -> flights.v1.ReplaceTicketResponse
+	7,  // 11: flights.v1.TicketService.DeleteTicket:output_type -> flights.v1.DeleteTicketResponse
+	9,  // 12: flights.v1.TicketService.ListTickets:output_type -> flights.v1.ListTicketsResponse
+	11, // 13: flights.v1.TicketService.GetTicketWholeInfo:output_type -> flights.v1.GetTicketWholeInfoResponse
+	9,  // [9:14] is the sub-list for method output_type
+	4,  // [4:9] is the sub-list for method input_type
+	4,  // [4:4] is the sub-list for extension type_name
+	4,  // [4:4] is the sub-list for extension extendee
+	0,  // [0:4] is the sub-list for field type_name
+}
+
+func init() { file_flights_v1_ticket_proto_init() }
+func file_flights_v1_ticket_proto_init() {
+	if File_flights_v1_ticket_proto != nil {
+		return
+	}
+	file_flights_v1_passenger_proto_init()
+	type x struct{}
+	out := protoimpl.TypeBuilder{
+		File: protoimpl.DescBuilder{
+			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
+			RawDescriptor: unsafe.Slice(unsafe.StringData(file_flights_v1_ticket_proto_rawDesc), len(file_flights_v1_ticket_proto_rawDesc)),
+			NumEnums:      0,
+			NumMessages:   12,
+			NumExtensions: 0,
+			NumServices:   1,
+		},
+		GoTypes:           file_flights_v1_ticket_proto_goTypes,
+		DependencyIndexes: file_flights_v1_ticket_proto_depIdxs,
+		MessageInfos:      file_flights_v1_ticket_proto_msgTypes,
+	}.Build()
+	File_flights_v1_ticket_proto = out.File
+	file_flights_v1_ticket_proto_goTypes = nil
+	file_flights_v1_ticket_proto_depIdxs = nil
+}
@@ -0,0 +1,82 @@
+package postgresql
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+)
+
+const _listenCloseTimeout = 5 * time.Second
+
+// ListenHandler receives the notifications of Listen.
+type ListenHandler interface {
+	// Listening is called every time listening starts,
+	// notifications sent while the connection was lost are not delivered, so it should resync.
+	Listening(ctx context.Context)
+	Notified(ctx context.Context, payload string)
+	// Disconnected is called with the error which stopped listening, Listen reconnects after it.
+	Disconnected(err error)
+}
+
+// Notify sends every payload to the listeners of channel, inside a transaction they are delivered on commit.
+func (p *Driver) Notify(ctx context.Context, channel string, payloads ...string) error {
+	if len(payloads) == 0 {
+		return nil
+	}
+
+	if _, err := p.Conn(ctx).Exec(ctx, "SELECT pg_notify($1, payload) FROM unnest($2::text[]) AS payload", channel, payloads); err != nil {
+		return fmt.Errorf("postgresql: listen: Notify: Exec: %w", err)
+	}
+
+	return nil
+}
+
+// Listen passes the notifications of channel to h until ctx is done.
+// It holds a connection of its own outside the pool and reconnects with backoff when it is lost.
+func (p *Driver) Listen(ctx context.Context, channel string, h ListenHandler) {
+	for attempt := 0; ; attempt++ {
+		err := p.listen(ctx, channel, h, func() { attempt = 0 })
+		if ctx.Err() != nil {
+			return
+		}
+
+		h.Disconnected(err)
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-time.After(p.backoff(attempt)):
+		}
+	}
+}
+
+func (p *Driver) listen(ctx context.Context, channel string, h ListenHandler, listening func()) error {
+	conn, err := pgx.ConnectConfig(ctx, p.Pool.Config().ConnConfig)
+	if err != nil {
+		return fmt.Errorf("postgresql: listen: listen: ConnectConfig: %w", err)
+	}
+	defer func() {
+		ctx, cancel := context.WithTimeout(context.Background(), _listenCloseTimeout)
+		defer cancel()
+
+		conn.Close(ctx)
+	}()
+
+	if _, err := conn.Exec(ctx, "LISTEN "+pgx.Identifier{channel}.Sanitize()); err != nil {
+		return fmt.Errorf("postgresql: listen: listen: Exec: %w", err)
+	}
+
+	listening()
+	h.Listening(ctx)
+
+	for {
+		n, err := conn.WaitForNotification(ctx)
+		if err != nil {
+			return fmt.Errorf("postgresql: listen: listen: WaitForNotification: %w", err)
+		}
+
+		h.Notified(ctx, n.Payload)
+	}
+}
@@ -1,9 +1,37 @@
 package postgresql
 
+import (
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+)
+
 type Option func(*Config)
 
+// ErrInvalidConfig is returned by Build for options it can't apply.
+var ErrInvalidConfig = errors.New("postgresql: invalid config")
+
+// Zero pool settings keep the pool_* parameters of the connection string or the defaults of the driver.
 type Config struct {
-	ConnStr string
+	ConnStr           string
+	TxIsoLevel        pgx.TxIsoLevel
+	TxMaxRetries      int
+	TxRetryDelay      time.Duration
+	QueryMaxRetries   int
+	RetryMaxDelay     time.Duration
+	Tracer            pgx.QueryTracer
+	MaxConns          int32
+	MinConns          int32
+	MaxConnLifetime   time.Duration
+	MaxConnIdleTime   time.Duration
+	HealthCheckPeriod time.Duration
+	StatementTimeout  time.Duration
+	ApplicationName   string
+	// ReplicaConnStrs are the connection strings of read replicas, see Driver.ReadConn
+	ReplicaConnStrs      []string
+	ReplicaCheckInterval time.Duration
 }
 
 func WithConnStr(connStr string) Option {
@@ -12,8 +40,116 @@ func WithConnStr(connStr string) Option {
 	}
 }
 
+// WithTxIsoLevel accepts one of "read committed", "repeatable read", "serializable", Build fails on others.
+func WithTxIsoLevel(lvl string) Option {
+	return func(cfg *Config) {
+		cfg.TxIsoLevel = pgx.TxIsoLevel(lvl)
+	}
+}
+
+func WithTxMaxRetries(n int) Option {
+	return func(cfg *Config) {
+		cfg.TxMaxRetries = n
+	}
+}
+
+// WithTxRetryDelay is the first delay of the jittered exponential backoff of transactions and queries.
+func WithTxRetryDelay(d time.Duration) Option {
+	return func(cfg *Config) {
+		cfg.TxRetryDelay = d
+	}
+}
+
+// WithQueryMaxRetries retries queries outside transactions which failed without taking effect, see Conn.
+func WithQueryMaxRetries(n int) Option {
+	return func(cfg *Config) {
+		cfg.QueryMaxRetries = n
+	}
+}
+
+// WithRetryMaxDelay caps the backoff between retries.
+func WithRetryMaxDelay(d time.Duration) Option {
+	return func(cfg *Config) {
+		cfg.RetryMaxDelay = d
+	}
+}
+
+// WithTracer traces the queries of every connection of the pool.
+func WithTracer(tracer pgx.QueryTracer) Option {
+	return func(cfg *Config) {
+		cfg.Tracer = tracer
+	}
+}
+
+// WithMaxConns limits the connections of the pool, 0 keeps the default of the driver.
+func WithMaxConns(n int32) Option {
+	return func(cfg *Config) {
+		cfg.MaxConns = n
+	}
+}
+
+func WithMinConns(n int32) Option {
+	return func(cfg *Config) {
+		cfg.MinConns = n
+	}
+}
+
+func WithMaxConnLifetime(d time.Duration) Option {
+	return func(cfg *Config) {
+		cfg.MaxConnLifetime = d
+	}
+}
+
+func WithMaxConnIdleTime(d time.Duration) Option {
+	return func(cfg *Config) {
+		cfg.MaxConnIdleTime = d
+	}
+}
+
+// WithHealthCheckPeriod sets how often idle connections are checked.
+func WithHealthCheckPeriod(d time.Duration) Option {
+	return func(cfg *Config) {
+		cfg.HealthCheckPeriod = d
+	}
+}
+
+// WithStatementTimeout makes the server cancel every statement running longer than d.
+func WithStatementTimeout(d time.Duration) Option {
+	return func(cfg *Config) {
+		cfg.StatementTimeout = d
+	}
+}
+
+// WithApplicationName names the connections in pg_stat_activity.
+func WithApplicationName(name string) Option {
+	return func(cfg *Config) {
+		cfg.ApplicationName = name
+	}
+}
+
+// WithReplicas adds read replicas, every replica gets the pool settings of the primary.
+func WithReplicas(connStrs ...string) Option {
+	return func(cfg *Config) {
+		cfg.ReplicaConnStrs = append(cfg.ReplicaConnStrs, connStrs...)
+	}
+}
+
+// WithReplicaCheckInterval sets how often replicas are pinged, so a replica that is down is skipped and one that is back is used again.
+func WithReplicaCheckInterval(d time.Duration) Option {
+	return func(cfg *Config) {
+		cfg.ReplicaCheckInterval = d
+	}
+}
+
 func config(opts ...Option) Config {
-	cfg := Config{}
+	cfg := Config{
+		TxIsoLevel:           pgx.ReadCommitted,
+		TxMaxRetries:         3,
+		TxRetryDelay:         10 * time.Millisecond,
+		QueryMaxRetries:      2,
+		RetryMaxDelay:        time.Second,
+		ReplicaCheckInterval: 5 * time.Second,
+	}
 
 	for _, opt := range opts {
 		opt(&cfg)
@@ -25,3 +161,13 @@ func config(opts ...Option) Config {
 
 	return cfg
 }
+
+func (cfg Config) validate() error {
+	switch cfg.TxIsoLevel {
+	case pgx.ReadCommitted, pgx.RepeatableRead, pgx.Serializable:
+	default:
+		return fmt.Errorf("%w: unknown transaction isolation level %q", ErrInvalidConfig, cfg.TxIsoLevel)
+	}
+
+	return nil
+}
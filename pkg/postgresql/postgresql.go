@@ -3,33 +3,96 @@ package postgresql
 import (
 	"context"
 	"fmt"
+	"strconv"
+	"time"
 
 	"github.com/Masterminds/squirrel"
+	"github.com/jackc/pgx/v5"
 	"github.com/jackc/pgx/v5/pgxpool"
 )
 
 type Driver struct {
-	Pool    *pgxpool.Pool
-	Builder squirrel.StatementBuilderType
+	Pool            *pgxpool.Pool
+	Builder         squirrel.StatementBuilderType
+	txIsoLevel      pgx.TxIsoLevel
+	txMaxRetries    int
+	txRetryDelay    time.Duration
+	queryMaxRetries int
+	retryMaxDelay   time.Duration
+	replicas        *replicaSet
 }
 
 func Build(ctx context.Context, opts ...Option) (*Driver, error) {
 	cfg := config(opts...)
 
-	pool, err := pgxpool.New(ctx, cfg.ConnStr)
+	if err := cfg.validate(); err != nil {
+		return nil, fmt.Errorf("postgresql: postgresql: Build: %w", err)
+	}
+
+	poolCfg, err := pgxpool.ParseConfig(cfg.ConnStr)
+	if err != nil {
+		return nil, fmt.Errorf("postgresql: postgresql: Build: ParseConfig: %w", err)
+	}
+
+	poolCfg.ConnConfig.Tracer = cfg.Tracer
+	applyPoolConfig(poolCfg, cfg)
+
+	pool, err := pgxpool.NewWithConfig(ctx, poolCfg)
 	if err != nil {
-		return nil, fmt.Errorf("postgresql: postgresql: Build: New: %w", err)
+		return nil, fmt.Errorf("postgresql: postgresql: Build: NewWithConfig: %w", err)
 	}
 
 	if err := pool.Ping(ctx); err != nil {
 		return nil, fmt.Errorf("postgresql: postgresql: Build: Ping: %w", err)
 	}
 
+	replicas, err := newReplicaSet(ctx, cfg)
+	if err != nil {
+		pool.Close()
+		return nil, fmt.Errorf("postgresql: postgresql: Build: %w", err)
+	}
+
 	builder := squirrel.StatementBuilder.PlaceholderFormat(squirrel.Dollar)
 
-	return &Driver{pool, builder}, nil
+	return &Driver{
+		Pool:            pool,
+		Builder:         builder,
+		txIsoLevel:      cfg.TxIsoLevel,
+		txMaxRetries:    cfg.TxMaxRetries,
+		txRetryDelay:    cfg.TxRetryDelay,
+		queryMaxRetries: cfg.QueryMaxRetries,
+		retryMaxDelay:   cfg.RetryMaxDelay,
+		replicas:        replicas,
+	}, nil
+}
+
+func applyPoolConfig(poolCfg *pgxpool.Config, cfg Config) {
+	if cfg.MaxConns > 0 {
+		poolCfg.MaxConns = cfg.MaxConns
+	}
+	if cfg.MinConns > 0 {
+		poolCfg.MinConns = cfg.MinConns
+	}
+	if cfg.MaxConnLifetime > 0 {
+		poolCfg.MaxConnLifetime = cfg.MaxConnLifetime
+	}
+	if cfg.MaxConnIdleTime > 0 {
+		poolCfg.MaxConnIdleTime = cfg.MaxConnIdleTime
+	}
+	if cfg.HealthCheckPeriod > 0 {
+		poolCfg.HealthCheckPeriod = cfg.HealthCheckPeriod
+	}
+
+	params := poolCfg.ConnConfig.RuntimeParams
+	if cfg.StatementTimeout > 0 {
+		params["statement_timeout"] = strconv.FormatInt(cfg.StatementTimeout.Milliseconds(), 10)
+	}
+	if cfg.ApplicationName != "" {
+		params["application_name"] = cfg.ApplicationName
+	}
 }
 
 func (p *Driver) Close() {
+	p.replicas.close()
 	p.Pool.Close()
 }
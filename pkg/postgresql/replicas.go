@@ -0,0 +1,270 @@
+package postgresql
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"strings"
+	"sync/atomic"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgconn"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+const _pgCodeCannotConnectNow = "57P03"
+
+type primaryKey struct{}
+
+// primaryScope tracks whether reads of a scope go to the primary.
+type primaryScope struct {
+	forced bool
+	wrote  atomic.Bool
+}
+
+// ReadYourWrites scopes ctx, e.g. to a request, so reads of ReadConn go to the primary once the scope wrote something.
+func ReadYourWrites(ctx context.Context) context.Context {
+	return context.WithValue(ctx, primaryKey{}, &primaryScope{})
+}
+
+// ForcePrimary makes every read of ctx go to the primary, writes are still reported by Wrote.
+func ForcePrimary(ctx context.Context) context.Context {
+	return context.WithValue(ctx, primaryKey{}, &primaryScope{forced: true})
+}
+
+// Wrote reports whether the ReadYourWrites or ForcePrimary scope of ctx wrote something.
+func Wrote(ctx context.Context) bool {
+	scope, ok := ctx.Value(primaryKey{}).(*primaryScope)
+
+	return ok && scope.wrote.Load()
+}
+
+func pinPrimary(ctx context.Context) {
+	if scope, ok := ctx.Value(primaryKey{}).(*primaryScope); ok {
+		scope.wrote.Store(true)
+	}
+}
+
+// PrimaryPinned reports whether the reads of ctx go to the primary.
+func PrimaryPinned(ctx context.Context) bool {
+	scope, ok := ctx.Value(primaryKey{}).(*primaryScope)
+
+	return ok && (scope.forced || scope.wrote.Load())
+}
+
+// ReadConn returns a healthy replica in round-robin for read-only statements.
+// The primary is used inside transactions, after a write of a ReadYourWrites scope and when no replica is healthy,
+// a statement that fails because its replica is down runs on the primary again.
+func (p *Driver) ReadConn(ctx context.Context) Querier {
+	if tx, ok := ctx.Value(txKey{}).(pgx.Tx); ok {
+		return tx
+	}
+
+	if PrimaryPinned(ctx) {
+		return retryQuerier{p}
+	}
+
+	r := p.replicas.next()
+	if r == nil {
+		return retryQuerier{p}
+	}
+
+	return replicaQuerier{p, r}
+}
+
+type replica struct {
+	pool    *pgxpool.Pool
+	healthy atomic.Bool
+}
+
+// markDown reports whether err means the replica is unreachable, then the replica is skipped until a health check passes.
+func (r *replica) markDown(err error) bool {
+	if err == nil || isCanceled(err) {
+		return false
+	}
+
+	down := isConnectionError(err)
+
+	var pgErr *pgconn.PgError
+	if errors.As(err, &pgErr) {
+		down = pgErr.Code == _pgCodeAdminShutdown ||
+			pgErr.Code == _pgCodeCannotConnectNow ||
+			strings.HasPrefix(pgErr.Code, _pgClassConnectionException)
+	}
+
+	if !down {
+		return false
+	}
+
+	if r.healthy.Swap(false) {
+		log.Printf("postgresql: replicas: replica %s is down: %s", r.pool.Config().ConnConfig.Host, err)
+	}
+
+	return true
+}
+
+type replicaSet struct {
+	replicas []*replica
+	cursor   atomic.Uint64
+	stop     context.CancelFunc
+}
+
+// newReplicaSet doesn't fail on unreachable replicas, they join once a health check passes.
+func newReplicaSet(ctx context.Context, cfg Config) (*replicaSet, error) {
+	rs := &replicaSet{}
+
+	for _, connStr := range cfg.ReplicaConnStrs {
+		poolCfg, err := pgxpool.ParseConfig(connStr)
+		if err != nil {
+			rs.close()
+			return nil, fmt.Errorf("postgresql: replicas: newReplicaSet: ParseConfig: %w", err)
+		}
+
+		poolCfg.ConnConfig.Tracer = cfg.Tracer
+		applyPoolConfig(poolCfg, cfg)
+
+		pool, err := pgxpool.NewWithConfig(ctx, poolCfg)
+		if err != nil {
+			rs.close()
+			return nil, fmt.Errorf("postgresql: replicas: newReplicaSet: NewWithConfig: %w", err)
+		}
+
+		r := &replica{pool: pool}
+		r.healthy.Store(pool.Ping(ctx) == nil)
+
+		rs.replicas = append(rs.replicas, r)
+	}
+
+	if len(rs.replicas) > 0 {
+		watchCtx, stop := context.WithCancel(context.Background())
+		rs.stop = stop
+
+		go rs.watch(watchCtx, cfg.ReplicaCheckInterval)
+	}
+
+	return rs, nil
+}
+
+func (rs *replicaSet) next() *replica {
+	if rs == nil {
+		return nil
+	}
+
+	healthy := 0
+	for _, r := range rs.replicas {
+		if r.healthy.Load() {
+			healthy++
+		}
+	}
+
+	if healthy == 0 {
+		return nil
+	}
+
+	// Turns are counted among healthy replicas only, so a replica that is down doesn't double the load of its neighbour
+	turn := int(rs.cursor.Add(1) % uint64(healthy))
+	for _, r := range rs.replicas {
+		if !r.healthy.Load() {
+			continue
+		}
+
+		if turn == 0 {
+			return r
+		}
+		turn--
+	}
+
+	// A replica went down since counting
+	return nil
+}
+
+// watch pings every replica each interval, so a replica that is down or back is noticed without traffic.
+func (rs *replicaSet) watch(ctx context.Context, interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+
+		for _, r := range rs.replicas {
+			pingCtx, cancel := context.WithTimeout(ctx, interval)
+			err := r.pool.Ping(pingCtx)
+			cancel()
+
+			if err != nil {
+				r.markDown(err)
+			} else if !r.healthy.Swap(true) {
+				log.Printf("postgresql: replicas: replica %s is up", r.pool.Config().ConnConfig.Host)
+			}
+		}
+	}
+}
+
+func (rs *replicaSet) close() {
+	if rs == nil {
+		return
+	}
+
+	if rs.stop != nil {
+		rs.stop()
+	}
+
+	for _, r := range rs.replicas {
+		r.pool.Close()
+	}
+}
+
+// replicaQuerier runs statements on a replica and on the primary when the replica turns out to be down.
+type replicaQuerier struct {
+	p *Driver
+	r *replica
+}
+
+func (q replicaQuerier) Exec(ctx context.Context, sql string, args ...any) (pgconn.CommandTag, error) {
+	tag, err := q.r.pool.Exec(ctx, sql, args...)
+	if q.r.markDown(err) {
+		return retryQuerier{q.p}.Exec(ctx, sql, args...)
+	}
+
+	return tag, err
+}
+
+func (q replicaQuerier) Query(ctx context.Context, sql string, args ...any) (pgx.Rows, error) {
+	rows, err := q.r.pool.Query(ctx, sql, args...)
+	if q.r.markDown(err) {
+		return retryQuerier{q.p}.Query(ctx, sql, args...)
+	}
+
+	return rows, err
+}
+
+func (q replicaQuerier) QueryRow(ctx context.Context, sql string, args ...any) pgx.Row {
+	return replicaRow{q, ctx, sql, args}
+}
+
+// CopyFrom writes, so it always goes to the primary.
+func (q replicaQuerier) CopyFrom(ctx context.Context, tableName pgx.Identifier, columnNames []string, rowSrc pgx.CopyFromSource) (int64, error) {
+	return retryQuerier{q.p}.CopyFrom(ctx, tableName, columnNames, rowSrc)
+}
+
+type replicaRow struct {
+	q    replicaQuerier
+	ctx  context.Context
+	sql  string
+	args []any
+}
+
+func (r replicaRow) Scan(dest ...any) error {
+	err := r.q.r.pool.QueryRow(r.ctx, r.sql, r.args...).Scan(dest...)
+	if r.q.r.markDown(err) {
+		return retryQuerier{r.q.p}.QueryRow(r.ctx, r.sql, r.args...).Scan(dest...)
+	}
+
+	return err
+}
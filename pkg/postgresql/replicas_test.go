@@ -0,0 +1,89 @@
+package postgresql
+
+import (
+	"context"
+	"net"
+	"syscall"
+	"testing"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgconn"
+	"github.com/jackc/pgx/v5/pgxpool"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func newTestReplica(t *testing.T, healthy bool) *replica {
+	// The pool connects lazily, so nothing listens on the port
+	pool, err := pgxpool.New(context.Background(), "postgres://rat@127.0.0.1:1/flights")
+	require.NoError(t, err)
+	t.Cleanup(pool.Close)
+
+	r := &replica{pool: pool}
+	r.healthy.Store(healthy)
+
+	return r
+}
+
+func TestReplicaSetNext(t *testing.T) {
+	a, b, c := newTestReplica(t, true), newTestReplica(t, false), newTestReplica(t, true)
+	rs := &replicaSet{replicas: []*replica{a, b, c}}
+
+	seen := map[*replica]int{}
+	for range 4 {
+		seen[rs.next()]++
+	}
+
+	assert.Equal(t, map[*replica]int{a: 2, c: 2}, seen, "round-robin skips unhealthy replicas")
+
+	a.healthy.Store(false)
+	c.healthy.Store(false)
+	assert.Nil(t, rs.next())
+
+	assert.Nil(t, (*replicaSet)(nil).next())
+}
+
+func TestReadConn(t *testing.T) {
+	p := &Driver{replicas: &replicaSet{replicas: []*replica{newTestReplica(t, true)}}}
+
+	ctx := ReadYourWrites(context.Background())
+	assert.IsType(t, replicaQuerier{}, p.ReadConn(ctx))
+	assert.False(t, Wrote(ctx))
+
+	p.Conn(ctx)
+	assert.IsType(t, retryQuerier{}, p.ReadConn(ctx), "reads after a write go to the primary")
+	assert.True(t, Wrote(ctx))
+
+	assert.IsType(t, replicaQuerier{}, p.ReadConn(context.Background()), "writes pin their scope only")
+
+	forced := ForcePrimary(context.Background())
+	assert.IsType(t, retryQuerier{}, p.ReadConn(forced))
+	assert.False(t, Wrote(forced), "forced reads are not writes")
+
+	assert.IsType(t, retryQuerier{}, (&Driver{}).ReadConn(context.Background()), "no replicas")
+}
+
+func TestReplicaMarkDown(t *testing.T) {
+	tests := []struct {
+		name string
+		err  error
+		down bool
+	}{
+		{"no error", nil, false},
+		{"no rows", pgx.ErrNoRows, false},
+		{"unique violation", &pgconn.PgError{Code: "23505"}, false},
+		{"canceled", context.Canceled, false},
+		{"refused", &net.OpError{Op: "dial", Err: syscall.ECONNREFUSED}, true},
+		{"recovery", &pgconn.PgError{Code: _pgCodeCannotConnectNow}, true},
+		{"connection failure", &pgconn.PgError{Code: "08006"}, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r := newTestReplica(t, true)
+
+			assert.Equal(t, tt.down, r.markDown(tt.err))
+			assert.Equal(t, !tt.down, r.healthy.Load())
+		})
+	}
+}
@@ -0,0 +1,153 @@
+package postgresql
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"math/rand/v2"
+	"net"
+	"strings"
+	"syscall"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgconn"
+)
+
+const (
+	_pgCodeSerializationFailure = "40001"
+	_pgCodeDeadlockDetected     = "40P01"
+	_pgCodeAdminShutdown        = "57P01"
+	_pgClassConnectionException = "08"
+)
+
+// ErrCommitUnknown means the connection was lost during commit, the transaction may have been committed, so it isn't retried.
+var ErrCommitUnknown = errors.New("postgresql: commit outcome is unknown")
+
+// IsTransient reports errors which may go away when the operation is run again:
+// serialization failures, deadlocks and lost connections.
+// A lost connection may have taken effect, so only idempotent operations and transactions are retried on it.
+func IsTransient(err error) bool {
+	if err == nil || isCanceled(err) || errors.Is(err, ErrCommitUnknown) {
+		return false
+	}
+
+	return isNotApplied(err) || isConnectionError(err)
+}
+
+func isConnectionError(err error) bool {
+	var (
+		connectErr *pgconn.ConnectError
+		netErr     net.Error
+	)
+
+	return errors.As(err, &connectErr) ||
+		errors.As(err, &netErr) ||
+		pgconn.SafeToRetry(err) ||
+		errors.Is(err, syscall.ECONNRESET) ||
+		errors.Is(err, syscall.EPIPE) ||
+		errors.Is(err, io.EOF) ||
+		errors.Is(err, io.ErrUnexpectedEOF)
+}
+
+// isNotApplied reports transient errors of statements that surely had no effect:
+// the server rejected them, or they weren't sent at all.
+func isNotApplied(err error) bool {
+	var pgErr *pgconn.PgError
+	if errors.As(err, &pgErr) {
+		return pgErr.Code == _pgCodeSerializationFailure ||
+			pgErr.Code == _pgCodeDeadlockDetected ||
+			pgErr.Code == _pgCodeAdminShutdown ||
+			strings.HasPrefix(pgErr.Code, _pgClassConnectionException)
+	}
+
+	var connectErr *pgconn.ConnectError
+
+	return pgconn.SafeToRetry(err) || errors.As(err, &connectErr)
+}
+
+func isCanceled(err error) bool {
+	return errors.Is(err, context.Canceled) || errors.Is(err, context.DeadlineExceeded)
+}
+
+// Retry runs fn again on transient errors with jittered exponential backoff, fn must be safe to run more than once.
+func (p *Driver) Retry(ctx context.Context, fn func(ctx context.Context) error) error {
+	return p.retry(ctx, p.queryMaxRetries, IsTransient, fn)
+}
+
+func (p *Driver) retry(ctx context.Context, maxRetries int, retryable func(error) bool, fn func(ctx context.Context) error) error {
+	for attempt := 0; ; attempt++ {
+		err := fn(ctx)
+		if err == nil || !retryable(err) || attempt >= maxRetries {
+			return err
+		}
+
+		select {
+		case <-ctx.Done():
+			return fmt.Errorf("postgresql: retry: retry: %w", ctx.Err())
+		case <-time.After(p.backoff(attempt)):
+		}
+	}
+}
+
+// backoff keeps half of the exponential delay and randomizes the other half, so retries of concurrent calls spread.
+func (p *Driver) backoff(attempt int) time.Duration {
+	delay := p.txRetryDelay << attempt
+	if delay <= 0 || delay > p.retryMaxDelay {
+		delay = p.retryMaxDelay
+	}
+
+	return delay/2 + rand.N(delay/2+1)
+}
+
+// retryQuerier retries statements which failed without taking effect, so it is safe for inserts too.
+type retryQuerier struct {
+	p *Driver
+}
+
+func (q retryQuerier) Exec(ctx context.Context, sql string, args ...any) (pgconn.CommandTag, error) {
+	var tag pgconn.CommandTag
+
+	err := q.p.retry(ctx, q.p.queryMaxRetries, isNotApplied, func(ctx context.Context) error {
+		var err error
+		tag, err = q.p.Pool.Exec(ctx, sql, args...)
+		return err
+	})
+
+	return tag, err
+}
+
+func (q retryQuerier) Query(ctx context.Context, sql string, args ...any) (pgx.Rows, error) {
+	var rows pgx.Rows
+
+	err := q.p.retry(ctx, q.p.queryMaxRetries, isNotApplied, func(ctx context.Context) error {
+		var err error
+		rows, err = q.p.Pool.Query(ctx, sql, args...)
+		return err
+	})
+
+	return rows, err
+}
+
+func (q retryQuerier) QueryRow(ctx context.Context, sql string, args ...any) pgx.Row {
+	return retryRow{q, ctx, sql, args}
+}
+
+// CopyFrom isn't retried, rowSrc can't be read twice.
+func (q retryQuerier) CopyFrom(ctx context.Context, tableName pgx.Identifier, columnNames []string, rowSrc pgx.CopyFromSource) (int64, error) {
+	return q.p.Pool.CopyFrom(ctx, tableName, columnNames, rowSrc)
+}
+
+type retryRow struct {
+	q    retryQuerier
+	ctx  context.Context
+	sql  string
+	args []any
+}
+
+func (r retryRow) Scan(dest ...any) error {
+	return r.q.p.retry(r.ctx, r.q.p.queryMaxRetries, isNotApplied, func(ctx context.Context) error {
+		return r.q.p.Pool.QueryRow(ctx, r.sql, r.args...).Scan(dest...)
+	})
+}
@@ -0,0 +1,91 @@
+package postgresql
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net"
+	"syscall"
+	"testing"
+	"time"
+
+	"github.com/jackc/pgx/v5/pgconn"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestIsTransient(t *testing.T) {
+	connReset := &net.OpError{Op: "read", Err: syscall.ECONNRESET}
+
+	tests := []struct {
+		name       string
+		err        error
+		transient  bool
+		notApplied bool
+	}{
+		{"serialization failure", &pgconn.PgError{Code: _pgCodeSerializationFailure}, true, true},
+		{"deadlock", fmt.Errorf("repository: %w", &pgconn.PgError{Code: _pgCodeDeadlockDetected}), true, true},
+		{"connection exception", &pgconn.PgError{Code: "08006"}, true, true},
+		{"unique violation", &pgconn.PgError{Code: "23505"}, false, false},
+		{"connection reset", fmt.Errorf("repository: %w", connReset), true, false},
+		{"commit outcome unknown", fmt.Errorf("%w: %w", ErrCommitUnknown, connReset), false, false},
+		{"canceled", fmt.Errorf("%w: %w", context.Canceled, connReset), false, false},
+		{"other", errors.New("boom"), false, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.transient, IsTransient(tt.err))
+			assert.Equal(t, tt.notApplied, isNotApplied(tt.err))
+		})
+	}
+}
+
+func TestRetry(t *testing.T) {
+	p := &Driver{
+		queryMaxRetries: 2,
+		txRetryDelay:    time.Millisecond,
+		retryMaxDelay:   2 * time.Millisecond,
+	}
+
+	calls := 0
+	err := p.Retry(context.Background(), func(context.Context) error {
+		calls++
+		return &pgconn.PgError{Code: _pgCodeDeadlockDetected}
+	})
+	assert.Error(t, err)
+	assert.Equal(t, 3, calls, "the first call and 2 retries")
+
+	calls = 0
+	err = p.Retry(context.Background(), func(context.Context) error {
+		calls++
+		if calls == 1 {
+			return &pgconn.PgError{Code: _pgCodeSerializationFailure}
+		}
+		return nil
+	})
+	assert.NoError(t, err)
+	assert.Equal(t, 2, calls)
+
+	calls = 0
+	err = p.Retry(context.Background(), func(context.Context) error {
+		calls++
+		return &pgconn.PgError{Code: "23505"}
+	})
+	assert.Error(t, err)
+	assert.Equal(t, 1, calls)
+}
+
+func TestBackoff(t *testing.T) {
+	p := &Driver{
+		txRetryDelay:  10 * time.Millisecond,
+		retryMaxDelay: 50 * time.Millisecond,
+	}
+
+	for attempt := range 70 {
+		delay := p.backoff(attempt)
+
+		expected := min(10*time.Millisecond<<min(attempt, 3), 50*time.Millisecond)
+		assert.GreaterOrEqual(t, delay, expected/2)
+		assert.LessOrEqual(t, delay, expected)
+	}
+}
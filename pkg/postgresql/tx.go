@@ -0,0 +1,72 @@
+package postgresql
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgconn"
+)
+
+type txKey struct{}
+
+type Querier interface {
+	Exec(ctx context.Context, sql string, args ...any) (pgconn.CommandTag, error)
+	Query(ctx context.Context, sql string, args ...any) (pgx.Rows, error)
+	QueryRow(ctx context.Context, sql string, args ...any) pgx.Row
+	CopyFrom(ctx context.Context, tableName pgx.Identifier, columnNames []string, rowSrc pgx.CopyFromSource) (int64, error)
+}
+
+// Conn returns the transaction started by WithinTx if ctx carries one,
+// otherwise the pool which retries statements that failed without taking effect.
+// Conn is meant for writes, later reads of a ReadYourWrites scope go to the primary.
+func (p *Driver) Conn(ctx context.Context) Querier {
+	pinPrimary(ctx)
+
+	if tx, ok := ctx.Value(txKey{}).(pgx.Tx); ok {
+		return tx
+	}
+
+	return retryQuerier{p}
+}
+
+// WithinTx runs fn in a transaction, every Conn(ctx) call inside fn uses it.
+// Nested calls join the outer transaction. The whole fn is retried on transient errors, see IsTransient,
+// except a connection lost during commit, see ErrCommitUnknown.
+func (p *Driver) WithinTx(ctx context.Context, fn func(ctx context.Context) error) error {
+	if _, ok := ctx.Value(txKey{}).(pgx.Tx); ok {
+		return fn(ctx)
+	}
+
+	pinPrimary(ctx)
+
+	return p.retry(ctx, p.txMaxRetries, IsTransient, func(ctx context.Context) error {
+		return p.runTx(ctx, fn)
+	})
+}
+
+func (p *Driver) runTx(ctx context.Context, fn func(ctx context.Context) error) error {
+	tx, err := p.Pool.BeginTx(ctx, pgx.TxOptions{
+		IsoLevel: p.txIsoLevel,
+	})
+	if err != nil {
+		return fmt.Errorf("postgresql: tx: runTx: BeginTx: %w", err)
+	}
+	defer tx.Rollback(ctx)
+
+	if err := fn(context.WithValue(ctx, txKey{}, tx)); err != nil {
+		return err
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		var pgErr *pgconn.PgError
+		if !errors.As(err, &pgErr) && !isCanceled(err) {
+			return fmt.Errorf("postgresql: tx: runTx: Commit: %w: %w", ErrCommitUnknown, err)
+		}
+
+		return fmt.Errorf("postgresql: tx: runTx: Commit: %w", err)
+	}
+
+	return nil
+}
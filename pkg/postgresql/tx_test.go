@@ -0,0 +1,143 @@
+package postgresql_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/jackc/pgx/v5/pgconn"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/v1adhope/flights/internal/testhelpers"
+	"github.com/v1adhope/flights/pkg/postgresql"
+)
+
+const _pgMigrationsSourceUrl = "file://../../db/migrations"
+
+func TestBuildInvalidTxIsoLevel(t *testing.T) {
+	_, err := postgresql.Build(
+		context.Background(),
+		postgresql.WithConnStr("postgres://rat@127.0.0.1:1/flights"),
+		postgresql.WithTxIsoLevel("snapshot"),
+	)
+	assert.ErrorIs(t, err, postgresql.ErrInvalidConfig)
+}
+
+func TestWithinTx(t *testing.T) {
+	ctx := context.Background()
+
+	pgC, err := testhelpers.BuildContainer(ctx, _pgMigrationsSourceUrl)
+	require.NoError(t, err)
+	t.Cleanup(func() {
+		pgC.Terminate(ctx)
+	})
+
+	pd, err := postgresql.Build(
+		ctx,
+		postgresql.WithConnStr(pgC.ConnStr),
+		postgresql.WithTxIsoLevel("serializable"),
+	)
+	require.NoError(t, err)
+	t.Cleanup(pd.Close)
+
+	_, err = pd.Pool.Exec(ctx, "create table tx_test (value text primary key)")
+	require.NoError(t, err)
+
+	insert := func(ctx context.Context, value string) error {
+		_, err := pd.Conn(ctx).Exec(ctx, "insert into tx_test (value) values ($1)", value)
+		return err
+	}
+
+	values := func(t *testing.T) []string {
+		rows, err := pd.Pool.Query(ctx, "select value from tx_test order by value")
+		require.NoError(t, err)
+		defer rows.Close()
+
+		values := []string{}
+		for rows.Next() {
+			value := ""
+			require.NoError(t, rows.Scan(&value))
+			values = append(values, value)
+		}
+		require.NoError(t, rows.Err())
+
+		return values
+	}
+
+	errRollback := errors.New("rollback")
+
+	t.Run("Commit", func(t *testing.T) {
+		err := pd.WithinTx(ctx, func(ctx context.Context) error {
+			if err := insert(ctx, "a"); err != nil {
+				return err
+			}
+
+			return insert(ctx, "b")
+		})
+		require.NoError(t, err)
+		assert.Equal(t, []string{"a", "b"}, values(t))
+	})
+
+	t.Run("Rollback", func(t *testing.T) {
+		err := pd.WithinTx(ctx, func(ctx context.Context) error {
+			if err := insert(ctx, "c"); err != nil {
+				return err
+			}
+
+			return errRollback
+		})
+		assert.ErrorIs(t, err, errRollback)
+		assert.Equal(t, []string{"a", "b"}, values(t))
+	})
+
+	t.Run("Nested", func(t *testing.T) {
+		err := pd.WithinTx(ctx, func(ctx context.Context) error {
+			if err := insert(ctx, "d"); err != nil {
+				return err
+			}
+
+			return pd.WithinTx(ctx, func(ctx context.Context) error {
+				if err := insert(ctx, "e"); err != nil {
+					return err
+				}
+
+				return errRollback
+			})
+		})
+		assert.ErrorIs(t, err, errRollback)
+		assert.Equal(t, []string{"a", "b"}, values(t))
+
+		err = pd.WithinTx(ctx, func(ctx context.Context) error {
+			if err := insert(ctx, "d"); err != nil {
+				return err
+			}
+
+			return pd.WithinTx(ctx, func(ctx context.Context) error {
+				return insert(ctx, "e")
+			})
+		})
+		require.NoError(t, err)
+		assert.Equal(t, []string{"a", "b", "d", "e"}, values(t))
+	})
+
+	t.Run("RetrySerializationFailure", func(t *testing.T) {
+		calls := 0
+
+		err := pd.WithinTx(ctx, func(ctx context.Context) error {
+			calls++
+
+			if err := insert(ctx, "f"); err != nil {
+				return err
+			}
+
+			if calls == 1 {
+				return &pgconn.PgError{Code: "40001"}
+			}
+
+			return nil
+		})
+		require.NoError(t, err)
+		assert.Equal(t, 2, calls)
+		assert.Equal(t, []string{"a", "b", "d", "e", "f"}, values(t))
+	})
+}
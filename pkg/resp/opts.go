@@ -0,0 +1,50 @@
+package resp
+
+import "time"
+
+type Option func(*Config)
+
+type Config struct {
+	Addr     string
+	Password string
+	Db       int
+	// Timeout bounds dialing and every command without a deadline of its own
+	Timeout time.Duration
+}
+
+func WithAddr(addr string) Option {
+	return func(cfg *Config) {
+		cfg.Addr = addr
+	}
+}
+
+func WithPassword(password string) Option {
+	return func(cfg *Config) {
+		cfg.Password = password
+	}
+}
+
+func WithDb(db int) Option {
+	return func(cfg *Config) {
+		cfg.Db = db
+	}
+}
+
+func WithTimeout(t time.Duration) Option {
+	return func(cfg *Config) {
+		cfg.Timeout = t
+	}
+}
+
+func config(opts ...Option) Config {
+	cfg := Config{
+		Addr:    "127.0.0.1:6379",
+		Timeout: time.Second,
+	}
+
+	for _, opt := range opts {
+		opt(&cfg)
+	}
+
+	return cfg
+}
@@ -0,0 +1,195 @@
+// Package resp speaks the Redis protocol: a minimal client and an in-memory stand-in server for local runs and tests.
+package resp
+
+import (
+	"bufio"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"strconv"
+	"sync"
+	"time"
+)
+
+// Error is an error reply of the server, the connection stays usable after it.
+type Error string
+
+func (e Error) Error() string {
+	return string(e)
+}
+
+// Client runs commands one at a time over a single connection, a broken connection is dialed again by the next command.
+type Client struct {
+	cfg Config
+
+	mu   sync.Mutex
+	conn net.Conn
+	rd   *bufio.Reader
+}
+
+func New(opts ...Option) *Client {
+	return &Client{cfg: config(opts...)}
+}
+
+// Do runs a command, replies are string, int64, []any or nil for null replies.
+func (c *Client) Do(ctx context.Context, args ...string) (any, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if c.conn == nil {
+		if err := c.dial(ctx); err != nil {
+			return nil, fmt.Errorf("resp: resp: Do: %w", err)
+		}
+	}
+
+	reply, err := c.roundTrip(ctx, args)
+
+	var replyErr Error
+	if err != nil && !errors.As(err, &replyErr) {
+		c.conn.Close()
+		c.conn = nil
+	}
+
+	if err != nil {
+		return nil, fmt.Errorf("resp: resp: Do: %s: %w", args[0], err)
+	}
+
+	return reply, nil
+}
+
+func (c *Client) Close() error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if c.conn == nil {
+		return nil
+	}
+
+	err := c.conn.Close()
+	c.conn = nil
+
+	return err
+}
+
+func (c *Client) dial(ctx context.Context) error {
+	dialer := net.Dialer{Timeout: c.cfg.Timeout}
+
+	conn, err := dialer.DialContext(ctx, "tcp", c.cfg.Addr)
+	if err != nil {
+		return fmt.Errorf("dial: %w", err)
+	}
+
+	c.conn = conn
+	c.rd = bufio.NewReader(conn)
+
+	if c.cfg.Password != "" {
+		if _, err := c.roundTrip(ctx, []string{"AUTH", c.cfg.Password}); err != nil {
+			c.conn.Close()
+			c.conn = nil
+			return fmt.Errorf("dial: AUTH: %w", err)
+		}
+	}
+
+	if c.cfg.Db != 0 {
+		if _, err := c.roundTrip(ctx, []string{"SELECT", strconv.Itoa(c.cfg.Db)}); err != nil {
+			c.conn.Close()
+			c.conn = nil
+			return fmt.Errorf("dial: SELECT: %w", err)
+		}
+	}
+
+	return nil
+}
+
+func (c *Client) roundTrip(ctx context.Context, args []string) (any, error) {
+	deadline, ok := ctx.Deadline()
+	if !ok {
+		deadline = time.Now().Add(c.cfg.Timeout)
+	}
+
+	if err := c.conn.SetDeadline(deadline); err != nil {
+		return nil, err
+	}
+
+	if err := writeCommand(c.conn, args); err != nil {
+		return nil, err
+	}
+
+	return readReply(c.rd)
+}
+
+func writeCommand(w io.Writer, args []string) error {
+	buf := make([]byte, 0, 64)
+	buf = fmt.Appendf(buf, "*%d\r\n", len(args))
+
+	for _, arg := range args {
+		buf = fmt.Appendf(buf, "$%d\r\n%s\r\n", len(arg), arg)
+	}
+
+	_, err := w.Write(buf)
+
+	return err
+}
+
+func readLine(rd *bufio.Reader) (string, error) {
+	line, err := rd.ReadString('\n')
+	if err != nil {
+		return "", err
+	}
+
+	if len(line) < 2 || line[len(line)-2] != '\r' {
+		return "", fmt.Errorf("malformed line %q", line)
+	}
+
+	return line[:len(line)-2], nil
+}
+
+func readReply(rd *bufio.Reader) (any, error) {
+	line, err := readLine(rd)
+	if err != nil {
+		return nil, err
+	}
+
+	if line == "" {
+		return nil, errors.New("empty reply")
+	}
+
+	switch line[0] {
+	case '+':
+		return line[1:], nil
+	case '-':
+		return nil, Error(line[1:])
+	case ':':
+		return strconv.ParseInt(line[1:], 10, 64)
+	case '$':
+		n, err := strconv.Atoi(line[1:])
+		if err != nil || n < 0 {
+			return nil, err
+		}
+
+		buf := make([]byte, n+2)
+		if _, err := io.ReadFull(rd, buf); err != nil {
+			return nil, err
+		}
+
+		return string(buf[:n]), nil
+	case '*':
+		n, err := strconv.Atoi(line[1:])
+		if err != nil || n < 0 {
+			return nil, err
+		}
+
+		items := make([]any, n)
+		for i := range items {
+			if items[i], err = readReply(rd); err != nil {
+				return nil, err
+			}
+		}
+
+		return items, nil
+	}
+
+	return nil, fmt.Errorf("unknown reply %q", line)
+}
@@ -0,0 +1,105 @@
+package resp_test
+
+import (
+	"context"
+	"net"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/v1adhope/flights/pkg/resp"
+)
+
+func startServer(t *testing.T) (*resp.Server, string) {
+	ln, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+
+	srv := resp.NewServer()
+	go srv.Serve(ln)
+	t.Cleanup(func() { srv.Close() })
+
+	return srv, ln.Addr().String()
+}
+
+func TestClientServer(t *testing.T) {
+	_, addr := startServer(t)
+	ctx := context.Background()
+
+	c := resp.New(resp.WithAddr(addr), resp.WithPassword("secret"), resp.WithDb(1))
+	defer c.Close()
+
+	reply, err := c.Do(ctx, "SET", "key", "value\r\nwith newline", "PX", "60000")
+	require.NoError(t, err)
+	assert.Equal(t, "OK", reply)
+
+	reply, err = c.Do(ctx, "GET", "key")
+	require.NoError(t, err)
+	assert.Equal(t, "value\r\nwith newline", reply)
+
+	reply, err = c.Do(ctx, "GET", "missing")
+	require.NoError(t, err)
+	assert.Nil(t, reply)
+
+	reply, err = c.Do(ctx, "SADD", "set", "a", "b", "a")
+	require.NoError(t, err)
+	assert.Equal(t, int64(2), reply)
+
+	reply, err = c.Do(ctx, "SMEMBERS", "set")
+	require.NoError(t, err)
+	assert.ElementsMatch(t, []any{"a", "b"}, reply)
+
+	reply, err = c.Do(ctx, "DEL", "key", "set", "missing")
+	require.NoError(t, err)
+	assert.Equal(t, int64(2), reply)
+
+	_, err = c.Do(ctx, "HGET", "key", "field")
+	var replyErr resp.Error
+	assert.ErrorAs(t, err, &replyErr)
+
+	reply, err = c.Do(ctx, "PING")
+	require.NoError(t, err)
+	assert.Equal(t, "PONG", reply, "the connection survives error replies")
+}
+
+func TestExpire(t *testing.T) {
+	_, addr := startServer(t)
+	ctx := context.Background()
+
+	c := resp.New(resp.WithAddr(addr))
+	defer c.Close()
+
+	_, err := c.Do(ctx, "SET", "key", "value", "PX", "20")
+	require.NoError(t, err)
+
+	assert.Eventually(t, func() bool {
+		reply, err := c.Do(ctx, "GET", "key")
+		return err == nil && reply == nil
+	}, time.Second, 10*time.Millisecond)
+}
+
+func TestReconnect(t *testing.T) {
+	srv, addr := startServer(t)
+	ctx := context.Background()
+
+	c := resp.New(resp.WithAddr(addr), resp.WithTimeout(100*time.Millisecond))
+	defer c.Close()
+
+	_, err := c.Do(ctx, "PING")
+	require.NoError(t, err)
+
+	srv.Close()
+
+	_, err = c.Do(ctx, "PING")
+	assert.Error(t, err)
+
+	ln, err := net.Listen("tcp", addr)
+	require.NoError(t, err)
+
+	srv = resp.NewServer()
+	go srv.Serve(ln)
+	defer srv.Close()
+
+	_, err = c.Do(ctx, "PING")
+	assert.NoError(t, err, "a broken connection is dialed again")
+}
@@ -0,0 +1,229 @@
+package resp
+
+import (
+	"bufio"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+// Server is an in-memory stand-in for Redis, so the Redis cache runs locally and in tests without a Redis server.
+// It keeps strings and sets and understands PING, AUTH, SELECT, GET, SET with PX or EX, DEL, SADD, SMEMBERS, PEXPIRE.
+type Server struct {
+	mu      sync.Mutex
+	values  map[string]any
+	expires map[string]time.Time
+	now     func() time.Time
+
+	connsMu sync.Mutex
+	ln      net.Listener
+	conns   map[net.Conn]struct{}
+}
+
+func NewServer() *Server {
+	return &Server{
+		values:  map[string]any{},
+		expires: map[string]time.Time{},
+		now:     time.Now,
+		conns:   map[net.Conn]struct{}{},
+	}
+}
+
+// Serve blocks until Close, every connection is served by its own goroutine.
+func (s *Server) Serve(ln net.Listener) error {
+	s.connsMu.Lock()
+	s.ln = ln
+	s.connsMu.Unlock()
+
+	for {
+		conn, err := ln.Accept()
+		if errors.Is(err, net.ErrClosed) {
+			return nil
+		}
+		if err != nil {
+			return fmt.Errorf("resp: server: Serve: Accept: %w", err)
+		}
+
+		s.connsMu.Lock()
+		s.conns[conn] = struct{}{}
+		s.connsMu.Unlock()
+
+		go s.serveConn(conn)
+	}
+}
+
+// Close stops accepting and drops the open connections.
+func (s *Server) Close() error {
+	s.connsMu.Lock()
+	defer s.connsMu.Unlock()
+
+	for conn := range s.conns {
+		conn.Close()
+	}
+
+	if s.ln == nil {
+		return nil
+	}
+
+	return s.ln.Close()
+}
+
+func (s *Server) serveConn(conn net.Conn) {
+	defer func() {
+		s.connsMu.Lock()
+		delete(s.conns, conn)
+		s.connsMu.Unlock()
+
+		conn.Close()
+	}()
+
+	rd := bufio.NewReader(conn)
+
+	for {
+		args, err := readCommand(rd)
+		if err != nil {
+			return
+		}
+
+		if _, err := io.WriteString(conn, s.exec(args)); err != nil {
+			return
+		}
+	}
+}
+
+func readCommand(rd *bufio.Reader) ([]string, error) {
+	reply, err := readReply(rd)
+	if err != nil {
+		return nil, err
+	}
+
+	items, ok := reply.([]any)
+	if !ok || len(items) == 0 {
+		return nil, errors.New("command is not an array")
+	}
+
+	args := make([]string, len(items))
+	for i, item := range items {
+		if args[i], ok = item.(string); !ok {
+			return nil, errors.New("argument is not a string")
+		}
+	}
+
+	return args, nil
+}
+
+func (s *Server) exec(args []string) string {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	cmd, args := strings.ToUpper(args[0]), args[1:]
+
+	switch {
+	case cmd == "PING":
+		return "+PONG\r\n"
+	case cmd == "AUTH" || cmd == "SELECT":
+		return "+OK\r\n"
+	case cmd == "GET" && len(args) == 1:
+		v, ok := s.get(args[0]).(string)
+		if !ok {
+			return "$-1\r\n"
+		}
+
+		return bulk(v)
+	case cmd == "SET" && len(args) >= 2:
+		s.values[args[0]] = args[1]
+		delete(s.expires, args[0])
+
+		if len(args) == 4 {
+			return s.expire(args[0], strings.ToUpper(args[2]), args[3])
+		}
+
+		return "+OK\r\n"
+	case cmd == "DEL" && len(args) >= 1:
+		n := 0
+		for _, key := range args {
+			if s.get(key) != nil {
+				n++
+			}
+
+			delete(s.values, key)
+			delete(s.expires, key)
+		}
+
+		return ":" + strconv.Itoa(n) + "\r\n"
+	case cmd == "SADD" && len(args) >= 2:
+		set, _ := s.get(args[0]).(map[string]struct{})
+		if set == nil {
+			set = map[string]struct{}{}
+			s.values[args[0]] = set
+		}
+
+		n := 0
+		for _, member := range args[1:] {
+			if _, ok := set[member]; !ok {
+				set[member] = struct{}{}
+				n++
+			}
+		}
+
+		return ":" + strconv.Itoa(n) + "\r\n"
+	case cmd == "SMEMBERS" && len(args) == 1:
+		set, _ := s.get(args[0]).(map[string]struct{})
+
+		reply := "*" + strconv.Itoa(len(set)) + "\r\n"
+		for member := range set {
+			reply += bulk(member)
+		}
+
+		return reply
+	case cmd == "PEXPIRE" && len(args) == 2:
+		if s.get(args[0]) == nil {
+			return ":0\r\n"
+		}
+
+		if reply := s.expire(args[0], "PX", args[1]); reply != "+OK\r\n" {
+			return reply
+		}
+
+		return ":1\r\n"
+	}
+
+	return "-ERR unknown command or wrong number of arguments for '" + cmd + "'\r\n"
+}
+
+// get drops the key when it has expired.
+func (s *Server) get(key string) any {
+	if at, ok := s.expires[key]; ok && !s.now().Before(at) {
+		delete(s.values, key)
+		delete(s.expires, key)
+	}
+
+	return s.values[key]
+}
+
+func (s *Server) expire(key, unit, amount string) string {
+	n, err := strconv.ParseInt(amount, 10, 64)
+	if err != nil || n <= 0 {
+		return "-ERR invalid expire time\r\n"
+	}
+
+	switch unit {
+	case "PX":
+		s.expires[key] = s.now().Add(time.Duration(n) * time.Millisecond)
+	case "EX":
+		s.expires[key] = s.now().Add(time.Duration(n) * time.Second)
+	default:
+		return "-ERR syntax error\r\n"
+	}
+
+	return "+OK\r\n"
+}
+
+func bulk(s string) string {
+	return "$" + strconv.Itoa(len(s)) + "\r\n" + s + "\r\n"
+}
@@ -0,0 +1,70 @@
+package tracing
+
+type Option func(*Config)
+
+type Config struct {
+	ServiceName string
+	// Exporter is one of "none", "otlp", "stdout", "file"
+	Exporter     string
+	OtlpEndpoint string
+	OtlpInsecure bool
+	FilePath     string
+	SampleRatio  float64
+}
+
+func WithServiceName(name string) Option {
+	return func(cfg *Config) {
+		cfg.ServiceName = name
+	}
+}
+
+// WithExporter accepts one of "none", "otlp", "stdout", "file".
+func WithExporter(exporter string) Option {
+	return func(cfg *Config) {
+		cfg.Exporter = exporter
+	}
+}
+
+// WithOtlpEndpoint sets the host:port of the OTLP/HTTP collector.
+func WithOtlpEndpoint(endpoint string) Option {
+	return func(cfg *Config) {
+		cfg.OtlpEndpoint = endpoint
+	}
+}
+
+func WithOtlpInsecure(insecure bool) Option {
+	return func(cfg *Config) {
+		cfg.OtlpInsecure = insecure
+	}
+}
+
+// WithFilePath sets the file the "file" exporter appends spans to.
+func WithFilePath(path string) Option {
+	return func(cfg *Config) {
+		cfg.FilePath = path
+	}
+}
+
+// WithSampleRatio samples the ratio of new traces, traces started by callers follow their decision.
+func WithSampleRatio(ratio float64) Option {
+	return func(cfg *Config) {
+		cfg.SampleRatio = ratio
+	}
+}
+
+func config(opts ...Option) Config {
+	cfg := Config{
+		ServiceName:  "flights",
+		Exporter:     "none",
+		OtlpEndpoint: "localhost:4318",
+		OtlpInsecure: true,
+		FilePath:     "traces.json",
+		SampleRatio:  1,
+	}
+
+	for _, opt := range opts {
+		opt(&cfg)
+	}
+
+	return cfg
+}
@@ -0,0 +1,98 @@
+package tracing
+
+import (
+	"context"
+	"strings"
+
+	"github.com/jackc/pgx/v5"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	semconv "go.opentelemetry.io/otel/semconv/v1.26.0"
+	"go.opentelemetry.io/otel/trace"
+)
+
+const _pgxInstrumentation = "github.com/v1adhope/flights/pkg/tracing/pgx"
+
+// _rowsKey is the number of rows a statement returned or affected.
+const _rowsKey = attribute.Key("db.rows")
+
+// PgxTracer records a span for every query and copy with its SQL and row count.
+type PgxTracer struct {
+	tracer trace.Tracer
+}
+
+var (
+	_ pgx.QueryTracer    = (*PgxTracer)(nil)
+	_ pgx.CopyFromTracer = (*PgxTracer)(nil)
+)
+
+func NewPgxTracer(tp trace.TracerProvider) *PgxTracer {
+	return &PgxTracer{
+		tracer: tp.Tracer(_pgxInstrumentation),
+	}
+}
+
+func (t *PgxTracer) TraceQueryStart(ctx context.Context, _ *pgx.Conn, data pgx.TraceQueryStartData) context.Context {
+	operation := sqlOperation(data.SQL)
+
+	ctx, _ = t.tracer.Start(
+		ctx,
+		"postgres "+operation,
+		trace.WithSpanKind(trace.SpanKindClient),
+		trace.WithAttributes(
+			semconv.DBSystemPostgreSQL,
+			semconv.DBOperationName(operation),
+			semconv.DBQueryText(data.SQL),
+		),
+	)
+
+	return ctx
+}
+
+func (t *PgxTracer) TraceQueryEnd(ctx context.Context, _ *pgx.Conn, data pgx.TraceQueryEndData) {
+	span := trace.SpanFromContext(ctx)
+	defer span.End()
+
+	endSpan(span, data.CommandTag.RowsAffected(), data.Err)
+}
+
+func (t *PgxTracer) TraceCopyFromStart(ctx context.Context, _ *pgx.Conn, data pgx.TraceCopyFromStartData) context.Context {
+	ctx, _ = t.tracer.Start(
+		ctx,
+		"postgres copy",
+		trace.WithSpanKind(trace.SpanKindClient),
+		trace.WithAttributes(
+			semconv.DBSystemPostgreSQL,
+			semconv.DBOperationName("copy"),
+			semconv.DBCollectionName(data.TableName.Sanitize()),
+		),
+	)
+
+	return ctx
+}
+
+func (t *PgxTracer) TraceCopyFromEnd(ctx context.Context, _ *pgx.Conn, data pgx.TraceCopyFromEndData) {
+	span := trace.SpanFromContext(ctx)
+	defer span.End()
+
+	endSpan(span, data.CommandTag.RowsAffected(), data.Err)
+}
+
+func endSpan(span trace.Span, rows int64, err error) {
+	span.SetAttributes(_rowsKey.Int64(rows))
+
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+}
+
+// sqlOperation is the first keyword of sql in lower case, e.g. "select".
+func sqlOperation(sql string) string {
+	fields := strings.Fields(sql)
+	if len(fields) == 0 {
+		return "unknown"
+	}
+
+	return strings.ToLower(fields[0])
+}
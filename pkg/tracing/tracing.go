@@ -0,0 +1,134 @@
+// Package tracing sets up OpenTelemetry tracing with W3C trace-context propagation.
+package tracing
+
+import (
+	"context"
+	"fmt"
+	"os"
+
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/exporters/otlp/otlptrace/otlptracehttp"
+	"go.opentelemetry.io/otel/exporters/stdout/stdouttrace"
+	"go.opentelemetry.io/otel/propagation"
+	"go.opentelemetry.io/otel/sdk/resource"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	semconv "go.opentelemetry.io/otel/semconv/v1.26.0"
+	"go.opentelemetry.io/otel/trace"
+	"go.opentelemetry.io/otel/trace/noop"
+)
+
+// Provider is the tracer provider of the service, it is registered globally by New.
+type Provider struct {
+	trace.TracerProvider
+	shutdown func(ctx context.Context) error
+}
+
+// New builds the provider of the configured exporter, with the "none" exporter spans are not recorded at all.
+func New(ctx context.Context, opts ...Option) (*Provider, error) {
+	cfg := config(opts...)
+
+	otel.SetTextMapPropagator(propagation.NewCompositeTextMapPropagator(
+		propagation.TraceContext{},
+		propagation.Baggage{},
+	))
+
+	if cfg.Exporter == "none" {
+		p := &Provider{
+			TracerProvider: noop.NewTracerProvider(),
+			shutdown: func(context.Context) error {
+				return nil
+			},
+		}
+
+		otel.SetTracerProvider(p)
+
+		return p, nil
+	}
+
+	exporter, closeExporter, err := newExporter(ctx, cfg)
+	if err != nil {
+		return nil, fmt.Errorf("tracing: tracing: New: %w", err)
+	}
+
+	res, err := resource.Merge(
+		resource.Default(),
+		resource.NewWithAttributes(semconv.SchemaURL, semconv.ServiceName(cfg.ServiceName)),
+	)
+	if err != nil {
+		return nil, fmt.Errorf("tracing: tracing: New: Merge: %w", err)
+	}
+
+	tp := sdktrace.NewTracerProvider(
+		sdktrace.WithBatcher(exporter),
+		sdktrace.WithResource(res),
+		sdktrace.WithSampler(sdktrace.ParentBased(sdktrace.TraceIDRatioBased(cfg.SampleRatio))),
+	)
+
+	otel.SetTracerProvider(tp)
+
+	return &Provider{
+		TracerProvider: tp,
+		shutdown: func(ctx context.Context) error {
+			if err := tp.Shutdown(ctx); err != nil {
+				return err
+			}
+
+			return closeExporter()
+		},
+	}, nil
+}
+
+func newExporter(ctx context.Context, cfg Config) (sdktrace.SpanExporter, func() error, error) {
+	noClose := func() error {
+		return nil
+	}
+
+	switch cfg.Exporter {
+	case "otlp":
+		opts := []otlptracehttp.Option{
+			otlptracehttp.WithEndpoint(cfg.OtlpEndpoint),
+		}
+
+		if cfg.OtlpInsecure {
+			opts = append(opts, otlptracehttp.WithInsecure())
+		}
+
+		exporter, err := otlptracehttp.New(ctx, opts...)
+		if err != nil {
+			return nil, nil, fmt.Errorf("newExporter: otlptracehttp: New: %w", err)
+		}
+
+		return exporter, noClose, nil
+	case "stdout":
+		exporter, err := stdouttrace.New(stdouttrace.WithPrettyPrint())
+		if err != nil {
+			return nil, nil, fmt.Errorf("newExporter: stdouttrace: New: %w", err)
+		}
+
+		return exporter, noClose, nil
+	case "file":
+		f, err := os.OpenFile(cfg.FilePath, os.O_CREATE|os.O_WRONLY|os.O_APPEND, 0o644)
+		if err != nil {
+			return nil, nil, fmt.Errorf("newExporter: OpenFile: %w", err)
+		}
+
+		exporter, err := stdouttrace.New(stdouttrace.WithWriter(f))
+		if err != nil {
+			f.Close()
+			return nil, nil, fmt.Errorf("newExporter: stdouttrace: New: %w", err)
+		}
+
+		return exporter, f.Close, nil
+	default:
+		return nil, nil, fmt.Errorf("newExporter: unknown exporter %q", cfg.Exporter)
+	}
+}
+
+// Shutdown flushes the spans that are not exported yet.
+func (p *Provider) Shutdown(ctx context.Context) error {
+	if err := p.shutdown(ctx); err != nil {
+		return fmt.Errorf("tracing: tracing: Shutdown: %w", err)
+	}
+
+	return nil
+}
@@ -0,0 +1,84 @@
+package tracing_test
+
+import (
+	"context"
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgconn"
+	"github.com/stretchr/testify/assert"
+	"github.com/v1adhope/flights/pkg/tracing"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+)
+
+func TestPgxTracer(t *testing.T) {
+	spans := tracetest.NewSpanRecorder()
+	tracer := tracing.NewPgxTracer(sdktrace.NewTracerProvider(sdktrace.WithSpanProcessor(spans)))
+
+	ctx := tracer.TraceQueryStart(context.Background(), nil, pgx.TraceQueryStartData{
+		SQL: "SELECT ticket_id FROM tickets WHERE provider = $1",
+	})
+	tracer.TraceQueryEnd(ctx, nil, pgx.TraceQueryEndData{
+		CommandTag: pgconn.NewCommandTag("SELECT 3"),
+	})
+
+	ctx = tracer.TraceQueryStart(context.Background(), nil, pgx.TraceQueryStartData{
+		SQL: "delete from tickets where ticket_id = $1",
+	})
+	tracer.TraceQueryEnd(ctx, nil, pgx.TraceQueryEndData{
+		Err: errors.New("boom"),
+	})
+
+	ended := spans.Ended()
+	assert.Len(t, ended, 2)
+
+	attrs := func(span sdktrace.ReadOnlySpan) map[attribute.Key]attribute.Value {
+		m := map[attribute.Key]attribute.Value{}
+		for _, kv := range span.Attributes() {
+			m[kv.Key] = kv.Value
+		}
+		return m
+	}
+
+	assert.Equal(t, "postgres select", ended[0].Name())
+	assert.Equal(t, "SELECT ticket_id FROM tickets WHERE provider = $1", attrs(ended[0])["db.query.text"].AsString())
+	assert.Equal(t, int64(3), attrs(ended[0])["db.rows"].AsInt64())
+	assert.Equal(t, codes.Unset, ended[0].Status().Code)
+
+	assert.Equal(t, "postgres delete", ended[1].Name())
+	assert.Equal(t, codes.Error, ended[1].Status().Code)
+}
+
+func TestFileExporter(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "traces.json")
+
+	tp, err := tracing.New(
+		context.Background(),
+		tracing.WithExporter("file"),
+		tracing.WithFilePath(path),
+	)
+	assert.NoError(t, err)
+
+	_, span := tp.Tracer("test").Start(context.Background(), "span")
+	span.End()
+
+	assert.NoError(t, tp.Shutdown(context.Background()))
+
+	data, err := os.ReadFile(path)
+	assert.NoError(t, err)
+	assert.Contains(t, string(data), `"Name":"span"`)
+}
+
+func TestUnknownExporter(t *testing.T) {
+	_, err := tracing.New(
+		context.Background(),
+		tracing.WithExporter("jaeger"),
+	)
+	assert.Error(t, err)
+}
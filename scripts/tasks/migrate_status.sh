@@ -0,0 +1,3 @@
+#!/bin/bash
+
+docker compose run --rm service ./service_start.sh migrate status